@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
+	"time"
 
 	"github.com/cloudwego/kitex/server"
 	"github.com/spf13/viper"
@@ -13,6 +15,7 @@ import (
 	"Redrock/seckill/internal/pkg/database"
 	"Redrock/seckill/internal/pkg/redis"
 	internalActivity "Redrock/seckill/kitex_gen/activity/internalactivityservice"
+	adminActivity "Redrock/seckill/kitex_gen/activity/adminactivityservice"
 	activity "Redrock/seckill/kitex_gen/activity/activityservice"
 	"Redrock/seckill/internal/pkg/models"
 )
@@ -52,7 +55,16 @@ func main(){
 	defer redis.CloseRedis()
 
 	// 启动kitex服务
-	activityImpl := service.NewActivityServiceImpl()
+	activityImpl := service.NewActivityServiceImpl(&config)
+
+	// 启动活动缓存预热任务
+	go activityImpl.RunWarmUp(context.Background(), time.Duration(config.WarmUp.Interval) * time.Second)
+
+	// 启动活动状态调度任务
+	go activityImpl.RunLifecycle(context.Background())
+
+	// 启动库存对账任务
+	go activityImpl.RunReconcile(context.Background())
 
 	// 将字符串转化为TCP地址
 	address, err := net.ResolveTCPAddr("tcp", fmt.Sprintf("%s:%d", config.Server.Host, config.Server.Port))
@@ -72,6 +84,11 @@ func main(){
 		log.Fatalf("注册InternalActivityService服务失败：%v", err)
 	}
 
+	// 注册AdminActivityService服务
+	if err := adminActivity.RegisterService(svr, activityImpl); err != nil {
+		log.Fatalf("注册AdminActivityService服务失败：%v", err)
+	}
+
 	if err := svr.Run(); err != nil{
 		log.Fatalf("活动服务启动失败：%v", err)
 	}
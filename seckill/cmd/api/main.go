@@ -9,7 +9,10 @@ import(
 
 	"Redrock/seckill/internal/api/config"
 	"Redrock/seckill/internal/api/client"
+	"Redrock/seckill/internal/api/handler"
+	"Redrock/seckill/internal/api/middleware"
 	"Redrock/seckill/internal/api/router"
+	"Redrock/seckill/internal/pkg/auth"
 	"Redrock/seckill/internal/pkg/redis"
 )
 
@@ -44,12 +47,39 @@ func main(){
 		log.Fatalf("初始化服务客户端失败：%v", err)
 	}
 
+	// 创建访问令牌校验器
+	tokens, err := auth.NewTokenManager(&config.Auth)
+	if err != nil{
+		log.Fatalf("初始化令牌校验失败：%v", err)
+	}
+	// 退出登录等操作撤销的令牌记录在Redis中，和用户服务共用
+	revoker := auth.NewRevoker(redis.GetRedis(), &config.Auth)
+
+	// 按server.rate_limit和rate_limit中的策略限流
+	rateLimiter, err := middleware.NewRateLimiter(redis.GetRedis(), &config)
+	if err != nil{
+		log.Fatalf("初始化限流器失败：%v", err)
+	}
+
+	// 每个活动同时处理的秒杀请求超过waiting_room.capacity时排队，排队状态保存在Redis中
+	waitingRoom := middleware.NewWaitingRoom(redis.GetRedis(), &config.WaitingRoom)
+
+	// 开启异步秒杀时创建排队队列
+	var seckillQueue *handler.SeckillQueue
+	if config.Seckill.Async{
+		seckillQueue, err = handler.NewSeckillQueue(&config)
+		if err != nil{
+			log.Fatalf("初始化秒杀排队队列失败：%v", err)
+		}
+		defer seckillQueue.Close()
+	}
+
 	// 启动Hertz服务器
 	h := server.Default(
 		server.WithHostPorts(fmt.Sprintf("%s:%d", config.Server.Host, config.Server.Port)),
 	)
 	
-	router.SetupRouter(h, clients)
+	router.SetupRouter(h, clients, seckillQueue, tokens, revoker, rateLimiter, waitingRoom)
 
 	log.Printf("Hertz服务器启动成功，监听地址：%s:%d", config.Server.Host, config.Server.Port)
 	h.Spin()
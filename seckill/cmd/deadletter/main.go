@@ -0,0 +1,98 @@
+// deadletter 查看、重新投递和清空订单服务消费的死信队列
+//
+// 用法(在seckill目录下执行，读取订单服务的配置)：
+//
+//	go run ./cmd/deadletter -queue order -action list -limit 10
+//	go run ./cmd/deadletter -queue seckill -action replay -limit 100
+//	go run ./cmd/deadletter -queue seckill -action purge
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"Redrock/seckill/internal/order/config"
+	"Redrock/seckill/internal/pkg/mq"
+	"Redrock/seckill/internal/pkg/redis"
+)
+
+func main(){
+	queue := flag.String("queue", "order", "死信所在的队列：order(订单消息) 或 seckill(异步秒杀排队消息)")
+	action := flag.String("action", "list", "操作：list(查看，不会移除消息)、replay(重新投递到业务队列)、purge(清空)")
+	limit := flag.Int("limit", 10, "list和replay处理的最大消息数")
+	configPath := flag.String("config", "./internal/order/config", "订单服务配置文件所在目录")
+	flag.Parse()
+
+	// 读取配置
+	viper.SetConfigName("order")
+	viper.SetConfigType("yaml")
+	viper.AddConfigPath(*configPath)
+	viper.AutomaticEnv()
+
+	if err := viper.ReadInConfig(); err != nil{
+		log.Fatalf("读取订单配置文件失败：%v", err)
+	}
+
+	var config config.Config
+	if err := viper.Unmarshal(&config); err != nil{
+		log.Fatalf("解析订单配置文件失败：%v", err)
+	}
+
+	var mqConfig *mq.MQConfig
+	switch *queue{
+	case "order":
+		mqConfig = &config.MQ
+	case "seckill":
+		mqConfig = &config.SeckillMQ
+	default:
+		log.Fatalf("未知的队列：%s", *queue)
+	}
+
+	// Redis Streams使用服务的Redis连接
+	if mqConfig.Type == "redis"{
+		if err := redis.InitRedis(&config.Redis); err != nil{
+			log.Fatalf("初始化连接Redis失败：%v", err)
+		}
+		defer redis.CloseRedis()
+	}
+
+	dlq, err := mq.NewDeadLetterQueue(mqConfig)
+	if err != nil{
+		log.Fatalf("连接死信队列失败：%v", err)
+	}
+	defer dlq.Close()
+
+	switch *action{
+	case "list":
+		letters, err := dlq.ListDeadLetters(*limit)
+		if err != nil{
+			log.Fatalf("查看死信失败：%v", err)
+		}
+
+		for i, letter := range letters{
+			fmt.Printf("%d. 时间：%s 重试次数：%d 失败原因：%s\n   %s\n",
+				i+1, letter.Timestamp.Format(time.DateTime), letter.RetryCount, letter.LastError, letter.Body)
+		}
+		fmt.Printf("共%d条死信\n", len(letters))
+	case "replay":
+		replayed, err := dlq.ReplayDeadLetters(*limit)
+		if err != nil{
+			log.Fatalf("已重新投递%d条死信，之后失败：%v", replayed, err)
+		}
+
+		fmt.Printf("已重新投递%d条死信\n", replayed)
+	case "purge":
+		purged, err := dlq.PurgeDeadLetters()
+		if err != nil{
+			log.Fatalf("清空死信队列失败：%v", err)
+		}
+
+		fmt.Printf("已删除%d条死信\n", purged)
+	default:
+		log.Fatalf("未知的操作：%s", *action)
+	}
+}
@@ -45,7 +45,7 @@ func main(){
 	defer database.CloseDB()
 
 	// 自动迁移数据库表
-	if err := database.MigrateDB(&models.User{}, &models.Product{}, &models.Activity{}, &models.Order{}); err != nil {
+	if err := database.MigrateDB(&models.User{}, &models.Product{}, &models.Activity{}, &models.Order{}, &models.OrderSaga{}, &models.OrderOutbox{}); err != nil {
 		log.Fatalf("数据库迁移失败: %v", err)
 	}
 
@@ -67,7 +67,7 @@ func main(){
 	}
 
 	// 初始化订单消息生产者
-	orderProducer, err := mq.NewOrderProducer(&config.MQ.RabbitMQ)
+	orderProducer, err := mq.NewOrderProducer(&config.MQ)
 	if err != nil{
 		log.Fatalf("初始化订单消息生产者失败：%v", err)
 	}
@@ -75,7 +75,7 @@ func main(){
 	defer orderProducer.Close()
 
 	// 初始化订单消费者
-	orderConsumer, err := mq.NewOrderConsumer(&config.MQ.RabbitMQ, data.NewOrderData())
+	orderConsumer, err := mq.NewOrderConsumer(&config.MQ, data.NewOrderData())
 	if err != nil{
 		log.Fatalf("初始化订单消费者失败：%v", err)
 	}
@@ -90,6 +90,21 @@ func main(){
 	// 启动kitex服务
 	orderImpl := service.NewOrderServiceImpl(orderProducer, activityServiceClient, &config)
 
+	// 启动异步秒杀排队消息的消费者，创建网关排队的订单
+	seckillConsumer, err := mq.NewSeckillConsumer(&config.SeckillMQ, orderImpl.HandleSeckillMessage)
+	if err != nil{
+		log.Fatalf("初始化秒杀消息消费者失败：%v", err)
+	}
+
+	defer seckillConsumer.Close()
+
+	// 超过最大重试次数的排队消息归还库存并记录失败结果，排队凭证不会一直停留在排队中
+	seckillConsumer.OnDeadLetter(orderImpl.HandleSeckillDeadLetter)
+
+	if err := seckillConsumer.StartConsume(); err != nil{
+		log.Fatalf("启动秒杀消息消费者失败：%v", err)
+	}
+
 	addr, err := net.ResolveTCPAddr("tcp", fmt.Sprintf("%s:%d", config.Server.Host, config.Server.Port))
 	if err != nil{
 		log.Fatalf("解析TCP地址失败: %v", err)
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net"
+
+	"github.com/cloudwego/kitex/server"
+	"github.com/spf13/viper"
+
+	"Redrock/seckill/internal/pkg/database"
+	"Redrock/seckill/internal/pkg/models"
+	"Redrock/seckill/internal/product/config"
+	"Redrock/seckill/internal/product/service"
+	productService "Redrock/seckill/kitex_gen/product/productservice"
+)
+
+func main(){
+	// 读取配置
+	viper.SetConfigName("product")
+	viper.SetConfigType("yaml")
+	viper.AddConfigPath("./internal/product/config")
+	viper.AutomaticEnv()
+
+	if err := viper.ReadInConfig(); err != nil{
+		log.Fatalf("读取商品配置文件失败：%v", err)
+	}
+
+	var cfg config.Config
+	if err := viper.Unmarshal(&cfg); err != nil{
+		log.Fatalf("解析商品配置文件失败：%v", err)
+	}
+
+	// 连接数据库
+	if err := database.InitDB(&cfg.Database); err != nil{
+		log.Fatalf("初始化连接数据库失败：%v", err)
+	}
+	defer database.CloseDB()
+
+	// 迁移表结构
+	if err := database.MigrateDB(&models.Product{}); err != nil{
+		log.Fatalf("迁移表结构失败：%v", err)
+	}
+
+	// 创建服务实现实例
+	productImpl := service.NewProductServiceImpl(&cfg)
+
+	address, err := net.ResolveTCPAddr("tcp", fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port))
+	if err != nil{
+		log.Fatalf("解析TCP地址失败：%v", err)
+	}
+
+	svr := productService.NewServer(
+		productImpl,
+		server.WithServiceAddr(address),
+	)
+
+	if err := svr.Run(); err != nil{
+		log.Fatalf("商品服务启动失败：%v", err)
+	}
+
+	log.Printf("商品服务启动成功，地址为：%s:%d", cfg.Server.Host, cfg.Server.Port)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net"
 	"fmt"
@@ -9,9 +10,11 @@ import (
 	"github.com/spf13/viper"
 
 	userService "Redrock/seckill/kitex_gen/user/userservice"
+	adminUserService "Redrock/seckill/kitex_gen/user/adminuserservice"
 	"Redrock/seckill/internal/user/config"
 	"Redrock/seckill/internal/pkg/database"
 	"Redrock/seckill/internal/pkg/models"
+	"Redrock/seckill/internal/pkg/redis"
 	"Redrock/seckill/internal/user/service"
 	
 )
@@ -45,8 +48,23 @@ func main() {
 		log.Fatalf("数据库迁移失败: %v", err)
 	}
 
+	// 初始化Redis，记录被撤销的令牌
+	if err := redis.InitRedis(&cfg.Redis); err != nil {
+		log.Fatalf("初始化Redis失败: %v", err)
+	}
+	defer redis.CloseRedis()
+
 	// 创建服务实现实例
-	userImpl := service.NewUserServiceImpl()
+	userImpl := service.NewUserServiceImpl(&cfg)
+
+	// 设置初始管理员
+	promoted, err := userImpl.PromoteAdmins(context.Background(), cfg.Admin.Usernames)
+	if err != nil {
+		log.Fatalf("设置初始管理员失败: %v", err)
+	}
+	if promoted > 0 {
+		log.Printf("已将%d个用户设置为管理员", promoted)
+	}
 
 	// 创建Kitex服务器
 	addr, err := net.ResolveTCPAddr("tcp", fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port))
@@ -54,12 +72,21 @@ func main() {
 		log.Fatalf("解析TCP地址失败: %v", err)
 	}
 
-	svr := userService.NewServer(
-		userImpl, 
+	svr := server.NewServer(
 		server.WithServiceAddr(addr),
 		server.WithServerBasicInfo(nil),
 	)
 
+	// 注册UserService服务
+	if err := userService.RegisterService(svr, userImpl); err != nil {
+		log.Fatalf("注册UserService服务失败: %v", err)
+	}
+
+	// 注册AdminUserService服务
+	if err := adminUserService.RegisterService(svr, userImpl); err != nil {
+		log.Fatalf("注册AdminUserService服务失败: %v", err)
+	}
+
 	// 启动Kitex服务器
 	if err := svr.Run(); err != nil {
 		log.Fatalf("启动服务器失败: %v", err)
@@ -19,6 +19,8 @@ struct ActivityInfo{
     9: i64      totalStock      // 总库存
     10: i64     availableStock  // 可用库存
     11: bool    isAvailable     // 活动是否可用
+    12: i32     status          // 活动状态 0: 未开始, 1: 进行中, 2: 已结束, 3: 已暂停
+    13: i64     perUserLimit    // 每个用户累计最多购买的数量
 }
 
 // 创建活动请求
@@ -29,6 +31,7 @@ struct CreateActivityRequest{
     4: i64      startTime       // 活动开始时间戳
     5: i64      endTime         // 活动结束时间戳
     6: i64      totalStock      // 总库存
+    7: optional i64 perUserLimit // 每个用户累计最多购买的数量，至少为1，默认1
 }
 
 // 常见活动响应
@@ -37,15 +40,26 @@ struct CreateActivityResponse{
     2: i64          activityID      // 活动ID
 }
 
-// 获取活动列表
+// 获取活动列表，按游标分页
 struct GetActivityListRequest{
-
+    1: i32                  status = -1 // 活动状态，-1表示所有活动
+    2: string               cursor      // 上一页返回的nextCursor，为空时从第一页开始
+    3: i32                  pageSize    // 每页数量，默认20，最多100
+    4: optional i64         timeFrom    // 时间范围起点时间戳，返回活动时间和范围有重叠的活动
+    5: optional i64         timeTo      // 时间范围终点时间戳
+    6: optional double      minPrice    // 最低秒杀价格
+    7: optional double      maxPrice    // 最高秒杀价格
+    8: string               productName // 商品名称，模糊匹配
+    9: string               sortBy      // 排序字段: created(默认), start_time, price
+    10: bool                asc         // 是否升序，默认降序
 }
 
 struct GetActivityListResponse{
     1: BaseResponse         baseResponse
     2: list<ActivityInfo>   activities  //  活动列表
-    3: i64                  total       // 总活动数量
+    3: i64                  total       // 符合筛选条件的活动总数，和分页无关
+    4: string               nextCursor  // 下一页的游标，没有下一页时为空
+    5: bool                 hasMore     // 是否还有下一页
 }
 
 // 获取活动
@@ -58,11 +72,68 @@ struct GetActivityResponse{
     2: ActivityInfo         activity    // 活动信息
 }
 
+// 修改活动，只修改设置了的字段
+// 进行中的活动不能修改，需要先暂停
+struct UpdateActivityRequest{
+    1: i64                  activityID      // 活动ID
+    2: optional string      name            // 活动名称
+    3: optional double      seckillPrice    // 秒杀价格
+    4: optional i64         startTime       // 活动开始时间戳
+    5: optional i64         endTime         // 活动结束时间戳
+    6: optional i64         perUserLimit    // 每个用户累计最多购买的数量，至少为1
+}
+
+struct UpdateActivityResponse{
+    1: BaseResponse         baseResponse
+    2: ActivityInfo         activity    // 修改后的活动信息
+}
+
+// 暂停活动，暂停期间不能抢购
+struct PauseActivityRequest{
+    1: i64                  activityID  // 活动ID
+}
+
+struct PauseActivityResponse{
+    1: BaseResponse         baseResponse
+    2: ActivityInfo         activity    // 暂停后的活动信息
+}
+
+// 恢复暂停的活动，按当前时间恢复为未开始、进行中或已结束
+struct ResumeActivityRequest{
+    1: i64                  activityID  // 活动ID
+}
+
+struct ResumeActivityResponse{
+    1: BaseResponse         baseResponse
+    2: ActivityInfo         activity    // 恢复后的活动信息
+}
+
+// 删除活动，进行中的活动不能删除
+struct DeleteActivityRequest{
+    1: i64                  activityID  // 活动ID
+}
+
+struct DeleteActivityResponse{
+    1: BaseResponse         baseResponse
+}
+
+// 调整库存，同时调整总库存和可用库存，进行中的活动不能调整
+struct AdjustStockRequest{
+    1: i64                  activityID  // 活动ID
+    2: i64                  delta       // 调整数量，负数表示减少，可用库存不能减到0以下
+}
+
+struct AdjustStockResponse{
+    1: BaseResponse         baseResponse
+    2: ActivityInfo         activity    // 调整后的活动信息
+}
+
 // 扣除库存
 struct DeductStockRequest{
     1: i64                  activityID  // 活动ID
     2: i64                  userID      // 用户ID
-    3: i64                  count = 1   // 扣除数量，default 1
+    3: i64                  count = 1   // 扣除数量，default 1，和用户之前购买的数量合计不能超过限购数量
+    4: string               orderSn     // 订单号，用于记录该订单扣除的库存，归还库存时以此为准
 }
 
 struct DeductStockResponse{
@@ -70,6 +141,72 @@ struct DeductStockResponse{
     2: bool                 success     // 是否成功扣除数量
 }
 
+// 归还库存
+struct ReturnStockRequest{
+    1: i64                  activityID  // 活动ID
+    2: i64                  userID      // 用户ID
+    3: i64                  count = 1   // 归还数量，default 1(以扣除库存时记录的数量为准，同时归还用户的限购额度)
+    4: string               orderSn     // 订单号，同一订单只会归还一次
+}
+
+struct ReturnStockResponse{
+    1: BaseResponse         baseResponse
+    2: bool                 success     // 是否成功归还库存(重复归还也视为成功)
+}
+
+// 统计使用商品且还没有结束的活动
+struct CountProductActivitiesRequest{
+    1: i64                  productID   // 商品ID
+}
+
+struct CountProductActivitiesResponse{
+    1: BaseResponse         baseResponse
+    2: i64                  count       // 使用该商品且还没有结束的活动数量
+}
+
+// 预热活动缓存
+struct WarmUpActivityRequest{
+    1: i64                  activityID  // 活动ID
+}
+
+struct WarmUpActivityResponse{
+    1: BaseResponse         baseResponse
+    2: i64                  stock       // 预热后Redis中的库存
+}
+
+// 库存对账结果
+struct StockReport{
+    1: i64                  activityID  // 活动ID
+    2: i64                  redisStock  // Redis中的库存，-1表示Redis中没有库存
+    3: i64                  dbStock     // 数据库中的可用库存
+    4: i64                  orderStock  // 总库存减去未取消订单和进行中扣除的商品数量
+    5: bool                 drift       // 三者是否不一致
+    6: bool                 corrected   // 是否已按配置的策略修正
+    7: i64                  checkTime   // 对账时间戳
+    8: i64                  inflight    // 已扣除库存但还没有有效订单的商品数量(排队中、下单中或取消后等待归还)
+}
+
+// 查询最近一次的对账结果
+struct GetStockReportsRequest{
+    1: i64                  activityID  // 活动ID，0表示所有活动
+    2: bool                 onlyDrift   // 是否只返回不一致的结果
+}
+
+struct GetStockReportsResponse{
+    1: BaseResponse         baseResponse
+    2: list<StockReport>    reports     // 对账结果
+}
+
+// 立即对账单个活动
+struct ReconcileStockRequest{
+    1: i64                  activityID  // 活动ID
+}
+
+struct ReconcileStockResponse{
+    1: BaseResponse         baseResponse
+    2: StockReport          report      // 对账结果
+}
+
 service ActivityService{
     // 创建活动
     CreateActivityResponse      CreateActivity(1: CreateActivityRequest req)
@@ -79,9 +216,41 @@ service ActivityService{
 
     // 获取活动详情
     GetActivityResponse         GetActivity(1: GetActivityRequest req)
+
+    // 修改活动
+    UpdateActivityResponse      UpdateActivity(1: UpdateActivityRequest req)
+
+    // 暂停活动
+    PauseActivityResponse       PauseActivity(1: PauseActivityRequest req)
+
+    // 恢复活动
+    ResumeActivityResponse      ResumeActivity(1: ResumeActivityRequest req)
+
+    // 删除活动
+    DeleteActivityResponse      DeleteActivity(1: DeleteActivityRequest req)
+
+    // 调整库存
+    AdjustStockResponse         AdjustStock(1: AdjustStockRequest req)
 }
 
 service InternalActivityService{
     // 扣除库存
     DeductStockResponse         DeductStock(1: DeductStockRequest req)
+
+    // 归还库存
+    ReturnStockResponse         ReturnStock(1: ReturnStockRequest req)
+
+    // 统计使用商品且还没有结束的活动，商品服务删除商品时使用
+    CountProductActivitiesResponse  CountProductActivities(1: CountProductActivitiesRequest req)
+}
+
+service AdminActivityService{
+    // 将活动信息和库存重新加载到Redis
+    WarmUpActivityResponse      WarmUpActivity(1: WarmUpActivityRequest req)
+
+    // 查询最近一次的库存对账结果
+    GetStockReportsResponse     GetStockReports(1: GetStockReportsRequest req)
+
+    // 立即对账单个活动的库存
+    ReconcileStockResponse      ReconcileStock(1: ReconcileStockRequest req)
 }
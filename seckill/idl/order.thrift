@@ -12,6 +12,7 @@ enum OrderStatus{
     PAID      = 2,        // 订单已支付
     FAILED    = 3,        // 订单创建失败
     CANCELLED = 4,        // 订单已取消
+    PAYING    = 5,        // 订单支付中，支付完成前不能取消
 }
 
 // 订单信息
@@ -22,18 +23,20 @@ struct OrderInfo{
     4: i64          activityID  // 活动ID
     5: i64          productID   // 商品ID
     6: string       ProductName // 商品名称
-    // 作业文档中提到默认只购买一个商品，所以这里没有设置数量字段
-    7: double       amount      // 订单总金额
+    7: double       amount      // 订单总金额，秒杀价格乘以购买数量
     8: OrderStatus  status      // 订单状态
     9: i64          createTime  // 订单创建时间戳
     10:i64          payTime     // 订单支付时间戳
     11:i64          expireTime  // 订单过期时间戳
+    12:i32          quantity    // 购买数量
+    13:double       price       // 下单时的秒杀价格
 }
 
 // 创建订单
 struct CreateOrderRequest{
     1: i64          userID      // 用户ID
     2: i64          activityID  // 活动ID
+    3: i32          quantity    // 购买数量，不设置时为1，累计购买数量不能超过活动的限购数量
 }
 
 struct CreateOrderResponse{
@@ -51,16 +54,76 @@ struct GetOrderResponse{
     2: OrderInfo    orderInfo   // 订单信息
 }
 
-// 获取用户的订单列表
+// 获取用户的订单列表，按创建时间排序，按游标分页
 struct ListOrdersRequest{
     1: i64          userID      // 用户ID
     2: OrderStatus  status = -1 // 订单状态，-1表示所有订单
+    3: string       cursor      // 上一页返回的nextCursor，为空时从第一页开始
+    4: i32          pageSize    // 每页数量，默认20，最多100
+    5: optional i64 timeFrom    // 创建时间不早于该时间戳
+    6: optional i64 timeTo      // 创建时间早于该时间戳
+    7: bool         asc         // 是否按创建时间升序，默认降序
 }
 
 struct ListOrdersResponse{
     1: BaseResponse baseResponse
     2: list<OrderInfo> orders   // 订单列表
-    3: i64              total   // 订单总数
+    3: i64              total   // 符合筛选条件的订单总数，和分页无关
+    4: string       nextCursor  // 下一页的游标，没有下一页时为空
+    5: bool         hasMore     // 是否还有下一页
+}
+
+// 支付订单
+struct PayOrderRequest{
+    1: i64          userID      // 用户ID
+    2: string       orderSn     // 订单号
+}
+
+struct PayOrderResponse{
+    1: BaseResponse baseResponse
+    2: OrderInfo    orderInfo   // 订单信息
+}
+
+// 取消订单
+struct CancelOrderRequest{
+    1: i64          userID      // 用户ID
+    2: string       orderSn     // 订单号
+}
+
+struct CancelOrderResponse{
+    1: BaseResponse baseResponse
+    2: OrderInfo    orderInfo   // 订单信息
+}
+
+// 异步秒杀的排队结果
+enum SeckillStatus{
+    QUEUED  = 0,          // 排队中
+    SUCCESS = 1,          // 下单成功
+    FAILED  = 2,          // 下单失败
+}
+
+// 查询异步秒杀的结果
+struct GetSeckillStatusRequest{
+    1: i64          userID      // 用户ID
+    2: string       ticket      // 秒杀时返回的排队凭证
+}
+
+struct GetSeckillStatusResponse{
+    1: BaseResponse     baseResponse
+    2: SeckillStatus    status      // 排队结果
+    3: string           orderSn     // 下单成功时的订单号
+    4: string           reason      // 下单失败的原因
+}
+
+// 关闭或重新开放用户的下单，用户注销账户时关闭
+// 关闭后排队中的秒杀请求下单失败并归还库存，关闭前已经开始下单的订单创建后会被取消
+struct SetUserOrdersClosedRequest{
+    1: i64          userID      // 用户ID
+    2: bool         closed      // true表示关闭，false表示重新开放
+}
+
+struct SetUserOrdersClosedResponse{
+    1: BaseResponse baseResponse
 }
 
 service OrderService{
@@ -72,4 +135,16 @@ service OrderService{
 
     // 获取用户所有订单
     ListOrdersResponse ListOrders(1:ListOrdersRequest req)
+
+    // 支付订单
+    PayOrderResponse PayOrder(1:PayOrderRequest req)
+
+    // 取消订单
+    CancelOrderResponse CancelOrder(1:CancelOrderRequest req)
+
+    // 查询异步秒杀结果
+    GetSeckillStatusResponse GetSeckillStatus(1:GetSeckillStatusRequest req)
+
+    // 关闭或重新开放用户的下单
+    SetUserOrdersClosedResponse SetUserOrdersClosed(1:SetUserOrdersClosedRequest req)
 }
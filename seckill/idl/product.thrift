@@ -0,0 +1,125 @@
+namespace go product
+
+// 基础的response
+struct BaseResponse{
+    1: i32      code     // 返回响应的状态码，0表示成功
+    2: string   msg      // 返回响应信息
+}
+
+// 商品信息
+struct ProductInfo{
+    1: i64      id              // 商品ID
+    2: string   name            // 商品名称
+    3: string   description     // 商品描述
+    4: double   price           // 商品原价
+    5: string   imageUrl        // 商品图片地址
+    6: string   category        // 商品分类
+    7: i64      createTime      // 创建时间戳
+    8: i64      merchantID      // 所属商家ID，0表示由管理员创建
+}
+
+// 创建商品
+struct CreateProductRequest{
+    1: string   name            // 商品名称
+    2: string   description     // 商品描述
+    3: double   price           // 商品原价
+    4: string   imageUrl        // 商品图片地址
+    5: string   category        // 商品分类
+    6: i64      merchantID      // 所属商家ID，商家创建时由网关设置为当前用户
+}
+
+struct CreateProductResponse{
+    1: BaseResponse     baseResponse
+    2: i64              productID   // 商品ID
+}
+
+// 修改商品，只修改设置了的字段
+struct UpdateProductRequest{
+    1: i64              productID       // 商品ID
+    2: optional string  name            // 商品名称
+    3: optional string  description     // 商品描述
+    4: optional double  price           // 商品原价
+    5: optional string  imageUrl        // 商品图片地址
+    6: optional string  category        // 商品分类
+}
+
+struct UpdateProductResponse{
+    1: BaseResponse     baseResponse
+    2: ProductInfo      product     // 修改后的商品信息
+}
+
+// 获取商品
+struct GetProductRequest{
+    1: i64              productID   // 商品ID
+}
+
+struct GetProductResponse{
+    1: BaseResponse     baseResponse
+    2: ProductInfo      product     // 商品信息
+}
+
+// 获取商品列表
+struct ListProductsRequest{
+    1: string           category    // 商品分类，为空表示所有分类
+    2: i32              page = 1    // 页码，从1开始
+    3: i32              pageSize = 20   // 每页数量
+    4: i64              merchantID  // 所属商家ID，0表示所有商家
+}
+
+struct ListProductsResponse{
+    1: BaseResponse         baseResponse
+    2: list<ProductInfo>    products    // 商品列表
+    3: i64                  total       // 商品总数
+}
+
+// 删除商品，有未结束的活动使用该商品时不能删除
+struct DeleteProductRequest{
+    1: i64              productID   // 商品ID
+}
+
+struct DeleteProductResponse{
+    1: BaseResponse     baseResponse
+}
+
+// 批量获取商品，不存在或已删除的商品不返回
+struct BatchGetProductsRequest{
+    1: list<i64>        productIDs  // 商品ID，最多100个
+}
+
+struct BatchGetProductsResponse{
+    1: BaseResponse         baseResponse
+    2: list<ProductInfo>    products    // 商品列表
+}
+
+// 按名称模糊查询商品ID
+struct SearchProductIDsRequest{
+    1: string           name        // 商品名称，模糊匹配，匹配的商品超过1000个时返回400
+}
+
+struct SearchProductIDsResponse{
+    1: BaseResponse     baseResponse
+    2: list<i64>        productIDs  // 名称匹配的商品ID
+}
+
+service ProductService{
+    // 创建商品
+    CreateProductResponse   CreateProduct(1: CreateProductRequest req)
+
+    // 修改商品
+    UpdateProductResponse   UpdateProduct(1: UpdateProductRequest req)
+
+    // 获取商品详情
+    GetProductResponse      GetProduct(1: GetProductRequest req)
+
+    // 获取商品列表
+    ListProductsResponse    ListProducts(1: ListProductsRequest req)
+
+    // 删除商品
+    DeleteProductResponse   DeleteProduct(1: DeleteProductRequest req)
+
+    // 批量获取商品
+    BatchGetProductsResponse    BatchGetProducts(1: BatchGetProductsRequest req)
+
+    // 按名称查询商品ID
+    SearchProductIDsResponse    SearchProductIDs(1: SearchProductIDsRequest req)
+}
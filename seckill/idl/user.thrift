@@ -10,6 +10,7 @@ struct BaseResp {
 struct UserInfo {
     1: i64 id                  // 用户ID
     2: string username         // 用户名
+    3: string role             // 角色: user, merchant, admin
 }
 
 // 用户注册请求
@@ -34,6 +35,79 @@ struct LoginRequest {
 struct LoginResponse {
     1: BaseResp baseResp       // 基础响应
     2: i64 userId              // 用户ID
+    3: string accessToken      // 访问令牌，请求时放在Authorization: Bearer <token>中
+    4: i64 expiresAt           // 访问令牌过期时间戳
+    5: string role             // 用户角色
+}
+
+// 以下请求只能操作访问令牌所属的用户，用户服务会重新校验令牌
+// 获取当前用户信息请求
+struct GetUserInfoRequest {
+    1: string accessToken      // 当前请求使用的访问令牌，由网关填写
+}
+
+// 获取当前用户信息响应
+struct GetUserInfoResponse {
+    1: BaseResp baseResp       // 基础响应
+    2: UserInfo user           // 用户信息
+}
+
+// 修改密码请求，修改后该用户已签发的令牌全部失效，需要重新登录
+struct UpdatePasswordRequest {
+    1: string accessToken      // 当前请求使用的访问令牌，由网关填写
+    2: string oldPassword      // 原密码
+    3: string password         // 新密码
+}
+
+// 修改密码响应
+struct UpdatePasswordResponse {
+    1: BaseResp baseResp       // 基础响应
+}
+
+// 退出登录请求，撤销当前的访问令牌
+struct LogoutRequest {
+    1: string accessToken      // 当前请求使用的访问令牌，由网关填写
+}
+
+// 退出登录响应
+struct LogoutResponse {
+    1: BaseResp baseResp       // 基础响应
+}
+
+// 注销账户请求，需要再次输入密码确认
+struct DeleteAccountRequest {
+    1: string accessToken      // 当前请求使用的访问令牌，由网关填写
+    2: string password         // 当前密码
+}
+
+// 注销账户响应
+struct DeleteAccountResponse {
+    1: BaseResp baseResp       // 基础响应
+    2: i32 cancelledOrders     // 注销时取消的未支付订单数
+}
+
+// 授予角色请求，用户只有一个角色，授予后替换原来的角色
+struct GrantRoleRequest {
+    1: i64 userId              // 用户ID
+    2: string role             // 角色: user, merchant, admin
+}
+
+// 授予角色响应
+struct GrantRoleResponse {
+    1: BaseResp baseResp       // 基础响应
+    2: UserInfo user           // 修改后的用户信息
+}
+
+// 撤销角色请求，撤销后恢复为普通用户
+struct RevokeRoleRequest {
+    1: i64 userId              // 用户ID
+    2: string role             // 要撤销的角色，需要和用户当前的角色一致
+}
+
+// 撤销角色响应
+struct RevokeRoleResponse {
+    1: BaseResp baseResp       // 基础响应
+    2: UserInfo user           // 修改后的用户信息
 }
 
 // 用户服务
@@ -43,4 +117,25 @@ service UserService {
     
     // 用户登录
     LoginResponse Login(1: LoginRequest req)
+
+    // 获取当前用户信息
+    GetUserInfoResponse GetUserInfo(1: GetUserInfoRequest req)
+
+    // 修改密码
+    UpdatePasswordResponse UpdatePassword(1: UpdatePasswordRequest req)
+
+    // 退出登录
+    LogoutResponse Logout(1: LogoutRequest req)
+
+    // 注销账户，未支付的订单会被取消
+    DeleteAccountResponse DeleteAccount(1: DeleteAccountRequest req)
+}
+
+// 用户管理服务，只有管理员可以调用
+service AdminUserService {
+    // 授予角色
+    GrantRoleResponse GrantRole(1: GrantRoleRequest req)
+
+    // 撤销角色
+    RevokeRoleResponse RevokeRole(1: RevokeRoleRequest req)
 }
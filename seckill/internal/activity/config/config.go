@@ -16,4 +16,31 @@ type Config struct{
 	Server 		ServerConfig 				`mapstructure:"server"` 
 	Database 	database.DatabaseConfig 	`mapstructure:"database"`
 	Redis 		redis.RedisConfig 			`mapstructure:"redis"`
+	WarmUp		WarmUpConfig				`mapstructure:"warm_up"`
+	Reconcile	ReconcileConfig				`mapstructure:"reconcile"`
+	Lifecycle	LifecycleConfig				`mapstructure:"lifecycle"`
+	ProductRPC	ProductRPCConfig			`mapstructure:"product_rpc"`
+}
+
+// 活动缓存预热配置
+type WarmUpConfig struct{
+	Interval	int		`mapstructure:"interval"` // 定时预热的间隔(秒)
+}
+
+// 库存对账配置
+type ReconcileConfig struct{
+	Interval		int		`mapstructure:"interval"`			// 对账间隔(秒)
+	SourceOfTruth	string	`mapstructure:"source_of_truth"`	// 修正策略: none, redis, orders
+}
+
+// 活动状态调度配置
+type LifecycleConfig struct{
+	ResyncInterval	int		`mapstructure:"resync_interval"`	// 从数据库重建定时器的间隔(秒)
+}
+
+// 商品服务客户端配置
+type ProductRPCConfig struct{
+	Host		string	`mapstructure:"host"`
+	Port		int		`mapstructure:"port"`
+	Timeout		int		`mapstructure:"timeout"`	// 毫秒
 }
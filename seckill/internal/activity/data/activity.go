@@ -6,6 +6,7 @@ import (
 
 	"Redrock/seckill/internal/pkg/database"
 	"Redrock/seckill/internal/pkg/models"
+	"Redrock/seckill/internal/pkg/pagination"
 
 	"gorm.io/gorm"
 )
@@ -36,8 +37,84 @@ func (d *ActivityData) GetByID(ctx context.Context, id uint) (*models.Activity,
 	return &activity, nil
 }
 
-// List 获取活动
-func (d *ActivityData) List(ctx context.Context, status int) ([]*models.Activity, int64, error){
+// ListUnfinished 获取在now时还没有结束的活动
+func (d *ActivityData) ListUnfinished(ctx context.Context, now time.Time) ([]*models.Activity, error){
+	var activities []*models.Activity
+
+	err := d.db.WithContext(ctx).Where("end_time > ?", now).Find(&activities).Error
+	if err != nil{
+		return nil, err
+	}
+
+	return activities, nil
+}
+
+// 活动列表的排序字段
+const(
+	SortByCreated	= "created"		// 创建时间
+	SortByStartTime	= "start_time"	// 开始时间
+	SortByPrice		= "price"		// 秒杀价格
+)
+
+// ActivityFilter 活动列表的筛选和排序条件
+type ActivityFilter struct{
+	Status		int			// 活动状态，-1表示所有状态
+	TimeFrom	*time.Time	// 和[TimeFrom, TimeTo)有重叠的活动
+	TimeTo		*time.Time
+	MinPrice	*float64	// 秒杀价格范围
+	MaxPrice	*float64
+	ProductIDs	[]uint		// 商品ID，为nil时不筛选
+	SortBy		string		// 排序字段，为空时按创建时间排序
+	Asc			bool		// 是否升序
+}
+
+// ValidSortBy 检查排序字段是否合法
+func ValidSortBy(sortBy string) bool{
+	switch sortBy{
+	case "", SortByCreated, SortByStartTime, SortByPrice:
+		return true
+	default:
+		return false
+	}
+}
+
+// SortKey 排序方式的标识，记录在分页游标中
+func (f *ActivityFilter) SortKey() string{
+	sortBy := f.SortBy
+	if sortBy == ""{
+		sortBy = SortByCreated
+	}
+	if f.Asc{
+		return sortBy + ":asc"
+	}
+
+	return sortBy + ":desc"
+}
+
+// sortValue 获取活动的排序字段的列名和游标中的值
+func (f *ActivityFilter) sortValue(a *models.Activity) (string, string){
+	switch f.SortBy{
+	case SortByStartTime:
+		return "activities.start_time", pagination.TimeValue(a.StartTime)
+	case SortByPrice:
+		return "activities.seckill_price", pagination.FloatValue(a.SeckillPrice)
+	default:
+		return "activities.created_at", pagination.TimeValue(a.CreatedAt)
+	}
+}
+
+// parseCursorValue 解析游标中的排序值
+func (f *ActivityFilter) parseCursorValue(cursor *pagination.Cursor) (any, error){
+	if f.SortBy == SortByPrice{
+		return cursor.ParseFloat()
+	}
+
+	return cursor.ParseTime()
+}
+
+// List 按筛选条件分页获取活动列表，cursor为nil时获取第一页
+// 返回的总数是符合筛选条件的活动总数，有下一页时返回下一页的游标
+func (d *ActivityData) List(ctx context.Context, filter *ActivityFilter, cursor *pagination.Cursor, limit int) ([]*models.Activity, int64, *pagination.Cursor, error){
 	var activities []*models.Activity
 	var count int64
 
@@ -45,22 +122,60 @@ func (d *ActivityData) List(ctx context.Context, status int) ([]*models.Activity
 
 	// 因为我们定义当status = -1时获取所有活动
 	// 因此定义一个过滤条件
-	if status >= 0{
-		query = query.Where("status = ?", status)
+	if filter.Status >= 0{
+		query = query.Where("activities.status = ?", filter.Status)
+	}
+	if filter.TimeFrom != nil{
+		query = query.Where("activities.end_time > ?", *filter.TimeFrom)
+	}
+	if filter.TimeTo != nil{
+		query = query.Where("activities.start_time < ?", *filter.TimeTo)
+	}
+	if filter.MinPrice != nil{
+		query = query.Where("activities.seckill_price >= ?", *filter.MinPrice)
+	}
+	if filter.MaxPrice != nil{
+		query = query.Where("activities.seckill_price <= ?", *filter.MaxPrice)
+	}
+	if filter.ProductIDs != nil{
+		query = query.Where("activities.product_id IN ?", filter.ProductIDs)
 	}
 
-	// 获取记录的数量
+	// 获取符合条件的记录数量，不受分页影响
 	if err := query.Count(&count).Error; err != nil{
-		return nil, 0, err
+		return nil, 0, nil, err
+	}
+
+	column, _ := filter.sortValue(&models.Activity{})
+	var value any
+	if cursor != nil{
+		var err error
+		value, err = filter.parseCursorValue(cursor)
+		if err != nil{
+			return nil, 0, nil, err
+		}
 	}
 
-	// 查询所需记录
-	err := query.Preload("Product").Order("created_at DESC").Find(&activities).Error
+	// 多查询一条判断是否还有下一页
+	err := pagination.Seek(query, column, "activities.id", filter.Asc, cursor, value).
+		Limit(limit + 1).Find(&activities).Error
 	if err != nil{
-		return nil, 0, err
+		return nil, 0, nil, err
+	}
+
+	if len(activities) <= limit{
+		return activities, count, nil, nil
 	}
 
-	return activities, count, nil
+	activities = activities[:limit]
+	last := activities[limit - 1]
+	_, lastValue := filter.sortValue(last)
+
+	return activities, count, &pagination.Cursor{
+		Sort:	filter.SortKey(),
+		Value:	lastValue,
+		ID:		last.ID,
+	}, nil
 }
 
 // UpdateStock 更新库存
@@ -70,6 +185,75 @@ func (d *ActivityData) UpdateStock(ctx context.Context, id uint, stock int64) er
 	return err
 }
 
+// IncreaseStock 增加库存
+func (d *ActivityData) IncreaseStock(ctx context.Context, id uint, count int64) error{
+	err := d.db.WithContext(ctx).Model(&models.Activity{}).Where("id = ?", id).
+		Update("available_stock", gorm.Expr("available_stock + ?", count)).Error
+
+	return err
+}
+
+// DecreaseStock 减少库存
+func (d *ActivityData) DecreaseStock(ctx context.Context, id uint, count int64) error{
+	err := d.db.WithContext(ctx).Model(&models.Activity{}).Where("id = ?", id).
+		Update("available_stock", gorm.Expr("available_stock - ?", count)).Error
+
+	return err
+}
+
+// CompareAndSetStock 库存仍为oldStock时更新为newStock，返回是否更新
+func (d *ActivityData) CompareAndSetStock(ctx context.Context, id uint, oldStock int64, newStock int64) (bool, error){
+	result := d.db.WithContext(ctx).Model(&models.Activity{}).
+		Where("id = ? AND available_stock = ?", id, oldStock).
+		Update("available_stock", newStock)
+	if result.Error != nil{
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// SumSoldQuantity 统计活动中未取消且未失败的订单的商品数量
+func (d *ActivityData) SumSoldQuantity(ctx context.Context, activityID uint) (int64, error){
+	var sold int64
+
+	err := d.db.WithContext(ctx).Model(&models.Order{}).
+		Where("activity_id = ? AND status NOT IN ?", activityID, []int{models.StatusFailed, models.StatusCancelled}).
+		Select("COALESCE(SUM(quantity), 0)").
+		Scan(&sold).Error
+	if err != nil{
+		return 0, err
+	}
+
+	return sold, nil
+}
+
+// soldOrderQueryBatch 按订单号查询订单时每批的订单号数量
+const soldOrderQueryBatch = 500
+
+// FilterSoldOrders 从orderSns中找出活动中未取消且未失败的订单，返回这些订单的订单号
+func (d *ActivityData) FilterSoldOrders(ctx context.Context, activityID uint, orderSns []string) (map[string]bool, error){
+	sold := make(map[string]bool)
+
+	for start := 0; start < len(orderSns); start += soldOrderQueryBatch{
+		end := min(start + soldOrderQueryBatch, len(orderSns))
+
+		var found []string
+		err := d.db.WithContext(ctx).Model(&models.Order{}).
+			Where("activity_id = ? AND order_sn IN ? AND status NOT IN ?", activityID, orderSns[start:end], []int{models.StatusFailed, models.StatusCancelled}).
+			Pluck("order_sn", &found).Error
+		if err != nil{
+			return nil, err
+		}
+
+		for _, orderSn := range found{
+			sold[orderSn] = true
+		}
+	}
+
+	return sold, nil
+}
+
 // 接下来来处理活动的状态
 
 // UpdataStatus 更新活动状态 
@@ -79,22 +263,80 @@ func (d *ActivityData) UpdateActivityStatus(ctx context.Context, id uint, status
 	return err
 }
 
-// AutoUpdateActivityStatus 根据活动的时间自动更新活动状态
-func (d *ActivityData) AutoUpdateActivityStatus(ctx context.Context) error{
-	now := time.Now()
+// TransitStatus 活动状态仍为from中的一个时更新为to，返回是否更新
+// 条件更新保证多个实例或并发的操作只有一个能切换成功
+func (d *ActivityData) TransitStatus(ctx context.Context, id uint, from []int, to int) (bool, error){
+	result := d.db.WithContext(ctx).Model(&models.Activity{}).
+		Where("id = ? AND status IN ?", id, from).
+		Update("status", to)
+	if result.Error != nil{
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// CountUnfinishedByProduct 统计使用该商品且状态还不是已结束的活动数量
+func (d *ActivityData) CountUnfinishedByProduct(ctx context.Context, productID uint) (int64, error){
+	var count int64
 
-	// 修改截止时间已过但未结束的活动
-	err := d.db.WithContext(ctx).Model(models.Activity{}).
-		Where("end_time < ? AND status != ?", now, 2).
-		Update("status",2).Error
+	err := d.db.WithContext(ctx).Model(&models.Activity{}).
+		Where("product_id = ? AND status != ?", productID, models.ActivityEnded).
+		Count(&count).Error
 	if err != nil{
-		return err
+		return 0, err
 	}
 
-	// 修改开始时间未到但未开始的活动
-	err = d.db.WithContext(ctx).Model(models.Activity{}).
-		Where("start_time <= ? AND end_time > ? AND status = ?",now,now,0).
-		Update("status", 1).Error
+	return count, nil
+}
+
+// ListNotEnded 获取状态还不是已结束的活动
+func (d *ActivityData) ListNotEnded(ctx context.Context) ([]*models.Activity, error){
+	var activities []*models.Activity
 
-	return err
+	err := d.db.WithContext(ctx).Where("status != ?", models.ActivityEnded).Find(&activities).Error
+	if err != nil{
+		return nil, err
+	}
+
+	return activities, nil
+}
+
+// UpdateFields 活动状态为statuses中的一个时修改活动，返回是否修改
+func (d *ActivityData) UpdateFields(ctx context.Context, id uint, statuses []int, fields map[string]any) (bool, error){
+	result := d.db.WithContext(ctx).Model(&models.Activity{}).
+		Where("id = ? AND status IN ?", id, statuses).
+		Updates(fields)
+	if result.Error != nil{
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// AdjustStock 活动状态为statuses中的一个时同时调整总库存和可用库存，可用库存不会小于0，返回是否调整
+func (d *ActivityData) AdjustStock(ctx context.Context, id uint, statuses []int, delta int64) (bool, error){
+	result := d.db.WithContext(ctx).Model(&models.Activity{}).
+		Where("id = ? AND status IN ? AND available_stock + ? >= 0", id, statuses, delta).
+		Updates(map[string]any{
+			"total_stock":		gorm.Expr("total_stock + ?", delta),
+			"available_stock":	gorm.Expr("available_stock + ?", delta),
+		})
+	if result.Error != nil{
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// Delete 活动状态为statuses中的一个时删除活动(软删除)，返回是否删除
+func (d *ActivityData) Delete(ctx context.Context, id uint, statuses []int) (bool, error){
+	result := d.db.WithContext(ctx).
+		Where("id = ? AND status IN ?", id, statuses).
+		Delete(&models.Activity{})
+	if result.Error != nil{
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
 }
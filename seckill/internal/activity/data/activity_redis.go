@@ -16,16 +16,31 @@ const(
 	// 活动信息缓存键名前缀
 	activityCacheKeyPrefix = "activity:info:"
 
+	// 扣除库存时检查的活动时间、状态和限购数量的键名前缀，哈希类型
+	activityMetaKeyPrefix = "activity:meta:"
+
 	// 活动库存缓存键名前缀
 	StockCacheKeyPrefix = "activity:stock:"
 
-	// 用户参与活动记录简明前缀
+	// 用户在活动中累计购买数量的键名前缀
 	userJoinKeyPrefix = "activity:join:user:"
 
+	// 订单扣除库存记录键名前缀
+	stockDeductKeyPrefix = "activity:deduct:"
+
+	// 活动中扣除过库存且没有归还的订单键名前缀，哈希类型，字段为订单号，值为扣除的数量，用于库存对账
+	deductedOrdersKeyPrefix = "activity:deducted:"
+
 	// 缓存数据过期时间
 	cacheExpireTime = 24 * time.Hour // 默认过期时间为24小时
 )
 
+// activityExpiration 活动相关缓存的过期时间，在活动结束后再保留cacheExpireTime
+// 活动结束后仍可能有订单取消需要归还库存
+func activityExpiration(endTime time.Time) time.Duration{
+	return max(time.Until(endTime), 0) + cacheExpireTime
+}
+
 type ActivityRedis struct{
 	client *redis.Client
 }
@@ -43,8 +58,11 @@ func (r *ActivityRedis) GetRedis() *redis.Client{
 }
 
 // SaveActivity 将活动信息保存到Redis
+// 同时保存扣除库存时检查的活动时间、状态和限购数量，扣除库存时不需要查询数据库
 func (r *ActivityRedis) SaveActivity(ctx context.Context, activity *models.Activity) error{
-	key :=  fmt.Sprintf("%s%d", activityCacheKeyPrefix, activity.ID)
+	key     := fmt.Sprintf("%s%d", activityCacheKeyPrefix, activity.ID)
+	metaKey := fmt.Sprintf("%s%d", activityMetaKeyPrefix, activity.ID)
+	expiration := activityExpiration(activity.EndTime)
 
 	// 将数据序列化为json后保存在redis
 	// func json.Marshal(v any) ([]byte, error)
@@ -52,11 +70,38 @@ func (r *ActivityRedis) SaveActivity(ctx context.Context, activity *models.Activ
 	if err != nil{
 		return err
 	}
-	// client.set()需要将字节切片转化为字符串
-	err = r.client.Set(ctx, key, string(data), cacheExpireTime).Err()
+
+	// 活动信息和扣除库存检查的信息在同一个事务中更新
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error{
+		// client.set()需要将字节切片转化为字符串
+		pipe.Set(ctx, key, string(data), expiration)
+		pipe.HSet(ctx, metaKey,
+			"start",	activity.StartTime.Unix(),
+			"end",		activity.EndTime.Unix(),
+			"status",	activity.Status,
+			"limit",	activity.PerUserLimit,
+		)
+		pipe.Expire(ctx, metaKey, expiration)
+		return nil
+	})
 	return err
 }
 
+// GetPerUserLimit 获取Redis中活动的每人限购数量，活动信息不存在时返回0
+func (r *ActivityRedis) GetPerUserLimit(ctx context.Context, activityID uint) (int64, error){
+	metaKey := fmt.Sprintf("%s%d", activityMetaKeyPrefix, activityID)
+
+	limit, err := r.client.HGet(ctx, metaKey, "limit").Int64()
+	if err != nil{
+		if err == redis.Nil{
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return limit, nil
+}
+
 // GetActivity 从Redis中获取活动信息
 func (r *ActivityRedis) GetActivity(ctx context.Context, id uint) (*models.Activity, error){
 	key := fmt.Sprintf("%s%d", activityCacheKeyPrefix, id)
@@ -81,15 +126,27 @@ func (r *ActivityRedis) GetActivity(ctx context.Context, id uint) (*models.Activ
 	return &activity, nil
 }
 
-// InitStock 初始化库存信息到Redis
-func (r *ActivityRedis) InitStock(ctx context.Context, activityID uint, stock int64) (int64, error){
+// InitStock 初始化库存信息到Redis，过期时间和活动结束时间对齐
+// 库存已存在时只刷新过期时间，返回是否写入了库存
+func (r *ActivityRedis) InitStock(ctx context.Context, activityID uint, stock int64, endTime time.Time) (bool, error){
 	key := fmt.Sprintf("%s%d", StockCacheKeyPrefix, activityID)
+	expiration := activityExpiration(endTime)
 
 	// 使用SetNX而不是Set的原因：
-	// 仅在键不存在时设置key的值，即初始化
-	err := r.client.SetNX(ctx, key, stock, cacheExpireTime).Err()
+	// 仅在键不存在时设置key的值，即初始化，避免覆盖已经扣除过的库存
+	created, err := r.client.SetNX(ctx, key, stock, expiration).Result()
+	if err != nil{
+		return false, err
+	}
 
-	return stock,err
+	if !created{
+		err = r.client.Expire(ctx, key, expiration).Err()
+		if err != nil{
+			return false, err
+		}
+	}
+
+	return created, nil
 }
 
 // GetStock 获取当前库存
@@ -114,55 +171,234 @@ func (r *ActivityRedis) GetStock(ctx context.Context, activityID uint) (int64, e
 	return stock, nil
 }
 
-// DeductStock 扣除库存 (Redis的事务无法回退，因此使用lua脚本保证原子性)
-func(r *ActivityRedis) DeductStock(ctx context.Context, activityID uint, count int64) (bool, error){
+// LookupStockAndOrders 在同一个事务中获取当前库存和扣除过库存且没有归还的订单，并返回库存缓存是否存在
+func (r *ActivityRedis) LookupStockAndOrders(ctx context.Context, activityID uint) (int64, bool, map[string]int64, error){
+	stockKey  := fmt.Sprintf("%s%d", StockCacheKeyPrefix, activityID)
+	ordersKey := fmt.Sprintf("%s%d", deductedOrdersKeyPrefix, activityID)
+
+	var stockCmd *redis.StringCmd
+	var ordersCmd *redis.MapStringStringCmd
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error{
+		stockCmd = pipe.Get(ctx, stockKey)
+		ordersCmd = pipe.HGetAll(ctx, ordersKey)
+		return nil
+	})
+	if err != nil && err != redis.Nil{
+		return 0, false, nil, err
+	}
+
+	orders := make(map[string]int64)
+	for orderSn, value := range ordersCmd.Val(){
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil{
+			return 0, false, nil, fmt.Errorf("订单%s的扣除数量格式错误：%w", orderSn, err)
+		}
+		orders[orderSn] = count
+	}
+
+	stock, err := stockCmd.Int64()
+	if err != nil{
+		if err == redis.Nil{
+			return 0, false, orders, nil
+		}
+		return 0, false, nil, err
+	}
+
+	return stock, true, orders, nil
+}
+
+// CompareAndSetStock 库存仍为oldStock时更新为newStock，不改变过期时间，返回是否更新
+func (r *ActivityRedis) CompareAndSetStock(ctx context.Context, activityID uint, oldStock int64, newStock int64) (bool, error){
+	key := fmt.Sprintf("%s%d", StockCacheKeyPrefix, activityID)
+
+	script := `
+	if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+		return 0
+	end
+
+	redis.call("SET", KEYS[1], ARGV[2], "KEEPTTL")
+	return 1
+	`
+	result, err := r.client.Eval(ctx, script, []string{key}, oldStock, newStock).Int()
+	if err != nil{
+		return false, err
+	}
+
+	return result == 1, nil
+}
+
+// AdjustStock 的结果，调整成功时返回调整后的库存
+const(
+	AdjustNoStock		= -1	// 库存信息不存在
+	AdjustInsufficient	= -2	// 调整后库存小于0
+)
+
+// AdjustStock 调整Redis中的库存，不改变过期时间
+// 库存不存在时不创建，由预热按数据库中的库存重新加载
+func (r *ActivityRedis) AdjustStock(ctx context.Context, activityID uint, delta int64) (int64, error){
 	key := fmt.Sprintf("%s%d", StockCacheKeyPrefix, activityID)
 
-	// 使用lua脚本扣除库存以保证原子性
 	script := `
 	local stock = tonumber(redis.call("GET", KEYS[1]))
 	if stock == nil then
 		return -1 -- 库存不存在
 	end
 
-	if stock < tonumber(ARGV[1]) then
-		return 0 -- 库存不足
+	if stock + tonumber(ARGV[1]) < 0 then
+		return -2 -- 库存不足
 	end
 
-	redis.call("decrby", KEYS[1], ARGV[1])
-		return 1 -- 扣除成功
+	return redis.call("INCRBY", KEYS[1], ARGV[1])
 	`
-	// 执行lua脚本
-	result, err := r.client.Eval(ctx, script, []string{key}, count).Int64()
+	result, err := r.client.Eval(ctx, script, []string{key}, delta).Int64()
 	if err != nil{
-		return false, err
+		return 0, err
 	}
 
-	if result == -1{
-		return false, fmt.Errorf("库存信息不存在")
-	}
+	return result, nil
+}
 
-	return result == 1, nil
+// DeleteActivity 删除活动信息和库存缓存
+// 用户参与记录和订单扣除记录保留到过期，删除后仍可以归还(不会创建库存)
+func (r *ActivityRedis) DeleteActivity(ctx context.Context, activityID uint) error{
+	infoKey  := fmt.Sprintf("%s%d", activityCacheKeyPrefix, activityID)
+	metaKey  := fmt.Sprintf("%s%d", activityMetaKeyPrefix, activityID)
+	stockKey := fmt.Sprintf("%s%d", StockCacheKeyPrefix, activityID)
+
+	return r.client.Del(ctx, infoKey, metaKey, stockKey).Err()
 }
 
-/// RecordUserJoin 记录用户参与记录
-func (r *ActivityRedis) RecordUserJoin(ctx context.Context, userID uint, activityID uint) error{
-	key := fmt.Sprintf("%s%d:%d",userJoinKeyPrefix,userID,activityID)
+// DeductStock 的结果
+const(
+	DeductSuccess		= 1		// 扣除成功
+	DeductRepeated		= 2		// 同一订单已经扣除过，本次没有扣除
+	DeductSoldOut		= 0		// 库存不足
+	DeductNoStock		= -1	// 库存信息不存在
+	DeductOverLimit		= -2	// 用户累计购买数量超过限购数量
+	DeductReturned		= -3	// 同一订单已经归还过库存，不能再扣除
+	DeductNoActivity	= -4	// 活动信息不存在，需要从数据库预热
+	DeductNotStarted	= -5	// 活动尚未开始
+	DeductEnded			= -6	// 活动已结束
+	DeductUnavailable	= -7	// 活动不是进行中的状态(如已暂停)
+)
 
-	// 设置用户参与记录以及有效期
-	err := r.client.SetNX(ctx, key, 1, cacheExpireTime).Err()
-	return err
+// deductReturnedMark 归还库存后订单扣除记录的值，之后同一订单的扣除会被拒绝
+const deductReturnedMark = "returned"
+
+// DeductStock 秒杀资格检查：检查活动时间和状态、检查用户累计购买数量、检查并扣除库存、累加用户购买数量，在同一个lua脚本中完成
+// 活动的时间、状态和限购数量由SaveActivity保存在Redis中，扣除库存时不查询数据库
+// lua脚本在Redis中原子执行，不需要额外的分布式锁，并发下单时用户累计购买数量也不会超过限购数量
+// 同一订单重复扣除时返回DeductRepeated，已经归还过时返回DeductReturned，扣除成功时会记录该订单扣除的数量用于归还
+// 购买数量和扣除记录不会早于库存过期，保证活动期间不会超过限购数量
+func (r *ActivityRedis) DeductStock(ctx context.Context, activityID uint, userID uint, count int64, orderSn string, now time.Time) (int, error){
+	stockKey  := fmt.Sprintf("%s%d", StockCacheKeyPrefix, activityID)
+	joinKey   := fmt.Sprintf("%s%d:%d", userJoinKeyPrefix, userID, activityID)
+	deductKey := fmt.Sprintf("%s%s", stockDeductKeyPrefix, orderSn)
+	metaKey   := fmt.Sprintf("%s%d", activityMetaKeyPrefix, activityID)
+	ordersKey := fmt.Sprintf("%s%d", deductedOrdersKeyPrefix, activityID)
+
+	script := `
+	local record = redis.call("GET", KEYS[3])
+	if record == ARGV[3] then
+		return -3 -- 该订单已归还过库存，迟到的扣除请求不能再扣除
+	end
+	if record then
+		return 2 -- 该订单已扣除过库存
+	end
+
+	local meta = redis.call("HMGET", KEYS[4], "start", "end", "status", "limit")
+	if not meta[1] then
+		return -4 -- 活动信息不存在
+	end
+
+	local now = tonumber(ARGV[4])
+	if now < tonumber(meta[1]) then
+		return -5 -- 活动尚未开始
+	end
+	if now > tonumber(meta[2]) then
+		return -6 -- 活动已结束
+	end
+	if tonumber(meta[3]) ~= 1 then
+		return -7 -- 活动不是进行中的状态
+	end
+
+	-- 早期的参与记录值为1，同样表示购买了1件
+	local bought = tonumber(redis.call("GET", KEYS[2])) or 0
+	if bought + tonumber(ARGV[1]) > tonumber(meta[4]) then
+		return -2 -- 超过限购数量
+	end
+
+	local stock = tonumber(redis.call("GET", KEYS[1]))
+	if stock == nil then
+		return -1 -- 库存不存在
+	end
+
+	if stock < tonumber(ARGV[1]) then
+		return 0 -- 库存不足
+	end
+
+	local ttl = math.max(redis.call("TTL", KEYS[1]), tonumber(ARGV[2]))
+
+	redis.call("DECRBY", KEYS[1], ARGV[1])
+	redis.call("INCRBY", KEYS[2], ARGV[1])
+	redis.call("EXPIRE", KEYS[2], ttl)
+	redis.call("SET", KEYS[3], ARGV[1], "EX", ttl)
+	redis.call("HSET", KEYS[5], ARGV[5], ARGV[1])
+	redis.call("EXPIRE", KEYS[5], ttl)
+	return 1 -- 扣除成功
+	`
+	result, err := r.client.Eval(ctx, script, []string{stockKey, joinKey, deductKey, metaKey, ordersKey}, count, int64(cacheExpireTime.Seconds()), deductReturnedMark, now.Unix(), orderSn).Int()
+	if err != nil{
+		return 0, err
+	}
+
+	return result, nil
 }
 
-// IsUserJoined 检查用户是否已参与活动
-func(r *ActivityRedis) IsUserJoined(ctx context.Context, userID uint, activityID uint) (bool, error){
-	key := fmt.Sprintf("%s%d:%d", userJoinKeyPrefix,userID,activityID)
+// ReturnStock 归还库存并从用户累计购买数量中减去该订单的数量，归还用户的限购额度
+// 只归还扣除库存时记录过的订单，同一订单重复归还或未扣除库存时返回0
+// 归还后扣除记录改为归还标记而不是删除，扣除请求晚于归还到达(例如扣除超时后已补偿)时不会再扣除库存
+func (r *ActivityRedis) ReturnStock(ctx context.Context, activityID uint, userID uint, orderSn string) (int64, error){
+	stockKey  := fmt.Sprintf("%s%d", StockCacheKeyPrefix, activityID)
+	joinKey   := fmt.Sprintf("%s%d:%d", userJoinKeyPrefix, userID, activityID)
+	deductKey := fmt.Sprintf("%s%s", stockDeductKeyPrefix, orderSn)
+	ordersKey := fmt.Sprintf("%s%d", deductedOrdersKeyPrefix, activityID)
 
-	// 检查记录是否存在
-	exists, err := r.client.Exists(ctx, key).Result()
+	// 检查记录、归还库存、归还限购额度在同一个lua脚本中完成，保证原子性
+	script := `
+	local record = redis.call("GET", KEYS[3])
+	if record == ARGV[1] then
+		return 0 -- 该订单已归还过库存
+	end
+
+	-- 归还标记保留到库存过期之后
+	local ttl = math.max(redis.call("TTL", KEYS[1]), redis.call("TTL", KEYS[3]), tonumber(ARGV[2]))
+
+	local count = tonumber(record)
+	if count == nil then
+		-- 扣除请求可能还没有到达，先留下归还标记
+		redis.call("SET", KEYS[3], ARGV[1], "EX", ttl)
+		return 0 -- 该订单没有需要归还的库存
+	end
+
+	-- 库存缓存不存在时不创建，避免生成一个只有归还数量的库存
+	if redis.call("EXISTS", KEYS[1]) == 1 then
+		redis.call("INCRBY", KEYS[1], count)
+	end
+
+	-- 减到0时删除购买数量
+	if redis.call("DECRBY", KEYS[2], count) <= 0 then
+		redis.call("DEL", KEYS[2])
+	end
+
+	redis.call("SET", KEYS[3], ARGV[1], "EX", ttl)
+	redis.call("HDEL", KEYS[4], ARGV[3])
+	return count
+	`
+	count, err := r.client.Eval(ctx, script, []string{stockKey, joinKey, deductKey, ordersKey}, deductReturnedMark, int64(cacheExpireTime.Seconds()), orderSn).Int64()
 	if err != nil{
-		return false, err
+		return 0, err
 	}
 
-	return exists == 1, nil
+	return count, nil
 }
@@ -0,0 +1,371 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"Redrock/seckill/internal/pkg/models"
+	"Redrock/seckill/internal/pkg/testenv"
+)
+
+// newTestRedis 使用进程内的miniredis，测试不依赖外部的Redis
+func newTestRedis(t *testing.T) *ActivityRedis{
+	return &ActivityRedis{client: testenv.NewRedis(t)}
+}
+
+// cleanupKeys 删除测试活动相关的所有键
+func cleanupKeys(t *testing.T, r *ActivityRedis, activityID uint){
+	ctx := context.Background()
+
+	patterns := []string{
+		fmt.Sprintf("%s%d", StockCacheKeyPrefix, activityID),
+		fmt.Sprintf("%s*:%d", userJoinKeyPrefix, activityID),
+		fmt.Sprintf("%stest-%d-*", stockDeductKeyPrefix, activityID),
+	}
+
+	for _, pattern := range patterns{
+		keys, err := r.client.Keys(ctx, pattern).Result()
+		if err != nil{
+			t.Fatalf("查询测试键失败：%v", err)
+		}
+		if len(keys) > 0{
+			r.client.Del(ctx, keys...)
+		}
+	}
+}
+
+// initTestActivity 保存进行中的测试活动和库存，活动在一小时后结束
+func initTestActivity(t *testing.T, r *ActivityRedis, activityID uint, stock int64, limit int64){
+	t.Helper()
+	ctx := context.Background()
+
+	a := &models.Activity{
+		StartTime:		time.Now().Add(-time.Minute),
+		EndTime:		time.Now().Add(time.Hour),
+		Status:			models.ActivityOngoing,
+		PerUserLimit:	limit,
+	}
+	a.ID = activityID
+
+	if err := r.SaveActivity(ctx, a); err != nil{
+		t.Fatalf("保存活动信息失败：%v", err)
+	}
+	if _, err := r.InitStock(ctx, activityID, stock, a.EndTime); err != nil{
+		t.Fatalf("初始化库存失败：%v", err)
+	}
+}
+
+func TestDeductStockConcurrent(t *testing.T){
+	r := newTestRedis(t)
+	ctx := context.Background()
+
+	const(
+		activityID		= 990001
+		stock			= 100
+		users			= 2000
+		requestsPerUser	= 3	// 每个用户用不同的订单号重复抢购
+	)
+
+	cleanupKeys(t, r, activityID)
+	t.Cleanup(func(){ cleanupKeys(t, r, activityID) })
+
+	initTestActivity(t, r, activityID, stock, 1)
+
+	var success atomic.Int64
+	var wins [users + 1]atomic.Int32
+
+	var wg sync.WaitGroup
+	for userID := 1; userID <= users; userID++{
+		for i := 0; i < requestsPerUser; i++{
+			wg.Add(1)
+			go func(userID int, i int){
+				defer wg.Done()
+
+				orderSn := fmt.Sprintf("test-%d-%d-%d", activityID, userID, i)
+				result, err := r.DeductStock(ctx, activityID, uint(userID), 1, orderSn, time.Now())
+				if err != nil{
+					t.Errorf("扣除库存失败：%v", err)
+					return
+				}
+
+				if result == DeductSuccess{
+					success.Add(1)
+					wins[userID].Add(1)
+				}
+			}(userID, i)
+		}
+	}
+	wg.Wait()
+
+	if got := success.Load(); got != stock{
+		t.Fatalf("成功扣除%d次，期望%d次", got, stock)
+	}
+
+	for userID := 1; userID <= users; userID++{
+		if got := wins[userID].Load(); got > 1{
+			t.Fatalf("用户%d抢购成功%d次", userID, got)
+		}
+	}
+
+	left, err := r.GetStock(ctx, activityID)
+	if err != nil{
+		t.Fatalf("获取库存失败：%v", err)
+	}
+	if left != 0{
+		t.Fatalf("剩余库存为%d，期望0", left)
+	}
+}
+
+func TestDeductStockIdempotent(t *testing.T){
+	r := newTestRedis(t)
+	ctx := context.Background()
+
+	const activityID = 990002
+
+	cleanupKeys(t, r, activityID)
+	t.Cleanup(func(){ cleanupKeys(t, r, activityID) })
+
+	initTestActivity(t, r, activityID, 10, 1)
+
+	orderSn := fmt.Sprintf("test-%d-1", activityID)
+	for i := 0; i < 2; i++{
+		want := DeductSuccess
+		if i > 0{
+			want = DeductRepeated
+		}
+
+		result, err := r.DeductStock(ctx, activityID, 1, 1, orderSn, time.Now())
+		if err != nil || result != want{
+			t.Fatalf("第%d次扣除结果为%d, %v，期望%d", i+1, result, err, want)
+		}
+	}
+
+	left, _ := r.GetStock(ctx, activityID)
+	if left != 9{
+		t.Fatalf("同一订单重复扣除后剩余库存为%d，期望9", left)
+	}
+
+	// 归还后用户可以重新参与
+	count, err := r.ReturnStock(ctx, activityID, 1, orderSn)
+	if err != nil || count != 1{
+		t.Fatalf("归还库存结果为%d, %v，期望1", count, err)
+	}
+
+	result, _ := r.DeductStock(ctx, activityID, 1, 1, fmt.Sprintf("test-%d-2", activityID), time.Now())
+	if result != DeductSuccess{
+		t.Fatalf("归还后重新扣除结果为%d，期望成功", result)
+	}
+}
+
+func TestDeductStockPerUserLimit(t *testing.T){
+	r := newTestRedis(t)
+	ctx := context.Background()
+
+	const(
+		activityID	= 990003
+		limit		= 3
+	)
+
+	cleanupKeys(t, r, activityID)
+	t.Cleanup(func(){ cleanupKeys(t, r, activityID) })
+
+	initTestActivity(t, r, activityID, 100, limit)
+
+	steps := []struct{
+		count	int64
+		want	int
+	}{
+		{2, DeductSuccess},
+		{2, DeductOverLimit},	// 累计4件，超过限购
+		{1, DeductSuccess},		// 累计3件，正好达到限购
+		{1, DeductOverLimit},
+	}
+	for i, step := range steps{
+		orderSn := fmt.Sprintf("test-%d-%d", activityID, i)
+		result, err := r.DeductStock(ctx, activityID, 1, step.count, orderSn, time.Now())
+		if err != nil || result != step.want{
+			t.Fatalf("第%d次购买%d件的结果为%d, %v，期望%d", i+1, step.count, result, err, step.want)
+		}
+	}
+
+	left, _ := r.GetStock(ctx, activityID)
+	if left != 97{
+		t.Fatalf("剩余库存为%d，期望97", left)
+	}
+
+	// 取消第一笔订单后归还2件的额度
+	count, err := r.ReturnStock(ctx, activityID, 1, fmt.Sprintf("test-%d-0", activityID))
+	if err != nil || count != 2{
+		t.Fatalf("归还库存结果为%d, %v，期望2", count, err)
+	}
+
+	result, _ := r.DeductStock(ctx, activityID, 1, 2, fmt.Sprintf("test-%d-4", activityID), time.Now())
+	if result != DeductSuccess{
+		t.Fatalf("归还额度后购买2件的结果为%d，期望成功", result)
+	}
+}
+
+func TestDeductStockPerUserLimitConcurrent(t *testing.T){
+	r := newTestRedis(t)
+	ctx := context.Background()
+
+	const(
+		activityID	= 990004
+		limit		= 5
+		requests	= 50
+	)
+
+	cleanupKeys(t, r, activityID)
+	t.Cleanup(func(){ cleanupKeys(t, r, activityID) })
+
+	initTestActivity(t, r, activityID, 1000, limit)
+
+	// 同一用户并发下单，累计购买数量不能超过限购
+	var bought atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++{
+		wg.Add(1)
+		go func(i int){
+			defer wg.Done()
+
+			orderSn := fmt.Sprintf("test-%d-%d", activityID, i)
+			result, err := r.DeductStock(ctx, activityID, 1, 2, orderSn, time.Now())
+			if err != nil{
+				t.Errorf("扣除库存失败：%v", err)
+				return
+			}
+			if result == DeductSuccess{
+				bought.Add(2)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if got := bought.Load(); got != 4{
+		t.Fatalf("用户累计购买%d件，期望4件", got)
+	}
+}
+
+// 归还后迟到的扣除请求不能再扣除库存
+func TestReturnStockTombstone(t *testing.T){
+	r := newTestRedis(t)
+	ctx := context.Background()
+
+	const activityID = 990005
+
+	cleanupKeys(t, r, activityID)
+	t.Cleanup(func(){ cleanupKeys(t, r, activityID) })
+
+	initTestActivity(t, r, activityID, 10, 5)
+
+	orderSn := fmt.Sprintf("test-%d-1", activityID)
+	if result, err := r.DeductStock(ctx, activityID, 1, 2, orderSn, time.Now()); err != nil || result != DeductSuccess{
+		t.Fatalf("扣除结果为%d, %v，期望成功", result, err)
+	}
+
+	for i := 0; i < 2; i++{
+		want := int64(2)
+		if i > 0{
+			want = 0
+		}
+
+		count, err := r.ReturnStock(ctx, activityID, 1, orderSn)
+		if err != nil || count != want{
+			t.Fatalf("第%d次归还结果为%d, %v，期望%d", i+1, count, err, want)
+		}
+	}
+
+	result, err := r.DeductStock(ctx, activityID, 1, 2, orderSn, time.Now())
+	if err != nil || result != DeductReturned{
+		t.Fatalf("归还后同一订单扣除结果为%d, %v，期望%d", result, err, DeductReturned)
+	}
+
+	// 扣除请求晚于归还到达(例如扣除超时后已经补偿)
+	lateSn := fmt.Sprintf("test-%d-2", activityID)
+	if count, err := r.ReturnStock(ctx, activityID, 1, lateSn); err != nil || count != 0{
+		t.Fatalf("归还未扣除的订单结果为%d, %v，期望0", count, err)
+	}
+
+	result, err = r.DeductStock(ctx, activityID, 1, 1, lateSn, time.Now())
+	if err != nil || result != DeductReturned{
+		t.Fatalf("迟到的扣除结果为%d, %v，期望%d", result, err, DeductReturned)
+	}
+
+	left, _ := r.GetStock(ctx, activityID)
+	if left != 10{
+		t.Errorf("剩余库存为%d，期望10", left)
+	}
+
+	bought, _ := r.client.Get(ctx, fmt.Sprintf("%s%d:%d", userJoinKeyPrefix, 1, activityID)).Int64()
+	if bought != 0{
+		t.Errorf("用户累计购买数量为%d，期望0", bought)
+	}
+}
+
+// 扣除库存时在Redis中检查活动的时间和状态，活动信息不存在时不扣除
+func TestDeductStockChecksActivity(t *testing.T){
+	r := newTestRedis(t)
+	ctx := context.Background()
+
+	const activityID = 990006
+	initTestActivity(t, r, activityID, 10, 2)
+
+	now := time.Now()
+	a := &models.Activity{
+		StartTime:		now.Add(-time.Minute),
+		EndTime:		now.Add(time.Hour),
+		Status:			models.ActivityOngoing,
+		PerUserLimit:	2,
+	}
+	a.ID = activityID
+
+	tests := []struct{
+		name	string
+		now		time.Time
+		status	int
+		want	int
+	}{
+		{"尚未开始", now.Add(-time.Hour), models.ActivityOngoing, DeductNotStarted},
+		{"已结束", now.Add(2 * time.Hour), models.ActivityOngoing, DeductEnded},
+		{"已暂停", now, models.ActivityPaused, DeductUnavailable},
+		{"进行中", now, models.ActivityOngoing, DeductSuccess},
+	}
+
+	for i, tt := range tests{
+		a.Status = tt.status
+		if err := r.SaveActivity(ctx, a); err != nil{
+			t.Fatalf("保存活动信息失败：%v", err)
+		}
+
+		result, err := r.DeductStock(ctx, activityID, 1, 1, fmt.Sprintf("test-%d-%d", activityID, i), tt.now)
+		if err != nil || result != tt.want{
+			t.Fatalf("%s：扣除结果为%d, %v，期望%d", tt.name, result, err, tt.want)
+		}
+	}
+
+	// 单次购买数量超过限购数量
+	result, _ := r.DeductStock(ctx, activityID, 2, 3, fmt.Sprintf("test-%d-over", activityID), now)
+	if result != DeductOverLimit{
+		t.Fatalf("超过限购数量时扣除结果为%d，期望%d", result, DeductOverLimit)
+	}
+	if limit, err := r.GetPerUserLimit(ctx, activityID); err != nil || limit != 2{
+		t.Fatalf("限购数量为%d, %v，期望2", limit, err)
+	}
+
+	if left, _ := r.GetStock(ctx, activityID); left != 9{
+		t.Fatalf("剩余库存为%d，期望9", left)
+	}
+
+	// 删除活动后活动信息不存在，不会扣除库存
+	if err := r.DeleteActivity(ctx, activityID); err != nil{
+		t.Fatalf("删除活动缓存失败：%v", err)
+	}
+	result, _ = r.DeductStock(ctx, activityID, 3, 1, fmt.Sprintf("test-%d-deleted", activityID), now)
+	if result != DeductNoActivity{
+		t.Fatalf("活动删除后扣除结果为%d，期望%d", result, DeductNoActivity)
+	}
+}
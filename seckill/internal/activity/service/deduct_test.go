@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"Redrock/seckill/internal/activity/data"
+	"Redrock/seckill/internal/pkg/models"
+	"Redrock/seckill/internal/pkg/testenv"
+	activity "Redrock/seckill/kitex_gen/activity"
+)
+
+func newTestService(t *testing.T) (*ActivityServiceImpl, *gorm.DB){
+	t.Helper()
+
+	db := testenv.NewDB(t, &models.Product{}, &models.Activity{})
+	testenv.NewRedis(t)
+
+	return &ActivityServiceImpl{
+		activityData:	data.NewActivityData(),
+		activityRedis:	data.NewActivityRedis(),
+		productClient:	newFakeProductClient(),
+	}, db
+}
+
+// createTestActivity 在数据库中创建活动，不写入Redis
+func createTestActivity(t *testing.T, s *ActivityServiceImpl, start time.Time, end time.Time) *models.Activity{
+	t.Helper()
+
+	a := &models.Activity{
+		Name:			"test",
+		Product:		models.Product{Name: "test", Price: 10},
+		StartTime:		start,
+		EndTime:		end,
+		TotalStock:		10,
+		AvailableStock:	10,
+		Status:			models.ActivityOngoing,
+		SeckillPrice:	1,
+		PerUserLimit:	2,
+	}
+	if err := s.activityData.Create(context.Background(), a); err != nil{
+		t.Fatalf("创建活动失败：%v", err)
+	}
+
+	return a
+}
+
+func deduct(s *ActivityServiceImpl, activityID uint, userID int64, count int64, orderSn string) *activity.DeductStockResponse{
+	resp, _ := s.DeductStock(context.Background(), &activity.DeductStockRequest{
+		ActivityID:	int64(activityID),
+		UserID:		userID,
+		Count:		count,
+		OrderSn:	orderSn,
+	})
+	return resp
+}
+
+// 活动信息不在Redis中时从数据库预热一次，之后扣除库存不再查询数据库
+func TestDeductStockWarmsUpMissingCache(t *testing.T){
+	s, db := newTestService(t)
+	a := createTestActivity(t, s, time.Now().Add(-time.Minute), time.Now().Add(time.Hour))
+
+	if resp := deduct(s, a.ID, 1, 1, "sn-1"); resp.BaseResponse.Code != 0 || !resp.Success{
+		t.Fatalf("扣除库存的结果为%+v，期望成功", resp.BaseResponse)
+	}
+
+	// 数据库不可用时仍然可以扣除库存
+	sqlDB, _ := db.DB()
+	sqlDB.Close()
+
+	if resp := deduct(s, a.ID, 2, 2, "sn-2"); resp.BaseResponse.Code != 0 || !resp.Success{
+		t.Fatalf("扣除库存的结果为%+v，期望不查询数据库也能成功", resp.BaseResponse)
+	}
+	if resp := deduct(s, a.ID, 3, 3, "sn-3"); resp.BaseResponse.Code != 400 || resp.BaseResponse.Msg != "超过每人限购数量2件"{
+		t.Fatalf("超过限购数量时的结果为%+v，期望400", resp.BaseResponse)
+	}
+	if resp := deduct(s, a.ID, 1, 2, "sn-4"); resp.BaseResponse.Code != 400 || resp.BaseResponse.Msg != "累计购买数量超过每人限购数量2件"{
+		t.Fatalf("累计超过限购数量时的结果为%+v，期望400", resp.BaseResponse)
+	}
+
+	if stock, _ := s.activityRedis.GetStock(context.Background(), a.ID); stock != 7{
+		t.Fatalf("Redis中的库存为%d，期望7", stock)
+	}
+}
+
+func TestDeductStockActivityState(t *testing.T){
+	s, _ := newTestService(t)
+	ctx := context.Background()
+
+	ended := createTestActivity(t, s, time.Now().Add(-time.Hour), time.Now().Add(-time.Minute))
+	if resp := deduct(s, ended.ID, 1, 1, "sn-ended"); resp.BaseResponse.Code != 400 || resp.BaseResponse.Msg != "活动已结束"{
+		t.Fatalf("活动结束后扣除库存的结果为%+v，期望活动已结束", resp.BaseResponse)
+	}
+	if stock, _ := s.activityRedis.GetStock(ctx, ended.ID); stock != 0{
+		t.Fatal("已结束的活动不应该被预热")
+	}
+
+	if resp := deduct(s, 999, 1, 1, "sn-missing"); resp.BaseResponse.Code != 404{
+		t.Fatalf("活动不存在时扣除库存的结果为%+v，期望404", resp.BaseResponse)
+	}
+
+	// 暂停后更新的缓存立即生效
+	paused := createTestActivity(t, s, time.Now().Add(-time.Minute), time.Now().Add(time.Hour))
+	paused.Status = models.ActivityPaused
+	if err := s.warmUpActivity(ctx, paused); err != nil{
+		t.Fatalf("预热活动失败：%v", err)
+	}
+	if resp := deduct(s, paused.ID, 1, 1, "sn-paused"); resp.BaseResponse.Code != 400 || resp.BaseResponse.Msg != "该活动暂不可用"{
+		t.Fatalf("活动暂停后扣除库存的结果为%+v，期望暂不可用", resp.BaseResponse)
+	}
+}
@@ -2,34 +2,52 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
+	"gorm.io/gorm"
+
+	"Redrock/seckill/internal/activity/config"
 	"Redrock/seckill/internal/activity/data"
-	"Redrock/seckill/internal/pkg/database"
 	"Redrock/seckill/internal/pkg/models"
-	"Redrock/seckill/internal/pkg/redis"
+	"Redrock/seckill/internal/pkg/pagination"
 	activity "Redrock/seckill/kitex_gen/activity"
+	"Redrock/seckill/kitex_gen/product/productservice"
 )
 
 // InternalActivityServiceImpl implements the last service interface defined in the IDL.
 type ActivityServiceImpl struct{
 	activityData 	*data.ActivityData
 	activityRedis 	*data.ActivityRedis
+	reconciler		*stockReconciler
+	scheduler		*lifecycleScheduler
+	productClient	productservice.Client
 }
 
 // NewInternalActivityServiceImpl 创建服务实例
-func NewInternalActivityServiceImpl() *ActivityServiceImpl{
+func NewInternalActivityServiceImpl(config *config.Config) *ActivityServiceImpl{
+	activityData := data.NewActivityData()
+	activityRedis := data.NewActivityRedis()
+
+	reconciler, err := newStockReconciler(activityData, activityRedis, config.Reconcile)
+	if err != nil{
+		panic(fmt.Sprintf("创建库存对账失败：%v", err))
+	}
+
 	return &ActivityServiceImpl{
-		activityData	: data.NewActivityData(),
-		activityRedis	: data.NewActivityRedis(),
+		activityData	: activityData,
+		activityRedis	: activityRedis,
+		reconciler		: reconciler,
+		scheduler		: newLifecycleScheduler(activityData, activityRedis, config.Lifecycle),
+		productClient	: newProductClient(config.ProductRPC),
 	}
 }
 
 // NewActivityServiceImpl 创建活动服务实例
-func NewActivityServiceImpl() *ActivityServiceImpl {
-	return NewInternalActivityServiceImpl()
+func NewActivityServiceImpl(config *config.Config) *ActivityServiceImpl {
+	return NewInternalActivityServiceImpl(config)
 }
 
 // CreateActivity 创建活动
@@ -46,6 +64,18 @@ func (s *ActivityServiceImpl) CreateActivity(ctx context.Context, req *activity.
 		return response, nil
 	}
 
+	// 默认每人限购1件
+	perUserLimit := int64(1)
+	if req.PerUserLimit != nil{
+		perUserLimit = *req.PerUserLimit
+	}
+	if perUserLimit < 1{
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg = "每人限购数量至少为1"
+
+		return response, nil
+	}
+
 	// 检查视奸是否合法
 	startTime := time.Unix(req.StartTime, 0)
 	endTime	:= time.Unix(req.EndTime, 0)
@@ -65,9 +95,15 @@ func (s *ActivityServiceImpl) CreateActivity(ctx context.Context, req *activity.
 		return response, nil
 	}
 
-	var product models.Product
-	result := database.GetDB().First(&product, req.ProductID)
-	if result.Error != nil{
+	// 通过商品服务检查商品是否存在
+	product, err := s.getProduct(ctx, uint(req.ProductID))
+	if err != nil{
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg = "获取商品信息失败：" + err.Error()
+
+		return response, nil
+	}
+	if product == nil{
 		response.BaseResponse.Code = 400
 		response.BaseResponse.Msg = "商品不存在"
 
@@ -82,34 +118,52 @@ func (s *ActivityServiceImpl) CreateActivity(ctx context.Context, req *activity.
 		SeckillPrice:	req.SeckillPrice,
 		TotalStock:		req.TotalStock,
 		AvailableStock:	req.TotalStock,
-		Status:			0, // 未开始
+		Status:			models.ActivityNotStarted,
+		PerUserLimit:	perUserLimit,
 	}
 
-	// 考虑到秒杀系统的高并发，我们选择先将商品信息存入缓存
-	database.GetDB().Model(activity).Association("Product").Find(&activity.Product)
-
 	// 将数据写到数据库
-	err := s.activityData.Create(ctx, activity)
+	err = s.activityData.Create(ctx, activity)
 	if err != nil{
 		response.BaseResponse.Code = 500
 		response.BaseResponse.Msg = "创建活动失败" + err.Error()
 		return response, nil
 	}
 
-	// 当活动创建成功后，将活动信息写入Redis
-	err = s.activityRedis.SaveActivity(ctx, activity)
-	if err != nil{
-		// 目的是创建活动，缓存是非关键操作，因此只记录不退出
-		log.Printf("缓存活动信息失败：%v\n", err)
+	// 商品服务删除商品时先删除商品再检查活动，这里写入活动后再检查一次商品，
+	// 两边至少有一方能看到对方的修改，不会留下使用已删除商品的活动
+	product, err = s.getProduct(ctx, uint(req.ProductID))
+	if err != nil || product == nil{
+		if _, delErr := s.activityData.Delete(ctx, activity.ID, []int{models.ActivityNotStarted}); delErr != nil{
+			log.Printf("删除活动%d失败：%v", activity.ID, delErr)
+		}
+
+		if err != nil{
+			response.BaseResponse.Code = 500
+			response.BaseResponse.Msg = "获取商品信息失败：" + err.Error()
+
+			return response, nil
+		}
+
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg = "商品不存在"
+
+		return response, nil
 	}
 
-	// 将库存写入Redis(预热)
-	quantity,err := s.activityRedis.InitStock(ctx, activity.ID,activity.AvailableStock)
-	log.Printf("库存数量：%d\n", quantity)
+	// 考虑到秒杀系统的高并发，我们选择先将商品信息存入缓存
+	activity.Product = *product
+
+	// 当活动创建成功后，将活动信息和库存写入Redis(预热)
+	// 目的是创建活动，缓存是非关键操作，因此只记录不退出，之后的定时预热会补上
+	err = s.warmUpActivity(ctx, activity)
 	if err != nil{
-		log.Printf("初始化Redis库存失败：%v\n", err)
+		log.Printf("预热活动缓存失败：%v\n", err)
 	}
 
+	// 在活动开始和结束时切换状态
+	s.scheduler.schedule(activity)
+
 	response.BaseResponse.Code = 0
 	response.BaseResponse.Msg = "活动创建成功"
 	response.ActivityID = int64(activity.ID)
@@ -124,42 +178,106 @@ func (s *ActivityServiceImpl) GetActivityList(ctx context.Context, req *activity
 		Activities:	[]*activity.ActivityInfo{},
 	}
 
-	// 更新所有活动状态
-	err := s.activityData.AutoUpdateActivityStatus(ctx)
+	filter := &data.ActivityFilter{
+		Status:			int(req.Status),
+		MinPrice:		req.MinPrice,
+		MaxPrice:		req.MaxPrice,
+		SortBy:			req.SortBy,
+		Asc:			req.Asc,
+	}
+	if req.TimeFrom != nil{
+		from := time.Unix(*req.TimeFrom, 0)
+		filter.TimeFrom = &from
+	}
+	if req.TimeTo != nil{
+		to := time.Unix(*req.TimeTo, 0)
+		filter.TimeTo = &to
+	}
+
+	if !data.ValidSortBy(req.SortBy){
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "排序字段错误，可选created, start_time, price"
+
+		return response, nil
+	}
+	if filter.TimeFrom != nil && filter.TimeTo != nil && !filter.TimeFrom.Before(*filter.TimeTo){
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "时间范围的起点需要早于终点"
+
+		return response, nil
+	}
+	if req.MinPrice != nil && req.MaxPrice != nil && *req.MinPrice > *req.MaxPrice{
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "最低价格不能高于最高价格"
+
+		return response, nil
+	}
+
+	cursor, err := pagination.Decode(req.Cursor, filter.SortKey())
 	if err != nil{
-		log.Printf("自动更新活动状态失败：%v\n", err)
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = err.Error()
+
+		return response, nil
+	}
+
+	// 商品名称通过商品服务转换为商品ID，没有匹配的商品时直接返回空列表
+	if req.ProductName != ""{
+		filter.ProductIDs, err = s.searchProductIDs(ctx, req.ProductName)
+		if err != nil{
+			if errors.Is(err, errTooManyProducts){
+				response.BaseResponse.Code = 400
+				response.BaseResponse.Msg  = err.Error()
+
+				return response, nil
+			}
+
+			response.BaseResponse.Code = 500
+			response.BaseResponse.Msg  = "查询商品失败：" + err.Error()
+
+			return response, nil
+		}
+		if len(filter.ProductIDs) == 0{
+			response.BaseResponse.Code = 0
+			response.BaseResponse.Msg  = "查询活动列表成功"
+
+			return response, nil
+		}
 	}
 
 	// 查询活动列表
-	activities, total, err := s.activityData.List(ctx, int(req.Status))
+	activities, total, next, err := s.activityData.List(ctx, filter, cursor, pagination.PageSize(req.PageSize))
 	if err != nil{
+		if errors.Is(err, pagination.ErrInvalidCursor){
+			response.BaseResponse.Code = 400
+			response.BaseResponse.Msg  = err.Error()
+
+			return response, nil
+		}
+
 		response.BaseResponse.Code = 500
 		response.BaseResponse.Msg  = "查询活动列表失败：" + err.Error()
 
 		return response, nil
 	}
 
+	// 商品信息以商品服务为准
+	err = s.attachProducts(ctx, activities...)
+	if err != nil{
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "获取商品信息失败：" + err.Error()
+
+		return response, nil
+	}
+
 	// 构建response
 	for _, a := range activities{
-		activityInfo := &activity.ActivityInfo{
-			Id:					int64(a.ID),
-			Name:				a.Name,
-			ProductId:			int64(a.ProductID),
-			SeckillPrice:		a.SeckillPrice,
-			OriginalPrice:		0,
-			StartTime:			a.StartTime.Unix(),
-			EndTime:			a.EndTime.Unix(),
-			TotalStock:			a.TotalStock,
-			AvailableStock:		a.AvailableStock,
-			Status:				int32(a.Status),
-		}
-		
-		if a.Product.ID != 0{
-			activityInfo.ProductName = a.Product.Name
-			activityInfo.OriginalPrice = a.Product.Price
-		}
+		response.Activities = append(response.Activities, toActivityInfo(a))
+	}
 
-		response.Activities = append(response.Activities, activityInfo)
+	if next != nil{
+		response.NextCursor = next.Encode()
+		response.HasMore = true
 	}
 
 	response.Total = total
@@ -175,12 +293,6 @@ func (s *ActivityServiceImpl) GetActivity(ctx context.Context, req *activity.Get
 		BaseResponse: &activity.BaseResponse{},
 	}
 
-	// 更新所有活动状态
-	err := s.activityData.AutoUpdateActivityStatus(ctx)
-	if err != nil{
-		log.Printf("自动更新活动状态失败：%v\n", err)
-	}
-
 	if req.ActivityID <= 0{
 		response.BaseResponse.Code = 400
 		response.BaseResponse.Msg  = "活动ID不能为空"
@@ -212,21 +324,16 @@ func (s *ActivityServiceImpl) GetActivity(ctx context.Context, req *activity.Get
 		localActivity.AvailableStock = stock
 	}
 
-	activityInfo := &activity.ActivityInfo{
-		Id:						int64(localActivity.ID),
-		Name:					localActivity.Name,
-		ProductId:				int64(localActivity.ProductID),
-		ProductName:			localActivity.Product.Name,
-		OriginalPrice:			localActivity.Product.Price,
-		SeckillPrice:			localActivity.SeckillPrice,
-		StartTime:				localActivity.StartTime.Unix(),
-		EndTime:				localActivity.EndTime.Unix(),
-		TotalStock:				localActivity.TotalStock,
-		AvailableStock:			localActivity.AvailableStock,
-		Status: 				int32(localActivity.Status),
+	// 商品信息以商品服务为准，获取失败时使用缓存中的商品信息
+	product, err := s.getProduct(ctx, localActivity.ProductID)
+	if err != nil{
+		log.Printf("获取活动%d的商品信息失败：%v", localActivity.ID, err)
+	}
+	if product != nil{
+		localActivity.Product = *product
 	}
 
-	response.Activity = activityInfo
+	response.Activity = toActivityInfo(localActivity)
 	response.BaseResponse.Code = 0
 	response.BaseResponse.Msg = "查询活动信息成功"
 	
@@ -240,125 +347,209 @@ func (s *ActivityServiceImpl) DeductStock(ctx context.Context, req *activity.Ded
 		Success: 		false,	
 	}
 
-	// 创建分布式锁
-	lockKey := fmt.Sprintf("activity:lock:%d", req.ActivityID)
-	lock 	:= redis.NewDistributedLock(s.activityRedis.GetRedis(), lockKey, 1*time.Second)
+	if req.ActivityID <= 0 || req.UserID <= 0 || req.Count <= 0 || req.OrderSn == ""{
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "活动、用户、数量或订单参数错误"
+
+		return response, nil
+	}
 
-	try, err := lock.TryLock(ctx)
-	// 返回err
+	// 检查活动时间和状态、用户累计购买数量、扣除库存并累加购买数量，由Redis原子完成，不查询数据库
+	result, err := s.deductStock(ctx, req)
 	if err != nil{
-		log.Printf("获取分布式锁失败：%v", err)
-	// 没有成功返回0
-	}else if !try{
-		response.BaseResponse.Code = 400
-		response.BaseResponse.Msg  = "系统繁忙，请稍后再试"
+		if errors.Is(err, gorm.ErrRecordNotFound){
+			response.BaseResponse.Code = 404
+			response.BaseResponse.Msg  = "活动不存在"
+
+			return response, nil
+		}
+
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "扣除库存失败" + err.Error()
 
 		return response, nil
-	// 成功defer unlock确保释放锁
-	} else{
-		defer lock.Unlock(ctx)
 	}
 
+	switch result{
+	case data.DeductNotStarted:
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "活动尚未开始"
 
-	if req.ActivityID <= 0 || req.UserID <= 0{
+		return response, nil
+	case data.DeductEnded:
 		response.BaseResponse.Code = 400
-		response.BaseResponse.Msg  = "活动或用户参数错误"
+		response.BaseResponse.Msg  = "活动已结束"
 
 		return response, nil
-	}
+	case data.DeductUnavailable:
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "该活动暂不可用"
 
-	// 检查用户是否参与过活动
-	joined, err := s.activityRedis.IsUserJoined(ctx, uint(req.UserID), uint(req.ActivityID))
-	if err != nil{
-		log.Printf("检查用户是否参与过秒杀活动失败：%v", err)
-	}
+		return response, nil
+	case data.DeductOverLimit:
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = s.overLimitMessage(ctx, req)
 
-	if joined{
+		return response, nil
+	case data.DeductSoldOut:
 		response.BaseResponse.Code = 400
-		response.BaseResponse.Msg  = "您已参与过此秒杀活动"
+		response.BaseResponse.Msg  = "库存不足"
+
+		return response, nil
+	case data.DeductNoStock:
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "扣除库存失败库存信息不存在"
+
+		return response, nil
+	case data.DeductReturned:
+		response.BaseResponse.Code = 409
+		response.BaseResponse.Msg  = "该订单已取消并归还库存"
 
 		return response, nil
 	}
 
-	// 获取活动信息以便之后检查活动状态
-	localActivity, err := s.activityData.GetByID(ctx, uint(req.ActivityID))
-	if err != nil{
-		response.BaseResponse.Code = 404
-		response.BaseResponse.Msg  = "获取活动信息失败" + err.Error()
+	// 同一订单重复扣除(如异步秒杀时网关已经扣除过)，数据库库存已经更新过
+	if result == data.DeductRepeated{
+		response.BaseResponse.Code = 0
+		response.BaseResponse.Msg  = "该订单已扣除过库存"
+		response.Success = true
 
 		return response, nil
 	}
 
-	// 检查活动状态
-	now := time.Now()
+	// 开启一个协程用于异步更新数据库中的库存
+	// 按扣除数量递减而不是写入Redis中的库存，避免并发写入旧值，遗漏的更新由库存对账修正
+	go func(){
+		err := s.activityData.DecreaseStock(context.Background(), uint(req.ActivityID), req.Count)
+		if err != nil{
+			log.Printf("更新数据库库存失败：%v\n", err)
+		}
+	}()
 
-	// 检查活动是否开始
-	if now.Before(localActivity.StartTime){
-		response.BaseResponse.Code = 400
-		response.BaseResponse.Msg  = "活动尚未开始"
+	response.BaseResponse.Code = 0
+	response.BaseResponse.Msg  = "扣除库存成功"
+	response.Success = true
 
-		return response, nil
+	return response, nil
+}
+
+// deductStock 在Redis中扣除库存，活动信息不在Redis中时(如缓存过期或Redis被清空)从数据库预热后重试一次
+// 活动不存在时返回gorm.ErrRecordNotFound
+func (s *ActivityServiceImpl) deductStock(ctx context.Context, req *activity.DeductStockRequest) (int, error){
+	result, err := s.activityRedis.DeductStock(ctx, uint(req.ActivityID), uint(req.UserID), req.Count, req.OrderSn, time.Now())
+	if err != nil || result != data.DeductNoActivity{
+		return result, err
 	}
 
-	// 检查活动是否结束
-	if now.After(localActivity.EndTime){
-		response.BaseResponse.Code = 400
-		response.BaseResponse.Msg  = "活动已结束"
+	localActivity, err := s.getActivityWithProduct(ctx, uint(req.ActivityID))
+	if err != nil{
+		return 0, err
+	}
 
-		return response, nil
+	// 已结束的活动不再预热
+	if localActivity.IsEnded(){
+		return data.DeductEnded, nil
 	}
 
-	// 其他原因关闭活动
-	if !localActivity.IsAvailable(){
+	err = s.warmUpActivity(ctx, localActivity)
+	if err != nil{
+		return 0, err
+	}
+
+	result, err = s.activityRedis.DeductStock(ctx, uint(req.ActivityID), uint(req.UserID), req.Count, req.OrderSn, time.Now())
+	if err != nil{
+		return 0, err
+	}
+	if result == data.DeductNoActivity{
+		return 0, fmt.Errorf("活动%d的缓存信息不存在", req.ActivityID)
+	}
+
+	return result, nil
+}
+
+// overLimitMessage 超过限购数量时的提示，区分单次购买数量和累计购买数量
+func (s *ActivityServiceImpl) overLimitMessage(ctx context.Context, req *activity.DeductStockRequest) string{
+	limit, err := s.activityRedis.GetPerUserLimit(ctx, uint(req.ActivityID))
+	if err != nil{
+		log.Printf("获取活动%d的限购数量失败：%v", req.ActivityID, err)
+		return "超过每人限购数量"
+	}
+
+	if req.Count > limit{
+		return fmt.Sprintf("超过每人限购数量%d件", limit)
+	}
+
+	return fmt.Sprintf("累计购买数量超过每人限购数量%d件", limit)
+}
+
+// ReturnStock 归还库存
+// 用于订单取消或过期后将已扣除的库存还回，同一订单号只会归还一次
+func (s *ActivityServiceImpl) ReturnStock(ctx context.Context, req *activity.ReturnStockRequest) (*activity.ReturnStockResponse, error){
+	response := &activity.ReturnStockResponse{
+		BaseResponse: &activity.BaseResponse{},
+		Success:		false,
+	}
+
+	if req.ActivityID <= 0 || req.UserID <= 0 || req.Count <= 0 || req.OrderSn == ""{
 		response.BaseResponse.Code = 400
-		response.BaseResponse.Msg  = "该活动暂不可用"
+		response.BaseResponse.Msg  = "归还库存参数错误"
 
 		return response, nil
 	}
 
-	// 从Redis扣除库存
-	success, err := s.activityRedis.DeductStock(ctx, uint(req.ActivityID), req.Count)
+	count, err := s.activityRedis.ReturnStock(ctx, uint(req.ActivityID), uint(req.UserID), req.OrderSn)
 	if err != nil{
 		response.BaseResponse.Code = 500
-		response.BaseResponse.Msg  = "扣除库存失败" + err.Error()
+		response.BaseResponse.Msg  = "归还库存失败" + err.Error()
 
 		return response, nil
 	}
 
-	if !success{
-		response.BaseResponse.Code = 400
-		response.BaseResponse.Msg  = "库存不足"
+	// 已经归还过或者没有扣除过库存的订单不再修改数据库
+	if count == 0{
+		response.BaseResponse.Code = 0
+		response.BaseResponse.Msg  = "该订单没有需要归还的库存"
+		response.Success = true
 
 		return response, nil
 	}
 
-	// 记录用户参与秒杀活动
-	err = s.activityRedis.RecordUserJoin(ctx, uint(req.UserID), uint(req.ActivityID))
+	err = s.activityData.IncreaseStock(ctx, uint(req.ActivityID), count)
 	if err != nil{
-		log.Printf("记录用户参与秒杀活动失败：%v\n", err)
+		log.Printf("更新数据库库存失败：%v, 订单号：%v\n", err, req.OrderSn)
 	}
 
-	// 开启一个协程用于异步更新数据库中的库存
-	go func(){
-		newCtx := context.Background()
+	response.BaseResponse.Code = 0
+	response.BaseResponse.Msg  = "归还库存成功"
+	response.Success = true
 
-		// 获取Redis中的库存
-		currentStock, err := s.activityRedis.GetStock(newCtx, uint(req.ActivityID))
-		if err != nil{
-			log.Printf("获取Redis中的库存失败：%v\n", err)
-			return
-		}
+	return response, nil
+}
 
-		// 并更新到数据库
-		err = s.activityData.UpdateStock(newCtx, uint(req.ActivityID),currentStock)
-		if err != nil{
-			log.Printf("更新数据库库存失败：%v\n", err)
-		}
-	}()
+// CountProductActivities 统计使用商品且还没有结束的活动数量
+func (s *ActivityServiceImpl) CountProductActivities(ctx context.Context, req *activity.CountProductActivitiesRequest) (*activity.CountProductActivitiesResponse, error){
+	response := &activity.CountProductActivitiesResponse{
+		BaseResponse: &activity.BaseResponse{},
+	}
+
+	if req.ProductID <= 0{
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "商品ID不能为空"
 
+		return response, nil
+	}
+
+	count, err := s.activityData.CountUnfinishedByProduct(ctx, uint(req.ProductID))
+	if err != nil{
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "查询商品的活动失败：" + err.Error()
+
+		return response, nil
+	}
+
+	response.Count = count
 	response.BaseResponse.Code = 0
-	response.BaseResponse.Msg  = "扣除库存成功"
-	response.Success = true
+	response.BaseResponse.Msg  = "查询商品的活动成功"
 
 	return response, nil
 }
@@ -0,0 +1,457 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+
+	"Redrock/seckill/internal/activity/data"
+	"Redrock/seckill/internal/pkg/models"
+	activity "Redrock/seckill/kitex_gen/activity"
+)
+
+// 可以修改、调整库存的活动状态，进行中的活动需要先暂停
+var editableStatuses = []int{models.ActivityNotStarted, models.ActivityPaused}
+
+// toActivityInfo 将活动转换为响应中的活动信息
+func toActivityInfo(a *models.Activity) *activity.ActivityInfo{
+	return &activity.ActivityInfo{
+		Id:					int64(a.ID),
+		Name:				a.Name,
+		ProductId:			int64(a.ProductID),
+		ProductName:		a.Product.Name,
+		OriginalPrice:		a.Product.Price,
+		SeckillPrice:		a.SeckillPrice,
+		StartTime:			a.StartTime.Unix(),
+		EndTime:			a.EndTime.Unix(),
+		TotalStock:			a.TotalStock,
+		AvailableStock:		a.AvailableStock,
+		IsAvailable:		a.IsAvailable(),
+		Status:				int32(a.Status),
+		PerUserLimit:		a.PerUserLimit,
+	}
+}
+
+// isRunning 活动是否正在进行
+// 已到开始时间但定时器还没有切换状态的活动也视为进行中
+func isRunning(a *models.Activity) bool{
+	if a.Status == models.ActivityOngoing{
+		return true
+	}
+
+	return a.Status == models.ActivityNotStarted && a.StatusAt(time.Now()) == models.ActivityOngoing
+}
+
+// isFinished 活动是否已经结束
+func isFinished(a *models.Activity) bool{
+	return a.Status == models.ActivityEnded || a.IsEnded()
+}
+
+// loadActivity 获取活动，失败时设置响应的错误码并返回nil
+func (s *ActivityServiceImpl) loadActivity(ctx context.Context, id int64, baseResponse *activity.BaseResponse) *models.Activity{
+	if id <= 0{
+		baseResponse.Code = 400
+		baseResponse.Msg  = "活动ID不能为空"
+
+		return nil
+	}
+
+	localActivity, err := s.activityData.GetByID(ctx, uint(id))
+	if err != nil{
+		if errors.Is(err, gorm.ErrRecordNotFound){
+			baseResponse.Code = 404
+			baseResponse.Msg  = "活动不存在"
+
+			return nil
+		}
+
+		baseResponse.Code = 500
+		baseResponse.Msg  = "获取活动信息失败：" + err.Error()
+
+		return nil
+	}
+
+	return localActivity
+}
+
+// refreshActivity 修改活动后从数据库重新加载活动，更新Redis缓存和状态定时器
+func (s *ActivityServiceImpl) refreshActivity(ctx context.Context, id uint) (*models.Activity, error){
+	localActivity, err := s.getActivityWithProduct(ctx, id)
+	if err != nil{
+		return nil, fmt.Errorf("获取活动信息失败：%w", err)
+	}
+
+	s.scheduler.schedule(localActivity)
+
+	err = s.warmUpActivity(ctx, localActivity)
+	if err != nil{
+		return localActivity, err
+	}
+
+	return localActivity, nil
+}
+
+// UpdateActivity 修改活动的名称、价格和时间
+func (s *ActivityServiceImpl) UpdateActivity(ctx context.Context, req *activity.UpdateActivityRequest) (*activity.UpdateActivityResponse, error){
+	response := &activity.UpdateActivityResponse{
+		BaseResponse: &activity.BaseResponse{},
+	}
+
+	localActivity := s.loadActivity(ctx, req.ActivityID, response.BaseResponse)
+	if localActivity == nil{
+		return response, nil
+	}
+
+	if isFinished(localActivity){
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "活动已结束，不能修改"
+
+		return response, nil
+	}
+
+	if isRunning(localActivity){
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "活动进行中，请先暂停活动再修改"
+
+		return response, nil
+	}
+
+	fields := map[string]any{}
+	startTime := localActivity.StartTime
+	endTime := localActivity.EndTime
+
+	if req.Name != nil{
+		if *req.Name == ""{
+			response.BaseResponse.Code = 400
+			response.BaseResponse.Msg  = "活动名称不能为空"
+
+			return response, nil
+		}
+		fields["name"] = *req.Name
+	}
+
+	if req.SeckillPrice != nil{
+		if *req.SeckillPrice < 0{
+			response.BaseResponse.Code = 400
+			response.BaseResponse.Msg  = "秒杀价格不能小于0"
+
+			return response, nil
+		}
+		fields["seckill_price"] = *req.SeckillPrice
+	}
+
+	// 降低限购数量不影响已经购买的数量，已达到新限购数量的用户不能再购买
+	if req.PerUserLimit != nil{
+		if *req.PerUserLimit < 1{
+			response.BaseResponse.Code = 400
+			response.BaseResponse.Msg  = "每人限购数量至少为1"
+
+			return response, nil
+		}
+		fields["per_user_limit"] = *req.PerUserLimit
+	}
+
+	if req.StartTime != nil{
+		startTime = time.Unix(*req.StartTime, 0)
+		fields["start_time"] = startTime
+	}
+
+	if req.EndTime != nil{
+		endTime = time.Unix(*req.EndTime, 0)
+		fields["end_time"] = endTime
+	}
+
+	if len(fields) == 0{
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "没有需要修改的内容"
+
+		return response, nil
+	}
+
+	if endTime.Before(startTime){
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "活动结束时间不能早于开始时间"
+
+		return response, nil
+	}
+
+	if !endTime.After(time.Now()){
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "活动结束时间不能早于当前时间"
+
+		return response, nil
+	}
+
+	// 只修改未开始或已暂停的活动，期间活动被其他请求或定时器切换了状态时放弃修改
+	updated, err := s.activityData.UpdateFields(ctx, localActivity.ID, editableStatuses, fields)
+	if err != nil{
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "修改活动失败：" + err.Error()
+
+		return response, nil
+	}
+	if !updated{
+		response.BaseResponse.Code = 409
+		response.BaseResponse.Msg  = "活动状态已变化，请重试"
+
+		return response, nil
+	}
+
+	localActivity, err = s.refreshActivity(ctx, localActivity.ID)
+	if err != nil{
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "活动已修改，更新缓存失败：" + err.Error()
+
+		return response, nil
+	}
+
+	response.Activity = toActivityInfo(localActivity)
+	response.BaseResponse.Code = 0
+	response.BaseResponse.Msg  = "修改活动成功"
+
+	return response, nil
+}
+
+// PauseActivity 暂停未开始或进行中的活动
+func (s *ActivityServiceImpl) PauseActivity(ctx context.Context, req *activity.PauseActivityRequest) (*activity.PauseActivityResponse, error){
+	response := &activity.PauseActivityResponse{
+		BaseResponse: &activity.BaseResponse{},
+	}
+
+	localActivity := s.loadActivity(ctx, req.ActivityID, response.BaseResponse)
+	if localActivity == nil{
+		return response, nil
+	}
+
+	if isFinished(localActivity){
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "活动已结束，不能暂停"
+
+		return response, nil
+	}
+
+	if localActivity.Status == models.ActivityPaused{
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "活动已经暂停"
+
+		return response, nil
+	}
+
+	paused, err := s.activityData.TransitStatus(ctx, localActivity.ID,
+		[]int{models.ActivityNotStarted, models.ActivityOngoing}, models.ActivityPaused)
+	if err != nil{
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "暂停活动失败：" + err.Error()
+
+		return response, nil
+	}
+	if !paused{
+		response.BaseResponse.Code = 409
+		response.BaseResponse.Msg  = "活动状态已变化，请重试"
+
+		return response, nil
+	}
+
+	localActivity, err = s.refreshActivity(ctx, localActivity.ID)
+	if err != nil{
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "活动已暂停，更新缓存失败：" + err.Error()
+
+		return response, nil
+	}
+
+	response.Activity = toActivityInfo(localActivity)
+	response.BaseResponse.Code = 0
+	response.BaseResponse.Msg  = "暂停活动成功"
+
+	return response, nil
+}
+
+// ResumeActivity 恢复暂停的活动，按当前时间恢复为未开始、进行中或已结束
+func (s *ActivityServiceImpl) ResumeActivity(ctx context.Context, req *activity.ResumeActivityRequest) (*activity.ResumeActivityResponse, error){
+	response := &activity.ResumeActivityResponse{
+		BaseResponse: &activity.BaseResponse{},
+	}
+
+	localActivity := s.loadActivity(ctx, req.ActivityID, response.BaseResponse)
+	if localActivity == nil{
+		return response, nil
+	}
+
+	if localActivity.Status != models.ActivityPaused{
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "活动没有暂停"
+
+		return response, nil
+	}
+
+	resumed, err := s.activityData.TransitStatus(ctx, localActivity.ID,
+		[]int{models.ActivityPaused}, localActivity.StatusAt(time.Now()))
+	if err != nil{
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "恢复活动失败：" + err.Error()
+
+		return response, nil
+	}
+	if !resumed{
+		response.BaseResponse.Code = 409
+		response.BaseResponse.Msg  = "活动状态已变化，请重试"
+
+		return response, nil
+	}
+
+	localActivity, err = s.refreshActivity(ctx, localActivity.ID)
+	if err != nil{
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "活动已恢复，更新缓存失败：" + err.Error()
+
+		return response, nil
+	}
+
+	response.Activity = toActivityInfo(localActivity)
+	response.BaseResponse.Code = 0
+	response.BaseResponse.Msg  = "恢复活动成功"
+
+	return response, nil
+}
+
+// DeleteActivity 删除活动并清除缓存，进行中的活动需要先暂停
+// 已创建的订单不受影响，取消时仍会归还库存记录
+func (s *ActivityServiceImpl) DeleteActivity(ctx context.Context, req *activity.DeleteActivityRequest) (*activity.DeleteActivityResponse, error){
+	response := &activity.DeleteActivityResponse{
+		BaseResponse: &activity.BaseResponse{},
+	}
+
+	localActivity := s.loadActivity(ctx, req.ActivityID, response.BaseResponse)
+	if localActivity == nil{
+		return response, nil
+	}
+
+	if isRunning(localActivity){
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "活动进行中，请先暂停活动再删除"
+
+		return response, nil
+	}
+
+	deleted, err := s.activityData.Delete(ctx, localActivity.ID,
+		[]int{models.ActivityNotStarted, models.ActivityPaused, models.ActivityEnded})
+	if err != nil{
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "删除活动失败：" + err.Error()
+
+		return response, nil
+	}
+	if !deleted{
+		response.BaseResponse.Code = 409
+		response.BaseResponse.Msg  = "活动状态已变化，请重试"
+
+		return response, nil
+	}
+
+	s.scheduler.unschedule(localActivity.ID)
+
+	err = s.activityRedis.DeleteActivity(ctx, localActivity.ID)
+	if err != nil{
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "活动已删除，清除缓存失败：" + err.Error()
+
+		return response, nil
+	}
+
+	response.BaseResponse.Code = 0
+	response.BaseResponse.Msg  = "删除活动成功"
+
+	return response, nil
+}
+
+// AdjustStock 调整活动的总库存和可用库存，进行中的活动需要先暂停
+// 先调整Redis中的库存，数据库调整失败时再还原，保证两者调整的数量一致
+func (s *ActivityServiceImpl) AdjustStock(ctx context.Context, req *activity.AdjustStockRequest) (*activity.AdjustStockResponse, error){
+	response := &activity.AdjustStockResponse{
+		BaseResponse: &activity.BaseResponse{},
+	}
+
+	if req.Delta == 0{
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "调整数量不能为0"
+
+		return response, nil
+	}
+
+	localActivity := s.loadActivity(ctx, req.ActivityID, response.BaseResponse)
+	if localActivity == nil{
+		return response, nil
+	}
+
+	if isFinished(localActivity){
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "活动已结束，不能调整库存"
+
+		return response, nil
+	}
+
+	if isRunning(localActivity){
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "活动进行中，请先暂停活动再调整库存"
+
+		return response, nil
+	}
+
+	result, err := s.activityRedis.AdjustStock(ctx, localActivity.ID, req.Delta)
+	if err != nil{
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "调整Redis库存失败：" + err.Error()
+
+		return response, nil
+	}
+	if result == data.AdjustInsufficient{
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "可用库存不足"
+
+		return response, nil
+	}
+
+	// Redis中没有库存时只调整数据库，之后由预热按数据库加载
+	redisAdjusted := result != data.AdjustNoStock
+
+	adjusted, err := s.activityData.AdjustStock(ctx, localActivity.ID, editableStatuses, req.Delta)
+	if err != nil || !adjusted{
+		if redisAdjusted{
+			_, revertErr := s.activityRedis.AdjustStock(ctx, localActivity.ID, -req.Delta)
+			if revertErr != nil{
+				log.Printf("还原活动%d的Redis库存失败：%v, 调整数量：%d", localActivity.ID, revertErr, req.Delta)
+			}
+		}
+
+		if err != nil{
+			response.BaseResponse.Code = 500
+			response.BaseResponse.Msg  = "调整库存失败：" + err.Error()
+
+			return response, nil
+		}
+
+		response.BaseResponse.Code = 409
+		response.BaseResponse.Msg  = "可用库存不足或活动状态已变化"
+
+		return response, nil
+	}
+
+	localActivity, err = s.refreshActivity(ctx, localActivity.ID)
+	if err != nil{
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "库存已调整，更新缓存失败：" + err.Error()
+
+		return response, nil
+	}
+
+	response.Activity = toActivityInfo(localActivity)
+	response.BaseResponse.Code = 0
+	response.BaseResponse.Msg  = "调整库存成功"
+
+	return response, nil
+}
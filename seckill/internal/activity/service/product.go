@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/cloudwego/kitex/client"
+
+	"Redrock/seckill/internal/activity/config"
+	"Redrock/seckill/internal/pkg/models"
+	product "Redrock/seckill/kitex_gen/product"
+	"Redrock/seckill/kitex_gen/product/productservice"
+)
+
+const(
+	// 每次批量获取商品的最大数量，和商品服务的限制一致
+	maxProductBatch = 100
+)
+
+// errTooManyProducts 名称匹配的商品超过商品服务的返回上限
+var errTooManyProducts = errors.New("商品名称匹配的商品过多，请输入更具体的名称")
+
+// newProductClient 创建商品服务客户端
+func newProductClient(cfg config.ProductRPCConfig) productservice.Client{
+	productClient, err := productservice.NewClient(
+		"product_service",
+		client.WithHostPorts(fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)),
+		client.WithRPCTimeout(time.Duration(cfg.Timeout) * time.Millisecond),
+	)
+	if err != nil{
+		panic(fmt.Sprintf("创建商品客户端失败：%v", err))
+	}
+
+	return productClient
+}
+
+// fromProductInfo 将商品服务返回的商品信息转换为商品
+func fromProductInfo(info *product.ProductInfo) *models.Product{
+	localProduct := &models.Product{
+		Name:			info.Name,
+		Description:	info.Description,
+		Price:			info.Price,
+		ImageURL:		info.ImageUrl,
+		Category:		info.Category,
+		MerchantID:		uint(info.MerchantID),
+	}
+	localProduct.ID = uint(info.Id)
+	localProduct.CreatedAt = time.Unix(info.CreateTime, 0)
+
+	return localProduct
+}
+
+// getProduct 通过商品服务获取商品，商品不存在时返回的商品为nil
+func (s *ActivityServiceImpl) getProduct(ctx context.Context, productID uint) (*models.Product, error){
+	resp, err := s.productClient.GetProduct(ctx, &product.GetProductRequest{
+		ProductID: int64(productID),
+	})
+	if err != nil{
+		return nil, err
+	}
+
+	switch resp.BaseResponse.Code{
+	case 0:
+	case 404:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("%s", resp.BaseResponse.Msg)
+	}
+
+	return fromProductInfo(resp.Product), nil
+}
+
+// attachProducts 通过商品服务批量获取活动的商品信息，商品不存在时活动的商品信息为空
+func (s *ActivityServiceImpl) attachProducts(ctx context.Context, activities ...*models.Activity) error{
+	if len(activities) == 0{
+		return nil
+	}
+
+	ids := make([]int64, 0, len(activities))
+	for _, a := range activities{
+		if !slices.Contains(ids, int64(a.ProductID)){
+			ids = append(ids, int64(a.ProductID))
+		}
+	}
+
+	products := make(map[uint]*models.Product, len(ids))
+	for batch := range slices.Chunk(ids, maxProductBatch){
+		resp, err := s.productClient.BatchGetProducts(ctx, &product.BatchGetProductsRequest{
+			ProductIDs: batch,
+		})
+		if err != nil{
+			return err
+		}
+		if resp.BaseResponse.Code != 0{
+			return fmt.Errorf("%s", resp.BaseResponse.Msg)
+		}
+
+		for _, info := range resp.Products{
+			products[uint(info.Id)] = fromProductInfo(info)
+		}
+	}
+
+	for _, a := range activities{
+		if p, ok := products[a.ProductID]; ok{
+			a.Product = *p
+		}
+	}
+
+	return nil
+}
+
+// getActivityWithProduct 从数据库获取活动，并通过商品服务获取商品信息
+// 商品信息只用于缓存和展示，获取失败时只记录日志
+func (s *ActivityServiceImpl) getActivityWithProduct(ctx context.Context, id uint) (*models.Activity, error){
+	localActivity, err := s.activityData.GetByID(ctx, id)
+	if err != nil{
+		return nil, err
+	}
+
+	err = s.attachProducts(ctx, localActivity)
+	if err != nil{
+		log.Printf("获取活动%d的商品信息失败：%v", id, err)
+	}
+
+	return localActivity, nil
+}
+
+// searchProductIDs 通过商品服务按名称模糊查询商品ID
+// 匹配的商品超过商品服务的返回上限时返回errTooManyProducts
+func (s *ActivityServiceImpl) searchProductIDs(ctx context.Context, name string) ([]uint, error){
+	resp, err := s.productClient.SearchProductIDs(ctx, &product.SearchProductIDsRequest{
+		Name: name,
+	})
+	if err != nil{
+		return nil, err
+	}
+	switch resp.BaseResponse.Code{
+	case 0:
+	case 400:
+		return nil, errTooManyProducts
+	default:
+		return nil, fmt.Errorf("%s", resp.BaseResponse.Msg)
+	}
+
+	ids := make([]uint, 0, len(resp.ProductIDs))
+	for _, id := range resp.ProductIDs{
+		ids = append(ids, uint(id))
+	}
+
+	return ids, nil
+}
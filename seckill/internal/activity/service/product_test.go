@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/kitex/client/callopt"
+
+	"Redrock/seckill/internal/pkg/models"
+	activity "Redrock/seckill/kitex_gen/activity"
+	product "Redrock/seckill/kitex_gen/product"
+	"Redrock/seckill/kitex_gen/product/productservice"
+)
+
+// fakeProductClient 模拟商品服务，products为商品ID到商品信息的映射
+type fakeProductClient struct{
+	productservice.Client
+
+	mu			sync.Mutex
+	products	map[int64]*product.ProductInfo
+	afterGet	func(f *fakeProductClient, id int64)	// 每次获取商品后调用，用于模拟并发删除商品
+}
+
+func newFakeProductClient(products ...*product.ProductInfo) *fakeProductClient{
+	f := &fakeProductClient{products: map[int64]*product.ProductInfo{}}
+	for _, p := range products{
+		f.products[p.Id] = p
+	}
+
+	return f
+}
+
+func (f *fakeProductClient) deleteProduct(id int64){
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.products, id)
+}
+
+func (f *fakeProductClient) GetProduct(ctx context.Context, req *product.GetProductRequest, callOptions ...callopt.Option) (*product.GetProductResponse, error){
+	f.mu.Lock()
+	info, ok := f.products[req.ProductID]
+	f.mu.Unlock()
+
+	if f.afterGet != nil{
+		f.afterGet(f, req.ProductID)
+	}
+
+	if !ok{
+		return &product.GetProductResponse{
+			BaseResponse: &product.BaseResponse{Code: 404, Msg: "商品不存在"},
+		}, nil
+	}
+
+	return &product.GetProductResponse{
+		BaseResponse:	&product.BaseResponse{},
+		Product:		info,
+	}, nil
+}
+
+func (f *fakeProductClient) BatchGetProducts(ctx context.Context, req *product.BatchGetProductsRequest, callOptions ...callopt.Option) (*product.BatchGetProductsResponse, error){
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	resp := &product.BatchGetProductsResponse{BaseResponse: &product.BaseResponse{}}
+	for _, id := range req.ProductIDs{
+		if info, ok := f.products[id]; ok{
+			resp.Products = append(resp.Products, info)
+		}
+	}
+
+	return resp, nil
+}
+
+func (f *fakeProductClient) SearchProductIDs(ctx context.Context, req *product.SearchProductIDsRequest, callOptions ...callopt.Option) (*product.SearchProductIDsResponse, error){
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	resp := &product.SearchProductIDsResponse{BaseResponse: &product.BaseResponse{}}
+	for id, info := range f.products{
+		if strings.Contains(info.Name, req.Name){
+			resp.ProductIDs = append(resp.ProductIDs, id)
+		}
+	}
+
+	return resp, nil
+}
+
+// insertActivity 直接在数据库中创建使用productID的活动
+func insertActivity(t *testing.T, s *ActivityServiceImpl, productID uint, status int){
+	t.Helper()
+
+	a := &models.Activity{
+		Name:			"test",
+		ProductID:		productID,
+		StartTime:		time.Now().Add(-time.Minute),
+		EndTime:		time.Now().Add(time.Hour),
+		TotalStock:		10,
+		AvailableStock:	10,
+		Status:			status,
+		SeckillPrice:	1,
+		PerUserLimit:	1,
+	}
+	if err := s.activityData.Create(context.Background(), a); err != nil{
+		t.Fatalf("创建活动失败：%v", err)
+	}
+}
+
+// 活动列表的商品信息和按商品名称筛选都通过商品服务获取
+func TestGetActivityListUsesProductService(t *testing.T){
+	s, _ := newTestService(t)
+	s.productClient = newFakeProductClient(
+		&product.ProductInfo{Id: 1, Name: "apple", Price: 10},
+		&product.ProductInfo{Id: 2, Name: "banana", Price: 20},
+	)
+	insertActivity(t, s, 1, models.ActivityOngoing)
+	insertActivity(t, s, 2, models.ActivityOngoing)
+
+	list := func(name string) *activity.GetActivityListResponse{
+		t.Helper()
+
+		resp, _ := s.GetActivityList(context.Background(), &activity.GetActivityListRequest{Status: -1, ProductName: name})
+		if resp.BaseResponse.Code != 0{
+			t.Fatalf("查询活动列表失败：%+v", resp.BaseResponse)
+		}
+		return resp
+	}
+
+	resp := list("")
+	if resp.Total != 2 || len(resp.Activities) != 2{
+		t.Fatalf("活动数量为%d，期望2", len(resp.Activities))
+	}
+	for _, a := range resp.Activities{
+		if a.ProductName == "" || a.OriginalPrice == 0{
+			t.Fatalf("活动%d没有商品信息：%+v", a.Id, a)
+		}
+	}
+
+	resp = list("app")
+	if resp.Total != 1 || len(resp.Activities) != 1 || resp.Activities[0].ProductName != "apple"{
+		t.Fatalf("按商品名称筛选的结果为%+v，期望只有apple", resp.Activities)
+	}
+
+	resp = list("cherry")
+	if resp.Total != 0 || len(resp.Activities) != 0{
+		t.Fatalf("没有匹配的商品时活动数量为%d，期望0", len(resp.Activities))
+	}
+}
+
+// 检查商品后商品被删除时，写入活动后的再次检查会删除活动
+func TestCreateActivityProductDeletedConcurrently(t *testing.T){
+	s, db := newTestService(t)
+	productClient := newFakeProductClient(&product.ProductInfo{Id: 1, Name: "apple", Price: 10})
+	productClient.afterGet = func(f *fakeProductClient, id int64){
+		f.deleteProduct(id)
+	}
+	s.productClient = productClient
+
+	resp, _ := s.CreateActivity(context.Background(), &activity.CreateActivityRequest{
+		Name:			"test",
+		ProductID:		1,
+		StartTime:		time.Now().Add(time.Hour).Unix(),
+		EndTime:		time.Now().Add(2 * time.Hour).Unix(),
+		SeckillPrice:	1,
+		TotalStock:		10,
+	})
+	if resp.BaseResponse.Code != 400{
+		t.Fatalf("创建活动的结果为%+v，期望400", resp.BaseResponse)
+	}
+
+	var count int64
+	db.Model(&models.Activity{}).Count(&count)
+	if count != 0{
+		t.Fatalf("商品被删除后还有%d个活动，期望0", count)
+	}
+}
+
+// 只统计使用该商品且还没有结束的活动
+func TestCountProductActivities(t *testing.T){
+	s, _ := newTestService(t)
+	insertActivity(t, s, 1, models.ActivityOngoing)
+	insertActivity(t, s, 1, models.ActivityPaused)
+	insertActivity(t, s, 1, models.ActivityEnded)
+	insertActivity(t, s, 2, models.ActivityNotStarted)
+
+	resp, _ := s.CountProductActivities(context.Background(), &activity.CountProductActivitiesRequest{ProductID: 1})
+	if resp.BaseResponse.Code != 0 || resp.Count != 2{
+		t.Fatalf("统计结果为%+v, %d，期望2", resp.BaseResponse, resp.Count)
+	}
+
+	resp, _ = s.CountProductActivities(context.Background(), &activity.CountProductActivitiesRequest{})
+	if resp.BaseResponse.Code != 400{
+		t.Fatalf("商品ID为空时的结果为%+v，期望400", resp.BaseResponse)
+	}
+}
@@ -0,0 +1,328 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"Redrock/seckill/internal/activity/config"
+	"Redrock/seckill/internal/activity/data"
+	"Redrock/seckill/internal/pkg/models"
+	activity "Redrock/seckill/kitex_gen/activity"
+)
+
+const(
+	// 库存修正策略
+	SourceNone		= "none"	// 只报告不一致，不修正
+	SourceRedis		= "redis"	// 以Redis库存为准修正数据库
+	SourceOrders	= "orders"	// 以订单为准修正Redis和数据库
+
+	// 活动结束后仍在对账的时间，和活动缓存在结束后保留的时间一致
+	reconcileWindow = 24 * time.Hour
+)
+
+// stockReconciler 定时比较Redis库存、数据库可用库存和订单数量
+// 已扣除库存但还没有有效订单的扣除(异步秒杀排队中、下单saga进行中、订单取消后等待归还库存)记为进行中，
+// 从订单库存中减去，排队消息积压时也不会把这部分库存当作漂移加回去造成超卖
+// 数据库库存异步更新，三者仍可能短暂不一致，因此只有连续两次对账得到相同的结果时才认为是真正的漂移并修正
+type stockReconciler struct{
+	activityData	*data.ActivityData
+	activityRedis	*data.ActivityRedis
+	config			config.ReconcileConfig
+
+	mu				sync.Mutex
+	reports			map[uint]*activity.StockReport	// 每个活动最近一次的对账结果
+}
+
+// newStockReconciler 创建库存对账，未知的修正策略返回错误，避免配置写错时静默地不修正或按错误的策略修正
+func newStockReconciler(activityData *data.ActivityData, activityRedis *data.ActivityRedis, cfg config.ReconcileConfig) (*stockReconciler, error){
+	switch cfg.SourceOfTruth{
+	case "":
+		cfg.SourceOfTruth = SourceNone
+	case SourceNone, SourceRedis, SourceOrders:
+	default:
+		return nil, fmt.Errorf("未知的库存修正策略：%s，可选none, redis, orders", cfg.SourceOfTruth)
+	}
+
+	return &stockReconciler{
+		activityData:	activityData,
+		activityRedis:	activityRedis,
+		config:			cfg,
+		reports:		make(map[uint]*activity.StockReport),
+	}, nil
+}
+
+// reconcile 对账单个活动，需要修正时按策略修正
+func (r *stockReconciler) reconcile(ctx context.Context, a *models.Activity) (*activity.StockReport, error){
+	redisStock, exists, deducted, err := r.activityRedis.LookupStockAndOrders(ctx, a.ID)
+	if err != nil{
+		return nil, fmt.Errorf("获取Redis库存失败：%w", err)
+	}
+	if !exists{
+		redisStock = -1
+	}
+
+	// 先读取Redis再查询订单，期间创建的订单计入已售，期间归还的扣除计入进行中，都和读到的Redis库存一致
+	sold, err := r.activityData.SumSoldQuantity(ctx, a.ID)
+	if err != nil{
+		return nil, fmt.Errorf("统计订单数量失败：%w", err)
+	}
+
+	inflight, err := r.inflight(ctx, a.ID, deducted)
+	if err != nil{
+		return nil, fmt.Errorf("统计进行中的扣除失败：%w", err)
+	}
+
+	report := &activity.StockReport{
+		ActivityID:		int64(a.ID),
+		RedisStock:		redisStock,
+		DbStock:		a.AvailableStock,
+		OrderStock:		a.TotalStock - sold - inflight,
+		CheckTime:		time.Now().Unix(),
+		Inflight:		inflight,
+	}
+
+	// Redis中没有库存时(缓存过期或被清空)只比较数据库，由预热任务重新加载
+	report.Drift = report.DbStock != report.OrderStock || (exists && report.RedisStock != report.OrderStock)
+
+	r.mu.Lock()
+	last := r.reports[a.ID]
+	r.reports[a.ID] = report
+	r.mu.Unlock()
+
+	if !report.Drift{
+		return report, nil
+	}
+
+	log.Printf("活动%d库存不一致：Redis：%d, 数据库：%d, 订单：%d", a.ID, report.RedisStock, report.DbStock, report.OrderStock)
+
+	stable := last != nil &&
+		last.RedisStock == report.RedisStock &&
+		last.DbStock == report.DbStock &&
+		last.OrderStock == report.OrderStock &&
+		last.Inflight == report.Inflight
+	if !stable{
+		return report, nil
+	}
+
+	report.Corrected, err = r.correct(ctx, report, exists)
+	if err != nil{
+		return report, fmt.Errorf("修正库存失败：%w", err)
+	}
+
+	if report.Corrected{
+		log.Printf("活动%d库存已按%s修正", a.ID, r.config.SourceOfTruth)
+	}
+
+	return report, nil
+}
+
+// inflight 统计扣除过库存且没有归还、但还没有有效订单的商品数量
+func (r *stockReconciler) inflight(ctx context.Context, activityID uint, deducted map[string]int64) (int64, error){
+	if len(deducted) == 0{
+		return 0, nil
+	}
+
+	orderSns := make([]string, 0, len(deducted))
+	for orderSn := range deducted{
+		orderSns = append(orderSns, orderSn)
+	}
+
+	sold, err := r.activityData.FilterSoldOrders(ctx, activityID, orderSns)
+	if err != nil{
+		return 0, err
+	}
+
+	var inflight int64
+	for orderSn, count := range deducted{
+		if !sold[orderSn]{
+			inflight += count
+		}
+	}
+
+	return inflight, nil
+}
+
+// correct 按策略修正库存，修正时比较对账时读到的值，期间库存被修改过则放弃本次修正
+func (r *stockReconciler) correct(ctx context.Context, report *activity.StockReport, redisExists bool) (bool, error){
+	id := uint(report.ActivityID)
+
+	switch r.config.SourceOfTruth{
+	case SourceRedis:
+		if !redisExists || report.DbStock == report.RedisStock{
+			return false, nil
+		}
+
+		return r.activityData.CompareAndSetStock(ctx, id, report.DbStock, report.RedisStock)
+	case SourceOrders:
+		corrected := false
+
+		if redisExists && report.RedisStock != report.OrderStock{
+			ok, err := r.activityRedis.CompareAndSetStock(ctx, id, report.RedisStock, report.OrderStock)
+			if err != nil{
+				return false, err
+			}
+			corrected = ok
+		}
+
+		if report.DbStock != report.OrderStock{
+			ok, err := r.activityData.CompareAndSetStock(ctx, id, report.DbStock, report.OrderStock)
+			if err != nil{
+				return corrected, err
+			}
+			corrected = corrected || ok
+		}
+
+		return corrected, nil
+	default:
+		return false, nil
+	}
+}
+
+// reconcileAll 对账所有未结束或刚结束的活动
+func (r *stockReconciler) reconcileAll(ctx context.Context) error{
+	activities, err := r.activityData.ListUnfinished(ctx, time.Now().Add(-reconcileWindow))
+	if err != nil{
+		return fmt.Errorf("获取活动失败：%w", err)
+	}
+
+	active := make(map[uint]bool, len(activities))
+	for _, a := range activities{
+		active[a.ID] = true
+
+		_, err = r.reconcile(ctx, a)
+		if err != nil{
+			log.Printf("活动%d库存对账失败：%v", a.ID, err)
+		}
+	}
+
+	// 清除已经不再对账的活动的结果
+	r.mu.Lock()
+	for id := range r.reports{
+		if !active[id]{
+			delete(r.reports, id)
+		}
+	}
+	r.mu.Unlock()
+
+	return nil
+}
+
+// getReports 获取最近一次的对账结果，activityID为0时返回所有活动
+func (r *stockReconciler) getReports(activityID uint, onlyDrift bool) []*activity.StockReport{
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reports := []*activity.StockReport{}
+	for id, report := range r.reports{
+		if activityID != 0 && id != activityID{
+			continue
+		}
+		if onlyDrift && !report.Drift{
+			continue
+		}
+
+		reports = append(reports, report)
+	}
+
+	sort.Slice(reports, func(i, j int) bool{
+		return reports[i].ActivityID < reports[j].ActivityID
+	})
+
+	return reports
+}
+
+// RunReconcile 定时对账库存
+func (s *ActivityServiceImpl) RunReconcile(ctx context.Context){
+	interval := time.Duration(s.reconciler.config.Interval) * time.Second
+	if interval <= 0{
+		interval = time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select{
+		case <- ticker.C:
+			err := s.reconciler.reconcileAll(ctx)
+			if err != nil{
+				log.Printf("库存对账失败：%v", err)
+			}
+		case <- ctx.Done():
+			log.Printf("库存对账任务停止")
+			return
+		}
+	}
+}
+
+// GetStockReports 查询最近一次的库存对账结果
+func (s *ActivityServiceImpl) GetStockReports(ctx context.Context, req *activity.GetStockReportsRequest) (*activity.GetStockReportsResponse, error){
+	response := &activity.GetStockReportsResponse{
+		BaseResponse: &activity.BaseResponse{},
+	}
+
+	if req.ActivityID < 0{
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "活动ID参数错误"
+
+		return response, nil
+	}
+
+	response.Reports = s.reconciler.getReports(uint(req.ActivityID), req.OnlyDrift)
+	response.BaseResponse.Code = 0
+	response.BaseResponse.Msg  = "查询对账结果成功"
+
+	return response, nil
+}
+
+// ReconcileStock 立即对账单个活动的库存
+// 和定时对账一样，只有连续两次得到相同的不一致结果时才会修正
+func (s *ActivityServiceImpl) ReconcileStock(ctx context.Context, req *activity.ReconcileStockRequest) (*activity.ReconcileStockResponse, error){
+	response := &activity.ReconcileStockResponse{
+		BaseResponse: &activity.BaseResponse{},
+	}
+
+	if req.ActivityID <= 0{
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "活动ID不能为空"
+
+		return response, nil
+	}
+
+	localActivity, err := s.activityData.GetByID(ctx, uint(req.ActivityID))
+	if err != nil{
+		if errors.Is(err, gorm.ErrRecordNotFound){
+			response.BaseResponse.Code = 404
+			response.BaseResponse.Msg  = "活动不存在"
+
+			return response, nil
+		}
+
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "获取活动信息失败：" + err.Error()
+
+		return response, nil
+	}
+
+	report, err := s.reconciler.reconcile(ctx, localActivity)
+	if err != nil{
+		response.Report = report
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "库存对账失败：" + err.Error()
+
+		return response, nil
+	}
+
+	response.Report = report
+	response.BaseResponse.Code = 0
+	response.BaseResponse.Msg  = "库存对账完成"
+
+	return response, nil
+}
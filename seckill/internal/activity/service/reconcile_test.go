@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Redrock/seckill/internal/activity/config"
+	"Redrock/seckill/internal/activity/data"
+	"Redrock/seckill/internal/pkg/database"
+	"Redrock/seckill/internal/pkg/models"
+	"Redrock/seckill/internal/pkg/testenv"
+)
+
+func TestNewStockReconcilerValidatesPolicy(t *testing.T){
+	for _, source := range []string{"", SourceNone, SourceRedis, SourceOrders}{
+		if _, err := newStockReconciler(nil, nil, config.ReconcileConfig{SourceOfTruth: source}); err != nil{
+			t.Fatalf("修正策略%q应该有效，但实际得到%v", source, err)
+		}
+	}
+
+	if _, err := newStockReconciler(nil, nil, config.ReconcileConfig{SourceOfTruth: "order"}); err == nil{
+		t.Fatal("未知的修正策略应该返回错误")
+	}
+}
+
+// newTestReconciler 创建总库存10的进行中活动，并预热到Redis
+func newTestReconciler(t *testing.T, source string) (*stockReconciler, *models.Activity){
+	t.Helper()
+
+	testenv.NewDB(t, &models.Product{}, &models.Activity{}, &models.Order{})
+	testenv.NewRedis(t)
+
+	s := &ActivityServiceImpl{
+		activityData:	data.NewActivityData(),
+		activityRedis:	data.NewActivityRedis(),
+	}
+	a := createTestActivity(t, s, time.Now().Add(-time.Minute), time.Now().Add(time.Hour))
+	if err := s.warmUpActivity(context.Background(), a); err != nil{
+		t.Fatalf("预热活动失败：%v", err)
+	}
+
+	reconciler, err := newStockReconciler(s.activityData, s.activityRedis, config.ReconcileConfig{SourceOfTruth: source})
+	if err != nil{
+		t.Fatalf("创建库存对账失败：%v", err)
+	}
+
+	return reconciler, a
+}
+
+// deductTestStock 在Redis中扣除库存并同步扣除数据库库存，不创建订单
+func deductTestStock(t *testing.T, r *stockReconciler, a *models.Activity, userID uint, orderSn string){
+	t.Helper()
+	ctx := context.Background()
+
+	result, err := r.activityRedis.DeductStock(ctx, a.ID, userID, 1, orderSn, time.Now())
+	if err != nil || result != data.DeductSuccess{
+		t.Fatalf("扣除库存的结果为%d, %v，期望成功", result, err)
+	}
+	if err := r.activityData.DecreaseStock(ctx, a.ID, 1); err != nil{
+		t.Fatalf("扣除数据库库存失败：%v", err)
+	}
+}
+
+func createTestOrder(t *testing.T, a *models.Activity, orderSn string, status int){
+	t.Helper()
+
+	o := &models.Order{
+		UserID:		1,
+		ProductID:	a.ProductID,
+		ActivityID:	a.ID,
+		OrderSn:	orderSn,
+		Quantity:	1,
+		Status:		status,
+		CreateTime:	time.Now(),
+		ExpireTime:	time.Now().Add(time.Hour),
+	}
+	if err := database.GetDB().Create(o).Error; err != nil{
+		t.Fatalf("创建订单失败：%v", err)
+	}
+}
+
+// 排队中、下单中和取消后等待归还的扣除计为进行中，orders策略不会把这部分库存加回Redis
+func TestReconcileExcludesInflight(t *testing.T){
+	r, a := newTestReconciler(t, SourceOrders)
+	ctx := context.Background()
+
+	deductTestStock(t, r, a, 1, "sn-queued")
+	deductTestStock(t, r, a, 2, "sn-created")
+	deductTestStock(t, r, a, 3, "sn-cancelled")
+	createTestOrder(t, a, "sn-created", models.StatusCreated)
+	createTestOrder(t, a, "sn-cancelled", models.StatusCancelled)
+
+	latest, _ := r.activityData.GetByID(ctx, a.ID)
+	for i := 0; i < 2; i++{
+		report, err := r.reconcile(ctx, latest)
+		if err != nil{
+			t.Fatalf("对账失败：%v", err)
+		}
+		if report.Drift || report.Corrected || report.Inflight != 2 || report.OrderStock != 7 || report.RedisStock != 7{
+			t.Fatalf("第%d次对账结果为%+v，期望进行中2件且没有漂移", i+1, report)
+		}
+	}
+
+	// 取消的订单归还库存后不再是进行中
+	if _, err := r.activityRedis.ReturnStock(ctx, a.ID, 3, "sn-cancelled"); err != nil{
+		t.Fatalf("归还库存失败：%v", err)
+	}
+	if err := r.activityData.IncreaseStock(ctx, a.ID, 1); err != nil{
+		t.Fatalf("归还数据库库存失败：%v", err)
+	}
+
+	latest, _ = r.activityData.GetByID(ctx, a.ID)
+	report, err := r.reconcile(ctx, latest)
+	if err != nil || report.Drift || report.Inflight != 1 || report.OrderStock != 8{
+		t.Fatalf("归还后的对账结果为%+v, %v，期望进行中1件且没有漂移", report, err)
+	}
+}
+
+// 真正的漂移连续两次得到相同结果后按订单修正
+func TestReconcileCorrectsDrift(t *testing.T){
+	r, a := newTestReconciler(t, SourceOrders)
+	ctx := context.Background()
+
+	deductTestStock(t, r, a, 1, "sn-queued")
+
+	// Redis中的库存多扣了2件
+	if ok, err := r.activityRedis.CompareAndSetStock(ctx, a.ID, 9, 7); err != nil || !ok{
+		t.Fatalf("修改Redis库存失败：%v", err)
+	}
+
+	latest, _ := r.activityData.GetByID(ctx, a.ID)
+	report, err := r.reconcile(ctx, latest)
+	if err != nil || !report.Drift || report.Corrected{
+		t.Fatalf("第一次对账结果为%+v, %v，期望发现漂移但不修正", report, err)
+	}
+
+	report, err = r.reconcile(ctx, latest)
+	if err != nil || !report.Drift || !report.Corrected{
+		t.Fatalf("第二次对账结果为%+v, %v，期望修正", report, err)
+	}
+
+	if stock, _ := r.activityRedis.GetStock(ctx, a.ID); stock != 9{
+		t.Fatalf("修正后Redis中的库存为%d，期望9", stock)
+	}
+}
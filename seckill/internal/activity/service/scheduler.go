@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"Redrock/seckill/internal/activity/config"
+	"Redrock/seckill/internal/activity/data"
+	"Redrock/seckill/internal/pkg/models"
+	"Redrock/seckill/internal/pkg/redis"
+)
+
+const(
+	// 状态切换锁的过期时间，切换完成后不主动释放，防止其他实例在此期间重复切换
+	lifecycleLockExpiration = time.Minute
+
+	// 状态切换后更新缓存失败时的重试间隔和最大次数，第n次重试等待n倍的间隔
+	lifecycleSyncRetryDelay	= 5 * time.Second
+	lifecycleSyncRetries	= 5
+)
+
+// lifecycleScheduler 在活动的开始时间和结束时间切换活动状态
+// 每个活动只保留下一次状态切换的定时器，启动时和之后定时从数据库重建定时器，
+// 多个实例同时运行时通过分布式锁和条件更新保证每次切换只执行一次
+type lifecycleScheduler struct{
+	activityData	*data.ActivityData
+	activityRedis	*data.ActivityRedis
+	config			config.LifecycleConfig
+	syncRetryDelay	time.Duration			// 更新缓存失败时的重试间隔
+
+	mu				sync.Mutex
+	ctx				context.Context
+	timers			map[uint]*time.Timer	// 每个活动下一次状态切换的定时器
+}
+
+func newLifecycleScheduler(activityData *data.ActivityData, activityRedis *data.ActivityRedis, cfg config.LifecycleConfig) *lifecycleScheduler{
+	return &lifecycleScheduler{
+		activityData:	activityData,
+		activityRedis:	activityRedis,
+		config:			cfg,
+		syncRetryDelay:	lifecycleSyncRetryDelay,
+		ctx:			context.Background(),
+		timers:			make(map[uint]*time.Timer),
+	}
+}
+
+// schedule 为活动设置下一次状态切换的定时器，已有的定时器会被替换
+// 切换时间已经过去时立即切换
+func (s *lifecycleScheduler) schedule(a *models.Activity){
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if timer, ok := s.timers[a.ID]; ok{
+		timer.Stop()
+		delete(s.timers, a.ID)
+	}
+
+	if s.ctx.Err() != nil || a.Status == models.ActivityEnded{
+		return
+	}
+
+	// 未开始的活动总是在开始时间切换，开始时间已过(如重启期间到达开始时间、创建时开始时间已过)时立即切换为进行中
+	// 暂停的活动不会开始，只在结束时间切换为已结束
+	next := a.StartTime
+	if a.Status != models.ActivityNotStarted{
+		next = a.EndTime
+	}
+
+	id := a.ID
+	s.timers[id] = time.AfterFunc(max(time.Until(next), 0), func(){
+		s.fire(id)
+	})
+}
+
+// unschedule 取消活动的定时器
+func (s *lifecycleScheduler) unschedule(id uint){
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if timer, ok := s.timers[id]; ok{
+		timer.Stop()
+		delete(s.timers, id)
+	}
+}
+
+// fire 定时器到期，重新读取活动后按当前时间切换状态并设置下一次切换
+// 活动时间在设置定时器后可能被修改，因此以数据库中的时间为准
+func (s *lifecycleScheduler) fire(id uint){
+	s.mu.Lock()
+	ctx := s.ctx
+	s.mu.Unlock()
+
+	a, err := s.activityData.GetByID(ctx, id)
+	if err != nil{
+		if errors.Is(err, gorm.ErrRecordNotFound){
+			s.unschedule(id)
+			return
+		}
+
+		// 读取失败时等待下一次重建定时器
+		log.Printf("获取活动%d失败：%v", id, err)
+		return
+	}
+
+	target := a.StatusAt(time.Now())
+	if target != a.Status && slices.Contains(transitFrom(target), a.Status){
+		err = s.transition(ctx, id, target)
+		if err != nil{
+			log.Printf("切换活动%d的状态失败：%v", id, err)
+			return
+		}
+
+		a.Status = target
+	}
+
+	s.schedule(a)
+}
+
+// transitFrom 按时间切换到status时活动可以处于的状态
+// 暂停的活动不会被切换为进行中，但到结束时间后仍会结束
+func transitFrom(status int) []int{
+	switch status{
+	case models.ActivityOngoing:
+		return []int{models.ActivityNotStarted}
+	case models.ActivityEnded:
+		return []int{models.ActivityNotStarted, models.ActivityOngoing, models.ActivityPaused}
+	default:
+		return nil
+	}
+}
+
+// transition 将活动状态切换到status，并更新Redis中的活动缓存
+// 获取不到锁说明其他实例正在切换，直接跳过
+func (s *lifecycleScheduler) transition(ctx context.Context, id uint, status int) error{
+	lock := redis.NewDistributedLock(s.activityRedis.GetRedis(), fmt.Sprintf("activity:lifecycle:%d:%d", id, status), lifecycleLockExpiration)
+
+	locked, err := lock.TryLock(ctx)
+	if err != nil{
+		return fmt.Errorf("获取状态切换锁失败：%w", err)
+	}
+	if !locked{
+		return nil
+	}
+
+	updated, err := s.activityData.TransitStatus(ctx, id, transitFrom(status), status)
+	if err != nil{
+		// 切换失败时释放锁，让其他实例或下一次重建定时器时重试
+		lock.Unlock(ctx)
+		return fmt.Errorf("更新活动状态失败：%w", err)
+	}
+	if !updated{
+		return nil
+	}
+
+	log.Printf("活动%d的状态已切换为%d", id, status)
+
+	// 数据库中的状态已经切换，更新缓存失败时释放锁并稍后重试，避免扣除库存时一直使用旧的状态
+	err = s.syncCache(ctx, id)
+	if err != nil{
+		log.Printf("更新活动%d的缓存失败：%v，稍后重试", id, err)
+		lock.Unlock(ctx)
+		s.retrySyncCache(id, 1)
+	}
+
+	return nil
+}
+
+// syncCache 从数据库重新读取活动并更新Redis中的活动缓存
+func (s *lifecycleScheduler) syncCache(ctx context.Context, id uint) error{
+	a, err := s.activityData.GetByID(ctx, id)
+	if err != nil{
+		return fmt.Errorf("获取活动信息失败：%w", err)
+	}
+
+	// 只切换状态，商品信息沿用缓存中的
+	cached, err := s.activityRedis.GetActivity(ctx, id)
+	if err != nil{
+		log.Printf("获取活动%d的缓存失败：%v", id, err)
+	}
+	if cached != nil{
+		a.Product = cached.Product
+	}
+
+	err = s.activityRedis.SaveActivity(ctx, a)
+	if err != nil{
+		return fmt.Errorf("更新活动缓存失败：%w", err)
+	}
+
+	return nil
+}
+
+// retrySyncCache 状态切换后更新缓存失败时按递增的间隔重试，超过次数后由定时预热补上
+func (s *lifecycleScheduler) retrySyncCache(id uint, attempt int){
+	time.AfterFunc(time.Duration(attempt) * s.syncRetryDelay, func(){
+		s.mu.Lock()
+		ctx := s.ctx
+		s.mu.Unlock()
+
+		if ctx.Err() != nil{
+			return
+		}
+
+		err := s.syncCache(ctx, id)
+		if err == nil{
+			log.Printf("活动%d的缓存已更新", id)
+			return
+		}
+
+		if attempt >= lifecycleSyncRetries{
+			log.Printf("更新活动%d的缓存失败：%v，等待定时预热", id, err)
+			return
+		}
+
+		log.Printf("第%d次更新活动%d的缓存失败：%v", attempt, id, err)
+		s.retrySyncCache(id, attempt + 1)
+	})
+}
+
+// rebuild 从数据库重建所有未结束活动的定时器
+// 其他实例创建的活动也会在重建时被加入
+func (s *lifecycleScheduler) rebuild(ctx context.Context) error{
+	activities, err := s.activityData.ListNotEnded(ctx)
+	if err != nil{
+		return fmt.Errorf("获取未结束的活动失败：%w", err)
+	}
+
+	active := make(map[uint]bool, len(activities))
+	for _, a := range activities{
+		active[a.ID] = true
+		s.schedule(a)
+	}
+
+	// 取消已经结束或被删除的活动的定时器
+	s.mu.Lock()
+	for id, timer := range s.timers{
+		if !active[id]{
+			timer.Stop()
+			delete(s.timers, id)
+		}
+	}
+	s.mu.Unlock()
+
+	return nil
+}
+
+// stop 停止所有定时器
+func (s *lifecycleScheduler) stop(){
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, timer := range s.timers{
+		timer.Stop()
+		delete(s.timers, id)
+	}
+}
+
+// RunLifecycle 启动时从数据库重建活动状态切换的定时器，之后定时重建
+func (s *ActivityServiceImpl) RunLifecycle(ctx context.Context){
+	interval := time.Duration(s.scheduler.config.ResyncInterval) * time.Second
+	if interval <= 0{
+		interval = 5 * time.Minute
+	}
+
+	s.scheduler.mu.Lock()
+	s.scheduler.ctx = ctx
+	s.scheduler.mu.Unlock()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.scheduler.rebuild(ctx)
+		if err != nil{
+			log.Printf("重建活动状态定时器失败：%v", err)
+		}
+
+		select{
+		case <- ticker.C:
+		case <- ctx.Done():
+			s.scheduler.stop()
+			log.Printf("活动状态调度任务停止")
+			return
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"Redrock/seckill/internal/activity/config"
+	"Redrock/seckill/internal/activity/data"
+	"Redrock/seckill/internal/pkg/database"
+	"Redrock/seckill/internal/pkg/models"
+)
+
+// newTestScheduler 创建状态调度器，测试结束时停止所有定时器
+func newTestScheduler(t *testing.T, s *ActivityServiceImpl) *lifecycleScheduler{
+	t.Helper()
+
+	scheduler := newLifecycleScheduler(s.activityData, s.activityRedis, config.LifecycleConfig{})
+	scheduler.syncRetryDelay = 10 * time.Millisecond
+	t.Cleanup(scheduler.stop)
+
+	return scheduler
+}
+
+// waitCachedStatus 等待Redis中活动的状态变为status
+func waitCachedStatus(t *testing.T, s *ActivityServiceImpl, id uint, status int){
+	t.Helper()
+
+	key := fmt.Sprintf("activity:meta:%d", id)
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		cached, err := s.activityRedis.GetRedis().HGet(context.Background(), key, "status").Int()
+		if err == nil && cached == status{
+			return
+		}
+		if time.Now().After(deadline){
+			t.Fatalf("Redis中活动%d的状态为%d(%v)，期望%d", id, cached, err, status)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// 开始时间已过但还未开始的活动立即切换为进行中，而不是等到结束时间直接结束
+func TestScheduleStartedActivity(t *testing.T){
+	s, _ := newTestService(t)
+	scheduler := newTestScheduler(t, s)
+
+	a := createTestActivity(t, s, time.Now().Add(-time.Minute), time.Now().Add(time.Hour))
+	if _, err := s.activityData.UpdateFields(context.Background(), a.ID, []int{models.ActivityOngoing}, map[string]any{"status": models.ActivityNotStarted}); err != nil{
+		t.Fatalf("修改活动状态失败：%v", err)
+	}
+	a.Status = models.ActivityNotStarted
+	if err := s.warmUpActivity(context.Background(), a); err != nil{
+		t.Fatalf("预热活动失败：%v", err)
+	}
+
+	scheduler.schedule(a)
+	waitCachedStatus(t, s, a.ID, models.ActivityOngoing)
+
+	localActivity, err := s.activityData.GetByID(context.Background(), a.ID)
+	if err != nil || localActivity.Status != models.ActivityOngoing{
+		t.Fatalf("数据库中活动的状态为%+v(%v)，期望进行中", localActivity, err)
+	}
+
+	result, err := s.activityRedis.DeductStock(context.Background(), a.ID, 1, 1, "sn-1", time.Now())
+	if err != nil || result != data.DeductSuccess{
+		t.Fatalf("扣除库存的结果为%d(%v)，期望成功", result, err)
+	}
+}
+
+// 状态已切换但更新缓存失败时释放锁并重试，Redis中的状态最终和数据库一致
+func TestTransitionRetriesCacheSync(t *testing.T){
+	s, _ := newTestService(t)
+	scheduler := newTestScheduler(t, s)
+
+	a := createTestActivity(t, s, time.Now().Add(time.Hour), time.Now().Add(2 * time.Hour))
+	if _, err := s.activityData.UpdateFields(context.Background(), a.ID, []int{models.ActivityOngoing}, map[string]any{"status": models.ActivityNotStarted}); err != nil{
+		t.Fatalf("修改活动状态失败：%v", err)
+	}
+	a.Status = models.ActivityNotStarted
+	if err := s.warmUpActivity(context.Background(), a); err != nil{
+		t.Fatalf("预热活动失败：%v", err)
+	}
+
+	// 切换状态后的第一次读取和第一次重试读取活动失败
+	var queries atomic.Int32
+	err := database.GetDB().Callback().Query().Before("gorm:query").Register("test:fail_activity_query", func(db *gorm.DB){
+		if db.Statement.Table != "activities"{
+			return
+		}
+		if n := queries.Add(1); n == 1 || n == 2{
+			db.AddError(fmt.Errorf("测试错误"))
+		}
+	})
+	if err != nil{
+		t.Fatalf("注册回调失败：%v", err)
+	}
+
+	if err := scheduler.transition(context.Background(), a.ID, models.ActivityOngoing); err != nil{
+		t.Fatalf("切换活动状态失败：%v", err)
+	}
+
+	lockKey := fmt.Sprintf("lock:activity:lifecycle:%d:%d", a.ID, models.ActivityOngoing)
+	if n, _ := s.activityRedis.GetRedis().Exists(context.Background(), lockKey).Result(); n != 0{
+		t.Fatalf("更新缓存失败后状态切换锁没有释放")
+	}
+
+	waitCachedStatus(t, s, a.ID, models.ActivityOngoing)
+	if n := queries.Load(); n < 3{
+		t.Fatalf("读取活动%d次，期望重试后成功", n)
+	}
+}
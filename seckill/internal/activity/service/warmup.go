@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+
+	"Redrock/seckill/internal/pkg/models"
+	activity "Redrock/seckill/kitex_gen/activity"
+)
+
+// warmUpActivity 将活动信息和库存写入Redis，过期时间和活动结束时间对齐
+// Redis中已有库存时不会覆盖，只刷新过期时间
+func (s *ActivityServiceImpl) warmUpActivity(ctx context.Context, a *models.Activity) error{
+	err := s.activityRedis.SaveActivity(ctx, a)
+	if err != nil{
+		return fmt.Errorf("缓存活动信息失败：%w", err)
+	}
+
+	created, err := s.activityRedis.InitStock(ctx, a.ID, a.AvailableStock, a.EndTime)
+	if err != nil{
+		return fmt.Errorf("初始化Redis库存失败：%w", err)
+	}
+
+	if created{
+		log.Printf("活动%d的库存已写入Redis，库存数量：%d", a.ID, a.AvailableStock)
+	}
+
+	return nil
+}
+
+// WarmUpActivities 预热所有未结束的活动，返回预热成功的活动数
+func (s *ActivityServiceImpl) WarmUpActivities(ctx context.Context) (int, error){
+	activities, err := s.activityData.ListUnfinished(ctx, time.Now())
+	if err != nil{
+		return 0, fmt.Errorf("获取未结束的活动失败：%w", err)
+	}
+
+	// 商品信息只用于缓存，获取失败时仍然预热库存
+	err = s.attachProducts(ctx, activities...)
+	if err != nil{
+		log.Printf("获取活动的商品信息失败：%v", err)
+	}
+
+	count := 0
+	for _, a := range activities{
+		err = s.warmUpActivity(ctx, a)
+		if err != nil{
+			log.Printf("预热活动%d失败：%v", a.ID, err)
+			continue
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+// RunWarmUp 启动时立即预热一次，之后按interval定时预热
+// Redis被清空或缓存过期后，定时预热会重新加载活动信息和库存
+func (s *ActivityServiceImpl) RunWarmUp(ctx context.Context, interval time.Duration){
+	if interval <= 0{
+		interval = 5 * time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		count, err := s.WarmUpActivities(ctx)
+		if err != nil{
+			log.Printf("预热活动缓存失败：%v", err)
+		}else{
+			log.Printf("预热活动缓存完成，活动数：%d", count)
+		}
+
+		select{
+		case <- ticker.C:
+		case <- ctx.Done():
+			log.Printf("预热活动缓存任务停止")
+			return
+		}
+	}
+}
+
+// WarmUpActivity 手动预热单个活动
+func (s *ActivityServiceImpl) WarmUpActivity(ctx context.Context, req *activity.WarmUpActivityRequest) (*activity.WarmUpActivityResponse, error){
+	response := &activity.WarmUpActivityResponse{
+		BaseResponse: &activity.BaseResponse{},
+	}
+
+	if req.ActivityID <= 0{
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "活动ID不能为空"
+
+		return response, nil
+	}
+
+	localActivity, err := s.getActivityWithProduct(ctx, uint(req.ActivityID))
+	if err != nil{
+		if errors.Is(err, gorm.ErrRecordNotFound){
+			response.BaseResponse.Code = 404
+			response.BaseResponse.Msg  = "活动不存在"
+
+			return response, nil
+		}
+
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "获取活动信息失败：" + err.Error()
+
+		return response, nil
+	}
+
+	if localActivity.IsEnded(){
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "活动已结束，无需预热"
+
+		return response, nil
+	}
+
+	err = s.warmUpActivity(ctx, localActivity)
+	if err != nil{
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "预热活动失败：" + err.Error()
+
+		return response, nil
+	}
+
+	stock, err := s.activityRedis.GetStock(ctx, localActivity.ID)
+	if err != nil{
+		log.Printf("获取Redis中的库存失败：%v", err)
+	}
+
+	response.Stock = stock
+	response.BaseResponse.Code = 0
+	response.BaseResponse.Msg  = "预热活动成功"
+
+	return response, nil
+}
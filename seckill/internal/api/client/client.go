@@ -8,14 +8,20 @@ import(
 
 	"Redrock/seckill/internal/api/config"
 	"Redrock/seckill/kitex_gen/activity/activityservice"
+	"Redrock/seckill/kitex_gen/activity/internalactivityservice"
 	"Redrock/seckill/kitex_gen/order/orderservice"
+	"Redrock/seckill/kitex_gen/product/productservice"
+	"Redrock/seckill/kitex_gen/user/adminuserservice"
 	"Redrock/seckill/kitex_gen/user/userservice"
 )
 
 type RPCClients struct{
 	ActivityClient 	activityservice.Client
+	InternalClient	internalactivityservice.Client	// 异步秒杀时由网关扣除库存
 	OrderClient 	orderservice.Client
 	UserClient 		userservice.Client
+	ProductClient	productservice.Client
+	AdminUserClient	adminuserservice.Client	// 管理员管理用户角色
 }
 
 func NewRPCClients(cfg *config.Config) (*RPCClients, error){
@@ -30,6 +36,17 @@ func NewRPCClients(cfg *config.Config) (*RPCClients, error){
 		return nil, fmt.Errorf("创建活动客户端失败：%v", err)
 	}
 
+	// 创建内部活动客户端
+	internalClient, err := internalactivityservice.NewClient(
+		cfg.ActivityRPC.ServiceName,
+		client.WithHostPorts(fmt.Sprintf("%s:%d", cfg.ActivityRPC.TargetHost, cfg.ActivityRPC.TargetPort)),
+		client.WithRPCTimeout(time.Duration(cfg.ActivityRPC.Timeout)*time.Second),
+	)
+
+	if err != nil{
+		return nil, fmt.Errorf("创建内部活动客户端失败：%v", err)
+	}
+
 	// 创建订单客户端
 	orderClient, err := orderservice.NewClient(
 		cfg.OrderRPC.ServiceName,
@@ -51,10 +68,35 @@ func NewRPCClients(cfg *config.Config) (*RPCClients, error){
 	if err != nil{
 		return nil, fmt.Errorf("创建用户客户端失败：%v", err)
 	}
+
+	// 创建用户管理客户端
+	adminUserClient, err := adminuserservice.NewClient(
+		cfg.UserRPC.ServiceName,
+		client.WithHostPorts(fmt.Sprintf("%s:%d", cfg.UserRPC.TargetHost, cfg.UserRPC.TargetPort)),
+		client.WithRPCTimeout(time.Duration(cfg.UserRPC.Timeout)*time.Second),
+	)
+
+	if err != nil{
+		return nil, fmt.Errorf("创建用户管理客户端失败：%v", err)
+	}
+
+	// 创建商品客户端
+	productClient, err := productservice.NewClient(
+		cfg.ProductRPC.ServiceName,
+		client.WithHostPorts(fmt.Sprintf("%s:%d", cfg.ProductRPC.TargetHost, cfg.ProductRPC.TargetPort)),
+		client.WithRPCTimeout(time.Duration(cfg.ProductRPC.Timeout)*time.Second),
+	)
+
+	if err != nil{
+		return nil, fmt.Errorf("创建商品客户端失败：%v", err)
+	}
 	
 	return &RPCClients{
 		ActivityClient: activityClient,
+		InternalClient: internalClient,
 		OrderClient: orderClient,
 		UserClient: userClient,
+		ProductClient: productClient,
+		AdminUserClient: adminUserClient,
 	}, nil
 }
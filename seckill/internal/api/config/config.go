@@ -1,7 +1,10 @@
 package config
 
 import (
+	"Redrock/seckill/internal/pkg/auth"
+	"Redrock/seckill/internal/pkg/mq"
 	"Redrock/seckill/internal/pkg/redis"
+	"Redrock/seckill/internal/pkg/snowflake"
 )
 
 type Config struct{
@@ -9,7 +12,45 @@ type Config struct{
 	UserRPC		ClientConfig		`mapstructure:"user_rpc"`
 	ActivityRPC	ClientConfig		`mapstructure:"activity_rpc"`
 	OrderRPC	ClientConfig		`mapstructure:"order_rpc"`
+	ProductRPC	ClientConfig		`mapstructure:"product_rpc"`
 	Redis		redis.RedisConfig	`mapstructure:"redis"`
+	Auth		auth.AuthConfig		`mapstructure:"auth"`
+	Seckill		SeckillConfig		`mapstructure:"seckill"`
+	SeckillMQ	mq.MQConfig			`mapstructure:"seckill_mq"`
+	Snowflake	snowflake.SnowflakeConfig	`mapstructure:"snowflake"`
+	RateLimit	RateLimitConfig		`mapstructure:"rate_limit"`
+	WaitingRoom	WaitingRoomConfig	`mapstructure:"waiting_room"`
+}
+
+// 按路由配置的限流策略
+type RateLimitConfig struct{
+	Policies	[]RateLimitPolicy	`mapstructure:"policies"`
+}
+
+// 一条限流策略，一个请求匹配多条策略时需要全部通过
+type RateLimitPolicy struct{
+	Name		string	`mapstructure:"name"`		// 策略名称，作为限流键的一部分，不能重复
+	Route		string	`mapstructure:"route"`		// 路由，如/api/order/seckill，以/*结尾时匹配前缀，*匹配所有路由
+	Key			string	`mapstructure:"key"`		// 限流维度: user, ip, activity
+	Algorithm	string	`mapstructure:"algorithm"`	// 限流算法: sliding_window, token_bucket
+	Limit		int		`mapstructure:"limit"`		// 滑动窗口：窗口内允许的请求数
+	Window		int		`mapstructure:"window"`		// 滑动窗口：窗口长度(毫秒)
+	Rate		float64	`mapstructure:"rate"`		// 令牌桶：每秒生成的令牌数
+	Burst		int		`mapstructure:"burst"`		// 令牌桶：桶的容量，即允许的突发请求数
+}
+
+// 秒杀接口的配置
+type SeckillConfig struct{
+	// 开启后网关只扣除库存并发送排队消息，立即返回排队凭证，由订单服务异步创建订单
+	Async		bool	`mapstructure:"async"`
+}
+
+// 秒杀排队的配置，每个活动单独排队
+type WaitingRoomConfig struct{
+	Capacity		int	`mapstructure:"capacity"`		// 每个活动同时处理的秒杀请求数，超过后进入排队，0表示不排队
+	AdmitTimeout	int	`mapstructure:"admit_timeout"`	// 轮到用户后需要在该时间内重新提交秒杀请求(毫秒)
+	PollTimeout		int	`mapstructure:"poll_timeout"`	// 排队中超过该时间没有查询排队状态的用户在轮到时移出队列(毫秒)
+	HoldTimeout		int	`mapstructure:"hold_timeout"`	// 一个秒杀请求最长占用名额的时间(毫秒)，网关异常退出时名额在超时后释放
 }
 
 // 这里为Hertz服务器的配置
@@ -17,7 +58,7 @@ type ServerConfig struct{
 	Host 		string 	`mapstructure:"host"`
 	Port 		int 	`mapstructure:"port"`
 	LogLevel 	string 	`mapstructure:"log_level"`
-	RateLimit 	int 	`mapstructure:"rate_limit"` // 限制每秒请求数
+	RateLimit 	int 	`mapstructure:"rate_limit"` // 限制每个IP每秒请求数，0表示不限制
 }
 
 // 这里是kitex client的配置
@@ -2,28 +2,34 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"log"
+	"math"
 	"strconv"
 
 	"github.com/cloudwego/hertz/pkg/app"
 	"github.com/cloudwego/hertz/pkg/protocol/consts"
 
 	"Redrock/seckill/internal/api/client"
+	"Redrock/seckill/internal/api/middleware"
 	"Redrock/seckill/kitex_gen/activity"
 )
 
 // ActivityHandler 活动相关处理器
 type ActivityHandler struct{
 	activityClients *client.RPCClients
+	waitingRoom     *middleware.WaitingRoom
 }
 
 // NewActivityHandler 创建活动处理器
-func NewActivityHandler(activityClient *client.RPCClients) *ActivityHandler{
+func NewActivityHandler(activityClient *client.RPCClients, waitingRoom *middleware.WaitingRoom) *ActivityHandler{
 	return &ActivityHandler{
 		activityClients: activityClient,
+		waitingRoom:     waitingRoom,
 	}
 }
 
-// CreateActivity 创建秒杀活动
+// CreateActivity 创建秒杀活动，商家只能为自己的商品创建活动
 func (h *ActivityHandler) CreateActivity(ctx context.Context, c *app.RequestContext){
 	var req activity.CreateActivityRequest
 	if err := c.BindJSON(&req); err != nil{
@@ -34,6 +40,10 @@ func (h *ActivityHandler) CreateActivity(ctx context.Context, c *app.RequestCont
 		return
 	}
 
+	if !checkProductOwner(ctx, c, h.activityClients, req.ProductID){
+		return
+	}
+
 	resp, err := h.activityClients.ActivityClient.CreateActivity(ctx, &req)
 	if err != nil{
 		c.JSON(consts.StatusInternalServerError, map[string]any{
@@ -47,24 +57,25 @@ func (h *ActivityHandler) CreateActivity(ctx context.Context, c *app.RequestCont
 }
 
 // ListActivities 获取秒杀活动列表
+// 查询参数：status, cursor, page_size, time_from, time_to, min_price, max_price,
+// product_name, sort_by(created, start_time, price), order(asc, desc)
 func (h *ActivityHandler) ListActivities(ctx context.Context, c *app.RequestContext){
-	status := int32(-1) // 默认获取所有状态的活动
-
-	statusStr := c.Query("status")
-	if statusStr != ""{
-		statusInt, err := strconv.Atoi(statusStr)
-		if err != nil{
-			c.JSON(consts.StatusBadRequest, map[string]any{
-				"code":    400,
-				"message": "status参数有误" + err.Error(),
-			})
-			return
-		}
-		status = int32(statusInt)
-	}
+	q := &listQuery{c: c}
 
 	req := &activity.GetActivityListRequest{
-		Status: status,
+		Status:			q.int32("status", -1), // 默认获取所有状态的活动
+		Cursor:			c.Query("cursor"),
+		PageSize:		q.int32("page_size", 0),
+		TimeFrom:		q.optionalInt64("time_from"),
+		TimeTo:			q.optionalInt64("time_to"),
+		MinPrice:		q.optionalFloat64("min_price"),
+		MaxPrice:		q.optionalFloat64("max_price"),
+		ProductName:	c.Query("product_name"),
+		SortBy:			c.Query("sort_by"),
+		Asc:			q.asc(),
+	}
+	if q.failed(){
+		return
 	}
 
 	// 根据status获取活动列表
@@ -107,3 +118,193 @@ func (h *ActivityHandler) GetActivity(ctx context.Context, c *app.RequestContext
 
 	c.JSON(consts.StatusOK, resp)
 }
+
+// activityIDParam 解析路径中的活动ID，解析失败时返回400
+func activityIDParam(c *app.RequestContext) (int64, bool){
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil{
+		c.JSON(consts.StatusBadRequest, map[string]any{
+			"code":    400,
+			"message": "id参数有误" + err.Error(),
+		})
+		return 0, false
+	}
+
+	return id, true
+}
+
+// UpdateActivity 修改秒杀活动，请求体中只需要包含要修改的字段
+func (h *ActivityHandler) UpdateActivity(ctx context.Context, c *app.RequestContext){
+	id, ok := activityIDParam(c)
+	if !ok || !checkActivityOwner(ctx, c, h.activityClients, id){
+		return
+	}
+
+	var req activity.UpdateActivityRequest
+	if err := c.BindJSON(&req); err != nil{
+		c.JSON(consts.StatusBadRequest, map[string]any{
+			"code":    400,
+			"message": "请求的参数有误: " + err.Error(),
+		})
+		return
+	}
+	req.ActivityID = id
+
+	resp, err := h.activityClients.ActivityClient.UpdateActivity(ctx, &req)
+	if err != nil{
+		c.JSON(consts.StatusInternalServerError, map[string]any{
+			"code":    500,
+			"message": "服务器内部错误: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(consts.StatusOK, resp)
+}
+
+// PauseActivity 暂停秒杀活动
+func (h *ActivityHandler) PauseActivity(ctx context.Context, c *app.RequestContext){
+	id, ok := activityIDParam(c)
+	if !ok || !checkActivityOwner(ctx, c, h.activityClients, id){
+		return
+	}
+
+	resp, err := h.activityClients.ActivityClient.PauseActivity(ctx, &activity.PauseActivityRequest{
+		ActivityID: id,
+	})
+	if err != nil{
+		c.JSON(consts.StatusInternalServerError, map[string]any{
+			"code":    500,
+			"message": "服务器内部错误: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(consts.StatusOK, resp)
+}
+
+// ResumeActivity 恢复暂停的秒杀活动
+func (h *ActivityHandler) ResumeActivity(ctx context.Context, c *app.RequestContext){
+	id, ok := activityIDParam(c)
+	if !ok || !checkActivityOwner(ctx, c, h.activityClients, id){
+		return
+	}
+
+	resp, err := h.activityClients.ActivityClient.ResumeActivity(ctx, &activity.ResumeActivityRequest{
+		ActivityID: id,
+	})
+	if err != nil{
+		c.JSON(consts.StatusInternalServerError, map[string]any{
+			"code":    500,
+			"message": "服务器内部错误: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(consts.StatusOK, resp)
+}
+
+// DeleteActivity 删除秒杀活动
+func (h *ActivityHandler) DeleteActivity(ctx context.Context, c *app.RequestContext){
+	id, ok := activityIDParam(c)
+	if !ok || !checkActivityOwner(ctx, c, h.activityClients, id){
+		return
+	}
+
+	resp, err := h.activityClients.ActivityClient.DeleteActivity(ctx, &activity.DeleteActivityRequest{
+		ActivityID: id,
+	})
+	if err != nil{
+		c.JSON(consts.StatusInternalServerError, map[string]any{
+			"code":    500,
+			"message": "服务器内部错误: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(consts.StatusOK, resp)
+}
+
+// AdjustStock 调整秒杀活动的库存，请求体为{"delta": 调整数量}
+func (h *ActivityHandler) AdjustStock(ctx context.Context, c *app.RequestContext){
+	id, ok := activityIDParam(c)
+	if !ok || !checkActivityOwner(ctx, c, h.activityClients, id){
+		return
+	}
+
+	var req activity.AdjustStockRequest
+	if err := c.BindJSON(&req); err != nil{
+		c.JSON(consts.StatusBadRequest, map[string]any{
+			"code":    400,
+			"message": "请求的参数有误: " + err.Error(),
+		})
+		return
+	}
+	req.ActivityID = id
+
+	resp, err := h.activityClients.ActivityClient.AdjustStock(ctx, &req)
+	if err != nil{
+		c.JSON(consts.StatusInternalServerError, map[string]any{
+			"code":    500,
+			"message": "服务器内部错误: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(consts.StatusOK, resp)
+}
+
+// GetQueueStatus 查询秒杀排队状态，查询参数token为秒杀接口返回的queueToken
+// 排队中的用户需要定时查询，超过waiting_room.poll_timeout没有查询时轮到后会被移出队列
+// 轮到后需要在expiresIn秒内重新提交秒杀请求
+func (h *ActivityHandler) GetQueueStatus(ctx context.Context, c *app.RequestContext){
+	activityID, ok := activityIDParam(c)
+	if !ok{
+		return
+	}
+
+	token := c.Query("token")
+	if token == ""{
+		c.JSON(consts.StatusBadRequest, map[string]any{
+			"code":    400,
+			"message": "排队凭证不能为空",
+		})
+		return
+	}
+
+	status, err := h.waitingRoom.Status(ctx, activityID, token)
+	if err != nil{
+		if errors.Is(err, middleware.ErrInvalidQueueToken){
+			c.JSON(consts.StatusNotFound, map[string]any{
+				"code":    404,
+				"message": "排队凭证无效或已过期，请重新提交秒杀请求",
+			})
+			return
+		}
+
+		log.Printf("查询活动%d排队状态失败：%v", activityID, err)
+		c.JSON(consts.StatusInternalServerError, map[string]any{
+			"code":    500,
+			"message": "服务器内部错误: " + err.Error(),
+		})
+		return
+	}
+
+	if status.Admitted{
+		c.JSON(consts.StatusOK, map[string]any{
+			"code":      0,
+			"message":   "已轮到您，请重新提交秒杀请求",
+			"admitted":  true,
+			"expiresIn": int64(math.Ceil(status.ExpiresIn.Seconds())),
+		})
+		return
+	}
+
+	c.JSON(consts.StatusOK, map[string]any{
+		"code":        0,
+		"message":     "排队中",
+		"admitted":    false,
+		"position":    status.Position,
+		"queueLength": status.QueueLength,
+	})
+}
@@ -2,28 +2,65 @@ package handler
 
 import (
 	"context"
-	"strconv"
+	"fmt"
+	"log"
 
 	"github.com/cloudwego/hertz/pkg/protocol/consts"
 	"github.com/cloudwego/hertz/pkg/app"
 
 	"Redrock/seckill/internal/api/client"
+	"Redrock/seckill/internal/api/config"
+	"Redrock/seckill/internal/api/middleware"
+	orderMQ "Redrock/seckill/internal/order/mq"
+	"Redrock/seckill/internal/pkg/snowflake"
+	"Redrock/seckill/kitex_gen/activity"
 	"Redrock/seckill/kitex_gen/order"
 )
 
+// SeckillQueue 异步秒杀使用的排队消息生产者和排队凭证生成器
+type SeckillQueue struct{
+	producer	*orderMQ.SeckillProducer
+	snGenerator	*snowflake.Generator
+}
+
+// NewSeckillQueue 创建异步秒杀队列
+func NewSeckillQueue(cfg *config.Config) (*SeckillQueue, error){
+	snGenerator, err := snowflake.NewGenerator(&cfg.Snowflake)
+	if err != nil{
+		return nil, fmt.Errorf("创建排队凭证生成器失败：%w", err)
+	}
+
+	producer, err := orderMQ.NewSeckillProducer(&cfg.SeckillMQ)
+	if err != nil{
+		return nil, err
+	}
+
+	return &SeckillQueue{
+		producer:		producer,
+		snGenerator:	snGenerator,
+	}, nil
+}
+
+// Close 关闭连接
+func (q *SeckillQueue) Close(){
+	q.producer.Close()
+}
+
 // OrderHandler 订单相关处理器
 type OrderHandler struct{
 	orderClients *client.RPCClients
+	seckillQueue *SeckillQueue // 为nil时同步下单
 }
 
 // NewOrderHandler 创建订单处理器
-func NewOrderHandler(orderClient *client.RPCClients) *OrderHandler{
+func NewOrderHandler(orderClient *client.RPCClients, seckillQueue *SeckillQueue) *OrderHandler{
 	return &OrderHandler{
 		orderClients: orderClient,
+		seckillQueue: seckillQueue,
 	}
 }
 
-// CreateOrder 创建秒杀订单
+// CreateOrder 创建秒杀订单，用户为访问令牌中的用户
 func (h *OrderHandler) CreateOrder(ctx context.Context, c *app.RequestContext){
 	var req order.CreateOrderRequest
 	if err := c.BindJSON(&req); err != nil{
@@ -33,6 +70,12 @@ func (h *OrderHandler) CreateOrder(ctx context.Context, c *app.RequestContext){
 		})
 		return
 	}
+	req.UserID = middleware.UserID(c)
+
+	if h.seckillQueue != nil{
+		h.createOrderAsync(ctx, c, &req)
+		return
+	}
 
 	resp, err := h.orderClients.OrderClient.CreateOrder(ctx, &req)
 	if err != nil{
@@ -46,30 +89,131 @@ func (h *OrderHandler) CreateOrder(ctx context.Context, c *app.RequestContext){
 	c.JSON(consts.StatusOK, resp)
 }
 
-// GetOrder 获取秒杀订单详情
-func (h *OrderHandler) GetOrder(ctx context.Context, c *app.RequestContext){
-	userIDStr := c.Param("user_id")
-	orderSn := c.Param("order_sn")
+// createOrderAsync 异步秒杀：网关只在Redis中扣除库存，然后发送排队消息并立即返回排队凭证
+// 订单由订单服务消费排队消息后创建，用户通过排队凭证查询结果
+func (h *OrderHandler) createOrderAsync(ctx context.Context, c *app.RequestContext, req *order.CreateOrderRequest){
+	if req.UserID <= 0 || req.ActivityID <= 0 || req.Quantity < 0{
+		c.JSON(consts.StatusBadRequest, map[string]any{
+			"code":    400,
+			"message": "输入参数错误",
+		})
+		return
+	}
+
+	// 未设置购买数量时为1，是否超过限购数量由活动服务扣除库存时检查
+	quantity := max(req.Quantity, 1)
+
+	// 排队凭证同时作为订单号，库存扣除按订单号记录
+	ticket, err := h.seckillQueue.snGenerator.NextSn()
+	if err != nil{
+		c.JSON(consts.StatusInternalServerError, map[string]any{
+			"code":    500,
+			"message": "生成排队凭证失败: " + err.Error(),
+		})
+		return
+	}
+
+	// 检查活动时间、状态和限购数量并扣除库存，由活动服务在Redis中原子完成，不查询数据库
+	deductResp, err := h.orderClients.InternalClient.DeductStock(ctx, &activity.DeductStockRequest{
+		ActivityID:	req.ActivityID,
+		UserID:		req.UserID,
+		Count:		int64(quantity),
+		OrderSn:	ticket,
+	})
+	if err != nil{
+		c.JSON(consts.StatusInternalServerError, map[string]any{
+			"code":    500,
+			"message": "服务器内部错误: " + err.Error(),
+		})
+		return
+	}
+
+	if deductResp.BaseResponse.Code != 0 || !deductResp.Success{
+		c.JSON(consts.StatusOK, map[string]any{
+			"code":    deductResp.BaseResponse.Code,
+			"message": deductResp.BaseResponse.Msg,
+		})
+		return
+	}
+
+	err = h.seckillQueue.producer.Produce(&orderMQ.SeckillMessage{
+		OrderSn:		ticket,
+		UserID:			uint(req.UserID),
+		ActivityID:		uint(req.ActivityID),
+		Quantity:		int(quantity),
+	})
+	if err != nil{
+		// 排队失败时归还库存，让用户可以重新抢购
+		returnResp, returnErr := h.orderClients.InternalClient.ReturnStock(ctx, &activity.ReturnStockRequest{
+			ActivityID:	req.ActivityID,
+			UserID:		req.UserID,
+			Count:		int64(quantity),
+			OrderSn:	ticket,
+		})
+		if returnErr == nil && returnResp.BaseResponse.Code != 0{
+			returnErr = fmt.Errorf("%s", returnResp.BaseResponse.Msg)
+		}
+		if returnErr != nil{
+			log.Printf("排队失败后归还库存失败：%v, 排队凭证：%v", returnErr, ticket)
+		}
+
+		c.JSON(consts.StatusInternalServerError, map[string]any{
+			"code":    500,
+			"message": "排队失败，请稍后再试: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(consts.StatusOK, map[string]any{
+		"code":    0,
+		"message": "排队中",
+		"ticket":  ticket,
+	})
+}
+
+// GetOrderResult 查询异步秒杀结果
+func (h *OrderHandler) GetOrderResult(ctx context.Context, c *app.RequestContext){
+	ticket := c.Param("ticket")
 
-	if userIDStr == "" || orderSn == ""{
+	if ticket == ""{
 		c.JSON(consts.StatusBadRequest, map[string]any{
 			"code":    400,
-			"message": "用户ID和订单号不能为空",
+			"message": "排队凭证不能为空",
 		})
 		return
 	}
 
-	userID, err := strconv.ParseInt(userIDStr, 10, 64)
+	req := &order.GetSeckillStatusRequest{
+		UserID: middleware.UserID(c),
+		Ticket: ticket,
+	}
+
+	resp, err := h.orderClients.OrderClient.GetSeckillStatus(ctx, req)
 	if err != nil{
+		c.JSON(consts.StatusInternalServerError, map[string]any{
+			"code":    500,
+			"message": "服务器内部错误: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(consts.StatusOK, resp)
+}
+
+// GetOrder 获取当前用户的秒杀订单详情
+func (h *OrderHandler) GetOrder(ctx context.Context, c *app.RequestContext){
+	orderSn := c.Param("order_sn")
+
+	if orderSn == ""{
 		c.JSON(consts.StatusBadRequest, map[string]any{
 			"code":    400,
-			"message": "用户ID参数有误" + err.Error(),
+			"message": "订单号不能为空",
 		})
 		return
 	}
 
 	req := &order.GetOrderRequest{
-		UserID: userID,
+		UserID: middleware.UserID(c),
 		OrderSn: orderSn,
 	}
 
@@ -86,40 +230,74 @@ func (h *OrderHandler) GetOrder(ctx context.Context, c *app.RequestContext){
 	c.JSON(consts.StatusOK, resp)
 }
 
-// ListUserOrders 获取用户订单列表
+// ListUserOrders 获取当前用户的订单列表，按创建时间排序
+// 查询参数：status, cursor, page_size, time_from, time_to, order(asc, desc)
 func (h *OrderHandler) ListUserOrders(ctx context.Context, c *app.RequestContext){
-	userIDStr := c.Param("user_id")
-	userID, err := strconv.ParseInt(userIDStr, 10, 64)
+	q := &listQuery{c: c}
+
+	req := &order.ListOrdersRequest{
+		UserID:		middleware.UserID(c),
+		Status:		order.OrderStatus(q.int32("status", -1)), // 默认获取所有状态的订单
+		Cursor:		c.Query("cursor"),
+		PageSize:	q.int32("page_size", 0),
+		TimeFrom:	q.optionalInt64("time_from"),
+		TimeTo:		q.optionalInt64("time_to"),
+		Asc:		q.asc(),
+	}
+	if q.failed(){
+		return
+	}
+
+	// 根据用户ID和订单状态获取订单列表
+	resp, err := h.orderClients.OrderClient.ListOrders(ctx, req)
 	if err != nil{
+		c.JSON(consts.StatusInternalServerError, map[string]any{
+			"code":    500,
+			"message": "服务器内部错误: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(consts.StatusOK, resp)
+}
+
+// PayOrder 支付当前用户的订单
+func (h *OrderHandler) PayOrder(ctx context.Context, c *app.RequestContext){
+	var req order.PayOrderRequest
+	if err := c.BindJSON(&req); err != nil{
 		c.JSON(consts.StatusBadRequest, map[string]any{
 			"code":    400,
-			"message": "用户ID参数有误" + err.Error(),
+			"message": "请求的参数有误: " + err.Error(),
 		})
 		return
 	}
+	req.UserID = middleware.UserID(c)
 
-	var status order.OrderStatus = -1 // 默认获取所有状态的订单
-	statusStr := c.Query("status")
-
-	if statusStr != ""{
-		statusInt, err := strconv.Atoi(statusStr)
-		if err != nil{
-			c.JSON(consts.StatusBadRequest, map[string]any{
-				"code":    400,
-				"message": "status参数有误" + err.Error(),
-			})
-			return
-		}
-		status = order.OrderStatus(statusInt)
+	resp, err := h.orderClients.OrderClient.PayOrder(ctx, &req)
+	if err != nil{
+		c.JSON(consts.StatusInternalServerError, map[string]any{
+			"code":    500,
+			"message": "服务器内部错误: " + err.Error(),
+		})
+		return
 	}
 
-	req := &order.ListOrdersRequest{
-		UserID: userID,
-		Status: status,
+	c.JSON(consts.StatusOK, resp)
+}
+
+// CancelOrder 取消当前用户的订单
+func (h *OrderHandler) CancelOrder(ctx context.Context, c *app.RequestContext){
+	var req order.CancelOrderRequest
+	if err := c.BindJSON(&req); err != nil{
+		c.JSON(consts.StatusBadRequest, map[string]any{
+			"code":    400,
+			"message": "请求的参数有误: " + err.Error(),
+		})
+		return
 	}
+	req.UserID = middleware.UserID(c)
 
-	// 根据用户ID和订单状态获取订单列表
-	resp, err := h.orderClients.OrderClient.ListOrders(ctx, req)
+	resp, err := h.orderClients.OrderClient.CancelOrder(ctx, &req)
 	if err != nil{
 		c.JSON(consts.StatusInternalServerError, map[string]any{
 			"code":    500,
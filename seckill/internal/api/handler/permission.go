@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+
+	"Redrock/seckill/internal/api/client"
+	"Redrock/seckill/internal/api/middleware"
+	"Redrock/seckill/internal/pkg/auth"
+	"Redrock/seckill/kitex_gen/activity"
+	"Redrock/seckill/kitex_gen/product"
+)
+
+// checkProductOwner 管理员可以管理所有商品，商家只能管理自己的商品，检查不通过时写入响应并返回false
+func checkProductOwner(ctx context.Context, c *app.RequestContext, clients *client.RPCClients, productID int64) bool{
+	if middleware.Role(c) == auth.RoleAdmin{
+		return true
+	}
+
+	resp, err := clients.ProductClient.GetProduct(ctx, &product.GetProductRequest{
+		ProductID: productID,
+	})
+	if err != nil{
+		c.JSON(consts.StatusInternalServerError, map[string]any{
+			"code":    500,
+			"message": "服务器内部错误: " + err.Error(),
+		})
+		return false
+	}
+
+	if resp.BaseResponse.Code != 0{
+		c.JSON(consts.StatusOK, map[string]any{
+			"code":    resp.BaseResponse.Code,
+			"message": resp.BaseResponse.Msg,
+		})
+		return false
+	}
+
+	if resp.Product.MerchantID != middleware.UserID(c){
+		c.JSON(consts.StatusForbidden, map[string]any{
+			"code":    403,
+			"message": "只能管理自己的商品",
+		})
+		return false
+	}
+
+	return true
+}
+
+// checkActivityOwner 商家只能管理自己商品的活动，检查不通过时写入响应并返回false
+func checkActivityOwner(ctx context.Context, c *app.RequestContext, clients *client.RPCClients, activityID int64) bool{
+	if middleware.Role(c) == auth.RoleAdmin{
+		return true
+	}
+
+	resp, err := clients.ActivityClient.GetActivity(ctx, &activity.GetActivityRequest{
+		ActivityID: activityID,
+	})
+	if err != nil{
+		c.JSON(consts.StatusInternalServerError, map[string]any{
+			"code":    500,
+			"message": "服务器内部错误: " + err.Error(),
+		})
+		return false
+	}
+
+	if resp.BaseResponse.Code != 0{
+		c.JSON(consts.StatusOK, map[string]any{
+			"code":    resp.BaseResponse.Code,
+			"message": resp.BaseResponse.Msg,
+		})
+		return false
+	}
+
+	return checkProductOwner(ctx, c, clients, resp.Activity.ProductId)
+}
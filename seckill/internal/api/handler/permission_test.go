@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	"github.com/cloudwego/kitex/client/callopt"
+
+	"Redrock/seckill/internal/api/client"
+	"Redrock/seckill/internal/api/middleware"
+	"Redrock/seckill/internal/pkg/auth"
+	"Redrock/seckill/internal/pkg/testenv"
+	"Redrock/seckill/kitex_gen/activity"
+	"Redrock/seckill/kitex_gen/activity/activityservice"
+	"Redrock/seckill/kitex_gen/product"
+	"Redrock/seckill/kitex_gen/product/productservice"
+)
+
+// fakeProductClient 商品ID到商家ID的映射
+type fakeProductClient struct{
+	productservice.Client
+	merchants	map[int64]int64
+	err			error
+}
+
+func (f *fakeProductClient) GetProduct(ctx context.Context, req *product.GetProductRequest, callOptions ...callopt.Option) (*product.GetProductResponse, error){
+	if f.err != nil{
+		return nil, f.err
+	}
+
+	merchantID, ok := f.merchants[req.ProductID]
+	if !ok{
+		return &product.GetProductResponse{
+			BaseResponse: &product.BaseResponse{Code: 404, Msg: "商品不存在"},
+		}, nil
+	}
+
+	return &product.GetProductResponse{
+		BaseResponse:	&product.BaseResponse{},
+		Product:		&product.ProductInfo{Id: req.ProductID, MerchantID: merchantID},
+	}, nil
+}
+
+// fakeActivityClient 活动ID到商品ID的映射
+type fakeActivityClient struct{
+	activityservice.Client
+	products	map[int64]int64
+}
+
+func (f *fakeActivityClient) GetActivity(ctx context.Context, req *activity.GetActivityRequest, callOptions ...callopt.Option) (*activity.GetActivityResponse, error){
+	productID, ok := f.products[req.ActivityID]
+	if !ok{
+		return &activity.GetActivityResponse{
+			BaseResponse: &activity.BaseResponse{Code: 404, Msg: "活动不存在"},
+		}, nil
+	}
+
+	return &activity.GetActivityResponse{
+		BaseResponse:	&activity.BaseResponse{},
+		Activity:		&activity.ActivityInfo{Id: req.ActivityID, ProductId: productID},
+	}, nil
+}
+
+// 商品1和活动10属于商家100
+func newTestClients() *client.RPCClients{
+	return &client.RPCClients{
+		ProductClient:	&fakeProductClient{merchants: map[int64]int64{1: 100}},
+		ActivityClient:	&fakeActivityClient{products: map[int64]int64{10: 1}},
+	}
+}
+
+// newAuthContext 通过认证中间件创建已认证用户的请求上下文
+func newAuthContext(t *testing.T, userID uint, role string) *app.RequestContext{
+	t.Helper()
+
+	tokens, err := auth.NewTokenManager(&auth.AuthConfig{Secret: "test-secret", AccessTTL: 60})
+	if err != nil{
+		t.Fatalf("创建令牌管理器失败：%v", err)
+	}
+	revoker := auth.NewRevoker(testenv.NewRedis(t), &auth.AuthConfig{AccessTTL: 60})
+
+	token, _, err := tokens.Issue(userID, role)
+	if err != nil{
+		t.Fatalf("签发令牌失败：%v", err)
+	}
+
+	c := app.NewContext(0)
+	c.Request.Header.Set("Authorization", "Bearer " + token)
+	middleware.Auth(tokens, revoker)(context.Background(), c)
+	if c.IsAborted(){
+		t.Fatalf("认证失败：%s", c.Response.Body())
+	}
+
+	return c
+}
+
+func TestCheckProductOwner(t *testing.T){
+	clients := newTestClients()
+
+	tests := []struct{
+		name		string
+		userID		uint
+		role		string
+		productID	int64
+		allowed		bool
+		status		int
+	}{
+		{"商家管理自己的商品", 100, auth.RoleMerchant, 1, true, consts.StatusOK},
+		{"商家管理别人的商品", 200, auth.RoleMerchant, 1, false, consts.StatusForbidden},
+		{"管理员管理所有商品", 300, auth.RoleAdmin, 1, true, consts.StatusOK},
+		{"管理员不查询商品", 300, auth.RoleAdmin, 999, true, consts.StatusOK},
+		{"商品不存在", 100, auth.RoleMerchant, 999, false, consts.StatusOK},
+	}
+
+	for _, tt := range tests{
+		c := newAuthContext(t, tt.userID, tt.role)
+
+		allowed := checkProductOwner(context.Background(), c, clients, tt.productID)
+		if allowed != tt.allowed || c.Response.StatusCode() != tt.status{
+			t.Fatalf("%s：检查结果为%v, %d，期望%v, %d", tt.name, allowed, c.Response.StatusCode(), tt.allowed, tt.status)
+		}
+	}
+}
+
+// 查询商品失败时拒绝操作
+func TestCheckProductOwnerRPCError(t *testing.T){
+	clients := &client.RPCClients{
+		ProductClient: &fakeProductClient{err: errors.New("连接失败")},
+	}
+	c := newAuthContext(t, 100, auth.RoleMerchant)
+
+	if checkProductOwner(context.Background(), c, clients, 1){
+		t.Fatal("查询商品失败时应该拒绝操作")
+	}
+	if c.Response.StatusCode() != consts.StatusInternalServerError{
+		t.Fatalf("响应状态为%d，期望500", c.Response.StatusCode())
+	}
+}
+
+func TestCheckActivityOwner(t *testing.T){
+	clients := newTestClients()
+
+	tests := []struct{
+		name		string
+		userID		uint
+		role		string
+		activityID	int64
+		allowed		bool
+		status		int
+	}{
+		{"商家管理自己商品的活动", 100, auth.RoleMerchant, 10, true, consts.StatusOK},
+		{"商家管理别人商品的活动", 200, auth.RoleMerchant, 10, false, consts.StatusForbidden},
+		{"管理员管理所有活动", 300, auth.RoleAdmin, 10, true, consts.StatusOK},
+		{"活动不存在", 100, auth.RoleMerchant, 999, false, consts.StatusOK},
+	}
+
+	for _, tt := range tests{
+		c := newAuthContext(t, tt.userID, tt.role)
+
+		allowed := checkActivityOwner(context.Background(), c, clients, tt.activityID)
+		if allowed != tt.allowed || c.Response.StatusCode() != tt.status{
+			t.Fatalf("%s：检查结果为%v, %d，期望%v, %d", tt.name, allowed, c.Response.StatusCode(), tt.allowed, tt.status)
+		}
+	}
+}
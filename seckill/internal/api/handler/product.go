@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+
+	"Redrock/seckill/internal/api/client"
+	"Redrock/seckill/internal/api/middleware"
+	"Redrock/seckill/internal/pkg/auth"
+	"Redrock/seckill/kitex_gen/product"
+)
+
+// ProductHandler 商品相关处理器
+type ProductHandler struct{
+	productClients *client.RPCClients
+}
+
+// NewProductHandler 创建商品处理器
+func NewProductHandler(productClient *client.RPCClients) *ProductHandler{
+	return &ProductHandler{
+		productClients: productClient,
+	}
+}
+
+// productIDParam 解析路径中的商品ID，解析失败时返回400
+func productIDParam(c *app.RequestContext) (int64, bool){
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil{
+		c.JSON(consts.StatusBadRequest, map[string]any{
+			"code":    400,
+			"message": "id参数有误" + err.Error(),
+		})
+		return 0, false
+	}
+
+	return id, true
+}
+
+// CreateProduct 创建商品，商家创建的商品属于自己，管理员可以指定所属商家
+func (h *ProductHandler) CreateProduct(ctx context.Context, c *app.RequestContext){
+	var req product.CreateProductRequest
+	if err := c.BindJSON(&req); err != nil{
+		c.JSON(consts.StatusBadRequest, map[string]any{
+			"code":    400,
+			"message": "请求的参数有误: " + err.Error(),
+		})
+		return
+	}
+
+	if middleware.Role(c) != auth.RoleAdmin{
+		req.MerchantID = middleware.UserID(c)
+	}
+
+	resp, err := h.productClients.ProductClient.CreateProduct(ctx, &req)
+	if err != nil{
+		c.JSON(consts.StatusInternalServerError, map[string]any{
+			"code":    500,
+			"message": "服务器内部错误: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(consts.StatusOK, resp)
+}
+
+// ListProducts 获取商品列表，可以通过category、merchant_id、page、page_size筛选和分页
+func (h *ProductHandler) ListProducts(ctx context.Context, c *app.RequestContext){
+	req := &product.ListProductsRequest{
+		Category:	c.Query("category"),
+		Page:		1,
+		PageSize:	20,
+	}
+
+	if merchantStr := c.Query("merchant_id"); merchantStr != ""{
+		merchantID, err := strconv.ParseInt(merchantStr, 10, 64)
+		if err != nil{
+			c.JSON(consts.StatusBadRequest, map[string]any{
+				"code":    400,
+				"message": "merchant_id参数有误" + err.Error(),
+			})
+			return
+		}
+		req.MerchantID = merchantID
+	}
+
+	if pageStr := c.Query("page"); pageStr != ""{
+		page, err := strconv.Atoi(pageStr)
+		if err != nil{
+			c.JSON(consts.StatusBadRequest, map[string]any{
+				"code":    400,
+				"message": "page参数有误" + err.Error(),
+			})
+			return
+		}
+		req.Page = int32(page)
+	}
+
+	if pageSizeStr := c.Query("page_size"); pageSizeStr != ""{
+		pageSize, err := strconv.Atoi(pageSizeStr)
+		if err != nil{
+			c.JSON(consts.StatusBadRequest, map[string]any{
+				"code":    400,
+				"message": "page_size参数有误" + err.Error(),
+			})
+			return
+		}
+		req.PageSize = int32(pageSize)
+	}
+
+	resp, err := h.productClients.ProductClient.ListProducts(ctx, req)
+	if err != nil{
+		c.JSON(consts.StatusInternalServerError, map[string]any{
+			"code":    500,
+			"message": "服务器内部错误: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(consts.StatusOK, resp)
+}
+
+// GetProduct 获取商品详情
+func (h *ProductHandler) GetProduct(ctx context.Context, c *app.RequestContext){
+	id, ok := productIDParam(c)
+	if !ok{
+		return
+	}
+
+	resp, err := h.productClients.ProductClient.GetProduct(ctx, &product.GetProductRequest{
+		ProductID: id,
+	})
+	if err != nil{
+		c.JSON(consts.StatusInternalServerError, map[string]any{
+			"code":    500,
+			"message": "服务器内部错误: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(consts.StatusOK, resp)
+}
+
+// UpdateProduct 修改商品，请求体中只需要包含要修改的字段，商家只能修改自己的商品
+func (h *ProductHandler) UpdateProduct(ctx context.Context, c *app.RequestContext){
+	id, ok := productIDParam(c)
+	if !ok || !checkProductOwner(ctx, c, h.productClients, id){
+		return
+	}
+
+	var req product.UpdateProductRequest
+	if err := c.BindJSON(&req); err != nil{
+		c.JSON(consts.StatusBadRequest, map[string]any{
+			"code":    400,
+			"message": "请求的参数有误: " + err.Error(),
+		})
+		return
+	}
+	req.ProductID = id
+
+	resp, err := h.productClients.ProductClient.UpdateProduct(ctx, &req)
+	if err != nil{
+		c.JSON(consts.StatusInternalServerError, map[string]any{
+			"code":    500,
+			"message": "服务器内部错误: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(consts.StatusOK, resp)
+}
+
+// DeleteProduct 删除商品，商家只能删除自己的商品
+func (h *ProductHandler) DeleteProduct(ctx context.Context, c *app.RequestContext){
+	id, ok := productIDParam(c)
+	if !ok || !checkProductOwner(ctx, c, h.productClients, id){
+		return
+	}
+
+	resp, err := h.productClients.ProductClient.DeleteProduct(ctx, &product.DeleteProductRequest{
+		ProductID: id,
+	})
+	if err != nil{
+		c.JSON(consts.StatusInternalServerError, map[string]any{
+			"code":    500,
+			"message": "服务器内部错误: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(consts.StatusOK, resp)
+}
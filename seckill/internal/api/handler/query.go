@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+)
+
+// listQuery 解析列表接口的查询参数，只记录第一个格式错误
+type listQuery struct{
+	c	*app.RequestContext
+	err	error
+}
+
+// optionalInt64 解析可选的整数参数，未设置时返回nil
+func (q *listQuery) optionalInt64(name string) *int64{
+	str := q.c.Query(name)
+	if str == "" || q.err != nil{
+		return nil
+	}
+
+	value, err := strconv.ParseInt(str, 10, 64)
+	if err != nil{
+		q.err = fmt.Errorf("%s参数有误", name)
+		return nil
+	}
+
+	return &value
+}
+
+// optionalFloat64 解析可选的数值参数，未设置时返回nil
+func (q *listQuery) optionalFloat64(name string) *float64{
+	str := q.c.Query(name)
+	if str == "" || q.err != nil{
+		return nil
+	}
+
+	value, err := strconv.ParseFloat(str, 64)
+	if err != nil{
+		q.err = fmt.Errorf("%s参数有误", name)
+		return nil
+	}
+
+	return &value
+}
+
+// int32 解析整数参数，未设置时返回def
+func (q *listQuery) int32(name string, def int32) int32{
+	str := q.c.Query(name)
+	if str == "" || q.err != nil{
+		return def
+	}
+
+	value, err := strconv.ParseInt(str, 10, 32)
+	if err != nil{
+		q.err = fmt.Errorf("%s参数有误", name)
+		return def
+	}
+
+	return int32(value)
+}
+
+// asc 解析排序方向参数order，只接受asc和desc，默认降序
+func (q *listQuery) asc() bool{
+	switch q.c.Query("order"){
+	case "", "desc":
+		return false
+	case "asc":
+		return true
+	default:
+		if q.err == nil{
+			q.err = fmt.Errorf("order参数有误，可选asc, desc")
+		}
+		return false
+	}
+}
+
+// failed 有参数格式错误时返回400
+func (q *listQuery) failed() bool{
+	if q.err == nil{
+		return false
+	}
+
+	q.c.JSON(consts.StatusBadRequest, map[string]any{
+		"code":    400,
+		"message": q.err.Error(),
+	})
+	return true
+}
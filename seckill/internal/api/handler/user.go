@@ -2,11 +2,13 @@ package handler
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/cloudwego/hertz/pkg/protocol/consts"
 	"github.com/cloudwego/hertz/pkg/app"
 
 	"Redrock/seckill/internal/api/client"
+	"Redrock/seckill/internal/api/middleware"
 	"Redrock/seckill/kitex_gen/user"
 )
 
@@ -68,3 +70,171 @@ func (h *UserHandler) Login(ctx context.Context, c *app.RequestContext) {
 
 	c.JSON(consts.StatusOK, resp)
 }
+
+// roleRequest 授予或撤销角色的请求体
+type roleRequest struct {
+	Role string `json:"role"`
+}
+
+// bindRoleRequest 解析路径中的用户ID和请求体中的角色，解析失败时返回400
+func bindRoleRequest(c *app.RequestContext) (int64, string, bool) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(consts.StatusBadRequest, map[string]any{
+			"code":    400,
+			"message": "用户ID参数有误" + err.Error(),
+		})
+		return 0, "", false
+	}
+
+	var req roleRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(consts.StatusBadRequest, map[string]any{
+			"code":    400,
+			"message": "请求的参数有误: " + err.Error(),
+		})
+		return 0, "", false
+	}
+
+	return userID, req.Role, true
+}
+
+// GrantRole 授予用户角色，只有管理员可以调用
+func (h *UserHandler) GrantRole(ctx context.Context, c *app.RequestContext) {
+	userID, role, ok := bindRoleRequest(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.userClient.AdminUserClient.GrantRole(ctx, &user.GrantRoleRequest{
+		UserId: userID,
+		Role:   role,
+	})
+	if err != nil {
+		c.JSON(consts.StatusInternalServerError, map[string]any{
+			"code":    500,
+			"message": "服务器内部错误: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(consts.StatusOK, resp)
+}
+
+// RevokeRole 撤销用户角色，只有管理员可以调用
+func (h *UserHandler) RevokeRole(ctx context.Context, c *app.RequestContext) {
+	userID, role, ok := bindRoleRequest(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.userClient.AdminUserClient.RevokeRole(ctx, &user.RevokeRoleRequest{
+		UserId: userID,
+		Role:   role,
+	})
+	if err != nil {
+		c.JSON(consts.StatusInternalServerError, map[string]any{
+			"code":    500,
+			"message": "服务器内部错误: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(consts.StatusOK, resp)
+}
+
+// GetUserInfo 获取当前用户信息
+func (h *UserHandler) GetUserInfo(ctx context.Context, c *app.RequestContext) {
+	resp, err := h.userClient.UserClient.GetUserInfo(ctx, &user.GetUserInfoRequest{
+		AccessToken: middleware.Token(c),
+	})
+	if err != nil {
+		c.JSON(consts.StatusInternalServerError, map[string]any{
+			"code":    500,
+			"message": "服务器内部错误: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(consts.StatusOK, resp)
+}
+
+// passwordRequest 修改密码的请求体
+type passwordRequest struct {
+	OldPassword string `json:"oldPassword"`
+	NewPassword string `json:"newPassword"`
+}
+
+// UpdatePassword 修改当前用户的密码，修改后需要重新登录
+func (h *UserHandler) UpdatePassword(ctx context.Context, c *app.RequestContext) {
+	var req passwordRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(consts.StatusBadRequest, map[string]any{
+			"code":    400,
+			"message": "请求的参数有误: " + err.Error(),
+		})
+		return
+	}
+
+	resp, err := h.userClient.UserClient.UpdatePassword(ctx, &user.UpdatePasswordRequest{
+		AccessToken: middleware.Token(c),
+		OldPassword: req.OldPassword,
+		Password:    req.NewPassword,
+	})
+	if err != nil {
+		c.JSON(consts.StatusInternalServerError, map[string]any{
+			"code":    500,
+			"message": "服务器内部错误: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(consts.StatusOK, resp)
+}
+
+// Logout 退出登录，撤销当前的访问令牌
+func (h *UserHandler) Logout(ctx context.Context, c *app.RequestContext) {
+	resp, err := h.userClient.UserClient.Logout(ctx, &user.LogoutRequest{
+		AccessToken: middleware.Token(c),
+	})
+	if err != nil {
+		c.JSON(consts.StatusInternalServerError, map[string]any{
+			"code":    500,
+			"message": "服务器内部错误: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(consts.StatusOK, resp)
+}
+
+// deleteAccountRequest 注销账户的请求体
+type deleteAccountRequest struct {
+	Password string `json:"password"`
+}
+
+// DeleteAccount 注销当前用户，未支付的订单会被取消
+func (h *UserHandler) DeleteAccount(ctx context.Context, c *app.RequestContext) {
+	var req deleteAccountRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(consts.StatusBadRequest, map[string]any{
+			"code":    400,
+			"message": "请求的参数有误: " + err.Error(),
+		})
+		return
+	}
+
+	resp, err := h.userClient.UserClient.DeleteAccount(ctx, &user.DeleteAccountRequest{
+		AccessToken: middleware.Token(c),
+		Password:    req.Password,
+	})
+	if err != nil {
+		c.JSON(consts.StatusInternalServerError, map[string]any{
+			"code":    500,
+			"message": "服务器内部错误: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(consts.StatusOK, resp)
+}
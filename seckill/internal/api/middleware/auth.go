@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+
+	"Redrock/seckill/internal/pkg/auth"
+)
+
+// 认证后的用户ID和角色在请求上下文中的键
+const(
+	userIDKey	= "auth_user_id"
+	roleKey		= "auth_role"
+	tokenKey	= "auth_token"
+)
+
+// Auth 认证中间件，校验Authorization: Bearer <token>中的访问令牌
+// 校验通过后将令牌中的用户ID和角色存入请求上下文，之后的处理器只使用该用户ID
+// 退出登录、修改密码或注销账户后被撤销的令牌会被拒绝
+func Auth(tokens *auth.TokenManager, revoker *auth.Revoker) app.HandlerFunc{
+	return func(c context.Context, ctx *app.RequestContext){
+		header := string(ctx.GetHeader("Authorization"))
+		token, found := strings.CutPrefix(header, "Bearer ")
+		if !found || token == ""{
+			ctx.AbortWithStatusJSON(consts.StatusUnauthorized, map[string]any{
+				"code":    401,
+				"message": "请先登录",
+			})
+			return
+		}
+
+		claims, err := tokens.Parse(token)
+		if err != nil{
+			message := "登录凭证无效，请重新登录"
+			if errors.Is(err, auth.ErrTokenExpired){
+				message = "登录已过期，请重新登录"
+			}
+
+			ctx.AbortWithStatusJSON(consts.StatusUnauthorized, map[string]any{
+				"code":    401,
+				"message": message,
+			})
+			return
+		}
+
+		revoked, err := revoker.IsRevoked(c, claims)
+		if err != nil{
+			log.Printf("校验令牌撤销记录失败：%v", err)
+			ctx.AbortWithStatusJSON(consts.StatusInternalServerError, map[string]any{
+				"code":    500,
+				"message": "校验登录状态失败，请稍后重试",
+			})
+			return
+		}
+		if revoked{
+			ctx.AbortWithStatusJSON(consts.StatusUnauthorized, map[string]any{
+				"code":    401,
+				"message": "登录已失效，请重新登录",
+			})
+			return
+		}
+
+		ctx.Set(userIDKey, int64(claims.UserID))
+		ctx.Set(roleKey, claims.Role)
+		ctx.Set(tokenKey, token)
+		ctx.Next(c)
+	}
+}
+
+// UserID 获取认证后的用户ID，未经过认证中间件时返回0
+func UserID(ctx *app.RequestContext) int64{
+	return ctx.GetInt64(userIDKey)
+}
+
+// Token 获取认证时使用的访问令牌，转发给用户服务校验
+func Token(ctx *app.RequestContext) string{
+	return ctx.GetString(tokenKey)
+}
+
+// Role 获取认证后的用户角色，未经过认证中间件时返回空字符串
+func Role(ctx *app.RequestContext) string{
+	return ctx.GetString(roleKey)
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"context"
+	"slices"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+)
+
+// RequireRole 权限中间件，只允许指定角色的用户访问，需要放在认证中间件之后
+func RequireRole(roles ...string) app.HandlerFunc{
+	return func(c context.Context, ctx *app.RequestContext){
+		if !slices.Contains(roles, Role(ctx)){
+			ctx.AbortWithStatusJSON(consts.StatusForbidden, map[string]any{
+				"code":    403,
+				"message": "没有权限执行该操作",
+			})
+			return
+		}
+
+		ctx.Next(c)
+	}
+}
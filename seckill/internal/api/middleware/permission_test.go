@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	hertzconfig "github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	"github.com/cloudwego/hertz/pkg/route"
+
+	"Redrock/seckill/internal/pkg/auth"
+	"Redrock/seckill/internal/pkg/testenv"
+)
+
+// newTestEngine 创建经过认证和权限中间件的测试路由，只允许商家和管理员访问
+func newTestEngine(t *testing.T) (*route.Engine, *auth.TokenManager, *auth.Revoker){
+	tokens, err := auth.NewTokenManager(&auth.AuthConfig{Secret: "test-secret", AccessTTL: 60})
+	if err != nil{
+		t.Fatalf("创建令牌管理器失败：%v", err)
+	}
+	revoker := auth.NewRevoker(testenv.NewRedis(t), &auth.AuthConfig{AccessTTL: 60})
+
+	engine := route.NewEngine(hertzconfig.NewOptions(nil))
+	engine.GET("/merchant", Auth(tokens, revoker), RequireRole(auth.RoleMerchant, auth.RoleAdmin), func(c context.Context, ctx *app.RequestContext){
+		ctx.JSON(consts.StatusOK, map[string]any{"user_id": UserID(ctx), "role": Role(ctx)})
+	})
+
+	return engine, tokens, revoker
+}
+
+func TestRequireRole(t *testing.T){
+	engine, tokens, _ := newTestEngine(t)
+
+	tests := []struct{
+		role	string
+		status	int
+	}{
+		{auth.RoleUser, consts.StatusForbidden},
+		{auth.RoleMerchant, consts.StatusOK},
+		{auth.RoleAdmin, consts.StatusOK},
+	}
+
+	for _, tt := range tests{
+		token, _, err := tokens.Issue(1, tt.role)
+		if err != nil{
+			t.Fatalf("签发令牌失败：%v", err)
+		}
+
+		resp := ut.PerformRequest(engine, "GET", "/merchant", nil,
+			ut.Header{Key: "Authorization", Value: "Bearer " + token}).Result()
+		if resp.StatusCode() != tt.status{
+			t.Fatalf("角色%q的响应状态为%d，期望%d：%s", tt.role, resp.StatusCode(), tt.status, resp.Body())
+		}
+	}
+}
+
+// 未认证或令牌被撤销时在权限检查之前被拒绝
+func TestRequireRoleUnauthenticated(t *testing.T){
+	engine, tokens, revoker := newTestEngine(t)
+
+	resp := ut.PerformRequest(engine, "GET", "/merchant", nil).Result()
+	if resp.StatusCode() != consts.StatusUnauthorized{
+		t.Fatalf("没有令牌时的响应状态为%d，期望401", resp.StatusCode())
+	}
+
+	token, claims, err := tokens.Issue(1, auth.RoleAdmin)
+	if err != nil{
+		t.Fatalf("签发令牌失败：%v", err)
+	}
+	if err := revoker.Revoke(context.Background(), claims); err != nil{
+		t.Fatalf("撤销令牌失败：%v", err)
+	}
+
+	resp = ut.PerformRequest(engine, "GET", "/merchant", nil,
+		ut.Header{Key: "Authorization", Value: "Bearer " + token}).Result()
+	if resp.StatusCode() != consts.StatusUnauthorized{
+		t.Fatalf("令牌被撤销后的响应状态为%d，期望401", resp.StatusCode())
+	}
+}
@@ -2,96 +2,313 @@ package middleware
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"log"
+	"math"
+	"math/rand/v2"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cloudwego/hertz/pkg/app"
 	"github.com/cloudwego/hertz/pkg/protocol/consts"
 	"github.com/redis/go-redis/v9"
+
+	"Redrock/seckill/internal/api/config"
+)
+
+// 限流算法
+const(
+	AlgorithmSlidingWindow	= "sliding_window"	// 滑动窗口日志，窗口内的请求数不超过limit
+	AlgorithmTokenBucket	= "token_bucket"	// 令牌桶，按rate生成令牌，最多允许burst个突发请求
+)
+
+// 限流维度
+const(
+	KeyUser		= "user"		// 认证后的用户，未认证时按IP
+	KeyIP		= "ip"			// 客户端IP
+	KeyActivity	= "activity"	// 活动ID，取自请求体的activityID、查询参数activity_id或路径参数id
 )
 
-// RatelimiterConfig 限流器配置
-type RateLimiterConfig struct{
-	Limit		int				// 每个周期允许的请求数
-	Period		time.Duration	// 时间周期
-	keyFunc		func(ctx *app.RequestContext) string	// 生成限流键的函数
+// slidingWindowScript 滑动窗口日志
+// 有序集合中记录窗口内每个请求的时间(微秒)，先删除窗口外的记录再计数
+// 使用Redis的时间，多个网关实例之间的时钟误差不影响限流
+// 返回：是否允许、剩余请求数、距离最早的请求移出窗口的时间(微秒)
+const slidingWindowScript = `
+local t = redis.call("TIME")
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+local window = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now - window)
+local count = redis.call("ZCARD", KEYS[1])
+
+local allowed = 0
+if count < limit then
+	redis.call("ZADD", KEYS[1], now, now .. "-" .. ARGV[3])
+	redis.call("PEXPIRE", KEYS[1], math.ceil(window / 1000))
+	count = count + 1
+	allowed = 1
+end
+
+local reset = window
+local oldest = redis.call("ZRANGE", KEYS[1], 0, 0, "WITHSCORES")
+if #oldest > 0 then
+	reset = tonumber(oldest[2]) + window - now
+end
+
+return {allowed, limit - count, reset}
+`
+
+// tokenBucketScript 令牌桶
+// 哈希中记录剩余令牌数和上次更新的时间(微秒)，请求时先按经过的时间补充令牌再消耗一个
+// 返回：是否允许、剩余令牌数(向下取整)、距离下一个令牌生成的时间(微秒)
+const tokenBucketScript = `
+local t = redis.call("TIME")
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(state[1]) or burst
+local ts = tonumber(state[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000000)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "ts", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst / rate * 1000) + 1000)
+
+local reset = 0
+if tokens < 1 then
+	reset = math.ceil((1 - tokens) / rate * 1000000)
+end
+
+return {allowed, math.floor(tokens), reset}
+`
+
+// rateLimitResult 一次限流检查的结果
+type rateLimitResult struct{
+	allowed		bool
+	limit		int64			// 限流上限，令牌桶为桶的容量
+	remaining	int64			// 剩余可用请求数
+	resetAfter	time.Duration	// 滑动窗口为最早的请求移出窗口的时间，令牌桶为下一个令牌生成的时间
 }
 
-// RedisRateLimiter 分布式限流中间件
-func RedisRateLimiter(redisClient *redis.Client, config *RateLimiterConfig) app.HandlerFunc{
-	return func(c context.Context, ctx *app.RequestContext){
-		key := fmt.Sprintf("ratelimit:%s", config.keyFunc(ctx))
-		
-		// 1. 获取当前计数
-		countKey := fmt.Sprintf("%s:count", key)
-		expireKey := fmt.Sprintf("%s:expire", key)
-		
-		// 检查是否存在过期时间
-		exists, err := redisClient.Exists(c, expireKey).Result()
-		if err != nil {
-			// 如果发生错误不限流,避免影响秒杀
-			ctx.Next(c)
-			return
+// RateLimiter 按路由和维度限流，策略在api.yaml的rate_limit中配置
+// 检查和计数在同一个lua脚本中完成，并发请求在窗口边界也不会超过限制
+type RateLimiter struct{
+	client		*redis.Client
+	policies	[]config.RateLimitPolicy
+	scripts		map[string]*redis.Script
+}
+
+// NewRateLimiter 创建限流器，server.rate_limit大于0时增加一条按IP限制每秒请求数的全局策略
+func NewRateLimiter(client *redis.Client, cfg *config.Config) (*RateLimiter, error){
+	policies := []config.RateLimitPolicy{}
+	if cfg.Server.RateLimit > 0{
+		policies = append(policies, config.RateLimitPolicy{
+			Name:		"global",
+			Route:		"*",
+			Key:		KeyIP,
+			Algorithm:	AlgorithmSlidingWindow,
+			Limit:		cfg.Server.RateLimit,
+			Window:		1000,
+		})
+	}
+	policies = append(policies, cfg.RateLimit.Policies...)
+
+	names := make(map[string]bool, len(policies))
+	for _, policy := range policies{
+		err := validatePolicy(&policy)
+		if err != nil{
+			return nil, fmt.Errorf("限流策略%s配置错误：%w", policy.Name, err)
+		}
+
+		if names[policy.Name]{
+			return nil, fmt.Errorf("限流策略%s重复", policy.Name)
+		}
+		names[policy.Name] = true
+	}
+
+	return &RateLimiter{
+		client:		client,
+		policies:	policies,
+		scripts:	map[string]*redis.Script{
+			AlgorithmSlidingWindow:	redis.NewScript(slidingWindowScript),
+			AlgorithmTokenBucket:	redis.NewScript(tokenBucketScript),
+		},
+	}, nil
+}
+
+// validatePolicy 检查限流策略的配置
+func validatePolicy(policy *config.RateLimitPolicy) error{
+	if policy.Name == "" || policy.Route == ""{
+		return fmt.Errorf("名称和路由不能为空")
+	}
+
+	switch policy.Key{
+	case KeyUser, KeyIP, KeyActivity:
+	default:
+		return fmt.Errorf("限流维度%q无效，可选user, ip, activity", policy.Key)
+	}
+
+	switch policy.Algorithm{
+	case AlgorithmSlidingWindow:
+		if policy.Limit <= 0 || policy.Window <= 0{
+			return fmt.Errorf("滑动窗口的limit和window需要大于0")
+		}
+	case AlgorithmTokenBucket:
+		if policy.Rate <= 0 || policy.Burst <= 0{
+			return fmt.Errorf("令牌桶的rate和burst需要大于0")
+		}
+	default:
+		return fmt.Errorf("限流算法%q无效，可选sliding_window, token_bucket", policy.Algorithm)
+	}
+
+	return nil
+}
+
+// matchRoute 检查策略的路由是否匹配请求的路由
+func matchRoute(pattern string, route string) bool{
+	if pattern == "*"{
+		return true
+	}
+
+	if prefix, ok := strings.CutSuffix(pattern, "/*"); ok{
+		return route == prefix || strings.HasPrefix(route, prefix + "/")
+	}
+
+	return pattern == route
+}
+
+// rateLimitKey 获取请求在该维度下的限流键，无法确定时返回空字符串，不按该策略限流
+func rateLimitKey(ctx *app.RequestContext, dimension string) string{
+	switch dimension{
+	case KeyUser:
+		// 只使用认证后的用户ID，请求头或请求体中的用户ID由客户端填写，不能作为限流依据
+		if userID := UserID(ctx); userID > 0{
+			return "user:" + strconv.FormatInt(userID, 10)
 		}
-		
-		// 不存在则创建
-		if exists == 0 {
-			redisClient.Set(c, countKey, 0, config.Period)
-			redisClient.Set(c, expireKey, time.Now().Add(config.Period).Unix(), config.Period)
+
+		// 未认证的请求按客户端IP限流
+		return "ip:" + ctx.ClientIP()
+	case KeyIP:
+		return "ip:" + ctx.ClientIP()
+	case KeyActivity:
+		if activityID := requestActivityID(ctx); activityID > 0{
+			return "activity:" + strconv.FormatInt(activityID, 10)
 		}
-		
-		// 2. 获取计数并增加
-		count, err := redisClient.Incr(c, countKey).Result()
-		if err != nil {
-			ctx.Next(c)
-			return
+	}
+
+	return ""
+}
+
+// requestActivityID 获取请求中的活动ID，依次查找请求体、查询参数和路径参数
+func requestActivityID(ctx *app.RequestContext) int64{
+	if body := ctx.Request.Body(); len(body) > 0{
+		var req struct{
+			ActivityID int64 `json:"activityID"`
 		}
-		
-		// 3. 获取限制重置时间
-		expireAt, err := redisClient.Get(c, expireKey).Int64()
-		if err != nil {
-			ctx.Next(c)
-			return
+		if json.Unmarshal(body, &req) == nil && req.ActivityID > 0{
+			return req.ActivityID
 		}
-		resetAfter := time.Until(time.Unix(expireAt, 0))
-		
-		// 设置RateLimit相关的HTTP头
-		// 限流上限 剩余可用请求数 限流重置时间
-		ctx.Header("X-RateLimit-Limit", strconv.Itoa(config.Limit))
-		ctx.Header("X-RateLimit-Remaining", strconv.Itoa(int(max(0, int64(config.Limit)-count+1))))
-		ctx.Header("X-RateLimit-Reset", strconv.FormatInt(int64(resetAfter.Seconds()), 10))
-		
-		// 如果超出限制
-		if count > int64(config.Limit) {
-			ctx.JSON(consts.StatusTooManyRequests, map[string]any{
-				"code":    429,
-				"message": "请求过于频繁，请稍后再试",
-				"wait":    resetAfter.Seconds(),
-			})
-			ctx.Abort()
-			return
+	}
+
+	for _, value := range []string{ctx.Query("activity_id"), ctx.Param("id")}{
+		if activityID, err := strconv.ParseInt(value, 10, 64); err == nil && activityID > 0{
+			return activityID
 		}
-		
-		ctx.Next(c)
 	}
+
+	return 0
+}
+
+// allow 按策略检查一次请求
+func (l *RateLimiter) allow(c context.Context, policy *config.RateLimitPolicy, key string) (*rateLimitResult, error){
+	redisKey := fmt.Sprintf("ratelimit:%s:%s", policy.Name, key)
+
+	var args []any
+	limit := int64(policy.Limit)
+	switch policy.Algorithm{
+	case AlgorithmSlidingWindow:
+		args = []any{int64(policy.Window) * 1000, policy.Limit, rand.Uint64()}
+	case AlgorithmTokenBucket:
+		args = []any{policy.Rate, policy.Burst}
+		limit = int64(policy.Burst)
+	}
+
+	values, err := l.scripts[policy.Algorithm].Run(c, l.client, []string{redisKey}, args...).Int64Slice()
+	if err != nil{
+		return nil, err
+	}
+
+	return &rateLimitResult{
+		allowed:	values[0] == 1,
+		limit:		limit,
+		remaining:	max(values[1], 0),
+		resetAfter:	time.Duration(values[2]) * time.Microsecond,
+	}, nil
+}
+
+// setRateLimitHeaders 设置RateLimit相关的HTTP头
+// 限流上限 剩余可用请求数 限流重置时间(秒)
+func setRateLimitHeaders(ctx *app.RequestContext, result *rateLimitResult){
+	ctx.Header("X-RateLimit-Limit", strconv.FormatInt(result.limit, 10))
+	ctx.Header("X-RateLimit-Remaining", strconv.FormatInt(result.remaining, 10))
+	ctx.Header("X-RateLimit-Reset", strconv.FormatInt(int64(math.Ceil(result.resetAfter.Seconds())), 10))
 }
 
-// SeckillLimiter 秒杀接口限流器 (每秒100个请求)
-func SeckillLimiter(redisClient *redis.Client) app.HandlerFunc{
-	return RedisRateLimiter(redisClient, &RateLimiterConfig{
-		Limit: 100,
-		Period: time.Second,
-		keyFunc: func(ctx *app.RequestContext) string{
-			// 优先从请求头获取用户ID
-			userID := string(ctx.GetHeader("x-User-ID"))
-			
-			// 如果没有用户ID，则使用客户端IP
-			if userID == "" {
-				userID = ctx.ClientIP()
+// Handler 限流中间件，需要放在认证中间件之后，按认证后的用户限流
+// 请求依次检查匹配的策略，被任一策略拒绝时返回429；响应头为剩余请求数最少的策略的结果
+// 被拒绝前已通过的策略仍会计数
+func (l *RateLimiter) Handler() app.HandlerFunc{
+	return func(c context.Context, ctx *app.RequestContext){
+		route := ctx.FullPath()
+
+		var tightest *rateLimitResult
+		for i := range l.policies{
+			policy := &l.policies[i]
+			if !matchRoute(policy.Route, route){
+				continue
 			}
-			
-			return userID
-		},
-	})
+
+			key := rateLimitKey(ctx, policy.Key)
+			if key == ""{
+				continue
+			}
+
+			result, err := l.allow(c, policy, key)
+			if err != nil{
+				// 如果发生错误不限流,避免影响秒杀
+				log.Printf("限流策略%s检查失败：%v", policy.Name, err)
+				continue
+			}
+
+			if !result.allowed{
+				setRateLimitHeaders(ctx, result)
+				ctx.AbortWithStatusJSON(consts.StatusTooManyRequests, map[string]any{
+					"code":    429,
+					"message": "请求过于频繁，请稍后再试",
+					"wait":    result.resetAfter.Seconds(),
+				})
+				return
+			}
+
+			if tightest == nil || result.remaining < tightest.remaining{
+				tightest = result
+			}
+		}
+
+		if tightest != nil{
+			setRateLimitHeaders(ctx, tightest)
+		}
+
+		ctx.Next(c)
+	}
 }
@@ -0,0 +1,140 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"Redrock/seckill/internal/api/config"
+	"Redrock/seckill/internal/pkg/testenv"
+)
+
+func TestMatchRoute(t *testing.T){
+	cases := []struct{
+		pattern	string
+		route	string
+		want	bool
+	}{
+		{"*", "/api/order/seckill", true},
+		{"/api/order/seckill", "/api/order/seckill", true},
+		{"/api/order/seckill", "/api/order/pay", false},
+		{"/api/order/*", "/api/order/detail/:order_sn", true},
+		{"/api/order/*", "/api/order", true},
+		{"/api/order/*", "/api/orders", false},
+	}
+
+	for _, c := range cases{
+		if got := matchRoute(c.pattern, c.route); got != c.want{
+			t.Fatalf("matchRoute(%q, %q) = %v，期望%v", c.pattern, c.route, got, c.want)
+		}
+	}
+}
+
+func TestNewRateLimiterValidatesPolicies(t *testing.T){
+	valid := config.RateLimitPolicy{Name: "a", Route: "*", Key: KeyIP, Algorithm: AlgorithmTokenBucket, Rate: 10, Burst: 10}
+
+	cases := map[string][]config.RateLimitPolicy{
+		"维度无效":	{{Name: "a", Route: "*", Key: "header", Algorithm: AlgorithmTokenBucket, Rate: 10, Burst: 10}},
+		"算法无效":	{{Name: "a", Route: "*", Key: KeyIP, Algorithm: "fixed_window", Limit: 10, Window: 1000}},
+		"窗口为0":	{{Name: "a", Route: "*", Key: KeyIP, Algorithm: AlgorithmSlidingWindow, Limit: 10}},
+		"名称重复":	{valid, valid},
+	}
+	for name, policies := range cases{
+		cfg := &config.Config{RateLimit: config.RateLimitConfig{Policies: policies}}
+		if _, err := NewRateLimiter(nil, cfg); err == nil{
+			t.Fatalf("%s时没有返回错误", name)
+		}
+	}
+
+	// server.rate_limit生成全局策略
+	cfg := &config.Config{RateLimit: config.RateLimitConfig{Policies: []config.RateLimitPolicy{valid}}}
+	cfg.Server.RateLimit = 100
+	limiter, err := NewRateLimiter(nil, cfg)
+	if err != nil{
+		t.Fatalf("创建限流器失败：%v", err)
+	}
+	if len(limiter.policies) != 2 || limiter.policies[0].Limit != 100{
+		t.Fatalf("限流策略为%+v，期望包含每秒100次的全局策略", limiter.policies)
+	}
+}
+
+// newTestRedis 使用进程内的miniredis，测试不依赖外部的Redis
+func newTestRedis(t *testing.T) *redis.Client{
+	return testenv.NewRedis(t)
+}
+
+// newTestLimiter 创建只有一条策略的限流器，并清除该策略的限流键
+func newTestLimiter(t *testing.T, policy config.RateLimitPolicy) *RateLimiter{
+	client := newTestRedis(t)
+
+	limiter, err := NewRateLimiter(client, &config.Config{
+		RateLimit: config.RateLimitConfig{Policies: []config.RateLimitPolicy{policy}},
+	})
+	if err != nil{
+		t.Fatalf("创建限流器失败：%v", err)
+	}
+
+	key := fmt.Sprintf("ratelimit:%s:test", policy.Name)
+	client.Del(context.Background(), key)
+	t.Cleanup(func(){ client.Del(context.Background(), key) })
+
+	return limiter
+}
+
+// countAllowed 并发发送requests个请求，返回通过的数量
+func countAllowed(t *testing.T, limiter *RateLimiter, requests int) int64{
+	var allowed atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++{
+		wg.Add(1)
+		go func(){
+			defer wg.Done()
+
+			result, err := limiter.allow(context.Background(), &limiter.policies[0], "test")
+			if err != nil{
+				t.Errorf("限流检查失败：%v", err)
+				return
+			}
+			if result.allowed{
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return allowed.Load()
+}
+
+func TestSlidingWindowConcurrent(t *testing.T){
+	limiter := newTestLimiter(t, config.RateLimitPolicy{
+		Name: "test_sliding", Route: "*", Key: KeyIP, Algorithm: AlgorithmSlidingWindow, Limit: 20, Window: 60000,
+	})
+
+	if got := countAllowed(t, limiter, 200); got != 20{
+		t.Fatalf("窗口内通过%d个请求，期望20个", got)
+	}
+
+	result, err := limiter.allow(context.Background(), &limiter.policies[0], "test")
+	if err != nil || result.allowed || result.remaining != 0{
+		t.Fatalf("超过限制后的结果为%+v, %v，期望拒绝", result, err)
+	}
+	if result.resetAfter <= 0 || result.resetAfter > time.Minute{
+		t.Fatalf("重置时间为%v，期望在窗口长度内", result.resetAfter)
+	}
+}
+
+func TestTokenBucketConcurrent(t *testing.T){
+	limiter := newTestLimiter(t, config.RateLimitPolicy{
+		Name: "test_bucket", Route: "*", Key: KeyIP, Algorithm: AlgorithmTokenBucket, Rate: 0.001, Burst: 10,
+	})
+
+	// 生成速率很慢，并发请求只能用掉桶中已有的令牌
+	if got := countAllowed(t, limiter, 100); got != 10{
+		t.Fatalf("通过%d个请求，期望10个", got)
+	}
+}
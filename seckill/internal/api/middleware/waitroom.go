@@ -0,0 +1,328 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	"github.com/redis/go-redis/v9"
+
+	"Redrock/seckill/internal/api/config"
+)
+
+// ErrInvalidQueueToken 排队凭证不存在或已过期
+var ErrInvalidQueueToken = errors.New("排队凭证无效或已过期")
+
+// 排队状态在活动结束后保留的时间，每次操作时刷新
+const waitingRoomKeyTTL = 24 * time.Hour
+
+// waitingRoomCommon 排队脚本的公共部分
+// KEYS: 1排队队列(凭证->序号) 2占用名额的凭证(凭证->名额过期时间) 3最近查询时间(凭证->时间) 4凭证->用户ID 5用户ID->凭证 6排队序号
+// ARGV: 1名额数 2轮到后的有效时间 3查询超时时间 4请求占用名额的时间(微秒) 5键的过期时间(毫秒)
+// 使用Redis的时间，多个网关实例之间的时钟误差不影响排队
+const waitingRoomCommon = `
+local t = redis.call("TIME")
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+local capacity = tonumber(ARGV[1])
+local admitTimeout = tonumber(ARGV[2])
+local pollTimeout = tonumber(ARGV[3])
+local holdTimeout = tonumber(ARGV[4])
+
+-- 删除凭证和用户的对应关系
+local function forget(token)
+	local uid = redis.call("HGET", KEYS[4], token)
+	redis.call("HDEL", KEYS[4], token)
+	if uid and redis.call("HGET", KEYS[5], uid) == token then
+		redis.call("HDEL", KEYS[5], uid)
+	end
+end
+
+-- 释放过期的名额，再按排队顺序放行，直到名额用完
+-- 超过pollTimeout没有查询过状态的用户已经离开，轮到时直接移出队列
+local function promote()
+	local expired = redis.call("ZRANGEBYSCORE", KEYS[2], "-inf", now)
+	for _, token in ipairs(expired) do
+		forget(token)
+	end
+	redis.call("ZREMRANGEBYSCORE", KEYS[2], "-inf", now)
+
+	while redis.call("ZCARD", KEYS[2]) < capacity do
+		local head = redis.call("ZRANGE", KEYS[1], 0, 0)
+		if #head == 0 then
+			break
+		end
+
+		local token = head[1]
+		local seen = tonumber(redis.call("ZSCORE", KEYS[3], token))
+		redis.call("ZREM", KEYS[1], token)
+		redis.call("ZREM", KEYS[3], token)
+		if seen and seen + pollTimeout >= now then
+			redis.call("ZADD", KEYS[2], now + admitTimeout, token)
+		else
+			forget(token)
+		end
+	end
+end
+
+local function touch()
+	for i = 1, #KEYS do
+		redis.call("PEXPIRE", KEYS[i], ARGV[5])
+	end
+end
+`
+
+// waitingRoomEnterScript 用户提交秒杀请求时占用名额
+// ARGV: 6用户ID 7新的排队凭证
+// 用户已经轮到时占用名额，排队中时返回当前位置，否则有空闲名额时直接占用，没有时排到队尾
+// 返回：是否占用名额、排队位置、排队凭证
+const waitingRoomEnterScript = waitingRoomCommon + `
+promote()
+
+local token = redis.call("HGET", KEYS[5], ARGV[6])
+if token then
+	if redis.call("ZSCORE", KEYS[2], token) then
+		redis.call("ZADD", KEYS[2], now + holdTimeout, token)
+		touch()
+		return {1, 0, token}
+	end
+
+	local rank = redis.call("ZRANK", KEYS[1], token)
+	if rank then
+		redis.call("ZADD", KEYS[3], now, token)
+		touch()
+		return {0, rank + 1, token}
+	end
+
+	-- 轮到后没有及时提交，名额已经释放，重新排队
+	forget(token)
+end
+
+token = ARGV[7]
+redis.call("HSET", KEYS[4], token, ARGV[6])
+redis.call("HSET", KEYS[5], ARGV[6], token)
+
+-- promote之后仍有空闲名额说明没有人在排队
+if redis.call("ZCARD", KEYS[2]) < capacity then
+	redis.call("ZADD", KEYS[2], now + holdTimeout, token)
+	touch()
+	return {1, 0, token}
+end
+
+redis.call("ZADD", KEYS[1], redis.call("INCR", KEYS[6]), token)
+redis.call("ZADD", KEYS[3], now, token)
+touch()
+
+return {0, redis.call("ZCARD", KEYS[1]), token}
+`
+
+// waitingRoomPollScript 查询排队状态，同时记录查询时间
+// ARGV: 6排队凭证
+// 返回：状态(1已轮到 0排队中 -1凭证无效)、排队位置、已轮到时为名额的剩余有效时间(微秒)，排队中时为排队人数
+const waitingRoomPollScript = waitingRoomCommon + `
+promote()
+
+local token = ARGV[6]
+local expireAt = redis.call("ZSCORE", KEYS[2], token)
+if expireAt then
+	touch()
+	return {1, 0, tonumber(expireAt) - now}
+end
+
+local rank = redis.call("ZRANK", KEYS[1], token)
+if rank then
+	redis.call("ZADD", KEYS[3], now, token)
+	touch()
+	return {0, rank + 1, redis.call("ZCARD", KEYS[1])}
+end
+
+return {-1, 0, 0}
+`
+
+// waitingRoomLeaveScript 秒杀请求处理完成后释放名额，并放行排在最前面的用户
+// ARGV: 6排队凭证
+const waitingRoomLeaveScript = waitingRoomCommon + `
+redis.call("ZREM", KEYS[2], ARGV[6])
+forget(ARGV[6])
+promote()
+touch()
+
+return 1
+`
+
+// QueueStatus 排队状态
+type QueueStatus struct{
+	Admitted	bool			// 是否已经轮到
+	Position	int64			// 排队位置，从1开始
+	QueueLength	int64			// 排队人数
+	ExpiresIn	time.Duration	// 轮到后需要在该时间内提交秒杀请求
+}
+
+// WaitingRoom 秒杀排队，每个活动同时处理的秒杀请求数不超过capacity，超过后按先后顺序排队
+// 名额、队列和凭证都保存在Redis中，网关重启或多实例部署时共享排队状态
+type WaitingRoom struct{
+	client			*redis.Client
+	config			config.WaitingRoomConfig
+	enterScript		*redis.Script
+	pollScript		*redis.Script
+	leaveScript		*redis.Script
+}
+
+// NewWaitingRoom 创建秒杀排队，未配置的超时时间使用默认值
+func NewWaitingRoom(client *redis.Client, cfg *config.WaitingRoomConfig) *WaitingRoom{
+	roomConfig := *cfg
+	if roomConfig.AdmitTimeout <= 0{
+		roomConfig.AdmitTimeout = 10000
+	}
+	if roomConfig.PollTimeout <= 0{
+		roomConfig.PollTimeout = 10000
+	}
+	if roomConfig.HoldTimeout <= 0{
+		roomConfig.HoldTimeout = 5000
+	}
+
+	return &WaitingRoom{
+		client:			client,
+		config:			roomConfig,
+		enterScript:	redis.NewScript(waitingRoomEnterScript),
+		pollScript:		redis.NewScript(waitingRoomPollScript),
+		leaveScript:	redis.NewScript(waitingRoomLeaveScript),
+	}
+}
+
+// keys 活动的排队相关的键，使用hash tag保证在Redis集群中位于同一个槽
+func (w *WaitingRoom) keys(activityID int64) []string{
+	prefix := fmt.Sprintf("waitroom:{%d}:", activityID)
+
+	return []string{
+		prefix + "queue",
+		prefix + "active",
+		prefix + "seen",
+		prefix + "tokens",
+		prefix + "users",
+		prefix + "seq",
+	}
+}
+
+// run 执行排队脚本，args为脚本各自的参数
+func (w *WaitingRoom) run(c context.Context, script *redis.Script, activityID int64, args ...any) *redis.Cmd{
+	common := []any{
+		w.config.Capacity,
+		int64(w.config.AdmitTimeout) * 1000,
+		int64(w.config.PollTimeout) * 1000,
+		int64(w.config.HoldTimeout) * 1000,
+		waitingRoomKeyTTL.Milliseconds(),
+	}
+
+	return script.Run(c, w.client, w.keys(activityID), append(common, args...)...)
+}
+
+// newQueueToken 生成随机的排队凭证，凭证只用于查询排队状态
+func newQueueToken() (string, error){
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil{
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
+
+// enter 为用户的秒杀请求占用名额，没有名额时排队，返回排队凭证
+func (w *WaitingRoom) enter(c context.Context, activityID int64, userID int64) (*QueueStatus, string, error){
+	token, err := newQueueToken()
+	if err != nil{
+		return nil, "", fmt.Errorf("生成排队凭证失败：%w", err)
+	}
+
+	values, err := w.run(c, w.enterScript, activityID, userID, token).Slice()
+	if err != nil{
+		return nil, "", err
+	}
+
+	status := &QueueStatus{
+		Admitted:	values[0].(int64) == 1,
+		Position:	values[1].(int64),
+	}
+
+	return status, values[2].(string), nil
+}
+
+// leave 秒杀请求处理完成后释放名额
+func (w *WaitingRoom) leave(c context.Context, activityID int64, token string) error{
+	return w.run(c, w.leaveScript, activityID, token).Err()
+}
+
+// Status 查询排队状态，凭证不存在或已过期时返回ErrInvalidQueueToken
+func (w *WaitingRoom) Status(c context.Context, activityID int64, token string) (*QueueStatus, error){
+	values, err := w.run(c, w.pollScript, activityID, token).Slice()
+	if err != nil{
+		return nil, err
+	}
+
+	switch values[0].(int64){
+	case 1:
+		return &QueueStatus{
+			Admitted:	true,
+			ExpiresIn:	time.Duration(values[2].(int64)) * time.Microsecond,
+		}, nil
+	case 0:
+		return &QueueStatus{
+			Position:		values[1].(int64),
+			QueueLength:	values[2].(int64),
+		}, nil
+	default:
+		return nil, ErrInvalidQueueToken
+	}
+}
+
+// Handler 秒杀排队中间件，需要放在认证中间件之后，按认证后的用户排队
+// 有名额时处理请求，处理完成后释放名额；没有名额时返回202和排队凭证，用户轮到后重新提交请求
+func (w *WaitingRoom) Handler() app.HandlerFunc{
+	return func(c context.Context, ctx *app.RequestContext){
+		if w.config.Capacity <= 0{
+			ctx.Next(c)
+			return
+		}
+
+		// 参数错误的请求由处理器返回
+		activityID := requestActivityID(ctx)
+		userID := UserID(ctx)
+		if activityID <= 0 || userID <= 0{
+			ctx.Next(c)
+			return
+		}
+
+		status, token, err := w.enter(c, activityID, userID)
+		if err != nil{
+			// 如果发生错误不排队，避免影响秒杀，请求量仍由限流控制
+			log.Printf("活动%d排队失败：%v", activityID, err)
+			ctx.Next(c)
+			return
+		}
+
+		if !status.Admitted{
+			ctx.AbortWithStatusJSON(consts.StatusAccepted, map[string]any{
+				"code":			202,
+				"message":		"当前参与人数较多，正在排队",
+				"queueToken":	token,
+				"position":		status.Position,
+			})
+			return
+		}
+
+		defer func(){
+			// 请求的context可能已经取消，使用新的context释放名额
+			err := w.leave(context.Background(), activityID, token)
+			if err != nil{
+				log.Printf("活动%d释放排队名额失败：%v，名额将在超时后释放", activityID, err)
+			}
+		}()
+
+		ctx.Next(c)
+	}
+}
@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"Redrock/seckill/internal/api/config"
+)
+
+// newTestWaitingRoom 创建测试用的排队，并清除测试活动的排队状态
+func newTestWaitingRoom(t *testing.T, activityID int64, cfg config.WaitingRoomConfig) *WaitingRoom{
+	w := NewWaitingRoom(newTestRedis(t), &cfg)
+
+	keys := w.keys(activityID)
+	w.client.Del(context.Background(), keys...)
+	t.Cleanup(func(){ w.client.Del(context.Background(), keys...) })
+
+	return w
+}
+
+func TestWaitingRoomFIFO(t *testing.T){
+	const activityID = 990101
+	w := newTestWaitingRoom(t, activityID, config.WaitingRoomConfig{Capacity: 2})
+	ctx := context.Background()
+
+	tokens := make(map[int64]string)
+	for userID := int64(1); userID <= 4; userID++{
+		status, token, err := w.enter(ctx, activityID, userID)
+		if err != nil{
+			t.Fatalf("用户%d排队失败：%v", userID, err)
+		}
+		tokens[userID] = token
+
+		wantAdmitted := userID <= 2
+		if status.Admitted != wantAdmitted{
+			t.Fatalf("用户%d是否占用名额为%v，期望%v", userID, status.Admitted, wantAdmitted)
+		}
+		if !wantAdmitted && status.Position != userID-2{
+			t.Fatalf("用户%d的排队位置为%d，期望%d", userID, status.Position, userID-2)
+		}
+	}
+
+	// 排队中重复提交不会重新排队
+	status, token, err := w.enter(ctx, activityID, 4)
+	if err != nil || status.Admitted || status.Position != 2 || token != tokens[4]{
+		t.Fatalf("重复提交的结果为%+v, %s, %v，期望仍排在第2位", status, token, err)
+	}
+
+	// 释放一个名额后排在最前面的用户轮到
+	if err := w.leave(ctx, activityID, tokens[1]); err != nil{
+		t.Fatalf("释放名额失败：%v", err)
+	}
+
+	status, err = w.Status(ctx, activityID, tokens[3])
+	if err != nil || !status.Admitted || status.ExpiresIn <= 0{
+		t.Fatalf("用户3的排队状态为%+v, %v，期望已轮到", status, err)
+	}
+	status, err = w.Status(ctx, activityID, tokens[4])
+	if err != nil || status.Admitted || status.Position != 1 || status.QueueLength != 1{
+		t.Fatalf("用户4的排队状态为%+v, %v，期望排在第1位", status, err)
+	}
+
+	// 轮到的用户重新提交时使用已放行的名额
+	status, _, err = w.enter(ctx, activityID, 3)
+	if err != nil || !status.Admitted{
+		t.Fatalf("用户3重新提交的结果为%+v, %v，期望占用名额", status, err)
+	}
+
+	// 释放名额后凭证失效
+	if err := w.leave(ctx, activityID, tokens[2]); err != nil{
+		t.Fatalf("释放名额失败：%v", err)
+	}
+	if _, err := w.Status(ctx, activityID, tokens[2]); !errors.Is(err, ErrInvalidQueueToken){
+		t.Fatalf("查询已释放的凭证返回%v，期望凭证无效", err)
+	}
+}
+
+func TestWaitingRoomDropsIdleWaiters(t *testing.T){
+	const activityID = 990102
+	w := newTestWaitingRoom(t, activityID, config.WaitingRoomConfig{Capacity: 1, PollTimeout: 50})
+	ctx := context.Background()
+
+	_, first, _ := w.enter(ctx, activityID, 1)
+	_, idle, _ := w.enter(ctx, activityID, 2)
+	_, active, _ := w.enter(ctx, activityID, 3)
+
+	// 用户2不再查询排队状态，用户3持续查询
+	deadline := time.Now().Add(100 * time.Millisecond)
+	for time.Now().Before(deadline){
+		if _, err := w.Status(ctx, activityID, active); err != nil{
+			t.Fatalf("查询排队状态失败：%v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err := w.leave(ctx, activityID, first); err != nil{
+		t.Fatalf("释放名额失败：%v", err)
+	}
+
+	if _, err := w.Status(ctx, activityID, idle); !errors.Is(err, ErrInvalidQueueToken){
+		t.Fatalf("查询离开的用户的凭证返回%v，期望凭证无效", err)
+	}
+	status, err := w.Status(ctx, activityID, active)
+	if err != nil || !status.Admitted{
+		t.Fatalf("用户3的排队状态为%+v, %v，期望已轮到", status, err)
+	}
+}
+
+func TestWaitingRoomCapacityConcurrent(t *testing.T){
+	const(
+		activityID	= 990103
+		capacity	= 10
+		users		= 200
+	)
+	w := newTestWaitingRoom(t, activityID, config.WaitingRoomConfig{Capacity: capacity})
+
+	var admitted atomic.Int64
+	var wg sync.WaitGroup
+	for userID := int64(1); userID <= users; userID++{
+		wg.Add(1)
+		go func(userID int64){
+			defer wg.Done()
+
+			status, _, err := w.enter(context.Background(), activityID, userID)
+			if err != nil{
+				t.Errorf("用户%d排队失败：%v", userID, err)
+				return
+			}
+			if status.Admitted{
+				admitted.Add(1)
+			}
+		}(userID)
+	}
+	wg.Wait()
+
+	if got := admitted.Load(); got != capacity{
+		t.Fatalf("占用名额的用户为%d个，期望%d个", got, capacity)
+	}
+
+	queued, err := w.client.ZCard(context.Background(), w.keys(activityID)[0]).Result()
+	if err != nil || queued != users-capacity{
+		t.Fatalf("排队人数为%d, %v，期望%d", queued, err, users-capacity)
+	}
+}
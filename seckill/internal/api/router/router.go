@@ -6,40 +6,88 @@ import (
 	"Redrock/seckill/internal/api/client"
 	"Redrock/seckill/internal/api/handler"
 	"Redrock/seckill/internal/api/middleware"
-	"Redrock/seckill/internal/pkg/redis"
+	"Redrock/seckill/internal/pkg/auth"
 )
 
-func SetupRouter(h *server.Hertz, clients *client.RPCClients){
-	// 获取Redis实例
-	redisClient := redis.GetRedis()
-	
+// SetupRouter 注册路由，seckillQueue为nil时秒杀接口同步下单
+func SetupRouter(h *server.Hertz, clients *client.RPCClients, seckillQueue *handler.SeckillQueue, tokens *auth.TokenManager, revoker *auth.Revoker, rateLimiter *middleware.RateLimiter, waitingRoom *middleware.WaitingRoom){
 	// 创建处理器
 	userHandler := handler.NewUserHandler(clients)
-	activityHandler := handler.NewActivityHandler(clients)
-	orderHandler := handler.NewOrderHandler(clients)
+	activityHandler := handler.NewActivityHandler(clients, waitingRoom)
+	orderHandler := handler.NewOrderHandler(clients, seckillQueue)
+	productHandler := handler.NewProductHandler(clients)
 
 	// API路由
 	api := h.Group("/api")
 
+	// 每个路由组都经过一次限流，需要认证的路由组在认证之后限流，按认证后的用户计数
+	limit := rateLimiter.Handler()
+	authenticate := middleware.Auth(tokens, revoker)
+
 	// 用户相关路由
-	userGroup := api.Group("/user")
+	userGroup := api.Group("/user", limit)
 	{
 	userGroup.POST("/register", userHandler.Register)
 	userGroup.POST("/login", userHandler.Login)
 	}
+
+	// 商品和活动的管理需要商家或管理员角色，商家只能管理自己的商品和活动
+	manage := middleware.RequireRole(auth.RoleMerchant, auth.RoleAdmin)
+
+	// 账户相关路由，只能操作访问令牌所属的用户
+	accountGroup := api.Group("/user", authenticate, limit)
+	{
+		accountGroup.GET("/info", userHandler.GetUserInfo)				// 获取当前用户信息
+		accountGroup.POST("/password", userHandler.UpdatePassword)		// 修改密码
+		accountGroup.POST("/logout", userHandler.Logout)				// 退出登录
+		accountGroup.DELETE("/delete", userHandler.DeleteAccount)		// 注销账户
+	}
+
 	// 活动相关路由
-	activityGroup := api.Group("/activity")
+	activityGroup := api.Group("/activity", limit)
 	{
-		activityGroup.POST("/create", activityHandler.CreateActivity)
 		activityGroup.GET("/list", activityHandler.ListActivities)
 		activityGroup.GET("/detail/:id", activityHandler.GetActivity)
+		activityGroup.GET("/:id/queue", activityHandler.GetQueueStatus)		// 查询秒杀排队状态，凭证不可猜测，不需要登录
+	}
+	activityManageGroup := api.Group("/activity", authenticate, limit, manage)
+	{
+		activityManageGroup.POST("/create", activityHandler.CreateActivity)
+		activityManageGroup.POST("/update/:id", activityHandler.UpdateActivity)		// 修改活动
+		activityManageGroup.POST("/pause/:id", activityHandler.PauseActivity)			// 暂停活动
+		activityManageGroup.POST("/resume/:id", activityHandler.ResumeActivity)		// 恢复活动
+		activityManageGroup.DELETE("/delete/:id", activityHandler.DeleteActivity)		// 删除活动
+		activityManageGroup.POST("/stock/:id", activityHandler.AdjustStock)			// 调整库存
+	}
+
+	// 商品相关路由
+	productGroup := api.Group("/product", limit)
+	{
+		productGroup.GET("/list", productHandler.ListProducts)
+		productGroup.GET("/detail/:id", productHandler.GetProduct)
+	}
+	productManageGroup := api.Group("/product", authenticate, limit, manage)
+	{
+		productManageGroup.POST("/create", productHandler.CreateProduct)
+		productManageGroup.POST("/update/:id", productHandler.UpdateProduct)
+		productManageGroup.DELETE("/delete/:id", productHandler.DeleteProduct)
+	}
+
+	// 管理员路由
+	adminGroup := api.Group("/admin", authenticate, limit, middleware.RequireRole(auth.RoleAdmin))
+	{
+		adminGroup.POST("/user/:id/role/grant", userHandler.GrantRole)		// 授予用户角色
+		adminGroup.POST("/user/:id/role/revoke", userHandler.RevokeRole)	// 撤销用户角色
 	}
 
-	// 订单相关路由
-	orderGroup := api.Group("/order")
+	// 订单相关路由，需要登录，用户ID取自访问令牌
+	orderGroup := api.Group("/order", authenticate, limit)
 	{
-		orderGroup.POST("/seckill", middleware.SeckillLimiter(redisClient), orderHandler.CreateOrder)      		// 秒杀接口
-		orderGroup.GET("/detail/:user_id/:order_sn", orderHandler.GetOrder)      		// 查询订单详情
-		orderGroup.GET("/list/:user_id", orderHandler.ListUserOrders) 	// 获取用户订单列表
+		orderGroup.POST("/seckill", waitingRoom.Handler(), orderHandler.CreateOrder)	// 秒杀接口，限流策略见api.yaml的rate_limit，超过排队名额时返回排队凭证
+		orderGroup.GET("/detail/:order_sn", orderHandler.GetOrder)      		// 查询订单详情
+		orderGroup.GET("/list", orderHandler.ListUserOrders) 			// 获取用户订单列表
+		orderGroup.POST("/pay", orderHandler.PayOrder) 					// 支付订单
+		orderGroup.POST("/cancel", orderHandler.CancelOrder) 			// 取消订单
+		orderGroup.GET("/result/:ticket", orderHandler.GetOrderResult) 	// 查询异步秒杀结果
 	}
 }
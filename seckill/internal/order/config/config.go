@@ -1,9 +1,11 @@
 package config
 
 import (
+	"Redrock/seckill/internal/order/payment"
 	"Redrock/seckill/internal/pkg/database"
 	"Redrock/seckill/internal/pkg/mq"
 	"Redrock/seckill/internal/pkg/redis"
+	"Redrock/seckill/internal/pkg/snowflake"
 )
 
 type Config struct{
@@ -11,7 +13,16 @@ type Config struct{
 	Database	database.DatabaseConfig	`mapstructure:"database"`
 	Redis		redis.RedisConfig		`mapstructure:"redis"`
 	MQ			mq.MQConfig				`mapstructure:"mq"`
+	SeckillMQ	mq.MQConfig				`mapstructure:"seckill_mq"`	// 异步秒杀的排队消息，和网关的配置一致
 	ActivityRPC	ActivityRPCConfig		`mapstructure:"activity_rpc"`
+	Payment		payment.PaymentConfig	`mapstructure:"payment"`
+	Order		OrderConfig				`mapstructure:"order"`
+	Snowflake	snowflake.SnowflakeConfig	`mapstructure:"snowflake"`
+}
+
+type OrderConfig struct{
+	ExpireTime		int		`mapstructure:"expire_time"`		// 未支付订单的过期时间(秒)
+	ScanInterval	int		`mapstructure:"scan_interval"`		// 扫描过期订单的间隔(秒)
 }
 
 type ActivityRPCConfig struct{
@@ -3,11 +3,22 @@ package data
 import(
 	"context"
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"Redrock/seckill/internal/pkg/database"
 	"Redrock/seckill/internal/pkg/models"
+	"Redrock/seckill/internal/pkg/pagination"
+)
+
+var(
+	// ErrOrderNotFound 订单不存在
+	ErrOrderNotFound = errors.New("订单不存在")
+
+	// ErrOrderStatusNotAllowed 订单当前状态不允许该操作
+	ErrOrderStatusNotAllowed = errors.New("订单当前状态不允许该操作")
 )
 
 type OrderData struct{
@@ -27,6 +38,26 @@ func (d *OrderData) Create(ctx context.Context, order *models.Order) error{
 	return err
 }
 
+// CreateWithOutbox 在同一个事务中创建订单和订单消息
+func (d *OrderData) CreateWithOutbox(ctx context.Context, order *models.Order, payload []byte) error{
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error{
+		err := tx.Create(order).Error
+		if err != nil{
+			return err
+		}
+
+		outbox := &models.OrderOutbox{
+			OrderSn:	order.OrderSn,
+			Payload:	payload,
+			Status:		models.OutboxPending,
+		}
+
+		return tx.Create(outbox).Error
+	})
+
+	return err
+}
+
 // GetByOrderSn 根据订单号获取订单 
 func (d *OrderData) GetByOrderSn(ctx context.Context, orderSn string) (*models.Order, error){
 	var order models.Order
@@ -34,7 +65,7 @@ func (d *OrderData) GetByOrderSn(ctx context.Context, orderSn string) (*models.O
 	err := d.db.WithContext(ctx).Where("order_sn = ?", orderSn).First(&order).Error
 	if err != nil{
 		if errors.Is(err, gorm.ErrRecordNotFound){
-			return nil, errors.New("订单不存在")
+			return nil, ErrOrderNotFound
 		}
 		return nil, err
 	}
@@ -60,43 +91,235 @@ func (d *OrderData) GetByUserIDAndOrderSn(ctx context.Context, userID uint, orde
 	return &order, nil
 }
 
-// ListByUserID 根据用户ID获取订单列表
-func (d *OrderData) ListByUserID(ctx context.Context, userID uint, status int) ([]*models.Order, int64, error){
+// OrderFilter 订单列表的筛选和排序条件
+type OrderFilter struct{
+	Status		int			// 订单状态，-1表示所有状态
+	TimeFrom	*time.Time	// 创建时间范围[TimeFrom, TimeTo)
+	TimeTo		*time.Time
+	Asc			bool		// 是否按创建时间升序
+}
+
+// SortKey 排序方式的标识，记录在分页游标中
+func (f *OrderFilter) SortKey() string{
+	if f.Asc{
+		return "create_time:asc"
+	}
+
+	return "create_time:desc"
+}
+
+// ListByUserID 按筛选条件分页获取用户的订单列表，cursor为nil时获取第一页
+// 返回的总数是符合筛选条件的订单总数，有下一页时返回下一页的游标
+func (d *OrderData) ListByUserID(ctx context.Context, userID uint, filter *OrderFilter, cursor *pagination.Cursor, limit int) ([]*models.Order, int64, *pagination.Cursor, error){
 	var orders []*models.Order
 	var count int64
 
-	query := d.db.WithContext(ctx).Where("user_id = ?", userID)
+	query := d.db.WithContext(ctx).Model(&models.Order{}).Where("orders.user_id = ?", userID)
 
-	if status != -1{
-		query = query.Where("status = ?", status)
+	if filter.Status != -1{
+		query = query.Where("orders.status = ?", filter.Status)
+	}
+	if filter.TimeFrom != nil{
+		query = query.Where("orders.create_time >= ?", *filter.TimeFrom)
+	}
+	if filter.TimeTo != nil{
+		query = query.Where("orders.create_time < ?", *filter.TimeTo)
 	}
 
-	// 获取count
-	err := query.Model(&models.Order{}).Count(&count).Error
+	// 获取count，不受分页影响
+	err := query.Count(&count).Error
 	if err != nil{
-		return nil, 0, err
+		return nil, 0, nil, err
+	}
+
+	var value any
+	if cursor != nil{
+		value, err = cursor.ParseTime()
+		if err != nil{
+			return nil, 0, nil, err
+		}
 	}
 
-	err = query.Preload("Product").Preload("Activity").Order("created_at DESC").Find(&orders).Error
+	// 多查询一条判断是否还有下一页，商品和活动只预加载当前页的
+	err = pagination.Seek(query, "orders.create_time", "orders.id", filter.Asc, cursor, value).
+		Limit(limit + 1).Preload("Product").Preload("Activity").Find(&orders).Error
 	if err != nil{
-		return nil, 0, err
+		return nil, 0, nil, err
+	}
+
+	if len(orders) <= limit{
+		return orders, count, nil, nil
+	}
+
+	orders = orders[:limit]
+	last := orders[limit - 1]
+
+	return orders, count, &pagination.Cursor{
+		Sort:	filter.SortKey(),
+		Value:	pagination.TimeValue(last.CreateTime),
+		ID:		last.ID,
+	}, nil
+}
+
+// MarkCreated 将处于Pending状态的订单标记为创建成功
+// 订单已被取消、过期或已经处理过时不会更新，返回值表示本次调用是否真正修改了订单
+func (d *OrderData) MarkCreated(ctx context.Context, orderSn string) (bool, error){
+	result := d.db.WithContext(ctx).Model(&models.Order{}).
+		Where("order_sn = ? AND status = ?", orderSn, models.StatusPending).
+		Update("status", models.StatusCreated)
+	if result.Error != nil{
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// MarkFailed 将处于Pending状态的订单标记为创建失败，订单不存在时不做任何操作
+func (d *OrderData) MarkFailed(ctx context.Context, orderSn string) error{
+	err := d.db.WithContext(ctx).Model(&models.Order{}).
+		Where("order_sn = ? AND status = ?", orderSn, models.StatusPending).
+		Update("status", models.StatusFailed).Error
+
+	return err
+}
+
+// ReservePayment 支付前将未过期的Created订单标记为支付中
+// 只有一个请求能预占成功，支付中的订单不会被取消，返回值表示本次调用是否预占成功
+func (d *OrderData) ReservePayment(ctx context.Context, orderSn string, now time.Time) (bool, error){
+	result := d.db.WithContext(ctx).Model(&models.Order{}).
+		Where("order_sn = ? AND status = ? AND expire_time > ?", orderSn, models.StatusCreated, now).
+		Update("status", models.StatusPaying)
+	if result.Error != nil{
+		return false, result.Error
 	}
 
-	return orders, count, nil
+	return result.RowsAffected == 1, nil
+}
+
+// ReleasePayment 支付失败时将支付中的订单恢复为Created，订单过期后会被正常取消
+func (d *OrderData) ReleasePayment(ctx context.Context, orderSn string) (bool, error){
+	result := d.db.WithContext(ctx).Model(&models.Order{}).
+		Where("order_sn = ? AND status = ?", orderSn, models.StatusPaying).
+		Update("status", models.StatusCreated)
+	if result.Error != nil{
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// MarkPaid 将支付中的订单标记为已支付
+// 只有处于Paying状态的订单会被更新，返回值表示本次调用是否真正修改了订单
+func (d *OrderData) MarkPaid(ctx context.Context, orderSn string, tradeNo string, payTime time.Time) (bool, error){
+	result := d.db.WithContext(ctx).Model(&models.Order{}).
+		Where("order_sn = ? AND status = ?", orderSn, models.StatusPaying).
+		Updates(map[string]any{
+			"status":	models.StatusPaid,
+			"pay_time":	payTime,
+			"trade_no":	tradeNo,
+		})
+	if result.Error != nil{
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// GetStalePayments 获取在before之前进入支付中状态、仍没有支付结果的订单
+func (d *OrderData) GetStalePayments(ctx context.Context, before time.Time, limit int) ([]*models.Order, error){
+	var orders []*models.Order
+
+	err := d.db.WithContext(ctx).
+		Where("status = ? AND updated_at < ?", models.StatusPaying, before).
+		Order("updated_at").
+		Limit(limit).
+		Find(&orders).Error
+	if err != nil{
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// GetExpiredOrders 获取已过期但仍未支付的订单
+func (d *OrderData) GetExpiredOrders(ctx context.Context, now time.Time, limit int) ([]*models.Order, error){
+	var orders []*models.Order
+
+	err := d.db.WithContext(ctx).
+		Where("status IN ? AND expire_time < ?", []int{models.StatusPending, models.StatusCreated}, now).
+		Order("expire_time").
+		Limit(limit).
+		Find(&orders).Error
+	if err != nil{
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// CancelOrder 在事务中取消订单
+// 订单行在事务中被加锁，只有处于allowedStatus中的订单才会被取消
+// 取消的同时标记库存待归还，事务提交后再归还库存，避免在持有行锁时调用其他服务
+func (d *OrderData) CancelOrder(ctx context.Context, orderSn string, allowedStatus []int) (*models.Order, error){
+	var order models.Order
+
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error{
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("order_sn = ?", orderSn).First(&order).Error
+		if err != nil{
+			if errors.Is(err, gorm.ErrRecordNotFound){
+				return ErrOrderNotFound
+			}
+			return err
+		}
+
+		allowed := false
+		for _, status := range allowedStatus{
+			if order.Status == status{
+				allowed = true
+				break
+			}
+		}
+		if !allowed{
+			return ErrOrderStatusNotAllowed
+		}
+
+		err = tx.Model(&order).Updates(map[string]any{
+			"status":				models.StatusCancelled,
+			"stock_return_pending":	true,
+		}).Error
+		if err != nil{
+			return err
+		}
+		order.Status = models.StatusCancelled
+		order.StockReturnPending = true
+
+		return nil
+	})
+	if err != nil{
+		return nil, err
+	}
+
+	return &order, nil
 }
 
-// UpdateStatus 更新订单状态
-func (d *OrderData) UpdateStatus(ctx context.Context, orderSn string, status int) error{
-	err := d.db.WithContext(ctx).Model(&models.Order{}).Where("order_sn = ?", orderSn).Update("status", status).Error
+// MarkStockReturned 已取消订单的库存归还成功后清除待归还标记
+func (d *OrderData) MarkStockReturned(ctx context.Context, orderSn string) error{
+	err := d.db.WithContext(ctx).Model(&models.Order{}).
+		Where("order_sn = ? AND status = ?", orderSn, models.StatusCancelled).
+		Update("stock_return_pending", false).Error
 
 	return err
 }
 
-// GetPendingOrders 获取处于Pending状态的订单
-func (d *OrderData) GetPendingOrders(ctx context.Context) ([]*models.Order, error){
+// GetPendingStockReturns 获取在before之前取消、库存仍待归还的订单
+func (d *OrderData) GetPendingStockReturns(ctx context.Context, before time.Time, limit int) ([]*models.Order, error){
 	var orders []*models.Order
 
-	err := d.db.WithContext(ctx).Where("status = ?", models.StatusPending).Find(&orders).Error
+	err := d.db.WithContext(ctx).
+		Where("status = ? AND stock_return_pending = ? AND updated_at < ?", models.StatusCancelled, true, before).
+		Order("updated_at").
+		Limit(limit).
+		Find(&orders).Error
 	if err != nil{
 		return nil, err
 	}
@@ -0,0 +1,121 @@
+package data
+
+import(
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"Redrock/seckill/internal/pkg/database"
+	"Redrock/seckill/internal/pkg/models"
+)
+
+// OutboxData 订单消息发件箱
+type OutboxData struct{
+	db *gorm.DB
+}
+
+func NewOutboxData() *OutboxData{
+	return &OutboxData{
+		db: database.GetDB(),
+	}
+}
+
+const (
+	// outboxClaimLease 领取消息的租约，relay在租约内没有标记结果(例如崩溃)时消息会被重新领取
+	outboxClaimLease = 5 * time.Minute
+
+	// outboxMaxBackoff 发送失败后重试间隔的上限
+	outboxMaxBackoff = 5 * time.Minute
+)
+
+// outboxBackoff 第attempts次发送失败后的重试间隔，从1秒开始倍增
+func outboxBackoff(attempts int) time.Duration{
+	if attempts > 9{
+		return outboxMaxBackoff
+	}
+
+	return min(time.Second << max(attempts - 1, 0), outboxMaxBackoff)
+}
+
+// RelayPending 发布到期的未发送消息，返回本次领取的消息数
+// 先在短事务中领取消息(加锁并跳过已被其他实例锁定的行，设置租约)，事务提交后再逐条发布，发布时不持有行锁
+// 发布成功的消息标记为已发送，失败的消息记录错误并按失败次数退避，不影响同一批的其他消息
+func (d *OutboxData) RelayPending(ctx context.Context, limit int, publish func(outbox *models.OrderOutbox) error) (int, error){
+	outboxes, err := d.claimPending(ctx, limit, time.Now())
+	if err != nil{
+		return 0, err
+	}
+
+	for _, outbox := range outboxes{
+		publishErr := publish(outbox)
+		if publishErr != nil{
+			err = d.markFailed(ctx, outbox, publishErr, time.Now())
+		}else{
+			err = d.markSent(ctx, outbox, time.Now())
+		}
+
+		// 标记失败时消息会在租约到期后重新发布
+		if err != nil{
+			return len(outboxes), err
+		}
+	}
+
+	return len(outboxes), nil
+}
+
+// claimPending 领取到期的未发送消息，领取后租约期内不会被其他relay领取
+func (d *OutboxData) claimPending(ctx context.Context, limit int, now time.Time) ([]*models.OrderOutbox, error){
+	var outboxes []*models.OrderOutbox
+
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error{
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND (next_attempt_at IS NULL OR next_attempt_at <= ?)", models.OutboxPending, now).
+			Order("id").
+			Limit(limit).
+			Find(&outboxes).Error
+		if err != nil || len(outboxes) == 0{
+			return err
+		}
+
+		ids := make([]uint, 0, len(outboxes))
+		for _, outbox := range outboxes{
+			ids = append(ids, outbox.ID)
+		}
+
+		return tx.Model(&models.OrderOutbox{}).Where("id IN ?", ids).Update("next_attempt_at", now.Add(outboxClaimLease)).Error
+	})
+	if err != nil{
+		return nil, err
+	}
+
+	return outboxes, nil
+}
+
+// markSent 将消息标记为已发送
+func (d *OutboxData) markSent(ctx context.Context, outbox *models.OrderOutbox, now time.Time) error{
+	err := d.db.WithContext(ctx).Model(outbox).Updates(map[string]any{
+		"status":			models.OutboxSent,
+		"sent_at":			now,
+		"next_attempt_at":	nil,
+	}).Error
+
+	return err
+}
+
+// markFailed 记录发送失败的原因，并在退避时间后重试
+func (d *OutboxData) markFailed(ctx context.Context, outbox *models.OrderOutbox, publishErr error, now time.Time) error{
+	lastError := publishErr.Error()
+	if len(lastError) > 255{
+		lastError = lastError[:255]
+	}
+
+	err := d.db.WithContext(ctx).Model(outbox).Updates(map[string]any{
+		"attempts":			gorm.Expr("attempts + 1"),
+		"last_error":		lastError,
+		"next_attempt_at":	now.Add(outboxBackoff(outbox.Attempts + 1)),
+	}).Error
+
+	return err
+}
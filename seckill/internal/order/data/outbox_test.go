@@ -0,0 +1,130 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"Redrock/seckill/internal/pkg/models"
+	"Redrock/seckill/internal/pkg/testenv"
+)
+
+// newTestOutbox 创建发件箱并写入指定订单号的消息
+func newTestOutbox(t *testing.T, orderSns ...string) *OutboxData{
+	db := testenv.NewDB(t, &models.OrderOutbox{})
+
+	for _, sn := range orderSns{
+		if err := db.Create(&models.OrderOutbox{OrderSn: sn, Payload: []byte(sn)}).Error; err != nil{
+			t.Fatalf("写入测试消息失败：%v", err)
+		}
+	}
+
+	return NewOutboxData()
+}
+
+func getOutbox(t *testing.T, d *OutboxData, orderSn string) *models.OrderOutbox{
+	t.Helper()
+
+	var outbox models.OrderOutbox
+	if err := d.db.Where("order_sn = ?", orderSn).First(&outbox).Error; err != nil{
+		t.Fatalf("查询消息失败：%v", err)
+	}
+
+	return &outbox
+}
+
+// 发送失败的消息不会阻塞同一批的其他消息
+func TestRelayPendingSkipsFailedMessage(t *testing.T){
+	d := newTestOutbox(t, "poison", "ok-1", "ok-2")
+
+	var published []string
+	claimed, err := d.RelayPending(context.Background(), 10, func(outbox *models.OrderOutbox) error{
+		if outbox.OrderSn == "poison"{
+			return errors.New("消息被拒绝")
+		}
+
+		published = append(published, outbox.OrderSn)
+		return nil
+	})
+	if err != nil || claimed != 3{
+		t.Fatalf("期待领取3条消息，但实际得到 %d, %v", claimed, err)
+	}
+
+	if len(published) != 2{
+		t.Errorf("期待发布2条消息，但实际发布了 %v", published)
+	}
+
+	for _, sn := range []string{"ok-1", "ok-2"}{
+		if outbox := getOutbox(t, d, sn); outbox.Status != models.OutboxSent || outbox.SentAt == nil{
+			t.Errorf("%s 期待已发送，但实际状态为 %d", sn, outbox.Status)
+		}
+	}
+
+	poison := getOutbox(t, d, "poison")
+	if poison.Status != models.OutboxPending || poison.Attempts != 1 || poison.LastError == ""{
+		t.Errorf("失败的消息期待等待重试并记录错误，但实际状态 %d，失败次数 %d，错误 %q", poison.Status, poison.Attempts, poison.LastError)
+	}
+	if poison.NextAttemptAt == nil || !poison.NextAttemptAt.After(time.Now()){
+		t.Errorf("失败的消息期待退避后重试，但实际下次发送时间为 %v", poison.NextAttemptAt)
+	}
+}
+
+// 退避期间和租约期间的消息不会被重新领取
+func TestRelayPendingBackoff(t *testing.T){
+	d := newTestOutbox(t, "retry")
+
+	publish := func(outbox *models.OrderOutbox) error{
+		return errors.New("连接断开")
+	}
+
+	if claimed, _ := d.RelayPending(context.Background(), 10, publish); claimed != 1{
+		t.Fatalf("期待领取1条消息，但实际领取 %d 条", claimed)
+	}
+
+	if claimed, _ := d.RelayPending(context.Background(), 10, publish); claimed != 0{
+		t.Fatalf("退避期间期待不领取消息，但实际领取 %d 条", claimed)
+	}
+
+	// 退避结束后重新领取
+	past := time.Now().Add(-time.Second)
+	if err := d.db.Model(&models.OrderOutbox{}).Where("order_sn = ?", "retry").Update("next_attempt_at", past).Error; err != nil{
+		t.Fatalf("修改下次发送时间失败：%v", err)
+	}
+
+	outboxes, err := d.claimPending(context.Background(), 10, time.Now())
+	if err != nil || len(outboxes) != 1{
+		t.Fatalf("退避结束后期待领取1条消息，但实际得到 %d, %v", len(outboxes), err)
+	}
+
+	// 已领取的消息在租约期内不会被其他relay领取
+	outboxes, _ = d.claimPending(context.Background(), 10, time.Now())
+	if len(outboxes) != 0{
+		t.Errorf("租约期内期待不领取消息，但实际领取 %d 条", len(outboxes))
+	}
+
+	outboxes, _ = d.claimPending(context.Background(), 10, time.Now().Add(outboxClaimLease + time.Second))
+	if len(outboxes) != 1{
+		t.Errorf("租约到期后期待重新领取消息，但实际领取 %d 条", len(outboxes))
+	}
+}
+
+func TestOutboxBackoff(t *testing.T){
+	tests := []struct{
+		attempts	int
+		want		time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{5, 16 * time.Second},
+		{9, 256 * time.Second},
+		{10, outboxMaxBackoff},
+		{100, outboxMaxBackoff},
+	}
+
+	for _, tt := range tests{
+		if got := outboxBackoff(tt.attempts); got != tt.want{
+			t.Errorf("第%d次失败后期待间隔 %v，但实际得到 %v", tt.attempts, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package data
+
+import(
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"Redrock/seckill/internal/pkg/database"
+	"Redrock/seckill/internal/pkg/models"
+	"Redrock/seckill/internal/pkg/saga"
+)
+
+// SagaData 下单saga的持久化
+type SagaData struct{
+	db *gorm.DB
+}
+
+func NewSagaData() *SagaData{
+	return &SagaData{
+		db: database.GetDB(),
+	}
+}
+
+// Create 创建saga记录
+func (d *SagaData) Create(ctx context.Context, orderSaga *models.OrderSaga) error{
+	return d.db.WithContext(ctx).Create(orderSaga).Error
+}
+
+// UpdateProgress 更新saga进度，sagaID即订单号
+func (d *SagaData) UpdateProgress(ctx context.Context, sagaID string, step int, status int) error{
+	err := d.db.WithContext(ctx).Model(&models.OrderSaga{}).Where("order_sn = ?", sagaID).
+		Updates(map[string]any{
+			"step":		step,
+			"status":	status,
+		}).Error
+
+	return err
+}
+
+// GetUnfinished 获取在before之前最后更新且未结束的saga
+func (d *SagaData) GetUnfinished(ctx context.Context, before time.Time, limit int) ([]*models.OrderSaga, error){
+	var sagas []*models.OrderSaga
+
+	err := d.db.WithContext(ctx).
+		Where("status IN ? AND updated_at < ?", []int{saga.StatusRunning, saga.StatusCompensating}, before).
+		Order("id").
+		Limit(limit).
+		Find(&sagas).Error
+	if err != nil{
+		return nil, err
+	}
+
+	return sagas, nil
+}
+
+// Exists 检查订单号对应的saga是否已存在
+func (d *SagaData) Exists(ctx context.Context, orderSn string) (bool, error){
+	var count int64
+
+	err := d.db.WithContext(ctx).Model(&models.OrderSaga{}).Where("order_sn = ?", orderSn).Count(&count).Error
+	if err != nil{
+		return false, err
+	}
+
+	return count > 0, nil
+}
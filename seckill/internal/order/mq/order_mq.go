@@ -25,80 +25,93 @@ type OrderMessage struct{
 
 // OrderProducer 订单消息生产者
 type OrderProducer struct{
-	rabbitmq *mq.RabbitMQ
+	publisher mq.Publisher
 }
 
 // OrderConsumer 订单消息消费者
 type OrderConsumer struct{
-	rabbitmq *mq.RabbitMQ
+	subscriber mq.Subscriber
 	orderData *data.OrderData
 }
 
 // NewOrderProducer 创建订单消息生产者
-func NewOrderProducer(config *mq.RabbitMQConfig) (*OrderProducer, error){
-	localRabbitMQ, err := mq.NewRabbitMQ(config)
+func NewOrderProducer(config *mq.MQConfig) (*OrderProducer, error){
+	publisher, err := mq.NewPublisher(config)
 	if err != nil{
 		return nil, fmt.Errorf("创建订单生产者失败：%w", err)
 	}
 
 	return &OrderProducer{
-		rabbitmq: localRabbitMQ,
+		publisher: publisher,
 	}, nil
 }
 
 // Close 关闭连接
 func (p *OrderProducer) Close(){
-	if p.rabbitmq != nil{
-		p.rabbitmq.Close()
+	if p.publisher != nil{
+		p.publisher.Close()
 	}
 }
 
-// Produce 生产订单消息
-func (p *OrderProducer) Produce(message *OrderMessage) error{
+// EncodeOrderMessage 校验并序列化订单消息
+func EncodeOrderMessage(message *OrderMessage) ([]byte, error){
 
 	if message.OrderSn == "" {
-        return fmt.Errorf("订单号不能为空")
+        return nil, fmt.Errorf("订单号不能为空")
     }
     
     if message.UserID == 0 {
-        return fmt.Errorf("用户ID不能为0")
+        return nil, fmt.Errorf("用户ID不能为0")
     }
     
     if message.ActivityID == 0 {
-        return fmt.Errorf("活动ID不能为0")
+        return nil, fmt.Errorf("活动ID不能为0")
     }
     
     if message.ProductID == 0 {
-        return fmt.Errorf("商品ID不能为0")
+        return nil, fmt.Errorf("商品ID不能为0")
     }
 
 	data, err := json.Marshal(message)
 	if err != nil{
-		return fmt.Errorf("序列化消息失败：%w", err)
+		return nil, fmt.Errorf("序列化消息失败：%w", err)
 	}
 
-	err = p.rabbitmq.PublishMessage(data)
+	return data, nil
+}
 
-	return err
+// Produce 生产订单消息，返回nil表示消息已被消息队列确认接收
+func (p *OrderProducer) Produce(message *OrderMessage) error{
+	data, err := EncodeOrderMessage(message)
+	if err != nil{
+		return err
+	}
+
+	return p.ProduceRaw(data)
+}
+
+// ProduceRaw 发布已经序列化的订单消息
+func (p *OrderProducer) ProduceRaw(data []byte) error{
+	return p.publisher.Publish(data)
 }
 
 // NewOrderConsumer 创建订单消息消费者
-func NewOrderConsumer(config *mq.RabbitMQConfig, orderData *data.OrderData) (*OrderConsumer, error){
-	rabbit, err := mq.NewRabbitMQ(config)
+func NewOrderConsumer(config *mq.MQConfig, orderData *data.OrderData) (*OrderConsumer, error){
+	subscriber, err := mq.NewSubscriber(config)
 	if err != nil{
 		return nil, fmt.Errorf("创建订单消息消费者失败：%w", err)
 	}
 
 	return &OrderConsumer{
-		rabbitmq: 	rabbit,
-		orderData: 	orderData,
+		subscriber: 	subscriber,
+		orderData: 		orderData,
 	}, nil
 }
 
 // Close 关闭连接
 func (c *OrderConsumer) Close(){
-	if c.rabbitmq != nil{
-		c.rabbitmq.Close()
+	if c.subscriber != nil{
+		c.subscriber.Close()
 	}
 }
 
@@ -126,25 +139,31 @@ func (c *OrderConsumer) handlerOrderMessage(body []byte) error{
 		return fmt.Errorf("订单不存在")
 	}
 
-	if exist.Status == models.StatusPending {
-		log.Printf("开始更新订单%v状态", msg.OrderSn)
-
-		err = c.orderData.UpdateStatus(ctx, msg.OrderSn, models.StatusCreated)
-		if err != nil {
-			return fmt.Errorf("更新订单状态失败：%w", err)
-		}
-		
-		log.Printf("订单%v状态更新成功", msg.OrderSn)
-	} else {
+	if exist.Status != models.StatusPending {
 		log.Printf("订单%v当前状态不是Pending(状态码:%d)，无需更新", msg.OrderSn, exist.Status)
+		return nil
 	}
 
+	log.Printf("开始更新订单%v状态", msg.OrderSn)
+
+	// 读取后订单可能被取消或过期，只在仍是Pending时更新，避免已取消的订单被改回Created
+	updated, err := c.orderData.MarkCreated(ctx, msg.OrderSn)
+	if err != nil {
+		return fmt.Errorf("更新订单状态失败：%w", err)
+	}
+	if !updated {
+		log.Printf("订单%v的状态已被修改，无需更新", msg.OrderSn)
+		return nil
+	}
+
+	log.Printf("订单%v状态更新成功", msg.OrderSn)
+
 	return nil
 }
 
 // StartConsume 开始消费订单消息
 func (c *OrderConsumer) StartConsume() error{
-	err := c.rabbitmq.ConsumeMessage(c.handlerOrderMessage)
+	err := c.subscriber.Subscribe(c.handlerOrderMessage)
 
 	return err
 }
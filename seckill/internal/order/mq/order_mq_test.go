@@ -0,0 +1,97 @@
+package mq
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"Redrock/seckill/internal/order/data"
+	"Redrock/seckill/internal/pkg/models"
+	"Redrock/seckill/internal/pkg/testenv"
+)
+
+func newTestConsumer(t *testing.T, orderSn string) (*OrderConsumer, *gorm.DB){
+	t.Helper()
+
+	db := testenv.NewDB(t, &models.Order{})
+	o := &models.Order{
+		UserID:		1,
+		ProductID:	1,
+		ActivityID:	1,
+		OrderSn:	orderSn,
+		Amount:		9.9,
+		Price:		9.9,
+		Quantity:	1,
+		Status:		models.StatusPending,
+		CreateTime:	time.Now(),
+		ExpireTime:	time.Now().Add(time.Hour),
+	}
+	if err := db.Create(o).Error; err != nil{
+		t.Fatalf("创建测试订单失败：%v", err)
+	}
+
+	return &OrderConsumer{orderData: data.NewOrderData()}, db
+}
+
+func handle(t *testing.T, c *OrderConsumer, orderSn string){
+	t.Helper()
+
+	body, _ := json.Marshal(&OrderMessage{OrderSn: orderSn})
+	if err := c.handlerOrderMessage(body); err != nil{
+		t.Fatalf("处理订单消息失败：%v", err)
+	}
+}
+
+func orderStatus(t *testing.T, db *gorm.DB, orderSn string) int{
+	t.Helper()
+
+	var o models.Order
+	if err := db.Where("order_sn = ?", orderSn).First(&o).Error; err != nil{
+		t.Fatalf("查询订单失败：%v", err)
+	}
+
+	return o.Status
+}
+
+func TestHandleOrderMessage(t *testing.T){
+	c, db := newTestConsumer(t, "order-ok")
+
+	handle(t, c, "order-ok")
+	if status := orderStatus(t, db, "order-ok"); status != models.StatusCreated{
+		t.Fatalf("订单状态为%d，期望Created", status)
+	}
+}
+
+// 读取订单后订单被取消时，不会被改回Created
+func TestHandleOrderMessageCancelledConcurrently(t *testing.T){
+	c, db := newTestConsumer(t, "order-cancelled")
+
+	cancelled := false
+	err := db.Callback().Query().After("gorm:query").Register("test:cancel_order", func(tx *gorm.DB){
+		if cancelled || tx.Statement.Table != "orders"{
+			return
+		}
+		cancelled = true
+
+		err := db.Session(&gorm.Session{NewDB: true}).WithContext(context.Background()).
+			Model(&models.Order{}).Where("order_sn = ?", "order-cancelled").
+			Update("status", models.StatusCancelled).Error
+		if err != nil{
+			t.Errorf("取消订单失败：%v", err)
+		}
+	})
+	if err != nil{
+		t.Fatalf("注册回调失败：%v", err)
+	}
+
+	handle(t, c, "order-cancelled")
+	if !cancelled{
+		t.Fatalf("读取订单后没有取消订单")
+	}
+	if status := orderStatus(t, db, "order-cancelled"); status != models.StatusCancelled{
+		t.Fatalf("订单状态为%d，期望保持已取消", status)
+	}
+}
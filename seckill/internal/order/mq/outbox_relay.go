@@ -0,0 +1,72 @@
+package mq
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"Redrock/seckill/internal/order/data"
+	"Redrock/seckill/internal/pkg/models"
+)
+
+// OutboxRelay 将发件箱中未发送的订单消息发布到MQ
+// 消息可能被重复发布(例如发布成功但标记失败)，消费者需要保证幂等
+type OutboxRelay struct{
+	outboxData	*data.OutboxData
+	producer	*OrderProducer
+	notify		chan struct{}
+}
+
+// NewOutboxRelay 创建发件箱relay
+func NewOutboxRelay(outboxData *data.OutboxData, producer *OrderProducer) *OutboxRelay{
+	return &OutboxRelay{
+		outboxData:	outboxData,
+		producer:	producer,
+		notify:		make(chan struct{}, 1),
+	}
+}
+
+// Notify 通知relay有新消息写入，不会阻塞
+func (r *OutboxRelay) Notify(){
+	select{
+	case r.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Run 持续发布发件箱中的消息，直到ctx结束
+func (r *OutboxRelay) Run(ctx context.Context){
+	// 兜底的定时发布，用于重试退避结束的消息
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select{
+		case <- r.notify:
+		case <- ticker.C:
+		case <- ctx.Done():
+			log.Printf("发件箱relay停止")
+			return
+		}
+
+		// 一次处理一批，领取满一批时继续处理剩余的消息，发送失败的消息退避后由之后的批次重试
+		for {
+			claimed, err := r.outboxData.RelayPending(ctx, 100, func(outbox *models.OrderOutbox) error{
+				err := r.producer.ProduceRaw(outbox.Payload)
+				if err != nil{
+					log.Printf("发送订单消息失败：%v, 订单号：%v", err, outbox.OrderSn)
+				}
+
+				return err
+			})
+			if err != nil{
+				log.Printf("发布发件箱消息失败：%v", err)
+				break
+			}
+
+			if claimed < 100{
+				break
+			}
+		}
+	}
+}
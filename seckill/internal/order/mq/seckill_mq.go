@@ -0,0 +1,111 @@
+package mq
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"Redrock/seckill/internal/pkg/mq"
+)
+
+// SeckillMessage 异步秒杀的排队消息
+// 网关完成库存扣除后发送，订单服务消费后创建订单，订单号即返回给用户的排队凭证
+type SeckillMessage struct{
+	OrderSn		string		`json:"order_sn"`
+	UserID		uint		`json:"user_id"`
+	ActivityID	uint		`json:"activity_id"`
+	Quantity	int			`json:"quantity"`
+}
+
+// SeckillProducer 秒杀排队消息生产者
+type SeckillProducer struct{
+	publisher mq.Publisher
+}
+
+// SeckillConsumer 秒杀排队消息消费者
+type SeckillConsumer struct{
+	subscriber	mq.Subscriber
+	handler		func(*SeckillMessage) error
+}
+
+// NewSeckillProducer 创建秒杀排队消息生产者
+func NewSeckillProducer(config *mq.MQConfig) (*SeckillProducer, error){
+	publisher, err := mq.NewPublisher(config)
+	if err != nil{
+		return nil, fmt.Errorf("创建秒杀消息生产者失败：%w", err)
+	}
+
+	return &SeckillProducer{
+		publisher: publisher,
+	}, nil
+}
+
+// Close 关闭连接
+func (p *SeckillProducer) Close(){
+	if p.publisher != nil{
+		p.publisher.Close()
+	}
+}
+
+// Produce 发布秒杀排队消息，返回nil表示消息已被消息队列确认接收
+func (p *SeckillProducer) Produce(message *SeckillMessage) error{
+	if message.OrderSn == "" || message.UserID == 0 || message.ActivityID == 0 || message.Quantity <= 0{
+		return fmt.Errorf("秒杀消息参数错误")
+	}
+
+	data, err := json.Marshal(message)
+	if err != nil{
+		return fmt.Errorf("序列化消息失败：%w", err)
+	}
+
+	return p.publisher.Publish(data)
+}
+
+// NewSeckillConsumer 创建秒杀排队消息消费者，handler返回错误时消息会被重试
+func NewSeckillConsumer(config *mq.MQConfig, handler func(*SeckillMessage) error) (*SeckillConsumer, error){
+	subscriber, err := mq.NewSubscriber(config)
+	if err != nil{
+		return nil, fmt.Errorf("创建秒杀消息消费者失败：%w", err)
+	}
+
+	return &SeckillConsumer{
+		subscriber:	subscriber,
+		handler:	handler,
+	}, nil
+}
+
+// Close 关闭连接
+func (c *SeckillConsumer) Close(){
+	if c.subscriber != nil{
+		c.subscriber.Close()
+	}
+}
+
+// OnDeadLetter 设置秒杀排队消息超过最大重试次数进入死信后的回调，需要在StartConsume之前设置
+func (c *SeckillConsumer) OnDeadLetter(handler func(*SeckillMessage, error)){
+	c.subscriber.OnDeadLetter(func(body []byte, handleErr error){
+		var msg SeckillMessage
+
+		err := json.Unmarshal(body, &msg)
+		if err != nil{
+			log.Printf("反序列化死信消息失败：%v, 消息：%s", err, body)
+			return
+		}
+
+		handler(&msg, handleErr)
+	})
+}
+
+// StartConsume 开始消费秒杀排队消息
+func (c *SeckillConsumer) StartConsume() error{
+	return c.subscriber.Subscribe(func(body []byte) error{
+		var msg SeckillMessage
+
+		err := json.Unmarshal(body, &msg)
+		if err != nil{
+			return fmt.Errorf("反序列化消息失败：%w", err)
+		}
+
+		return c.handler(&msg)
+	})
+}
@@ -0,0 +1,5 @@
+package payment
+
+type PaymentConfig struct{
+	Type	string	`mapstructure:"type"` // 支付渠道类型，目前只支持mock
+}
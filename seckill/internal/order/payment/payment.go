@@ -0,0 +1,110 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrTradeNotFound 支付渠道中没有该订单的交易
+var ErrTradeNotFound = errors.New("交易不存在")
+
+// Provider 支付渠道
+// 同一个订单号重复调用Pay时应当返回同一个交易流水号，以保证支付回调重试时的幂等
+type Provider interface{
+	Pay(ctx context.Context, orderSn string, amount float64) (tradeNo string, err error)
+
+	// Query 查询订单的交易流水号，订单没有支付成功时返回ErrTradeNotFound
+	Query(ctx context.Context, orderSn string) (tradeNo string, err error)
+
+	// Refund 全额退款，重复退款时直接返回成功
+	Refund(ctx context.Context, orderSn string, tradeNo string) error
+}
+
+// NewProvider 根据配置创建支付渠道
+func NewProvider(config *PaymentConfig) (Provider, error){
+	switch config.Type{
+	case "", "mock":
+		return NewMockProvider(), nil
+	default:
+		return nil, fmt.Errorf("不支持的支付渠道：%s", config.Type)
+	}
+}
+
+// MockProvider 模拟支付渠道，不请求任何外部服务，方便离线测试
+type MockProvider struct{
+	mu			sync.Mutex
+	trades		map[string]string	// 订单号 -> 交易流水号
+	refunds		map[string]string	// 订单号 -> 已退款的交易流水号
+	FailErr		error				// 不为nil时所有支付都返回该错误，用于模拟支付失败
+}
+
+// NewMockProvider 创建模拟支付渠道
+func NewMockProvider() *MockProvider{
+	return &MockProvider{
+		trades:		make(map[string]string),
+		refunds:	make(map[string]string),
+	}
+}
+
+// Pay 模拟支付
+func (p *MockProvider) Pay(ctx context.Context, orderSn string, amount float64) (string, error){
+	if p.FailErr != nil{
+		return "", p.FailErr
+	}
+
+	if orderSn == ""{
+		return "", fmt.Errorf("订单号不能为空")
+	}
+
+	if amount < 0{
+		return "", fmt.Errorf("支付金额不能为负数")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// 同一订单重复支付时返回已有的流水号
+	if tradeNo, ok := p.trades[orderSn]; ok{
+		return tradeNo, nil
+	}
+
+	tradeNo := fmt.Sprintf("MOCK%d%s", time.Now().UnixNano(), orderSn)
+	p.trades[orderSn] = tradeNo
+
+	return tradeNo, nil
+}
+
+// Query 查询模拟的交易，已退款的交易视为不存在
+func (p *MockProvider) Query(ctx context.Context, orderSn string) (string, error){
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	tradeNo, ok := p.trades[orderSn]
+	if !ok{
+		return "", ErrTradeNotFound
+	}
+
+	return tradeNo, nil
+}
+
+// Refund 模拟退款，退款后同一订单可以重新支付
+func (p *MockProvider) Refund(ctx context.Context, orderSn string, tradeNo string) error{
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.refunds[orderSn] == tradeNo{
+		return nil
+	}
+
+	if p.trades[orderSn] != tradeNo{
+		return fmt.Errorf("订单%s的交易%s不存在", orderSn, tradeNo)
+	}
+
+	delete(p.trades, orderSn)
+	p.refunds[orderSn] = tradeNo
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMockProviderIdempotent(t *testing.T) {
+	p := NewMockProvider()
+
+	first, err := p.Pay(context.Background(), "10001", 9.9)
+	if err != nil {
+		t.Fatalf("支付失败：%v", err)
+	}
+
+	second, err := p.Pay(context.Background(), "10001", 9.9)
+	if err != nil {
+		t.Fatalf("重复支付失败：%v", err)
+	}
+
+	if first != second {
+		t.Errorf("重复支付应返回相同流水号，期待得到 %q, 但实际得到 %q", first, second)
+	}
+}
+
+func TestMockProviderFail(t *testing.T) {
+	p := NewMockProvider()
+	p.FailErr = errors.New("余额不足")
+
+	_, err := p.Pay(context.Background(), "10002", 9.9)
+	if err == nil {
+		t.Errorf("期待支付失败，但实际支付成功")
+	}
+}
+
+func TestMockProviderRefund(t *testing.T) {
+	p := NewMockProvider()
+
+	tradeNo, err := p.Pay(context.Background(), "10003", 9.9)
+	if err != nil {
+		t.Fatalf("支付失败：%v", err)
+	}
+
+	if got, err := p.Query(context.Background(), "10003"); err != nil || got != tradeNo {
+		t.Fatalf("查询交易期待得到 %q, 但实际得到 %q, %v", tradeNo, got, err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := p.Refund(context.Background(), "10003", tradeNo); err != nil {
+			t.Fatalf("第%d次退款失败：%v", i+1, err)
+		}
+	}
+
+	if _, err := p.Query(context.Background(), "10003"); !errors.Is(err, ErrTradeNotFound) {
+		t.Errorf("退款后查询交易期待得到ErrTradeNotFound, 但实际得到 %v", err)
+	}
+
+	if err := p.Refund(context.Background(), "10004", "MOCK1"); err == nil {
+		t.Errorf("退款不存在的交易期待失败，但实际成功")
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	if _, err := NewProvider(&PaymentConfig{Type: "mock"}); err != nil {
+		t.Errorf("创建mock支付渠道失败：%v", err)
+	}
+
+	if _, err := NewProvider(&PaymentConfig{Type: "alipay"}); err == nil {
+		t.Errorf("不支持的支付渠道应返回错误")
+	}
+}
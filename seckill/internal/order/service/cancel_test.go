@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Redrock/seckill/internal/pkg/database"
+	"Redrock/seckill/internal/pkg/models"
+	order "Redrock/seckill/kitex_gen/order"
+)
+
+func cancelRequest(orderSn string) *order.CancelOrderRequest{
+	return &order.CancelOrderRequest{UserID: 1, OrderSn: orderSn}
+}
+
+// 未支付的订单可以取消，取消后归还库存
+func TestCancelOrderTransitions(t *testing.T){
+	s, internal, _ := newTestService(t)
+
+	tests := []struct{
+		orderSn		string
+		status		int
+		wantCode	int32
+		wantStatus	int
+	}{
+		{"cancel-pending", models.StatusPending, 0, models.StatusCancelled},
+		{"cancel-created", models.StatusCreated, 0, models.StatusCancelled},
+		{"cancel-paying", models.StatusPaying, 400, models.StatusPaying},
+		{"cancel-paid", models.StatusPaid, 400, models.StatusPaid},
+		{"cancel-failed", models.StatusFailed, 400, models.StatusFailed},
+		{"cancel-cancelled", models.StatusCancelled, 0, models.StatusCancelled},
+	}
+
+	for _, tt := range tests{
+		createTestOrder(t, s, tt.orderSn, tt.status)
+
+		resp, err := s.CancelOrder(context.Background(), cancelRequest(tt.orderSn))
+		if err != nil{
+			t.Fatalf("%s 取消订单返回错误：%v", tt.orderSn, err)
+		}
+
+		if resp.BaseResponse.Code != tt.wantCode{
+			t.Errorf("%s 期待返回 %d，但实际得到 %d：%s", tt.orderSn, tt.wantCode, resp.BaseResponse.Code, resp.BaseResponse.Msg)
+		}
+
+		if status := orderStatus(t, s, tt.orderSn); status != tt.wantStatus{
+			t.Errorf("%s 期待状态为 %d，但实际为 %d", tt.orderSn, tt.wantStatus, status)
+		}
+	}
+
+	returned := internal.returnedOrders()
+	if len(returned) != 2 || returned[0] != "cancel-pending" || returned[1] != "cancel-created"{
+		t.Errorf("期待只归还本次取消的订单的库存，但实际归还了 %v", returned)
+	}
+}
+
+// 重复取消只归还一次库存
+func TestCancelOrderTwice(t *testing.T){
+	s, internal, _ := newTestService(t)
+	createTestOrder(t, s, "cancel-twice", models.StatusCreated)
+
+	for i := 0; i < 2; i++{
+		resp, _ := s.CancelOrder(context.Background(), cancelRequest("cancel-twice"))
+		if resp.BaseResponse.Code != 0{
+			t.Errorf("第%d次取消期待返回0，但实际得到 %d", i+1, resp.BaseResponse.Code)
+		}
+	}
+
+	if returned := internal.returnedOrders(); len(returned) != 1{
+		t.Errorf("期待归还库存1次，但实际归还 %d 次", len(returned))
+	}
+}
+
+// 归还库存失败时订单仍然取消，后台任务重试归还
+func TestCancelOrderRetriesStockReturn(t *testing.T){
+	s, internal, _ := newTestService(t)
+	createTestOrder(t, s, "cancel-retry", models.StatusCreated)
+
+	internal.returnErr = errTest
+	resp, _ := s.CancelOrder(context.Background(), cancelRequest("cancel-retry"))
+	if resp.BaseResponse.Code != 0{
+		t.Fatalf("归还库存失败时期待取消成功，但实际得到 %d：%s", resp.BaseResponse.Code, resp.BaseResponse.Msg)
+	}
+
+	o, err := s.orderData.GetByOrderSn(context.Background(), "cancel-retry")
+	if err != nil{
+		t.Fatalf("查询订单失败：%v", err)
+	}
+	if o.Status != models.StatusCancelled || !o.StockReturnPending{
+		t.Fatalf("期待订单已取消且库存待归还，但实际状态为 %d，待归还为 %v", o.Status, o.StockReturnPending)
+	}
+
+	// 刚取消的订单不会被重试
+	internal.returnErr = nil
+	s.retryStockReturns(context.Background())
+	if returned := internal.returnedOrders(); len(returned) != 0{
+		t.Fatalf("期待不重试刚取消的订单，但实际归还了 %v", returned)
+	}
+
+	past := time.Now().Add(-2 * stockReturnRetryAfter)
+	err = database.GetDB().Model(&models.Order{}).Where("order_sn = ?", "cancel-retry").UpdateColumn("updated_at", past).Error
+	if err != nil{
+		t.Fatalf("修改订单更新时间失败：%v", err)
+	}
+
+	s.retryStockReturns(context.Background())
+	if returned := internal.returnedOrders(); len(returned) != 1 || returned[0] != "cancel-retry"{
+		t.Fatalf("期待重试归还库存，但实际归还了 %v", returned)
+	}
+
+	o, _ = s.orderData.GetByOrderSn(context.Background(), "cancel-retry")
+	if o.StockReturnPending{
+		t.Errorf("库存归还后期待清除待归还标记")
+	}
+
+	// 归还成功后不再重试
+	s.retryStockReturns(context.Background())
+	if returned := internal.returnedOrders(); len(returned) != 1{
+		t.Errorf("期待不再重试，但实际归还了 %v", returned)
+	}
+}
+
+// 过期未支付的订单被取消，支付中的订单不会过期
+func TestCancelExpiredOrders(t *testing.T){
+	s, internal, _ := newTestService(t)
+
+	for sn, status := range map[string]int{
+		"expired-created":	models.StatusCreated,
+		"expired-paying":	models.StatusPaying,
+		"expired-paid":		models.StatusPaid,
+	}{
+		o := createTestOrder(t, s, sn, status)
+		err := database.GetDB().Model(o).UpdateColumn("expire_time", time.Now().Add(-time.Minute)).Error
+		if err != nil{
+			t.Fatalf("修改订单过期时间失败：%v", err)
+		}
+	}
+	createTestOrder(t, s, "unexpired-created", models.StatusCreated)
+
+	s.cancelExpiredOrders(context.Background())
+
+	want := map[string]int{
+		"expired-created":		models.StatusCancelled,
+		"expired-paying":		models.StatusPaying,
+		"expired-paid":			models.StatusPaid,
+		"unexpired-created":	models.StatusCreated,
+	}
+	for sn, status := range want{
+		if got := orderStatus(t, s, sn); got != status{
+			t.Errorf("%s 期待状态为 %d，但实际为 %d", sn, status, got)
+		}
+	}
+
+	if returned := internal.returnedOrders(); len(returned) != 1 || returned[0] != "expired-created"{
+		t.Errorf("期待只归还过期订单的库存，但实际归还了 %v", returned)
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -12,21 +13,39 @@ import (
 	"Redrock/seckill/internal/order/data"
 	"Redrock/seckill/internal/order/mq"
 	"Redrock/seckill/internal/order/config"
+	"Redrock/seckill/internal/order/payment"
 	"Redrock/seckill/internal/pkg/models"
+	"Redrock/seckill/internal/pkg/pagination"
 	myRedis "Redrock/seckill/internal/pkg/redis"
-	"Redrock/seckill/kitex_gen/activity"
+	"Redrock/seckill/internal/pkg/snowflake"
 	activityClient "Redrock/seckill/kitex_gen/activity/activityservice"
 	internalClient "Redrock/seckill/kitex_gen/activity/internalactivityservice"
 	order "Redrock/seckill/kitex_gen/order"
 )
 
+const (
+	// paymentTimeout 调用支付渠道的超时时间
+	paymentTimeout = 30 * time.Second
+
+	// paymentRecoverAfter 订单处于支付中超过该时间后，由后台任务查询支付渠道确认结果
+	paymentRecoverAfter = time.Minute
+
+	// stockReturnRetryAfter 订单取消超过该时间后库存仍待归还时，由后台任务重试
+	stockReturnRetryAfter = 30 * time.Second
+)
+
 // OrderServiceImpl implements the last service interface defined in the IDL.
 type OrderServiceImpl struct{
 	orderData 		*data.OrderData
+	sagaData		*data.SagaData
 	orderProducer 	*mq.OrderProducer
+	outboxRelay		*mq.OutboxRelay
 	activityClient 	activityClient.Client
 	redisClient 	*redis.Client
 	internalClient internalClient.Client
+	payProvider		payment.Provider
+	orderConfig		config.OrderConfig
+	snGenerator		*snowflake.Generator
 }
 
 // NewOrderServiceImpl 创建服务实现实例
@@ -40,25 +59,60 @@ func NewOrderServiceImpl(producer *mq.OrderProducer, activityClient activityClie
 		panic(fmt.Sprintf("创建内部活动客户端失败：%v",err))
 	}
 
+	payProvider, err := payment.NewProvider(&config.Payment)
+	if err != nil{
+		panic(fmt.Sprintf("创建支付渠道失败：%v",err))
+	}
+
+	snGenerator, err := snowflake.NewGenerator(&config.Snowflake)
+	if err != nil{
+		panic(fmt.Sprintf("创建订单号生成器失败：%v",err))
+	}
+
+	// 未配置过期时间时默认15分钟
+	if config.Order.ExpireTime <= 0{
+		config.Order.ExpireTime = 900
+	}
+
 	serviceImpl := &OrderServiceImpl{
 		orderData: 		data.NewOrderData(),
+		sagaData:		data.NewSagaData(),
 		orderProducer: 	producer,
+		outboxRelay:	mq.NewOutboxRelay(data.NewOutboxData(), producer),
 		activityClient: activityClient,
 		redisClient: 	myRedis.GetRedis(),
 		internalClient: internalActivityClient,
+		payProvider:	payProvider,
+		orderConfig:	config.Order,
+		snGenerator:	snGenerator,
 	}
 
-	// 启动恢复处于Pending状态的订单任务
-	go serviceImpl.RecoverPendingOrder(context.Background())
+	// 启动发件箱relay，将订单消息发布到mq
+	go serviceImpl.outboxRelay.Run(context.Background())
+
+	// 启动恢复中断的下单saga任务
+	go serviceImpl.RecoverSagas(context.Background())
+
+	// 启动取消过期未支付订单的任务
+	go serviceImpl.CancelExpiredOrders(context.Background())
 	
 	return serviceImpl
 }
 
-// GenerateOrderSn 生成订单号
-// 这里简单地用时间戳来表示
-func generateOrderSn() string{
-	
-	return fmt.Sprintf("%d", time.Now().Unix())
+// generateOrderSn 生成订单号
+// 使用雪花算法，保证多个订单服务实例之间生成的订单号不重复且按时间递增
+func (s *OrderServiceImpl) generateOrderSn() (string, error){
+	return s.snGenerator.NextSn()
+}
+
+// orderQuantity 获取下单数量，未设置时为1
+// 是否超过限购数量由活动服务扣除库存时检查
+func orderQuantity(quantity int32) int{
+	if quantity <= 0{
+		return 1
+	}
+
+	return int(quantity)
 }
 
 // CreateOrder 创建订单
@@ -67,7 +121,7 @@ func (s *OrderServiceImpl) CreateOrder(ctx context.Context, req *order.CreateOrd
 		BaseResponse : &order.BaseResponse{},
 	}
 
-	if req.UserID <= 0 || req.ActivityID <= 0{
+	if req.UserID <= 0 || req.ActivityID <= 0 || req.Quantity < 0{
 		response.BaseResponse.Code = 400
 		response.BaseResponse.Msg  = "输入参数错误"
 
@@ -76,93 +130,35 @@ func (s *OrderServiceImpl) CreateOrder(ctx context.Context, req *order.CreateOrd
 
 	userID 		:= uint(req.UserID)
 	activityID  := uint(req.ActivityID)
+	quantity	:= orderQuantity(req.Quantity)
 
 	// 生成订单号
-	orderSn := generateOrderSn()
-
-	// 1. 扣除库存
-	deductResquest := &activity.DeductStockRequest{
-		ActivityID:		req.ActivityID,
-		UserID:			req.UserID,
-		Count:			1, // 这里简单地扣1个
-	}
-
-	deductResponse, err := s.internalClient.DeductStock(ctx, deductResquest)
+	orderSn, err := s.generateOrderSn()
 	if err != nil{
 		response.BaseResponse.Code = 500
-		response.BaseResponse.Msg  = "扣除库存失败" + err.Error()
+		response.BaseResponse.Msg  = "生成订单号失败：" + err.Error()
 
 		return response, nil
 	}
 
-	// 判断扣除库存是否成功, 如果不成功则将扣除失败的响应返回给调用方
-	if deductResponse.BaseResponse.Code != 0 || !deductResponse.Success{
-		response.BaseResponse.Code = deductResponse.BaseResponse.Code
-		response.BaseResponse.Msg  = deductResponse.BaseResponse.Msg
-		
-		return response, nil
-	}
-
-	// 2. 获取活动详情， 计算amount等
-	activityRequest := &activity.GetActivityRequest{
-		ActivityID:			int64(activityID),
-	}
-
-	activityResponse, err := s.activityClient.GetActivity(ctx, activityRequest)
+	localOrder, err := s.placeOrder(ctx, orderSn, userID, activityID, quantity)
 	if err != nil{
-		response.BaseResponse.Code = 500
-		response.BaseResponse.Msg  = "获取活动信息失败：" + err.Error()
-
-		return response, nil
-	}
-
-	// 如果调用成功但未成功获取，如果活动已结束等
-	if activityResponse.BaseResponse.Code != 0{
-		response.BaseResponse.Code = activityResponse.BaseResponse.Code
-		response.BaseResponse.Msg  = activityResponse.BaseResponse.Msg
-
-		return response, nil
-	}
+		// 下游服务返回的业务错误(如库存不足、活动已结束)直接返回给调用方
+		var respErr *responseError
+		if errors.As(err, &respErr){
+			response.BaseResponse.Code = respErr.code
+			response.BaseResponse.Msg  = respErr.msg
 
-	// 3.创建订单并写入数据库
-	localOrder := &models.Order{
-		OrderSn:			orderSn,
-		UserID:				userID,
-		ActivityID:			activityID,
-		ProductID:			uint(activityResponse.Activity.ProductId),
-		Amount:				activityResponse.Activity.SeckillPrice,   // 因为我们这里默认秒杀一件商品，所以seckillprice == amount
-		Status:				models.StatusPending,
-		CreateTime: 		time.Now(),
-		Price:				activityResponse.Activity.SeckillPrice,
-		Quantity:			1,
-	}
+			return response, nil
+		}
 
-	err = s.orderData.Create(ctx, localOrder)
-	if err != nil{
 		response.BaseResponse.Code = 500
 		response.BaseResponse.Msg  = "创建订单失败：" + err.Error()
 
 		return response, nil
 	}
 
-	// 4. 发送消息到mq，异步创建订单
-	msg := &mq.OrderMessage{
-		OrderSn:			orderSn,
-		UserID:				userID,
-		ActivityID:			activityID,
-		ProductID:			uint(activityResponse.Activity.ProductId),
-		Amount:				activityResponse.Activity.SeckillPrice,
-		Price:				activityResponse.Activity.SeckillPrice,
-		Quantity:			1, // 默认数量为1
-	}
-		
-	// 订单创建成功， handler主要负责创建订单，是否发送成功并不重要
-	err = s.orderProducer.Produce(msg)
-	if err != nil{
-		log.Printf("发送订单消息失败：%v, 订单号：%v", err, orderSn)
-	}
-
-	// 5. 构建返回的订单信息
+	// 构建返回的订单信息
 	orderInfo := &order.OrderInfo{
 		Id:					int64(localOrder.ID),
 		OrderSn:			orderSn,
@@ -172,6 +168,9 @@ func (s *OrderServiceImpl) CreateOrder(ctx context.Context, req *order.CreateOrd
 		Amount:				localOrder.Amount,
 		Status:				models.StatusPending,
 		CreateTime: 		localOrder.CreatedAt.Unix(),	
+		ExpireTime: 		localOrder.ExpireTime.Unix(),
+		Quantity:			int32(localOrder.Quantity),
+		Price:				localOrder.Price,
 	}
 
 	response.OrderInfo = orderInfo
@@ -181,49 +180,95 @@ func (s *OrderServiceImpl) CreateOrder(ctx context.Context, req *order.CreateOrd
 	return response, nil
 }
 
-// RecoverPendingOrder 恢复处于Pending状态的订单
-func (s *OrderServiceImpl) RecoverPendingOrder(ctx context.Context){
-	// 定时调用函数,检测发送失败而未修改状态的消息
-	ticker := time.NewTicker(3 * time.Minute)
+// CancelExpiredOrders 取消过期未支付的订单，并归还库存
+func (s *OrderServiceImpl) CancelExpiredOrders(ctx context.Context){
+	interval := time.Duration(s.orderConfig.ScanInterval) * time.Second
+	if interval <= 0{
+		interval = 30 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
 		select{
 		case <- ticker.C:
-			log.Printf("开始恢复处于Pending状态的订单")
-
-			// 获取所有处于Pending状态的订单
-			orders, err := s.orderData.GetPendingOrders(ctx)
-			if err != nil{
-				log.Printf("获取处于Pending状态的订单失败：%v", err)
-				continue
-			}
-
-			for _, order := range orders{
-				msg := &mq.OrderMessage{
-                    OrderSn:     order.OrderSn,
-                    UserID:      order.UserID,
-                    ActivityID:  order.ActivityID,
-                    ProductID:   order.ProductID,
-                    Amount:      order.Amount,
-                    Price:       order.Price,
-                    Quantity:    order.Quantity,
-                }
-
-				err = s.orderProducer.Produce(msg)
-				if err != nil{
-					log.Printf("重发订单消息失败：%v, 订单号：%v", err, order.OrderSn)
-				}else{
-					log.Printf("重发订单消息成功,订单号：%v", order.OrderSn)
-				}
-			}
+			// 先确认支付中的订单，没有支付成功的订单恢复为Created后按过期时间取消
+			s.recoverPayments(ctx)
+			s.cancelExpiredOrders(ctx)
+			s.retryStockReturns(ctx)
 		case <- ctx.Done():
-			log.Printf("恢复处于Pending状态的订单任务停止")
+			log.Printf("取消过期订单任务停止")
 			return
 		}
 	}
 }
 
+// cancelExpiredOrders 取消一批过期未支付的订单
+func (s *OrderServiceImpl) cancelExpiredOrders(ctx context.Context){
+	orders, err := s.orderData.GetExpiredOrders(ctx, time.Now(), 100)
+	if err != nil{
+		log.Printf("获取过期订单失败：%v", err)
+		return
+	}
+
+	for _, o := range orders{
+		_, err = s.cancelOrder(ctx, o.OrderSn, []int{models.StatusPending, models.StatusCreated})
+		if err != nil{
+			// 订单可能在扫描后被支付，下一轮扫描时会被过滤掉
+			log.Printf("取消过期订单失败：%v, 订单号：%v", err, o.OrderSn)
+		}else{
+			log.Printf("过期订单已取消,订单号：%v", o.OrderSn)
+		}
+	}
+}
+
+// cancelOrder 取消订单并归还库存
+// 先提交取消订单的事务再归还库存，归还失败时订单保留待归还标记，由后台任务重试
+// 归还库存按订单号幂等，重复归还不会多加库存
+func (s *OrderServiceImpl) cancelOrder(ctx context.Context, orderSn string, allowedStatus []int) (*models.Order, error){
+	o, err := s.orderData.CancelOrder(ctx, orderSn, allowedStatus)
+	if err != nil{
+		return nil, err
+	}
+
+	err = s.releaseStock(ctx, o)
+	if err != nil{
+		log.Printf("订单%v已取消，%v，将由后台任务重试", o.OrderSn, err)
+	}
+
+	return o, nil
+}
+
+// releaseStock 归还已取消订单的库存并清除待归还标记
+func (s *OrderServiceImpl) releaseStock(ctx context.Context, o *models.Order) error{
+	err := s.returnStock(ctx, o.ActivityID, o.UserID, o.Quantity, o.OrderSn)
+	if err != nil{
+		return err
+	}
+
+	return s.orderData.MarkStockReturned(ctx, o.OrderSn)
+}
+
+// retryStockReturns 重试归还已取消订单的库存
+func (s *OrderServiceImpl) retryStockReturns(ctx context.Context){
+	orders, err := s.orderData.GetPendingStockReturns(ctx, time.Now().Add(-stockReturnRetryAfter), 100)
+	if err != nil{
+		log.Printf("获取待归还库存的订单失败：%v", err)
+		return
+	}
+
+	for _, o := range orders{
+		err = s.releaseStock(ctx, o)
+		if err != nil{
+			log.Printf("重试归还库存失败：%v, 订单号：%v", err, o.OrderSn)
+			continue
+		}
+
+		log.Printf("已取消订单的库存已归还,订单号：%v", o.OrderSn)
+	}
+}
+
 // GetOrder 获取订单信息
 func (s *OrderServiceImpl) GetOrder(ctx context.Context, req *order.GetOrderRequest) (resp *order.GetOrderResponse, err error) {
 	response := &order.GetOrderResponse{
@@ -246,41 +291,7 @@ func (s *OrderServiceImpl) GetOrder(ctx context.Context, req *order.GetOrderRequ
 		return response, nil
 	}
 
-	// 将orderStatus int转化为	api响应中的 enum
-	var orderStatus order.OrderStatus
-
-	switch localOrder.Status {
-	case models.StatusPending:
-		orderStatus = order.OrderStatus_PENDING
-	case models.StatusCreated:
-		orderStatus = order.OrderStatus_CREATED
-	case models.StatusPaid:
-		orderStatus = order.OrderStatus_PAID
-	case models.StatusFailed:
-		orderStatus = order.OrderStatus_FAILED
-	case models.StatusCancelled:
-		orderStatus = order.OrderStatus_CANCELLED
-	default:
-		orderStatus = order.OrderStatus_PENDING
-	}
-
-	orderInfo := &order.OrderInfo{
-		Id:				int64(localOrder.ID),
-		OrderSn:		localOrder.OrderSn,
-		UserID:			int64(localOrder.UserID),
-		ActivityID:		int64(localOrder.ActivityID),
-		ProductID:		int64(localOrder.ProductID),
-		Amount:			localOrder.Amount,
-		Status:			orderStatus,
-		CreateTime:		localOrder.CreatedAt.Unix(),
-	}
-
-	// 如果预加载成功
-	if localOrder.Product.ID >0{
-		orderInfo.ProductName = localOrder.Product.Name
-	}
-
-	response.OrderInfo = orderInfo
+	response.OrderInfo = buildOrderInfo(localOrder)
 	response.BaseResponse.Code = 0
 	response.BaseResponse.Msg  = "查询订单信息成功"
 	
@@ -302,13 +313,45 @@ func (s *OrderServiceImpl) ListOrders(ctx context.Context, req *order.ListOrders
 	}
 
 	// 查询订单列表
-	status := -1 // 默认为-1, 查询所有订单
+	filter := &data.OrderFilter{
+		Status:	-1, // 默认为-1, 查询所有订单
+		Asc:	req.Asc,
+	}
 	if req.Status != order.OrderStatus(-1){
-		status = int(req.Status)
+		filter.Status = int(req.Status)
+	}
+	if req.TimeFrom != nil{
+		from := time.Unix(*req.TimeFrom, 0)
+		filter.TimeFrom = &from
+	}
+	if req.TimeTo != nil{
+		to := time.Unix(*req.TimeTo, 0)
+		filter.TimeTo = &to
+	}
+	if filter.TimeFrom != nil && filter.TimeTo != nil && !filter.TimeFrom.Before(*filter.TimeTo){
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "时间范围的起点需要早于终点"
+
+		return response, nil
+	}
+
+	cursor, err := pagination.Decode(req.Cursor, filter.SortKey())
+	if err != nil{
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = err.Error()
+
+		return response, nil
 	}
 
-	orders, total, err := s.orderData.ListByUserID(ctx, uint(req.UserID), status)
+	orders, total, next, err := s.orderData.ListByUserID(ctx, uint(req.UserID), filter, cursor, pagination.PageSize(req.PageSize))
 	if err != nil{
+		if errors.Is(err, pagination.ErrInvalidCursor){
+			response.BaseResponse.Code = 400
+			response.BaseResponse.Msg  = err.Error()
+
+			return response, nil
+		}
+
 		response.BaseResponse.Code = 500
 		response.BaseResponse.Msg  = "查询订单列表失败：" + err.Error()
 
@@ -317,45 +360,319 @@ func (s *OrderServiceImpl) ListOrders(ctx context.Context, req *order.ListOrders
 
 	// 构建返回的订单列表
 	for _, o := range orders{
-		// 转化orderStatus
-		var orderStatus order.OrderStatus
-
-		switch o.Status {
-		case models.StatusPending:
-			orderStatus = order.OrderStatus_PENDING
-		case models.StatusCreated:
-			orderStatus = order.OrderStatus_CREATED
-		case models.StatusPaid:
-			orderStatus = order.OrderStatus_PAID
-		case models.StatusFailed:
-			orderStatus = order.OrderStatus_FAILED
-		case models.StatusCancelled:
-			orderStatus = order.OrderStatus_CANCELLED
-		default:
-			orderStatus = order.OrderStatus_PENDING
+		response.Orders = append(response.Orders, buildOrderInfo(o))
+	}
+	
+	if next != nil{
+		response.NextCursor = next.Encode()
+		response.HasMore = true
+	}
+
+	response.Total = total
+	response.BaseResponse.Code = 0
+	response.BaseResponse.Msg  = "查询用户列表订单成功"
+
+	return response, nil
+}
+
+// PayOrder 支付订单
+// 先将Created订单预占为Paying再调用支付渠道，同一订单只有一个请求会扣款，支付中的订单不会被取消或过期
+// 已支付的订单重复支付时直接返回成功，保证支付回调重试的幂等
+func (s *OrderServiceImpl) PayOrder(ctx context.Context, req *order.PayOrderRequest) (resp *order.PayOrderResponse, err error) {
+	response := &order.PayOrderResponse{
+		BaseResponse: &order.BaseResponse{},
+	}
+
+	if req.UserID <= 0 || req.OrderSn == ""{
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "输入参数错误"
+
+		return response, nil
+	}
+
+	// 查询订单的同时校验订单是否属于该用户
+	localOrder, err := s.orderData.GetByUserIDAndOrderSn(ctx, uint(req.UserID), req.OrderSn)
+	if err != nil{
+		response.BaseResponse.Code = 404
+		response.BaseResponse.Msg  = "查询订单信息失败：" + err.Error()
+
+		return response, nil
+	}
+
+	switch localOrder.Status{
+	case models.StatusPaid:
+		// 重复的支付回调，直接返回已支付的订单
+		response.OrderInfo = buildOrderInfo(localOrder)
+		response.BaseResponse.Code = 0
+		response.BaseResponse.Msg  = "订单已支付"
+
+		return response, nil
+	case models.StatusPaying:
+		response.OrderInfo = buildOrderInfo(localOrder)
+		response.BaseResponse.Code = 409
+		response.BaseResponse.Msg  = "订单正在支付中，请稍后查询支付结果"
+
+		return response, nil
+	case models.StatusCreated:
+		// 已过期但还未被扫描取消的订单
+		if !localOrder.ExpireTime.IsZero() && time.Now().After(localOrder.ExpireTime){
+			response.BaseResponse.Code = 400
+			response.BaseResponse.Msg  = "订单已过期"
+
+			return response, nil
 		}
+	case models.StatusPending:
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "订单正在创建中，请稍后再试"
+
+		return response, nil
+	default:
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "订单状态不允许支付"
+
+		return response, nil
+	}
 
-		orderInfo := &order.OrderInfo{
-			Id: 			int64(o.ID),
-			OrderSn: 		o.OrderSn,
-			UserID: 		int64(o.UserID),
-			ActivityID: 	int64(o.ActivityID),
-			ProductID: 		int64(o.ProductID),
-			Amount: 		o.Amount,
-			Status: 		orderStatus,
-			CreateTime: 	o.CreatedAt.Unix(),
+	// 扣款前预占订单，并发的支付请求和取消请求只有一个能成功
+	reserved, err := s.orderData.ReservePayment(ctx, localOrder.OrderSn, time.Now())
+	if err != nil{
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "预占订单失败：" + err.Error()
+
+		return response, nil
+	}
+	if !reserved{
+		return s.payConflict(ctx, req, response), nil
+	}
+
+	// 调用支付渠道，超时时间小于支付结果的确认时间，避免确认时扣款仍在进行
+	payCtx, cancel := context.WithTimeout(ctx, paymentTimeout)
+	tradeNo, err := s.payProvider.Pay(payCtx, localOrder.OrderSn, localOrder.Amount)
+	cancel()
+	if err != nil{
+		// 扣款可能已经成功但没有返回结果，以支付渠道的交易记录为准
+		_, settleErr := s.settlePayment(context.Background(), localOrder)
+		if settleErr != nil{
+			log.Printf("确认订单%v的支付结果失败：%v，将由后台任务重试", localOrder.OrderSn, settleErr)
 		}
 
-		if o.Product.ID > 0{
-			orderInfo.ProductName = o.Product.Name
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "支付失败：" + err.Error()
+
+		return response, nil
+	}
+
+	paid, err := s.orderData.MarkPaid(ctx, localOrder.OrderSn, tradeNo, time.Now())
+	if err != nil{
+		// 订单仍处于支付中，后台任务会根据支付渠道的交易记录更新状态
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "更新订单支付状态失败，支付结果确认中：" + err.Error()
+
+		return response, nil
+	}
+	if !paid{
+		// 支付中的订单已被后台任务恢复并取消，退回这次扣款
+		err = s.payProvider.Refund(context.Background(), localOrder.OrderSn, tradeNo)
+		if err != nil{
+			log.Printf("订单%v状态已变更，退款失败：%v，交易流水号：%v", localOrder.OrderSn, err, tradeNo)
 		}
 
-		response.Orders = append(response.Orders, orderInfo)
+		return s.payConflict(ctx, req, response), nil
 	}
-	
-	response.Total = total
+
+	// 重新查询订单，以数据库中的最终状态为准
+	localOrder, err = s.orderData.GetByUserIDAndOrderSn(ctx, uint(req.UserID), req.OrderSn)
+	if err != nil{
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "查询订单信息失败：" + err.Error()
+
+		return response, nil
+	}
+
+	response.OrderInfo = buildOrderInfo(localOrder)
 	response.BaseResponse.Code = 0
-	response.BaseResponse.Msg  = "查询用户列表订单成功"
+	response.BaseResponse.Msg  = "支付成功"
+
+	return response, nil
+}
+
+// payConflict 支付过程中订单被并发修改时，按订单的最新状态返回
+func (s *OrderServiceImpl) payConflict(ctx context.Context, req *order.PayOrderRequest, response *order.PayOrderResponse) *order.PayOrderResponse{
+	localOrder, err := s.orderData.GetByUserIDAndOrderSn(ctx, uint(req.UserID), req.OrderSn)
+	if err != nil{
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "查询订单信息失败：" + err.Error()
+
+		return response
+	}
+
+	response.OrderInfo = buildOrderInfo(localOrder)
+
+	switch localOrder.Status{
+	case models.StatusPaid:
+		response.BaseResponse.Code = 0
+		response.BaseResponse.Msg  = "订单已支付"
+	case models.StatusPaying:
+		response.BaseResponse.Code = 409
+		response.BaseResponse.Msg  = "订单正在支付中，请稍后查询支付结果"
+	default:
+		response.BaseResponse.Code = 409
+		response.BaseResponse.Msg  = "订单状态已变更，支付失败"
+	}
+
+	return response
+}
+
+// settlePayment 根据支付渠道的交易记录确认支付中订单的结果
+// 有交易记录时标记为已支付，没有时恢复为Created，返回订单是否已支付
+func (s *OrderServiceImpl) settlePayment(ctx context.Context, o *models.Order) (bool, error){
+	tradeNo, err := s.payProvider.Query(ctx, o.OrderSn)
+	if errors.Is(err, payment.ErrTradeNotFound){
+		_, err = s.orderData.ReleasePayment(ctx, o.OrderSn)
+
+		return false, err
+	}
+	if err != nil{
+		return false, err
+	}
+
+	_, err = s.orderData.MarkPaid(ctx, o.OrderSn, tradeNo, time.Now())
+	if err != nil{
+		return false, err
+	}
+
+	return true, nil
+}
+
+// recoverPayments 确认长时间处于支付中的订单(例如扣款后服务重启)
+func (s *OrderServiceImpl) recoverPayments(ctx context.Context){
+	orders, err := s.orderData.GetStalePayments(ctx, time.Now().Add(-paymentRecoverAfter), 100)
+	if err != nil{
+		log.Printf("获取支付中的订单失败：%v", err)
+		return
+	}
+
+	for _, o := range orders{
+		paid, err := s.settlePayment(ctx, o)
+		if err != nil{
+			log.Printf("确认订单支付结果失败：%v, 订单号：%v", err, o.OrderSn)
+			continue
+		}
+
+		log.Printf("订单%v的支付结果已确认，是否已支付：%v", o.OrderSn, paid)
+	}
+}
+
+// CancelOrder 用户取消订单
+// 只有Pending和Created状态的订单可以取消，取消时会归还已扣除的库存
+func (s *OrderServiceImpl) CancelOrder(ctx context.Context, req *order.CancelOrderRequest) (resp *order.CancelOrderResponse, err error) {
+	response := &order.CancelOrderResponse{
+		BaseResponse: &order.BaseResponse{},
+	}
+
+	if req.UserID <= 0 || req.OrderSn == ""{
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "输入参数错误"
+
+		return response, nil
+	}
+
+	// 校验订单是否属于该用户
+	localOrder, err := s.orderData.GetByUserIDAndOrderSn(ctx, uint(req.UserID), req.OrderSn)
+	if err != nil{
+		response.BaseResponse.Code = 404
+		response.BaseResponse.Msg  = "查询订单信息失败：" + err.Error()
+
+		return response, nil
+	}
+
+	// 重复取消直接返回成功
+	if localOrder.Status == models.StatusCancelled{
+		response.OrderInfo = buildOrderInfo(localOrder)
+		response.BaseResponse.Code = 0
+		response.BaseResponse.Msg  = "订单已取消"
+
+		return response, nil
+	}
+
+	_, err = s.cancelOrder(ctx, req.OrderSn, []int{models.StatusPending, models.StatusCreated})
+	if err != nil{
+		if errors.Is(err, data.ErrOrderStatusNotAllowed){
+			response.BaseResponse.Code = 400
+			response.BaseResponse.Msg  = "订单当前状态不允许取消"
+
+			return response, nil
+		}
+
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "取消订单失败：" + err.Error()
+
+		return response, nil
+	}
+
+	// 重新查询订单以返回最新状态(包含预加载的商品信息)
+	localOrder, err = s.orderData.GetByUserIDAndOrderSn(ctx, uint(req.UserID), req.OrderSn)
+	if err != nil{
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "查询订单信息失败：" + err.Error()
+
+		return response, nil
+	}
+
+	response.OrderInfo = buildOrderInfo(localOrder)
+	response.BaseResponse.Code = 0
+	response.BaseResponse.Msg  = "取消订单成功"
 
 	return response, nil
 }
+
+// convertOrderStatus 将orderStatus int转化为api响应中的 enum
+func convertOrderStatus(status int) order.OrderStatus{
+	switch status {
+	case models.StatusPending:
+		return order.OrderStatus_PENDING
+	case models.StatusCreated:
+		return order.OrderStatus_CREATED
+	case models.StatusPaid:
+		return order.OrderStatus_PAID
+	case models.StatusFailed:
+		return order.OrderStatus_FAILED
+	case models.StatusCancelled:
+		return order.OrderStatus_CANCELLED
+	case models.StatusPaying:
+		return order.OrderStatus_PAYING
+	default:
+		return order.OrderStatus_PENDING
+	}
+}
+
+// buildOrderInfo 构建返回的订单信息
+func buildOrderInfo(o *models.Order) *order.OrderInfo{
+	orderInfo := &order.OrderInfo{
+		Id:				int64(o.ID),
+		OrderSn:		o.OrderSn,
+		UserID:			int64(o.UserID),
+		ActivityID:		int64(o.ActivityID),
+		ProductID:		int64(o.ProductID),
+		Amount:			o.Amount,
+		Status:			convertOrderStatus(o.Status),
+		CreateTime:		o.CreatedAt.Unix(),
+		Quantity:		int32(o.Quantity),
+		Price:			o.Price,
+	}
+
+	if o.PayTime != nil{
+		orderInfo.PayTime = o.PayTime.Unix()
+	}
+
+	if !o.ExpireTime.IsZero(){
+		orderInfo.ExpireTime = o.ExpireTime.Unix()
+	}
+
+	// 如果预加载成功
+	if o.Product.ID > 0{
+		orderInfo.ProductName = o.Product.Name
+	}
+
+	return orderInfo
+}
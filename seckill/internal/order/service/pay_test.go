@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"Redrock/seckill/internal/pkg/database"
+	"Redrock/seckill/internal/pkg/models"
+	order "Redrock/seckill/kitex_gen/order"
+)
+
+func payRequest(orderSn string) *order.PayOrderRequest{
+	return &order.PayOrderRequest{UserID: 1, OrderSn: orderSn}
+}
+
+// 并发支付同一订单时只扣款一次
+func TestPayOrderConcurrent(t *testing.T){
+	s, _, provider := newTestService(t)
+	createTestOrder(t, s, "pay-concurrent", models.StatusCreated)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++{
+		wg.Add(1)
+		go func(){
+			defer wg.Done()
+
+			resp, err := s.PayOrder(context.Background(), payRequest("pay-concurrent"))
+			if err != nil{
+				t.Errorf("支付返回错误：%v", err)
+				return
+			}
+
+			code := resp.BaseResponse.Code
+			if code != 0 && code != 409{
+				t.Errorf("并发支付期待返回0或409，但实际得到 %d：%s", code, resp.BaseResponse.Msg)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if charges, _ := provider.counts(); charges != 1{
+		t.Errorf("期待扣款1次，但实际扣款 %d 次", charges)
+	}
+
+	if status := orderStatus(t, s, "pay-concurrent"); status != models.StatusPaid{
+		t.Errorf("期待订单已支付，但实际状态为 %d", status)
+	}
+
+	// 重复支付直接返回成功
+	resp, _ := s.PayOrder(context.Background(), payRequest("pay-concurrent"))
+	if resp.BaseResponse.Code != 0{
+		t.Errorf("重复支付期待返回0，但实际得到 %d", resp.BaseResponse.Code)
+	}
+}
+
+// 扣款过程中取消订单会失败，订单最终为已支付
+func TestPayOrderThenCancel(t *testing.T){
+	s, internal, provider := newTestService(t)
+	createTestOrder(t, s, "pay-then-cancel", models.StatusCreated)
+
+	charging := make(chan struct{})
+	release := make(chan struct{})
+	provider.payHook = func(){
+		close(charging)
+		<-release
+	}
+
+	done := make(chan *order.PayOrderResponse)
+	go func(){
+		resp, _ := s.PayOrder(context.Background(), payRequest("pay-then-cancel"))
+		done <- resp
+	}()
+
+	<-charging
+	cancelResp, _ := s.CancelOrder(context.Background(), &order.CancelOrderRequest{UserID: 1, OrderSn: "pay-then-cancel"})
+	if cancelResp.BaseResponse.Code != 400{
+		t.Errorf("支付中取消订单期待返回400，但实际得到 %d：%s", cancelResp.BaseResponse.Code, cancelResp.BaseResponse.Msg)
+	}
+	close(release)
+
+	payResp := <-done
+	if payResp.BaseResponse.Code != 0{
+		t.Errorf("期待支付成功，但实际得到 %d：%s", payResp.BaseResponse.Code, payResp.BaseResponse.Msg)
+	}
+
+	if status := orderStatus(t, s, "pay-then-cancel"); status != models.StatusPaid{
+		t.Errorf("期待订单已支付，但实际状态为 %d", status)
+	}
+
+	if returned := internal.returnedOrders(); len(returned) != 0{
+		t.Errorf("已支付的订单不应归还库存，但实际归还了 %v", returned)
+	}
+}
+
+// 订单取消后支付不会扣款
+func TestCancelOrderThenPay(t *testing.T){
+	s, _, provider := newTestService(t)
+	createTestOrder(t, s, "cancel-then-pay", models.StatusCreated)
+
+	cancelResp, _ := s.CancelOrder(context.Background(), &order.CancelOrderRequest{UserID: 1, OrderSn: "cancel-then-pay"})
+	if cancelResp.BaseResponse.Code != 0{
+		t.Fatalf("取消订单失败：%s", cancelResp.BaseResponse.Msg)
+	}
+
+	payResp, _ := s.PayOrder(context.Background(), payRequest("cancel-then-pay"))
+	if payResp.BaseResponse.Code != 400{
+		t.Errorf("支付已取消的订单期待返回400，但实际得到 %d：%s", payResp.BaseResponse.Code, payResp.BaseResponse.Msg)
+	}
+
+	if charges, _ := provider.counts(); charges != 0{
+		t.Errorf("已取消的订单不应扣款，但实际扣款 %d 次", charges)
+	}
+}
+
+// 订单在查询和预占之间被取消时返回409且不扣款
+func TestPayOrderReserveConflict(t *testing.T){
+	s, _, provider := newTestService(t)
+	createTestOrder(t, s, "reserve-conflict", models.StatusCreated)
+
+	// 模拟查询之后订单被取消：预占时订单已经不是Created
+	if _, err := s.cancelOrder(context.Background(), "reserve-conflict", []int{models.StatusCreated}); err != nil{
+		t.Fatalf("取消订单失败：%v", err)
+	}
+	reserved, err := s.orderData.ReservePayment(context.Background(), "reserve-conflict", time.Now())
+	if err != nil || reserved{
+		t.Fatalf("已取消的订单期待预占失败，但实际得到 %v, %v", reserved, err)
+	}
+
+	resp := s.payConflict(context.Background(), payRequest("reserve-conflict"), &order.PayOrderResponse{BaseResponse: &order.BaseResponse{}})
+	if resp.BaseResponse.Code != 409{
+		t.Errorf("期待返回409，但实际得到 %d", resp.BaseResponse.Code)
+	}
+
+	if charges, _ := provider.counts(); charges != 0{
+		t.Errorf("期待不扣款，但实际扣款 %d 次", charges)
+	}
+}
+
+// 扣款失败时订单恢复为Created，可以重新支付
+func TestPayOrderFailureReleases(t *testing.T){
+	s, _, provider := newTestService(t)
+	createTestOrder(t, s, "pay-fail", models.StatusCreated)
+
+	provider.payErr = errTest
+	resp, _ := s.PayOrder(context.Background(), payRequest("pay-fail"))
+	if resp.BaseResponse.Code != 500{
+		t.Errorf("扣款失败期待返回500，但实际得到 %d", resp.BaseResponse.Code)
+	}
+
+	if status := orderStatus(t, s, "pay-fail"); status != models.StatusCreated{
+		t.Fatalf("扣款失败后期待订单恢复为Created，但实际状态为 %d", status)
+	}
+
+	provider.payErr = nil
+	resp, _ = s.PayOrder(context.Background(), payRequest("pay-fail"))
+	if resp.BaseResponse.Code != 0{
+		t.Errorf("重新支付期待成功，但实际得到 %d：%s", resp.BaseResponse.Code, resp.BaseResponse.Msg)
+	}
+}
+
+// 扣款期间订单被后台任务恢复并取消时退款
+func TestPayOrderRefundsWhenStateChanged(t *testing.T){
+	s, _, provider := newTestService(t)
+	createTestOrder(t, s, "pay-refund", models.StatusCreated)
+
+	provider.payHook = func(){
+		if _, err := s.orderData.ReleasePayment(context.Background(), "pay-refund"); err != nil{
+			t.Errorf("恢复订单失败：%v", err)
+		}
+		if _, err := s.cancelOrder(context.Background(), "pay-refund", []int{models.StatusCreated}); err != nil{
+			t.Errorf("取消订单失败：%v", err)
+		}
+	}
+
+	resp, _ := s.PayOrder(context.Background(), payRequest("pay-refund"))
+	if resp.BaseResponse.Code != 409{
+		t.Errorf("期待返回409，但实际得到 %d：%s", resp.BaseResponse.Code, resp.BaseResponse.Msg)
+	}
+
+	if charges, refunds := provider.counts(); charges != 1 || refunds != 1{
+		t.Errorf("期待扣款1次并退款1次，但实际扣款 %d 次，退款 %d 次", charges, refunds)
+	}
+
+	if status := orderStatus(t, s, "pay-refund"); status != models.StatusCancelled{
+		t.Errorf("期待订单已取消，但实际状态为 %d", status)
+	}
+}
+
+// 后台任务根据支付渠道的交易记录确认长时间处于支付中的订单
+func TestRecoverPayments(t *testing.T){
+	s, _, provider := newTestService(t)
+	createTestOrder(t, s, "recover-paid", models.StatusPaying)
+	createTestOrder(t, s, "recover-unpaid", models.StatusPaying)
+
+	if _, err := provider.MockProvider.Pay(context.Background(), "recover-paid", 9.9); err != nil{
+		t.Fatalf("扣款失败：%v", err)
+	}
+
+	// 刚进入支付中的订单不会被确认
+	s.recoverPayments(context.Background())
+	if status := orderStatus(t, s, "recover-unpaid"); status != models.StatusPaying{
+		t.Fatalf("期待订单仍在支付中，但实际状态为 %d", status)
+	}
+
+	past := time.Now().Add(-2 * paymentRecoverAfter)
+	err := database.GetDB().Model(&models.Order{}).Where("status = ?", models.StatusPaying).UpdateColumn("updated_at", past).Error
+	if err != nil{
+		t.Fatalf("修改订单更新时间失败：%v", err)
+	}
+
+	s.recoverPayments(context.Background())
+
+	if status := orderStatus(t, s, "recover-paid"); status != models.StatusPaid{
+		t.Errorf("已扣款的订单期待为已支付，但实际状态为 %d", status)
+	}
+	if status := orderStatus(t, s, "recover-unpaid"); status != models.StatusCreated{
+		t.Errorf("未扣款的订单期待恢复为Created，但实际状态为 %d", status)
+	}
+}
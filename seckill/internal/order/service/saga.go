@@ -0,0 +1,280 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"Redrock/seckill/internal/order/data"
+	"Redrock/seckill/internal/order/mq"
+	"Redrock/seckill/internal/pkg/models"
+	"Redrock/seckill/internal/pkg/saga"
+	"Redrock/seckill/kitex_gen/activity"
+)
+
+// responseError 携带响应码的错误，用于将下游服务的业务错误透传给调用方
+type responseError struct{
+	code	int32
+	msg		string
+}
+
+func (e *responseError) Error() string{
+	return e.msg
+}
+
+// errSagaNotStarted saga记录创建失败，没有执行任何步骤
+var errSagaNotStarted = errors.New("创建下单saga失败")
+
+// createOrderState 下单saga各步骤之间共享的数据
+type createOrderState struct{
+	orderSn		string
+	userID		uint
+	activityID	uint
+	quantity	int
+	activity	*activity.ActivityInfo	// 第2步获取的活动信息
+	order		*models.Order			// 第3步创建的订单
+}
+
+// newCreateOrderSaga 创建下单saga
+// 步骤：1. 扣除库存 2. 获取活动信息 3. 创建订单
+// 任一步骤失败时会归还库存并清除用户参与记录
+func (s *OrderServiceImpl) newCreateOrderSaga(state *createOrderState) *saga.Saga{
+	return saga.New(state.orderSn, s.sagaData,
+		saga.Step{
+			Name:		"扣除库存",
+			Action:		func(ctx context.Context) error{
+				deductResquest := &activity.DeductStockRequest{
+					ActivityID:		int64(state.activityID),
+					UserID:			int64(state.userID),
+					Count:			int64(state.quantity),
+					OrderSn:		state.orderSn,
+				}
+
+				deductResponse, err := s.internalClient.DeductStock(ctx, deductResquest)
+				if err != nil{
+					return err
+				}
+
+				// 扣除库存被拒绝时(如库存不足)没有扣除任何库存，不需要补偿
+				if deductResponse.BaseResponse.Code != 0 || !deductResponse.Success{
+					return saga.Abort(&responseError{
+						code:	deductResponse.BaseResponse.Code,
+						msg:	deductResponse.BaseResponse.Msg,
+					})
+				}
+
+				return nil
+			},
+			// 归还库存的同时会清除用户参与记录
+			Compensate:	func(ctx context.Context) error{
+				return s.returnStock(ctx, state.activityID, state.userID, state.quantity, state.orderSn)
+			},
+		},
+		saga.Step{
+			Name:		"获取活动信息",
+			Action:		func(ctx context.Context) error{
+				activityRequest := &activity.GetActivityRequest{
+					ActivityID:			int64(state.activityID),
+				}
+
+				activityResponse, err := s.activityClient.GetActivity(ctx, activityRequest)
+				if err != nil{
+					return err
+				}
+
+				// 如果调用成功但未成功获取，如果活动已结束等
+				if activityResponse.BaseResponse.Code != 0{
+					return &responseError{
+						code:	activityResponse.BaseResponse.Code,
+						msg:	activityResponse.BaseResponse.Msg,
+					}
+				}
+
+				state.activity = activityResponse.Activity
+
+				return nil
+			},
+		},
+		saga.Step{
+			Name:		"创建订单",
+			Action:		func(ctx context.Context) error{
+				now := time.Now()
+				state.order = &models.Order{
+					OrderSn:			state.orderSn,
+					UserID:				state.userID,
+					ActivityID:			state.activityID,
+					ProductID:			uint(state.activity.ProductId),
+					Amount:				orderAmount(state.activity.SeckillPrice, state.quantity),
+					Status:				models.StatusPending,
+					CreateTime: 		now,
+					ExpireTime: 		now.Add(time.Duration(s.orderConfig.ExpireTime) * time.Second),
+					Price:				state.activity.SeckillPrice,
+					Quantity:			state.quantity,
+				}
+
+				msg := &mq.OrderMessage{
+					OrderSn:			state.order.OrderSn,
+					UserID:				state.order.UserID,
+					ActivityID:			state.order.ActivityID,
+					ProductID:			state.order.ProductID,
+					Amount:				state.order.Amount,
+					Price:				state.order.Price,
+					Quantity:			state.order.Quantity,
+				}
+
+				payload, err := mq.EncodeOrderMessage(msg)
+				if err != nil{
+					return err
+				}
+
+				// 订单和订单消息在同一个事务中写入，由发件箱relay保证消息至少发送一次
+				return s.orderData.CreateWithOutbox(ctx, state.order, payload)
+			},
+			// 写入数据库可能已成功但返回了错误，此时将订单标记为失败
+			Compensate:	func(ctx context.Context) error{
+				return s.orderData.MarkFailed(ctx, state.orderSn)
+			},
+		},
+	)
+}
+
+// orderAmount 订单金额为秒杀价格乘以购买数量，按分四舍五入，和数据库中保存的精度一致
+func orderAmount(price float64, quantity int) float64{
+	return math.Round(price * float64(quantity) * 100) / 100
+}
+
+// placeOrder 记录并执行下单saga，返回创建的订单
+// 1. 扣除库存 2. 获取活动详情 3. 创建订单并写入数据库，任一步骤失败时都会归还库存
+func (s *OrderServiceImpl) placeOrder(ctx context.Context, orderSn string, userID uint, activityID uint, quantity int) (*models.Order, error){
+	// 已注销的用户不再下单，无法确认时不开始下单
+	closed, err := s.isUserClosed(ctx, userID)
+	if err != nil{
+		return nil, fmt.Errorf("%w：检查用户是否已注销失败：%w", errSagaNotStarted, err)
+	}
+	if closed{
+		return nil, errUserClosed
+	}
+
+	// 记录saga，服务崩溃后可以根据记录恢复
+	orderSaga := &models.OrderSaga{
+		OrderSn:			orderSn,
+		UserID:				userID,
+		ActivityID:			activityID,
+		Quantity:			quantity,
+		Status:				saga.StatusRunning,
+	}
+
+	err = s.sagaData.Create(ctx, orderSaga)
+	if err != nil{
+		return nil, fmt.Errorf("%w：%w", errSagaNotStarted, err)
+	}
+
+	state := &createOrderState{
+		orderSn:			orderSn,
+		userID:				userID,
+		activityID:			activityID,
+		quantity:			quantity,
+	}
+
+	err = s.newCreateOrderSaga(state).Execute(ctx)
+	if err != nil{
+		return nil, err
+	}
+
+	// 订单消息已和订单一起写入发件箱，通知relay立即发布
+	s.outboxRelay.Notify()
+
+	if s.cancelIfUserClosed(ctx, orderSn, userID){
+		return nil, errUserClosed
+	}
+
+	return state.order, nil
+}
+
+// returnStock 调用活动服务归还订单扣除的库存
+func (s *OrderServiceImpl) returnStock(ctx context.Context, activityID uint, userID uint, quantity int, orderSn string) error{
+	returnRequest := &activity.ReturnStockRequest{
+		ActivityID:		int64(activityID),
+		UserID:			int64(userID),
+		Count:			int64(quantity),
+		OrderSn:		orderSn,
+	}
+
+	returnResponse, err := s.internalClient.ReturnStock(ctx, returnRequest)
+	if err != nil{
+		return fmt.Errorf("归还库存失败：%w", err)
+	}
+
+	if returnResponse.BaseResponse.Code != 0 || !returnResponse.Success{
+		return fmt.Errorf("归还库存失败：%s", returnResponse.BaseResponse.Msg)
+	}
+
+	return nil
+}
+
+// RecoverSagas 恢复因服务崩溃而中断的下单saga
+// 启动时立即执行一次，之后定时执行
+func (s *OrderServiceImpl) RecoverSagas(ctx context.Context){
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		s.recoverSagas(ctx)
+
+		select{
+		case <- ticker.C:
+		case <- ctx.Done():
+			log.Printf("恢复下单saga任务停止")
+			return
+		}
+	}
+}
+
+func (s *OrderServiceImpl) recoverSagas(ctx context.Context){
+	// 只处理一段时间内没有更新的saga，避免和正在执行的下单流程冲突
+	sagas, err := s.sagaData.GetUnfinished(ctx, time.Now().Add(-time.Minute), 100)
+	if err != nil{
+		log.Printf("获取未完成的下单saga失败：%v", err)
+		return
+	}
+
+	for _, st := range sagas{
+		state := &createOrderState{
+			orderSn:	st.OrderSn,
+			userID:		st.UserID,
+			activityID:	st.ActivityID,
+			quantity:	st.Quantity,
+		}
+		orderSaga := s.newCreateOrderSaga(state)
+
+		// 订单已写入数据库说明所有步骤都已完成，只是没有保存最终进度
+		if st.Status == saga.StatusRunning{
+			_, err := s.orderData.GetByOrderSn(ctx, st.OrderSn)
+			if err == nil{
+				err = orderSaga.Recover(ctx, len(orderSaga.Steps), saga.StatusRunning)
+				if err != nil{
+					log.Printf("更新下单saga状态失败：%v, 订单号：%v", err, st.OrderSn)
+				}
+
+				// 中断前可能还没有检查用户是否已注销
+				s.cancelIfUserClosed(ctx, st.OrderSn, st.UserID)
+				continue
+			}
+
+			if !errors.Is(err, data.ErrOrderNotFound){
+				log.Printf("查询订单失败：%v, 订单号：%v", err, st.OrderSn)
+				continue
+			}
+		}
+
+		err = orderSaga.Recover(ctx, st.Step, st.Status)
+		if err != nil{
+			log.Printf("恢复下单saga失败：%v, 订单号：%v", err, st.OrderSn)
+		}else{
+			log.Printf("下单saga已恢复,订单号：%v", st.OrderSn)
+		}
+	}
+}
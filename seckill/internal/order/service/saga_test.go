@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"Redrock/seckill/internal/order/data"
+	"Redrock/seckill/internal/pkg/database"
+	"Redrock/seckill/internal/pkg/models"
+	"Redrock/seckill/internal/pkg/saga"
+)
+
+// sagaStatus 查询下单saga的状态
+func sagaStatus(t *testing.T, orderSn string) int{
+	t.Helper()
+
+	var st models.OrderSaga
+	if err := database.GetDB().Where("order_sn = ?", orderSn).First(&st).Error; err != nil{
+		t.Fatalf("查询下单saga失败：%v", err)
+	}
+
+	return st.Status
+}
+
+// assertNoOrder 下单失败后不应留下有效订单
+func assertNoOrder(t *testing.T, s *OrderServiceImpl, orderSn string){
+	t.Helper()
+
+	o, err := s.orderData.GetByOrderSn(context.Background(), orderSn)
+	if err == nil && o.Status != models.StatusFailed{
+		t.Errorf("下单失败后期待没有有效订单，但实际订单状态为 %d", o.Status)
+	}
+	if err != nil && !errors.Is(err, data.ErrOrderNotFound){
+		t.Errorf("查询订单失败：%v", err)
+	}
+}
+
+func TestPlaceOrderSuccess(t *testing.T){
+	s, internal, _ := newTestService(t)
+
+	o, err := s.placeOrder(context.Background(), "saga-ok", 1, 1, 2)
+	if err != nil{
+		t.Fatalf("下单失败：%v", err)
+	}
+
+	if o.Status != models.StatusPending || o.Quantity != 2 || o.Amount != 19.8{
+		t.Errorf("订单信息不正确：状态 %d，数量 %d，金额 %v", o.Status, o.Quantity, o.Amount)
+	}
+
+	if status := sagaStatus(t, "saga-ok"); status != saga.StatusDone{
+		t.Errorf("期待saga已完成，但实际状态为 %d", status)
+	}
+
+	var outboxCount int64
+	database.GetDB().Model(&models.OrderOutbox{}).Where("order_sn = ?", "saga-ok").Count(&outboxCount)
+	if outboxCount != 1{
+		t.Errorf("期待写入1条订单消息，但实际写入 %d 条", outboxCount)
+	}
+
+	if returned := internal.returnedOrders(); len(returned) != 0{
+		t.Errorf("下单成功时不应归还库存，但实际归还了 %v", returned)
+	}
+}
+
+// 扣除库存被拒绝时没有扣除任何库存，不需要归还
+func TestPlaceOrderDeductRejected(t *testing.T){
+	s, internal, _ := newTestService(t)
+	internal.deductCode = 409
+
+	_, err := s.placeOrder(context.Background(), "saga-rejected", 1, 1, 1)
+
+	var respErr *responseError
+	if !errors.As(err, &respErr) || respErr.code != 409{
+		t.Fatalf("期待透传扣除库存的响应码409，但实际得到 %v", err)
+	}
+
+	if returned := internal.returnedOrders(); len(returned) != 0{
+		t.Errorf("扣除被拒绝时不应归还库存，但实际归还了 %v", returned)
+	}
+
+	if status := sagaStatus(t, "saga-rejected"); status != saga.StatusCompensated{
+		t.Errorf("期待saga已补偿，但实际状态为 %d", status)
+	}
+	assertNoOrder(t, s, "saga-rejected")
+}
+
+// 每个步骤失败时都会归还库存，不会留下有效订单
+func TestPlaceOrderStepFailures(t *testing.T){
+	tests := []struct{
+		name	string
+		setup	func(t *testing.T, s *OrderServiceImpl, internal *fakeInternalClient)
+	}{
+		{
+			// 扣除库存超时，活动服务可能已经扣除
+			name:	"扣除库存",
+			setup:	func(t *testing.T, s *OrderServiceImpl, internal *fakeInternalClient){
+				internal.deductErr = errTest
+			},
+		},
+		{
+			name:	"获取活动信息",
+			setup:	func(t *testing.T, s *OrderServiceImpl, internal *fakeInternalClient){
+				s.activityClient = &fakeActivityClient{err: errTest}
+			},
+		},
+		{
+			// 写入订单消息失败时整个事务回滚
+			name:	"创建订单",
+			setup:	func(t *testing.T, s *OrderServiceImpl, internal *fakeInternalClient){
+				err := database.GetDB().Callback().Create().Before("gorm:create").Register("test:fail_outbox", func(db *gorm.DB){
+					if db.Statement.Table == "order_outboxes"{
+						db.AddError(errTest)
+					}
+				})
+				if err != nil{
+					t.Fatalf("注册回调失败：%v", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests{
+		t.Run(tt.name, func(t *testing.T){
+			s, internal, _ := newTestService(t)
+			tt.setup(t, s, internal)
+
+			_, err := s.placeOrder(context.Background(), "saga-fail", 1, 1, 1)
+			if !errors.Is(err, errTest){
+				t.Fatalf("期待步骤%s失败，但实际得到 %v", tt.name, err)
+			}
+
+			returned := internal.returnedOrders()
+			if len(returned) != 1 || returned[0] != "saga-fail"{
+				t.Errorf("期待归还订单的库存，但实际归还了 %v", returned)
+			}
+
+			if status := sagaStatus(t, "saga-fail"); status != saga.StatusCompensated{
+				t.Errorf("期待saga已补偿，但实际状态为 %d", status)
+			}
+			assertNoOrder(t, s, "saga-fail")
+		})
+	}
+}
+
+// 补偿失败的saga由恢复任务重试
+func TestRecoverCompensatingSaga(t *testing.T){
+	s, internal, _ := newTestService(t)
+	s.activityClient = &fakeActivityClient{err: errTest}
+	internal.returnErr = errTest
+
+	if _, err := s.placeOrder(context.Background(), "saga-recover", 1, 1, 1); err == nil{
+		t.Fatalf("期待下单失败")
+	}
+
+	if status := sagaStatus(t, "saga-recover"); status != saga.StatusCompensating{
+		t.Fatalf("归还库存失败时期待saga处于补偿中，但实际状态为 %d", status)
+	}
+
+	internal.returnErr = nil
+	err := database.GetDB().Model(&models.OrderSaga{}).Where("order_sn = ?", "saga-recover").UpdateColumn("updated_at", time.Now().Add(-time.Hour)).Error
+	if err != nil{
+		t.Fatalf("修改saga更新时间失败：%v", err)
+	}
+
+	s.recoverSagas(context.Background())
+
+	if status := sagaStatus(t, "saga-recover"); status != saga.StatusCompensated{
+		t.Errorf("恢复后期待saga已补偿，但实际状态为 %d", status)
+	}
+
+	if returned := internal.returnedOrders(); len(returned) != 1{
+		t.Errorf("期待恢复时归还库存，但实际归还了 %v", returned)
+	}
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"Redrock/seckill/internal/order/data"
+	"Redrock/seckill/internal/order/mq"
+	"Redrock/seckill/internal/pkg/models"
+	order "Redrock/seckill/kitex_gen/order"
+)
+
+const(
+	// 异步秒杀失败结果的键名前缀，键名后接排队凭证(订单号)
+	seckillResultKeyPrefix = "order:result:"
+
+	// 失败结果的保存时间
+	seckillResultExpireTime = 24 * time.Hour
+)
+
+// seckillFailure 异步秒杀的失败结果
+type seckillFailure struct{
+	UserID	uint	`json:"user_id"`
+	Reason	string	`json:"reason"`
+}
+
+// HandleSeckillMessage 消费网关发送的秒杀排队消息并创建订单
+// 库存已经由网关扣除，下单saga中的扣除库存步骤按订单号幂等，不会重复扣除
+// 下单失败属于业务结果，记录后不再重试；只有无法判断是否处理过时返回错误等待重试
+func (s *OrderServiceImpl) HandleSeckillMessage(msg *mq.SeckillMessage) error{
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 重复投递的消息，saga已经创建过则由saga本身或恢复任务负责完成
+	exists, err := s.sagaData.Exists(ctx, msg.OrderSn)
+	if err != nil{
+		return fmt.Errorf("检查下单saga失败：%w", err)
+	}
+
+	if exists{
+		log.Printf("秒杀消息已处理，订单号：%v", msg.OrderSn)
+		return nil
+	}
+
+	_, err = s.placeOrder(ctx, msg.OrderSn, msg.UserID, msg.ActivityID, msg.Quantity)
+	if errors.Is(err, errSagaNotStarted){
+		return err
+	}
+
+	if err != nil{
+		reason := "创建订单失败"
+
+		var respErr *responseError
+		if errors.As(err, &respErr){
+			reason = respErr.msg
+		}
+
+		log.Printf("异步秒杀下单失败：%v, 订单号：%v", err, msg.OrderSn)
+
+		// 扣除库存的步骤被拒绝时(如活动已结束)saga不会补偿，但网关已经扣除过库存，这里需要归还
+		// 归还按订单号幂等，saga已经补偿过时不会重复归还
+		returnErr := s.returnStock(ctx, msg.ActivityID, msg.UserID, msg.Quantity, msg.OrderSn)
+		if returnErr != nil{
+			log.Printf("异步秒杀归还库存失败：%v, 订单号：%v", returnErr, msg.OrderSn)
+		}
+
+		s.saveSeckillFailure(ctx, msg.OrderSn, msg.UserID, reason)
+	}
+
+	return nil
+}
+
+// HandleSeckillDeadLetter 秒杀排队消息超过最大重试次数进入死信后，归还网关扣除的库存并记录失败结果
+// 下单saga已经创建时由saga本身或恢复任务负责完成，不做处理；无法确认saga是否创建时不归还，避免订单已创建但库存被归还
+// 归还后扣除记录改为归还标记，死信重新投递后下单的扣除步骤会被拒绝，不会在库存已归还后创建订单
+func (s *OrderServiceImpl) HandleSeckillDeadLetter(msg *mq.SeckillMessage, handleErr error){
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	log.Printf("秒杀消息进入死信：%v, 订单号：%v", handleErr, msg.OrderSn)
+
+	exists, err := s.sagaData.Exists(ctx, msg.OrderSn)
+	if err != nil{
+		log.Printf("检查下单saga失败，死信消息未归还库存：%v, 订单号：%v", err, msg.OrderSn)
+		return
+	}
+	if exists{
+		return
+	}
+
+	err = s.returnStock(ctx, msg.ActivityID, msg.UserID, msg.Quantity, msg.OrderSn)
+	if err != nil{
+		log.Printf("死信消息归还库存失败：%v, 订单号：%v", err, msg.OrderSn)
+	}
+
+	s.saveSeckillFailure(ctx, msg.OrderSn, msg.UserID, "排队处理失败，请重新抢购")
+}
+
+// saveSeckillFailure 记录异步秒杀的失败原因，供用户查询
+func (s *OrderServiceImpl) saveSeckillFailure(ctx context.Context, ticket string, userID uint, reason string){
+	value, err := json.Marshal(&seckillFailure{
+		UserID:	userID,
+		Reason:	reason,
+	})
+	if err != nil{
+		log.Printf("序列化秒杀失败结果失败：%v", err)
+		return
+	}
+
+	err = s.redisClient.Set(ctx, seckillResultKeyPrefix + ticket, value, seckillResultExpireTime).Err()
+	if err != nil{
+		log.Printf("保存秒杀失败结果失败：%v, 订单号：%v", err, ticket)
+	}
+}
+
+// getSeckillFailure 获取异步秒杀的失败原因，没有失败记录时返回nil
+func (s *OrderServiceImpl) getSeckillFailure(ctx context.Context, ticket string) (*seckillFailure, error){
+	value, err := s.redisClient.Get(ctx, seckillResultKeyPrefix + ticket).Bytes()
+	if err != nil{
+		if errors.Is(err, redis.Nil){
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var failure seckillFailure
+	err = json.Unmarshal(value, &failure)
+	if err != nil{
+		return nil, err
+	}
+
+	return &failure, nil
+}
+
+// GetSeckillStatus 查询异步秒杀的结果
+func (s *OrderServiceImpl) GetSeckillStatus(ctx context.Context, req *order.GetSeckillStatusRequest) (resp *order.GetSeckillStatusResponse, err error){
+	response := &order.GetSeckillStatusResponse{
+		BaseResponse:	&order.BaseResponse{},
+	}
+
+	if req.UserID <= 0 || req.Ticket == ""{
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "用户ID和排队凭证不能为空"
+
+		return response, nil
+	}
+
+	// 订单已创建，说明排队成功
+	localOrder, err := s.orderData.GetByOrderSn(ctx, req.Ticket)
+	if err == nil{
+		if localOrder.UserID != uint(req.UserID){
+			response.BaseResponse.Code = 404
+			response.BaseResponse.Msg  = "排队凭证不存在"
+
+			return response, nil
+		}
+
+		if localOrder.Status == models.StatusFailed{
+			response.Status = order.SeckillStatus_FAILED
+			response.Reason = "创建订单失败"
+		}else{
+			response.Status  = order.SeckillStatus_SUCCESS
+			response.OrderSn = localOrder.OrderSn
+		}
+
+		response.BaseResponse.Code = 0
+		response.BaseResponse.Msg  = "查询成功"
+
+		return response, nil
+	}
+
+	if !errors.Is(err, data.ErrOrderNotFound){
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "查询订单失败：" + err.Error()
+
+		return response, nil
+	}
+
+	failure, err := s.getSeckillFailure(ctx, req.Ticket)
+	if err != nil{
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "查询秒杀结果失败：" + err.Error()
+
+		return response, nil
+	}
+
+	if failure != nil{
+		if failure.UserID != uint(req.UserID){
+			response.BaseResponse.Code = 404
+			response.BaseResponse.Msg  = "排队凭证不存在"
+
+			return response, nil
+		}
+
+		response.Status = order.SeckillStatus_FAILED
+		response.Reason = failure.Reason
+	}else{
+		// 消息还没有被消费，或者正在创建订单
+		response.Status = order.SeckillStatus_QUEUED
+	}
+
+	response.BaseResponse.Code = 0
+	response.BaseResponse.Msg  = "查询成功"
+
+	return response, nil
+}
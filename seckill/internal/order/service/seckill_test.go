@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"Redrock/seckill/internal/order/mq"
+	"Redrock/seckill/internal/pkg/database"
+	pkgMQ "Redrock/seckill/internal/pkg/mq"
+	order "Redrock/seckill/kitex_gen/order"
+)
+
+// failSagaCreate 让下单saga记录创建失败，消息处理会返回错误等待重试
+func failSagaCreate(t *testing.T){
+	t.Helper()
+
+	err := database.GetDB().Callback().Create().Before("gorm:create").Register("test:fail_saga", func(db *gorm.DB){
+		if db.Statement.Table == "order_sagas"{
+			db.AddError(errTest)
+		}
+	})
+	if err != nil{
+		t.Fatalf("注册回调失败：%v", err)
+	}
+}
+
+// seckillStatus 查询排队凭证的秒杀结果
+func seckillStatus(t *testing.T, s *OrderServiceImpl, ticket string) *order.GetSeckillStatusResponse{
+	t.Helper()
+
+	resp, err := s.GetSeckillStatus(context.Background(), &order.GetSeckillStatusRequest{UserID: 1, Ticket: ticket})
+	if err != nil || resp.BaseResponse.Code != 0{
+		t.Fatalf("查询秒杀结果失败：%v, %+v", err, resp.BaseResponse)
+	}
+
+	return resp
+}
+
+func TestHandleSeckillMessage(t *testing.T){
+	s, internal, _ := newTestService(t)
+	msg := &mq.SeckillMessage{OrderSn: "seckill-ok", UserID: 1, ActivityID: 1, Quantity: 1}
+
+	if status := seckillStatus(t, s, msg.OrderSn).Status; status != order.SeckillStatus_QUEUED{
+		t.Fatalf("消费前的秒杀结果为%v，期望排队中", status)
+	}
+
+	// 重复投递的消息不会重复下单
+	for i := 0; i < 2; i++{
+		if err := s.HandleSeckillMessage(msg); err != nil{
+			t.Fatalf("第%d次处理秒杀消息失败：%v", i+1, err)
+		}
+	}
+
+	resp := seckillStatus(t, s, msg.OrderSn)
+	if resp.Status != order.SeckillStatus_SUCCESS || resp.OrderSn != msg.OrderSn{
+		t.Fatalf("秒杀结果为%v, %s，期望成功", resp.Status, resp.OrderSn)
+	}
+	if returned := internal.returnedOrders(); len(returned) != 0{
+		t.Fatalf("下单成功时不应归还库存，但实际归还了%v", returned)
+	}
+}
+
+// 扣除库存被拒绝时归还网关扣除的库存并记录失败原因，消息不再重试
+func TestHandleSeckillMessageRejected(t *testing.T){
+	s, internal, _ := newTestService(t)
+	internal.deductCode = 409
+	msg := &mq.SeckillMessage{OrderSn: "seckill-rejected", UserID: 1, ActivityID: 1, Quantity: 1}
+
+	if err := s.HandleSeckillMessage(msg); err != nil{
+		t.Fatalf("业务失败时不应返回错误，但实际得到%v", err)
+	}
+
+	resp := seckillStatus(t, s, msg.OrderSn)
+	if resp.Status != order.SeckillStatus_FAILED || resp.Reason != "扣除库存被拒绝"{
+		t.Fatalf("秒杀结果为%v, %s，期望失败", resp.Status, resp.Reason)
+	}
+	if returned := internal.returnedOrders(); len(returned) == 0{
+		t.Fatal("下单失败时应该归还网关扣除的库存")
+	}
+}
+
+// 进入死信的消息归还库存并记录失败结果，排队凭证不会一直停留在排队中
+func TestHandleSeckillDeadLetter(t *testing.T){
+	s, internal, _ := newTestService(t)
+	failSagaCreate(t)
+
+	// 同名的内存队列在进程内共享，每次运行使用新的队列
+	queueName := fmt.Sprintf("test_seckill_dead_letter_%d", time.Now().UnixNano())
+
+	consumer, err := mq.NewSeckillConsumer(&pkgMQ.MQConfig{
+		Type:	"memory",
+		Memory:	pkgMQ.MemoryConfig{QueueName: queueName, MaxRetries: 1, RetryDelay: 1},
+	}, s.HandleSeckillMessage)
+	if err != nil{
+		t.Fatalf("创建秒杀消息消费者失败：%v", err)
+	}
+	consumer.OnDeadLetter(s.HandleSeckillDeadLetter)
+	if err := consumer.StartConsume(); err != nil{
+		t.Fatalf("启动秒杀消息消费者失败：%v", err)
+	}
+
+	producer, err := mq.NewSeckillProducer(&pkgMQ.MQConfig{
+		Type:	"memory",
+		Memory:	pkgMQ.MemoryConfig{QueueName: queueName},
+	})
+	if err != nil{
+		t.Fatalf("创建秒杀消息生产者失败：%v", err)
+	}
+
+	const ticket = "seckill-dead"
+	if err := producer.Produce(&mq.SeckillMessage{OrderSn: ticket, UserID: 1, ActivityID: 1, Quantity: 1}); err != nil{
+		t.Fatalf("发布秒杀消息失败：%v", err)
+	}
+
+	deadline := time.Now().Add(3 * time.Second)
+	for len(internal.returnedOrders()) == 0 && time.Now().Before(deadline){
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if returned := internal.returnedOrders(); len(returned) != 1 || returned[0] != ticket{
+		t.Fatalf("死信消息归还库存的订单为%v，期望%s", returned, ticket)
+	}
+
+	resp := seckillStatus(t, s, ticket)
+	if resp.Status != order.SeckillStatus_FAILED{
+		t.Fatalf("死信消息的秒杀结果为%v，期望失败", resp.Status)
+	}
+}
+
+// 下单saga已经创建时由saga负责完成，进入死信后不归还库存
+func TestHandleSeckillDeadLetterSagaStarted(t *testing.T){
+	s, internal, _ := newTestService(t)
+	msg := &mq.SeckillMessage{OrderSn: "seckill-started", UserID: 1, ActivityID: 1, Quantity: 1}
+
+	if err := s.HandleSeckillMessage(msg); err != nil{
+		t.Fatalf("处理秒杀消息失败：%v", err)
+	}
+
+	s.HandleSeckillDeadLetter(msg, errTest)
+
+	if returned := internal.returnedOrders(); len(returned) != 0{
+		t.Fatalf("saga已创建时不应归还库存，但实际归还了%v", returned)
+	}
+	if status := seckillStatus(t, s, msg.OrderSn).Status; status != order.SeckillStatus_SUCCESS{
+		t.Fatalf("秒杀结果为%v，期望成功", status)
+	}
+}
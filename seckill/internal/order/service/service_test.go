@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/kitex/client/callopt"
+
+	"Redrock/seckill/internal/order/config"
+	"Redrock/seckill/internal/order/data"
+	"Redrock/seckill/internal/order/mq"
+	"Redrock/seckill/internal/order/payment"
+	"Redrock/seckill/internal/pkg/models"
+	"Redrock/seckill/internal/pkg/testenv"
+	"Redrock/seckill/kitex_gen/activity"
+	activityClient "Redrock/seckill/kitex_gen/activity/activityservice"
+	internalClient "Redrock/seckill/kitex_gen/activity/internalactivityservice"
+)
+
+// fakeInternalClient 记录扣除和归还库存请求的内部活动服务，未覆盖的方法调用时会panic
+type fakeInternalClient struct{
+	internalClient.Client
+
+	mu			sync.Mutex
+	deducted	[]string	// 已扣除库存的订单号
+	returned	[]string	// 已归还库存的订单号
+	deductCode	int32		// 扣除库存返回的响应码
+	deductErr	error		// 不为nil时扣除库存返回该错误
+	returnErr	error		// 不为nil时归还库存返回该错误
+	afterDeduct	func()		// 扣除库存成功后调用
+}
+
+func (c *fakeInternalClient) DeductStock(ctx context.Context, req *activity.DeductStockRequest, callOptions ...callopt.Option) (*activity.DeductStockResponse, error){
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.deductErr != nil{
+		return nil, c.deductErr
+	}
+
+	if c.deductCode != 0{
+		return &activity.DeductStockResponse{
+			BaseResponse:	&activity.BaseResponse{Code: c.deductCode, Msg: "扣除库存被拒绝"},
+		}, nil
+	}
+
+	c.deducted = append(c.deducted, req.OrderSn)
+	if c.afterDeduct != nil{
+		c.afterDeduct()
+	}
+
+	return &activity.DeductStockResponse{
+		BaseResponse:	&activity.BaseResponse{},
+		Success:		true,
+	}, nil
+}
+
+func (c *fakeInternalClient) ReturnStock(ctx context.Context, req *activity.ReturnStockRequest, callOptions ...callopt.Option) (*activity.ReturnStockResponse, error){
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.returnErr != nil{
+		return nil, c.returnErr
+	}
+
+	c.returned = append(c.returned, req.OrderSn)
+
+	return &activity.ReturnStockResponse{
+		BaseResponse:	&activity.BaseResponse{},
+		Success:		true,
+	}, nil
+}
+
+// returnedOrders 已归还库存的订单号
+func (c *fakeInternalClient) returnedOrders() []string{
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]string(nil), c.returned...)
+}
+
+// fakeActivityClient 返回固定活动信息的活动服务，未覆盖的方法调用时会panic
+type fakeActivityClient struct{
+	activityClient.Client
+
+	err		error	// 不为nil时获取活动信息返回该错误
+}
+
+func (c *fakeActivityClient) GetActivity(ctx context.Context, req *activity.GetActivityRequest, callOptions ...callopt.Option) (*activity.GetActivityResponse, error){
+	if c.err != nil{
+		return nil, c.err
+	}
+
+	return &activity.GetActivityResponse{
+		BaseResponse:	&activity.BaseResponse{},
+		Activity:		&activity.ActivityInfo{
+			Id:				req.ActivityID,
+			ProductId:		1,
+			SeckillPrice:	9.9,
+			PerUserLimit:	5,
+		},
+	}, nil
+}
+
+// fakeProvider 统计扣款次数的支付渠道，设置payHook时在扣款前调用，用于构造并发场景
+type fakeProvider struct{
+	*payment.MockProvider
+
+	mu		sync.Mutex
+	charges	int
+	refunds	int
+	payHook	func()
+	payErr	error
+}
+
+func newFakeProvider() *fakeProvider{
+	return &fakeProvider{MockProvider: payment.NewMockProvider()}
+}
+
+func (p *fakeProvider) Pay(ctx context.Context, orderSn string, amount float64) (string, error){
+	if p.payHook != nil{
+		p.payHook()
+	}
+	if p.payErr != nil{
+		return "", p.payErr
+	}
+
+	p.mu.Lock()
+	p.charges++
+	p.mu.Unlock()
+
+	return p.MockProvider.Pay(ctx, orderSn, amount)
+}
+
+func (p *fakeProvider) Refund(ctx context.Context, orderSn string, tradeNo string) error{
+	p.mu.Lock()
+	p.refunds++
+	p.mu.Unlock()
+
+	return p.MockProvider.Refund(ctx, orderSn, tradeNo)
+}
+
+func (p *fakeProvider) counts() (int, int){
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.charges, p.refunds
+}
+
+// newTestService 创建使用内存数据库和假客户端的订单服务，不启动后台任务
+func newTestService(t *testing.T) (*OrderServiceImpl, *fakeInternalClient, *fakeProvider){
+	testenv.NewDB(t, &models.Product{}, &models.Activity{}, &models.Order{}, &models.OrderSaga{}, &models.OrderOutbox{})
+
+	internal := &fakeInternalClient{}
+	provider := newFakeProvider()
+
+	return &OrderServiceImpl{
+		orderData:		data.NewOrderData(),
+		sagaData:		data.NewSagaData(),
+		outboxRelay:	mq.NewOutboxRelay(data.NewOutboxData(), nil),
+		activityClient:	&fakeActivityClient{},
+		internalClient:	internal,
+		payProvider:	provider,
+		orderConfig:	config.OrderConfig{ExpireTime: 900},
+		redisClient:	testenv.NewRedis(t),
+	}, internal, provider
+}
+
+// createTestOrder 创建指定状态的订单，过期时间为一小时后
+func createTestOrder(t *testing.T, s *OrderServiceImpl, orderSn string, status int) *models.Order{
+	t.Helper()
+
+	o := &models.Order{
+		UserID:		1,
+		ProductID:	1,
+		ActivityID:	1,
+		OrderSn:	orderSn,
+		Amount:		9.9,
+		Price:		9.9,
+		Quantity:	1,
+		Status:		status,
+		CreateTime:	time.Now(),
+		ExpireTime:	time.Now().Add(time.Hour),
+	}
+	if err := s.orderData.Create(context.Background(), o); err != nil{
+		t.Fatalf("创建测试订单失败：%v", err)
+	}
+
+	return o
+}
+
+// orderStatus 查询订单的当前状态
+func orderStatus(t *testing.T, s *OrderServiceImpl, orderSn string) int{
+	t.Helper()
+
+	o, err := s.orderData.GetByOrderSn(context.Background(), orderSn)
+	if err != nil{
+		t.Fatalf("查询订单失败：%v", err)
+	}
+
+	return o.Status
+}
+
+var errTest = errors.New("测试错误")
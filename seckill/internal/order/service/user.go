@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"Redrock/seckill/internal/pkg/models"
+	order "Redrock/seckill/kitex_gen/order"
+)
+
+const(
+	// 已关闭下单的用户的键名前缀，键名后接用户ID，用户注销后不再删除
+	closedUserKeyPrefix = "order:closed:user:"
+)
+
+// errUserClosed 用户已注销，不能再下单
+var errUserClosed = &responseError{code: 403, msg: "用户已注销，不能下单"}
+
+// isUserClosed 用户是否已关闭下单
+func (s *OrderServiceImpl) isUserClosed(ctx context.Context, userID uint) (bool, error){
+	count, err := s.redisClient.Exists(ctx, fmt.Sprintf("%s%d", closedUserKeyPrefix, userID)).Result()
+	if err != nil{
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// cancelIfUserClosed 订单创建后用户已关闭下单时取消订单
+// 注销账户时先关闭下单再取消未支付的订单，订单写入后再检查一次，
+// 两边至少有一方能看到对方的修改，关闭前已经开始下单的订单不会被遗漏
+func (s *OrderServiceImpl) cancelIfUserClosed(ctx context.Context, orderSn string, userID uint) bool{
+	closed, err := s.isUserClosed(ctx, userID)
+	if err != nil{
+		log.Printf("检查用户%d是否已关闭下单失败：%v, 订单号：%v", userID, err, orderSn)
+		return false
+	}
+	if !closed{
+		return false
+	}
+
+	_, err = s.cancelOrder(ctx, orderSn, []int{models.StatusPending, models.StatusCreated})
+	if err != nil{
+		log.Printf("取消已注销用户%d的订单失败：%v, 订单号：%v", userID, err, orderSn)
+	}else{
+		log.Printf("用户%d已注销，订单已取消，订单号：%v", userID, orderSn)
+	}
+
+	return true
+}
+
+// SetUserOrdersClosed 关闭或重新开放用户的下单
+// 用户服务注销账户时先关闭下单，注销失败时重新开放
+func (s *OrderServiceImpl) SetUserOrdersClosed(ctx context.Context, req *order.SetUserOrdersClosedRequest) (resp *order.SetUserOrdersClosedResponse, err error){
+	response := &order.SetUserOrdersClosedResponse{
+		BaseResponse: &order.BaseResponse{},
+	}
+
+	if req.UserID <= 0{
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "用户ID不能为空"
+
+		return response, nil
+	}
+
+	key := fmt.Sprintf("%s%d", closedUserKeyPrefix, req.UserID)
+	if req.Closed{
+		err = s.redisClient.Set(ctx, key, 1, 0).Err()
+	}else{
+		err = s.redisClient.Del(ctx, key).Err()
+	}
+	if err != nil{
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "修改用户的下单状态失败：" + err.Error()
+
+		return response, nil
+	}
+
+	response.BaseResponse.Code = 0
+	response.BaseResponse.Msg  = "修改用户的下单状态成功"
+
+	return response, nil
+}
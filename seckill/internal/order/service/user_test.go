@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"Redrock/seckill/internal/order/data"
+	"Redrock/seckill/internal/order/mq"
+	"Redrock/seckill/internal/pkg/models"
+	order "Redrock/seckill/kitex_gen/order"
+)
+
+// setUserClosed 关闭或重新开放用户1的下单
+func setUserClosed(t *testing.T, s *OrderServiceImpl, closed bool){
+	t.Helper()
+
+	resp, _ := s.SetUserOrdersClosed(context.Background(), &order.SetUserOrdersClosedRequest{UserID: 1, Closed: closed})
+	if resp.BaseResponse.Code != 0{
+		t.Fatalf("修改下单状态失败：%+v", resp.BaseResponse)
+	}
+}
+
+// 关闭下单后不再开始下单，重新开放后可以下单
+func TestSetUserOrdersClosed(t *testing.T){
+	s, internal, _ := newTestService(t)
+
+	setUserClosed(t, s, true)
+	_, err := s.placeOrder(context.Background(), "closed-order", 1, 1, 1)
+	if !errors.Is(err, errUserClosed){
+		t.Fatalf("关闭下单后下单的结果为%v，期望用户已注销", err)
+	}
+	if len(internal.deducted) != 0{
+		t.Fatalf("关闭下单后扣除了库存：%v", internal.deducted)
+	}
+	if _, err := s.orderData.GetByOrderSn(context.Background(), "closed-order"); !errors.Is(err, data.ErrOrderNotFound){
+		t.Fatalf("关闭下单后查询订单的结果为%v，期望订单不存在", err)
+	}
+
+	setUserClosed(t, s, false)
+	if _, err := s.placeOrder(context.Background(), "reopened-order", 1, 1, 1); err != nil{
+		t.Fatalf("重新开放后下单失败：%v", err)
+	}
+
+	resp, _ := s.SetUserOrdersClosed(context.Background(), &order.SetUserOrdersClosedRequest{Closed: true})
+	if resp.BaseResponse.Code != 400{
+		t.Fatalf("用户ID为空时的结果为%+v，期望400", resp.BaseResponse)
+	}
+}
+
+// 注销后仍在排队的秒杀请求下单失败，归还网关扣除的库存
+func TestHandleSeckillMessageUserClosed(t *testing.T){
+	s, internal, _ := newTestService(t)
+	setUserClosed(t, s, true)
+	msg := &mq.SeckillMessage{OrderSn: "seckill-closed", UserID: 1, ActivityID: 1, Quantity: 1}
+
+	if err := s.HandleSeckillMessage(msg); err != nil{
+		t.Fatalf("用户已注销时不应返回错误，但实际得到%v", err)
+	}
+
+	resp := seckillStatus(t, s, msg.OrderSn)
+	if resp.Status != order.SeckillStatus_FAILED || resp.Reason != errUserClosed.msg{
+		t.Fatalf("秒杀结果为%v, %s，期望失败", resp.Status, resp.Reason)
+	}
+	if returned := internal.returnedOrders(); !slices.Equal(returned, []string{msg.OrderSn}){
+		t.Fatalf("归还库存的订单为%v，期望归还%s", returned, msg.OrderSn)
+	}
+}
+
+// 开始下单后用户注销时，订单创建后被取消
+func TestPlaceOrderCancelledAfterUserClosed(t *testing.T){
+	s, internal, _ := newTestService(t)
+	internal.afterDeduct = func(){
+		setUserClosed(t, s, true)
+	}
+
+	_, err := s.placeOrder(context.Background(), "closed-later", 1, 1, 1)
+	if !errors.Is(err, errUserClosed){
+		t.Fatalf("下单的结果为%v，期望用户已注销", err)
+	}
+
+	if status := orderStatus(t, s, "closed-later"); status != models.StatusCancelled{
+		t.Fatalf("订单状态为%d，期望已取消", status)
+	}
+	if returned := internal.returnedOrders(); !slices.Equal(returned, []string{"closed-later"}){
+		t.Fatalf("归还库存的订单为%v，期望归还closed-later", returned)
+	}
+}
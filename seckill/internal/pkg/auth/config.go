@@ -0,0 +1,8 @@
+package auth
+
+// 签发令牌的用户服务和校验令牌的网关需要使用相同的配置
+type AuthConfig struct{
+	Secret		string	`mapstructure:"secret"`		// HMAC签名密钥
+	Issuer		string	`mapstructure:"issuer"`		// 签发者
+	AccessTTL	int		`mapstructure:"access_ttl"`	// 访问令牌有效期(秒)
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const(
+	revokedTokenKeyPrefix	= "auth:revoked:token:"	// 被撤销的单个令牌，后面跟令牌ID
+	revokedUserKeyPrefix	= "auth:revoked:user:"	// 用户的令牌撤销时间，后面跟用户ID
+)
+
+// Revoker 在Redis中记录被撤销的访问令牌，用户服务撤销令牌，网关校验令牌时检查
+// 用户服务和网关需要连接同一个Redis库
+// 记录的过期时间不超过令牌的有效期，令牌过期后记录也随之删除
+type Revoker struct{
+	client	*redis.Client
+	ttl		time.Duration
+	now		func() time.Time
+}
+
+// NewRevoker 创建令牌撤销记录
+func NewRevoker(client *redis.Client, config *AuthConfig) *Revoker{
+	return &Revoker{
+		client:	client,
+		ttl:	accessTTL(config),
+		now:	time.Now,
+	}
+}
+
+// Revoke 撤销单个令牌，用于退出登录
+func (r *Revoker) Revoke(ctx context.Context, claims *Claims) error{
+	if claims.ID == "" || claims.ExpiresAt == nil{
+		return ErrInvalidToken
+	}
+
+	// 令牌已经过期时无需记录
+	ttl := claims.ExpiresAt.Sub(r.now())
+	if ttl <= 0{
+		return nil
+	}
+
+	return r.client.Set(ctx, revokedTokenKeyPrefix + claims.ID, 1, ttl).Err()
+}
+
+// RevokeUser 撤销用户在此之前签发的所有令牌，用于修改密码和注销账户
+// 令牌的签发时间只精确到秒，与撤销在同一秒内签发的令牌不会被撤销，
+// 因此调用方还需要用Revoke撤销当前请求使用的令牌
+func (r *Revoker) RevokeUser(ctx context.Context, userID uint) error{
+	key := revokedUserKeyPrefix + strconv.FormatUint(uint64(userID), 10)
+
+	return r.client.Set(ctx, key, r.now().Unix(), r.ttl).Err()
+}
+
+// IsRevoked 检查令牌是否被撤销
+func (r *Revoker) IsRevoked(ctx context.Context, claims *Claims) (bool, error){
+	values, err := r.client.MGet(ctx,
+		revokedTokenKeyPrefix + claims.ID,
+		revokedUserKeyPrefix + strconv.FormatUint(uint64(claims.UserID), 10),
+	).Result()
+	if err != nil{
+		return false, fmt.Errorf("查询令牌撤销记录失败：%w", err)
+	}
+
+	if values[0] != nil{
+		return true, nil
+	}
+
+	if values[1] == nil{
+		return false, nil
+	}
+
+	raw, ok := values[1].(string)
+	if !ok{
+		return false, errors.New("令牌撤销时间格式错误")
+	}
+	revokedAt, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil{
+		return false, fmt.Errorf("令牌撤销时间格式错误：%w", err)
+	}
+
+	return claims.IssuedAt == nil || claims.IssuedAt.Unix() < revokedAt, nil
+}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Redrock/seckill/internal/pkg/testenv"
+)
+
+// newTestRevoker 使用进程内的miniredis，测试不依赖外部的Redis
+func newTestRevoker(t *testing.T) *Revoker {
+	return NewRevoker(testenv.NewRedis(t), &AuthConfig{AccessTTL: 60})
+}
+
+func TestRevokeToken(t *testing.T) {
+	r := newTestRevoker(t)
+	m := newTestManager(t, "test-secret")
+	ctx := context.Background()
+
+	_, revoked, _ := m.Issue(990001, RoleUser)
+	_, other, _ := m.Issue(990001, RoleUser)
+	t.Cleanup(func() { r.client.Del(ctx, revokedTokenKeyPrefix+revoked.ID) })
+
+	if err := r.Revoke(ctx, revoked); err != nil {
+		t.Fatalf("撤销令牌失败：%v", err)
+	}
+
+	if ok, err := r.IsRevoked(ctx, revoked); err != nil || !ok {
+		t.Fatalf("撤销后的令牌检查结果为%v, %v，期望已撤销", ok, err)
+	}
+	if ok, err := r.IsRevoked(ctx, other); err != nil || ok {
+		t.Fatalf("同一用户的其他令牌检查结果为%v, %v，期望未撤销", ok, err)
+	}
+}
+
+func TestRevokeUser(t *testing.T) {
+	r := newTestRevoker(t)
+	m := newTestManager(t, "test-secret")
+	ctx := context.Background()
+	t.Cleanup(func() { r.client.Del(ctx, revokedUserKeyPrefix+"990002") })
+
+	now := time.Now()
+	m.now = func() time.Time { return now.Add(-time.Second) }
+	_, before, _ := m.Issue(990002, RoleUser)
+
+	r.now = func() time.Time { return now }
+	if err := r.RevokeUser(ctx, 990002); err != nil {
+		t.Fatalf("撤销用户令牌失败：%v", err)
+	}
+
+	m.now = func() time.Time { return now.Add(time.Second) }
+	_, after, _ := m.Issue(990002, RoleUser)
+
+	if ok, err := r.IsRevoked(ctx, before); err != nil || !ok {
+		t.Fatalf("撤销前签发的令牌检查结果为%v, %v，期望已撤销", ok, err)
+	}
+	if ok, err := r.IsRevoked(ctx, after); err != nil || ok {
+		t.Fatalf("撤销后签发的令牌检查结果为%v, %v，期望未撤销", ok, err)
+	}
+}
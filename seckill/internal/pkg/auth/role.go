@@ -0,0 +1,18 @@
+package auth
+
+// 用户角色
+const(
+	RoleUser		= "user"		// 普通用户，只能参与秒杀
+	RoleMerchant	= "merchant"	// 商家，可以管理自己的商品和活动
+	RoleAdmin		= "admin"		// 管理员，可以管理所有商品、活动和用户角色
+)
+
+// ValidRole 是否为有效的角色
+func ValidRole(role string) bool{
+	switch role{
+	case RoleUser, RoleMerchant, RoleAdmin:
+		return true
+	default:
+		return false
+	}
+}
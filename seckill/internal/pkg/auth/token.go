@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// 未配置有效期时的默认有效期
+const defaultAccessTTL = 2 * time.Hour
+
+var (
+	ErrInvalidToken	= errors.New("令牌无效")
+	ErrTokenExpired	= errors.New("令牌已过期")
+)
+
+// Claims 访问令牌中的声明
+type Claims struct{
+	UserID	uint	`json:"uid"`
+	Role	string	`json:"role"`
+	jwt.RegisteredClaims
+}
+
+// TokenManager 签发和校验HS256签名的访问令牌
+type TokenManager struct{
+	secret	[]byte
+	issuer	string
+	ttl		time.Duration
+	now		func() time.Time	// 获取当前时间，方便测试时模拟过期
+}
+
+// NewTokenManager 创建令牌管理器
+func NewTokenManager(config *AuthConfig) (*TokenManager, error){
+	if config.Secret == ""{
+		return nil, fmt.Errorf("令牌签名密钥不能为空")
+	}
+
+	return &TokenManager{
+		secret:	[]byte(config.Secret),
+		issuer:	config.Issuer,
+		ttl:	accessTTL(config),
+		now:	time.Now,
+	}, nil
+}
+
+// accessTTL 获取配置的访问令牌有效期
+func accessTTL(config *AuthConfig) time.Duration{
+	ttl := time.Duration(config.AccessTTL) * time.Second
+	if ttl <= 0{
+		ttl = defaultAccessTTL
+	}
+
+	return ttl
+}
+
+// Issue 为用户签发访问令牌，每个令牌有唯一的ID
+// 角色在签发时写入令牌，修改角色后需要重新登录才能生效
+func (m *TokenManager) Issue(userID uint, role string) (string, *Claims, error){
+	now := m.now()
+
+	claims := &Claims{
+		UserID: userID,
+		Role:	role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:			uuid.New().String(),
+			Issuer:		m.issuer,
+			IssuedAt:	jwt.NewNumericDate(now),
+			ExpiresAt:	jwt.NewNumericDate(now.Add(m.ttl)),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
+	if err != nil{
+		return "", nil, fmt.Errorf("签发令牌失败：%w", err)
+	}
+
+	return token, claims, nil
+}
+
+// Parse 校验令牌的签名、签发者和有效期，返回令牌中的声明
+func (m *TokenManager) Parse(tokenString string) (*Claims, error){
+	claims := &Claims{}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(m.now),
+	}
+	if m.issuer != ""{
+		options = append(options, jwt.WithIssuer(m.issuer))
+	}
+
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (any, error){
+		return m.secret, nil
+	}, options...)
+	if err != nil{
+		if errors.Is(err, jwt.ErrTokenExpired){
+			return nil, ErrTokenExpired
+		}
+		return nil, fmt.Errorf("%w：%v", ErrInvalidToken, err)
+	}
+
+	if claims.UserID == 0 || !ValidRole(claims.Role){
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
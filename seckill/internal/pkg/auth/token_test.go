@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestManager(t *testing.T, secret string) *TokenManager {
+	m, err := NewTokenManager(&AuthConfig{Secret: secret, Issuer: "seckill", AccessTTL: 60})
+	if err != nil {
+		t.Fatalf("创建令牌管理器失败：%v", err)
+	}
+	return m
+}
+
+func TestIssueAndParse(t *testing.T) {
+	m := newTestManager(t, "test-secret")
+
+	token, issued, err := m.Issue(42, RoleMerchant)
+	if err != nil {
+		t.Fatalf("签发令牌失败：%v", err)
+	}
+
+	claims, err := m.Parse(token)
+	if err != nil {
+		t.Fatalf("校验令牌失败：%v", err)
+	}
+	if claims.UserID != 42 || claims.ID != issued.ID {
+		t.Fatalf("令牌中的用户为%d(%s)，期望42(%s)", claims.UserID, claims.ID, issued.ID)
+	}
+	if claims.Role != RoleMerchant {
+		t.Fatalf("令牌中的角色为%s，期望%s", claims.Role, RoleMerchant)
+	}
+}
+
+func TestParseRejectsForgedToken(t *testing.T) {
+	token, _, err := newTestManager(t, "other-secret").Issue(42, RoleUser)
+	if err != nil {
+		t.Fatalf("签发令牌失败：%v", err)
+	}
+
+	_, err = newTestManager(t, "test-secret").Parse(token)
+	if !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("其他密钥签发的令牌校验结果为%v，期望ErrInvalidToken", err)
+	}
+
+	_, err = newTestManager(t, "test-secret").Parse("not-a-token")
+	if !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("格式错误的令牌校验结果为%v，期望ErrInvalidToken", err)
+	}
+}
+
+func TestParseExpiredToken(t *testing.T) {
+	m := newTestManager(t, "test-secret")
+
+	token, _, err := m.Issue(42, RoleMerchant)
+	if err != nil {
+		t.Fatalf("签发令牌失败：%v", err)
+	}
+
+	m.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+
+	_, err = m.Parse(token)
+	if !errors.Is(err, ErrTokenExpired) {
+		t.Fatalf("过期令牌校验结果为%v，期望ErrTokenExpired", err)
+	}
+}
@@ -5,6 +5,14 @@ import(
 	"time"
 )
 
+const(
+	// 活动状态常量
+	ActivityNotStarted	= 0 // 未开始
+	ActivityOngoing		= 1 // 进行中
+	ActivityEnded		= 2 // 已结束
+	ActivityPaused		= 3 // 已暂停
+)
+
 type Activity struct{
 	gorm.Model
 	Name			string		`gorm:"not null;index"`
@@ -14,8 +22,9 @@ type Activity struct{
 	EndTime   		time.Time 	`gorm:"not null"`
 	TotalStock 		int64 		`gorm:"not null"`
 	AvailableStock 	int64 		`gorm:"not null"`
-	Status 			int 		`gorm:"not null"` // 0: 未开始, 1: 进行中, 2: 已结束
+	Status 			int 		`gorm:"not null"` // 0: 未开始, 1: 进行中, 2: 已结束, 3: 已暂停
 	SeckillPrice 	float64 	`gorm:"type:decimal(10,2); not null"`
+	PerUserLimit	int64		`gorm:"not null;default:1"` // 每个用户累计最多购买的数量
 }
 
 // 活动是否开始
@@ -31,4 +40,16 @@ func (a *Activity) IsEnded() bool{
 // 活动是否可用
 func (a *Activity) IsAvailable() bool{
 	return a.Status == 1
-}
\ No newline at end of file
+}
+
+// 按时间计算活动在now时应处于的状态
+func (a *Activity) StatusAt(now time.Time) int{
+	if !now.Before(a.EndTime){
+		return ActivityEnded
+	}
+	if !now.Before(a.StartTime){
+		return ActivityOngoing
+	}
+
+	return ActivityNotStarted
+}
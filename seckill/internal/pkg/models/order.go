@@ -13,6 +13,7 @@ const (
 	StatusPaid      = 2 // 订单已支付
 	StatusFailed    = 3 // 订单创建失败
 	StatusCancelled = 4 // 订单已取消
+	StatusPaying    = 5 // 订单支付中，已向支付渠道发起扣款
 )
 
 type Order struct{
@@ -25,7 +26,11 @@ type Order struct{
 	OrderSn 	string 	`gorm:"not null;unique"`
 	Amount 		float64 `gorm:"type:decimal(10,2); not null"`
 	CreateTime 	time.Time `gorm:"not null"`
+	ExpireTime 	time.Time `gorm:"index"` // 超过该时间仍未支付的订单会被自动取消
 	Price     	float64 `gorm:"type:decimal(10,2); not null"`
 	Quantity 	int 	`gorm:"not null"`
 	Status 		int 	`gorm:"not null;default:0"`
+	PayTime 	*time.Time	// 支付时间，未支付时为NULL
+	TradeNo 	string 	`gorm:"type:varchar(64)"` // 支付渠道的交易流水号
+	StockReturnPending	bool	`gorm:"not null;default:false;index"` // 订单已取消但库存还没有归还成功
 }
\ No newline at end of file
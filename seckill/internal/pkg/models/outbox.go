@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// outbox消息状态常量
+	OutboxPending	= 0 // 等待发送
+	OutboxSent		= 1 // 已发送
+)
+
+// OrderOutbox 订单消息发件箱，与订单在同一个事务中写入，由relay异步发布到MQ
+type OrderOutbox struct{
+	gorm.Model
+	OrderSn 	string 	`gorm:"type:varchar(64);not null;index"`
+	Payload 	[]byte 	`gorm:"type:blob;not null"`
+	Status 		int 	`gorm:"not null;default:0;index"`
+	Attempts 	int 	`gorm:"not null;default:0"` // 发送失败次数
+	LastError 	string 	`gorm:"type:varchar(255)"`
+	SentAt 		*time.Time
+	NextAttemptAt	*time.Time	`gorm:"index"` // 早于该时间不会再次发送：被relay领取后为租约到期时间，发送失败后为退避结束时间
+}
@@ -9,4 +9,7 @@ type Product struct{
 	Name 		string `gorm:"type:varchar(255); not null"`
 	Description string `gorm:"type:text"`
 	Price 		float64 `gorm:"type:decimal(10,2); not null"`
-}
\ No newline at end of file
+	ImageURL	string `gorm:"type:varchar(512)"`
+	Category	string `gorm:"type:varchar(64); index"`
+	MerchantID	uint   `gorm:"index"` // 商品所属的商家，0表示由管理员创建
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// OrderSaga 创建订单saga的执行记录，用于服务重启后恢复中断的下单流程
+type OrderSaga struct{
+	gorm.Model
+	OrderSn 	string 	`gorm:"type:varchar(64);not null;unique"`
+	UserID 		uint 	`gorm:"not null"`
+	ActivityID 	uint 	`gorm:"not null"`
+	Quantity 	int 	`gorm:"not null"`
+	Step 		int 	`gorm:"not null;default:0"` // 可能已生效的步骤数
+	Status 		int 	`gorm:"not null;default:0;index"` // 0: 执行中, 1: 已完成, 2: 补偿中, 3: 已补偿
+}
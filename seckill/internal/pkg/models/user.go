@@ -8,4 +8,5 @@ type User struct{
 	gorm.Model
 	Username string `gorm:"type:varchar(255); not null; unique"`
 	Password string `gorm:"type:varchar(255); not null"`
+	Role     string `gorm:"type:varchar(16); not null; default:'user'"` // user, merchant, admin
 }
\ No newline at end of file
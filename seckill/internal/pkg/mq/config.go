@@ -1,5 +1,9 @@
 package mq
 
+import (
+	myRedis "Redrock/seckill/internal/pkg/redis"
+)
+
 // message发给exchange,再根据routingKey 分拣到 对应的queue
 type RabbitMQConfig struct{
 	Host			string		`mapstructure:"host"`
@@ -9,9 +13,36 @@ type RabbitMQConfig struct{
 	ExchangeName	string		`mapstructure:"exchange_name"`
 	QueueName		string		`mapstructure:"queue_name"`
 	RoutingKey		string		`mapstructure:"routing_key"`
+	MaxRetries		int			`mapstructure:"max_retries"`	// 消息处理失败的最大重试次数，超过后进入死信队列
+	RetryDelay		int			`mapstructure:"retry_delay"`	// 第一次重试的延迟(毫秒)，之后每次翻倍
+	ReconnectDelay		int		`mapstructure:"reconnect_delay"`		// 断线后第一次重连的延迟(毫秒)，之后每次翻倍
+	MaxReconnectDelay	int		`mapstructure:"max_reconnect_delay"`	// 重连延迟的上限(毫秒)
+	ConfirmTimeout		int		`mapstructure:"confirm_timeout"`		// 等待发布确认的超时时间(毫秒)
+}
+
+// 进程内的消息队列，只能在同一个进程内的生产者和消费者之间传递消息，用于开发和测试
+type MemoryConfig struct{
+	QueueName		string		`mapstructure:"queue_name"`
+	BufferSize		int			`mapstructure:"buffer_size"`	// 队列容量，队列满时发布失败
+	MaxRetries		int			`mapstructure:"max_retries"`
+	RetryDelay		int			`mapstructure:"retry_delay"`	// 毫秒
+}
+
+// 基于Redis Streams和消费者组的消息队列
+// 配置了connection时使用独立的Redis连接，否则使用服务已初始化的Redis连接
+type RedisStreamConfig struct{
+	Stream			string		`mapstructure:"stream"`
+	Group			string		`mapstructure:"group"`
+	Consumer		string		`mapstructure:"consumer"`		// 消费者名称，多个实例需要不同
+	MaxLen			int64		`mapstructure:"max_len"`		// stream的最大长度(近似值)，已确认的消息会被删除，超过时最早的消息会被裁剪，0表示不限制
+	MaxRetries		int			`mapstructure:"max_retries"`
+	RetryDelay		int			`mapstructure:"retry_delay"`	// 第一次重试的延迟(毫秒)，之后每次翻倍
+	Connection		*myRedis.RedisConfig	`mapstructure:"connection"`	// 不同服务之间传递消息时配置为同一个Redis库
 }
 
 type MQConfig struct{
-	Type	string	`mapstructure:"type"`
-	RabbitMQ RabbitMQConfig `mapstructure:"rabbitmq"`
+	Type		string				`mapstructure:"type"` // rabbitmq, memory, redis
+	RabbitMQ	RabbitMQConfig		`mapstructure:"rabbitmq"`
+	Memory		MemoryConfig		`mapstructure:"memory"`
+	Redis		RedisStreamConfig	`mapstructure:"redis"`
 }
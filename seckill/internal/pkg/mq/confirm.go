@@ -0,0 +1,121 @@
+package mq
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/streadway/amqp"
+)
+
+const(
+	defaultConfirmTimeout	= 5000 // 毫秒
+	confirmBufferSize		= 64
+)
+
+var(
+	// ErrPublishNacked RabbitMQ拒绝了消息(例如内部错误)
+	ErrPublishNacked	= errors.New("消息被RabbitMQ拒绝")
+	// ErrPublishReturned 消息无法路由到任何队列
+	ErrPublishReturned	= errors.New("消息无法路由到队列")
+	// ErrPublishTimeout 等待RabbitMQ确认超时，消息可能已投递也可能丢失
+	ErrPublishTimeout	= errors.New("等待消息确认超时")
+)
+
+// confirmPublisher 开启了发布确认的发布通道
+// 每次发布都以mandatory方式发送，并等待RabbitMQ的ack/nack，无法路由的消息会通过return通知
+// 同一时间只有一条消息在等待确认，保证确认和发布一一对应
+type confirmPublisher struct{
+	mu			sync.Mutex
+	channel		*amqp.Channel
+	confirms	chan amqp.Confirmation
+	returns		chan amqp.Return
+	deliveryTag	uint64	// 最后一条消息的投递标签，开启确认后从1开始递增
+	timeout		time.Duration
+}
+
+// newConfirmPublisher 将通道设置为确认模式
+func newConfirmPublisher(ch *amqp.Channel, timeout time.Duration) (*confirmPublisher, error){
+	err := ch.Confirm(false)
+	if err != nil{
+		return nil, fmt.Errorf("开启发布确认失败: %w", err)
+	}
+
+	// RabbitMQ先发送return再发送ack，通道带缓冲保证收到ack时return已经就绪
+	// 通知通道满时会阻塞整个连接的读取，缓冲需要能容纳超时后迟到的通知
+	return &confirmPublisher{
+		channel:	ch,
+		confirms:	ch.NotifyPublish(make(chan amqp.Confirmation, confirmBufferSize)),
+		returns:	ch.NotifyReturn(make(chan amqp.Return, confirmBufferSize)),
+		timeout:	timeout,
+	}, nil
+}
+
+// publish 发布消息并等待确认
+func (p *confirmPublisher) publish(exchange string, routingKey string, msg amqp.Publishing) error{
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// 丢弃之前超时的消息迟到的return
+	p.checkReturned("")
+
+	p.deliveryTag++
+	tag := p.deliveryTag
+
+	// 用投递标签作为消息ID，用于识别return对应的消息
+	msg.MessageId = strconv.FormatUint(tag, 10)
+
+	err := p.channel.Publish(
+		exchange,	// 交换机名称
+		routingKey,	// 路由键名称
+		true,		// 是否强制发送，无法路由时返回给生产者
+		false,		// 是否立即发送
+		msg,
+	)
+	if err != nil{
+		return err
+	}
+
+	timer := time.NewTimer(p.timeout)
+	defer timer.Stop()
+
+	for {
+		select{
+		case confirm, ok := <- p.confirms:
+			if !ok{
+				return ErrNotConnected
+			}
+
+			// 之前超时的消息的确认，忽略
+			if confirm.DeliveryTag < tag{
+				continue
+			}
+
+			if !confirm.Ack{
+				return ErrPublishNacked
+			}
+
+			return p.checkReturned(msg.MessageId)
+		case <- timer.C:
+			return ErrPublishTimeout
+		}
+	}
+}
+
+// checkReturned 检查消息是否因无法路由被返回
+func (p *confirmPublisher) checkReturned(messageID string) error{
+	for {
+		select{
+		case ret := <- p.returns:
+			if ret.MessageId != messageID{
+				continue
+			}
+
+			return fmt.Errorf("%w: %s", ErrPublishReturned, ret.ReplyText)
+		default:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package mq
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/streadway/amqp"
+)
+
+const(
+	// 消息头中记录重试次数和最后一次失败原因的键
+	retryCountHeader	= "x-retry-count"
+	lastErrorHeader		= "x-last-error"
+
+	defaultMaxRetries	= 3
+	defaultRetryDelay	= 1000 // 毫秒
+)
+
+// DeadLetter 死信队列中的消息
+type DeadLetter struct{
+	Body		[]byte
+	RetryCount	int
+	LastError	string
+	Timestamp	time.Time
+}
+
+// deadLetterExchange 死信交换机名称
+func deadLetterExchange(config *RabbitMQConfig) string{
+	return config.ExchangeName + ".dlx"
+}
+
+// deadLetterQueue 死信队列名称
+func deadLetterQueue(config *RabbitMQConfig) string{
+	return config.QueueName + ".dlq"
+}
+
+// retryQueue 第attempt次重试使用的延迟队列名称
+func retryQueue(config *RabbitMQConfig, attempt int) string{
+	return fmt.Sprintf("%s.retry.%d", config.QueueName, attempt)
+}
+
+// retryDelay 第attempt次重试的延迟，指数增长
+func retryDelay(config *RabbitMQConfig, attempt int) time.Duration{
+	return time.Duration(config.RetryDelay << (attempt - 1)) * time.Millisecond
+}
+
+// declareRetryAndDeadLetter 声明延迟重试队列和死信交换机/队列
+// 每次重试对应一个设置了TTL的延迟队列，消息过期后通过死信机制回到业务交换机重新投递
+func declareRetryAndDeadLetter(ch *amqp.Channel, config *RabbitMQConfig) error{
+	for attempt := 1; attempt <= config.MaxRetries; attempt++{
+		_, err := ch.QueueDeclare(
+			retryQueue(config, attempt),	// 队列名称
+			true,							// 是否持久化
+			false,							// 是否自动删除
+			false,							// 是否排他
+			false,							// 是否阻塞
+			amqp.Table{
+				"x-message-ttl":				int64(retryDelay(config, attempt) / time.Millisecond),
+				"x-dead-letter-exchange":		config.ExchangeName,
+				"x-dead-letter-routing-key":	config.RoutingKey,
+			},
+		)
+		if err != nil{
+			return fmt.Errorf("声明重试队列失败: %w", err)
+		}
+	}
+
+	// 声明死信交换机
+	err := ch.ExchangeDeclare(
+		deadLetterExchange(config),	// 交换机名称
+		"direct",					// 交换机类型
+		true,						// 是否持久化
+		false,						// 是否自动删除
+		false,						// 是否内部交换机
+		false,						// 是否阻塞
+		nil,						// 其他参数
+	)
+	if err != nil{
+		return fmt.Errorf("声明死信交换机失败: %w", err)
+	}
+
+	// 声明死信队列
+	_, err = ch.QueueDeclare(
+		deadLetterQueue(config),	// 队列名称
+		true,						// 是否持久化
+		false,						// 是否自动删除
+		false,						// 是否排他
+		false,						// 是否阻塞
+		nil,						// 其他参数
+	)
+	if err != nil{
+		return fmt.Errorf("声明死信队列失败: %w", err)
+	}
+
+	err = ch.QueueBind(
+		deadLetterQueue(config),	// 队列名称
+		config.RoutingKey,			// 路由键
+		deadLetterExchange(config),	// 交换机名称
+		false,						// 是否阻塞
+		nil,						// 其他参数
+	)
+	if err != nil{
+		return fmt.Errorf("绑定死信队列失败: %w", err)
+	}
+
+	return nil
+}
+
+// getRetryCount 从消息头中读取已重试次数
+func getRetryCount(headers amqp.Table) int{
+	switch v := headers[retryCountHeader].(type){
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case int:
+		return v
+	default:
+		return 0
+	}
+}
+
+// retryTarget 根据已重试次数确定失败消息的去向，返回交换机、路由键和本次的重试次数
+// 未超过最大重试次数时通过默认交换机投递到对应的延迟队列，否则投递到死信交换机
+func retryTarget(config *RabbitMQConfig, headers amqp.Table) (string, string, int){
+	retryCount := getRetryCount(headers) + 1
+
+	if retryCount > config.MaxRetries{
+		return deadLetterExchange(config), config.RoutingKey, retryCount
+	}
+
+	return "", retryQueue(config, retryCount), retryCount
+}
+
+// retryPublishing 构造重新投递的消息，消息头记录重试次数和最后一次失败原因
+func retryPublishing(msg amqp.Delivery, retryCount int, handleErr error) amqp.Publishing{
+	lastError := handleErr.Error()
+	if len(lastError) > 255{
+		lastError = lastError[:255]
+	}
+
+	return amqp.Publishing{
+		DeliveryMode:	amqp.Persistent,
+		ContentType:	msg.ContentType,
+		Body:			msg.Body,
+		Timestamp:		time.Now(),
+		Headers:		amqp.Table{
+			retryCountHeader:	int32(retryCount),
+			lastErrorHeader:	lastError,
+		},
+	}
+}
+
+// retryOrDeadLetter 处理失败的消息：未超过最大重试次数时投递到对应的延迟队列，否则投递到死信队列，返回是否投递到了死信队列
+// 通过开启了发布确认的发布通道投递，返回nil时消息已被RabbitMQ确认并路由到队列，调用方才能确认原消息
+func (r *RabbitMQ) retryOrDeadLetter(msg amqp.Delivery, handleErr error) (bool, error){
+	publisher, err := r.getPublisher()
+	if err != nil{
+		return false, err
+	}
+
+	exchange, routingKey, retryCount := retryTarget(r.config, msg.Headers)
+
+	err = publisher.publish(exchange, routingKey, retryPublishing(msg, retryCount, handleErr))
+	if err != nil{
+		return false, err
+	}
+
+	return exchange == deadLetterExchange(r.config), nil
+}
+
+// ListDeadLetters 查看死信队列中最多limit条消息，消息会被放回队列
+func (r *RabbitMQ) ListDeadLetters(limit int) ([]*DeadLetter, error){
+	ch, err := r.getChannel()
+	if err != nil{
+		return nil, fmt.Errorf("获取死信消息失败：%w", err)
+	}
+
+	var letters []*DeadLetter
+	var deliveries []amqp.Delivery
+
+	// 查看完成后统一放回，避免同一条消息被重复获取
+	defer func(){
+		for _, d := range deliveries{
+			d.Nack(false, true)
+		}
+	}()
+
+	for len(letters) < limit{
+		msg, ok, err := ch.Get(deadLetterQueue(r.config), false)
+		if err != nil{
+			return nil, fmt.Errorf("获取死信消息失败：%w", err)
+		}
+		if !ok{
+			break
+		}
+
+		deliveries = append(deliveries, msg)
+
+		lastError, _ := msg.Headers[lastErrorHeader].(string)
+		letters = append(letters, &DeadLetter{
+			Body:		msg.Body,
+			RetryCount:	getRetryCount(msg.Headers),
+			LastError:	lastError,
+			Timestamp:	msg.Timestamp,
+		})
+	}
+
+	return letters, nil
+}
+
+// ReplayDeadLetters 将死信队列中最多limit条消息重新投递到业务队列，重试次数清零，返回重新投递的消息数
+func (r *RabbitMQ) ReplayDeadLetters(limit int) (int, error){
+	ch, err := r.getChannel()
+	if err != nil{
+		return 0, fmt.Errorf("获取死信消息失败：%w", err)
+	}
+
+	replayed := 0
+
+	for replayed < limit{
+		msg, ok, err := ch.Get(deadLetterQueue(r.config), false)
+		if err != nil{
+			return replayed, fmt.Errorf("获取死信消息失败：%w", err)
+		}
+		if !ok{
+			break
+		}
+
+		err = r.publish(amqp.Publishing{
+			DeliveryMode:	amqp.Persistent,
+			ContentType:	msg.ContentType,
+			Body:			msg.Body,
+		})
+		if err != nil{
+			msg.Nack(false, true)
+
+			return replayed, fmt.Errorf("重新投递死信消息失败：%w", err)
+		}
+
+		msg.Ack(false)
+		replayed++
+	}
+
+	return replayed, nil
+}
+
+// PurgeDeadLetters 清空死信队列，返回删除的消息数
+func (r *RabbitMQ) PurgeDeadLetters() (int, error){
+	ch, err := r.getChannel()
+	if err != nil{
+		return 0, fmt.Errorf("清空死信队列失败：%w", err)
+	}
+
+	count, err := ch.QueuePurge(deadLetterQueue(r.config), false)
+	if err != nil{
+		return 0, fmt.Errorf("清空死信队列失败：%w", err)
+	}
+
+	return count, nil
+}
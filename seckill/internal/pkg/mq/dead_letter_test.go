@@ -0,0 +1,78 @@
+package mq
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/streadway/amqp"
+)
+
+func testRabbitConfig() *RabbitMQConfig{
+	return &RabbitMQConfig{
+		ExchangeName:	"order_exchange",
+		QueueName:		"order_queue",
+		RoutingKey:		"order",
+		MaxRetries:		3,
+		RetryDelay:		1000,
+	}
+}
+
+// 失败的消息依次进入各级延迟队列，超过最大重试次数后进入死信交换机
+func TestRetryTarget(t *testing.T){
+	config := testRabbitConfig()
+
+	tests := []struct{
+		headers		amqp.Table
+		exchange	string
+		routingKey	string
+		retryCount	int
+	}{
+		{nil, "", "order_queue.retry.1", 1},
+		{amqp.Table{retryCountHeader: int32(1)}, "", "order_queue.retry.2", 2},
+		{amqp.Table{retryCountHeader: int64(2)}, "", "order_queue.retry.3", 3},
+		{amqp.Table{retryCountHeader: int32(3)}, "order_exchange.dlx", "order", 4},
+		{amqp.Table{retryCountHeader: "3"}, "", "order_queue.retry.1", 1},	// 无法识别的重试次数按0处理
+	}
+
+	for _, tt := range tests{
+		exchange, routingKey, retryCount := retryTarget(config, tt.headers)
+		if exchange != tt.exchange || routingKey != tt.routingKey || retryCount != tt.retryCount{
+			t.Errorf("消息头 %v 期待投递到 (%q, %q, %d)，但实际得到 (%q, %q, %d)",
+				tt.headers, tt.exchange, tt.routingKey, tt.retryCount, exchange, routingKey, retryCount)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T){
+	config := testRabbitConfig()
+
+	for attempt, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second}{
+		if got := retryDelay(config, attempt); got != want{
+			t.Errorf("第%d次重试期待延迟 %v，但实际得到 %v", attempt, want, got)
+		}
+	}
+}
+
+// 重新投递的消息保留消息体并记录重试次数和截断后的失败原因
+func TestRetryPublishing(t *testing.T){
+	msg := amqp.Delivery{
+		ContentType:	"application/json",
+		Body:			[]byte(`{"orderSn":"1"}`),
+	}
+
+	publishing := retryPublishing(msg, 2, errors.New(strings.Repeat("错", 200)))
+
+	if string(publishing.Body) != string(msg.Body) || publishing.DeliveryMode != amqp.Persistent{
+		t.Errorf("重新投递的消息期待保留消息体并持久化")
+	}
+
+	if getRetryCount(publishing.Headers) != 2{
+		t.Errorf("期待重试次数为2，但实际得到 %d", getRetryCount(publishing.Headers))
+	}
+
+	if lastError, _ := publishing.Headers[lastErrorHeader].(string); len(lastError) > 255 || lastError == ""{
+		t.Errorf("失败原因期待截断到255字节，但实际长度为 %d", len(lastError))
+	}
+}
@@ -0,0 +1,197 @@
+package mq
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// 同名的内存队列在进程内共享，保证生产者和消费者拿到的是同一个队列
+var(
+	memoryQueues	= make(map[string]*MemoryQueue)
+	memoryQueuesMu	sync.Mutex
+)
+
+// memoryDeadLetterLimit 内存队列最多保留的死信消息数，超出时丢弃最早的死信
+const memoryDeadLetterLimit = 1024
+
+// memoryMessage 内存队列中的消息
+type memoryMessage struct{
+	body		[]byte
+	retryCount	int
+}
+
+// MemoryQueue 基于channel的进程内消息队列
+// 消息不会持久化，进程退出后丢失，只适合开发和测试环境
+type MemoryQueue struct{
+	config		MemoryConfig
+	messages	chan *memoryMessage
+
+	// 超过最大重试次数或重试时队列已满的消息，保存在内存中供查看和重新投递
+	deadLetters		[]*DeadLetter
+	deadLettersMu	sync.Mutex
+	onDeadLetter	DeadLetterCallback
+}
+
+// NewMemoryQueue 获取或创建内存队列
+func NewMemoryQueue(config *MemoryConfig) *MemoryQueue{
+	memoryQueuesMu.Lock()
+	defer memoryQueuesMu.Unlock()
+
+	if queue, ok := memoryQueues[config.QueueName]; ok{
+		return queue
+	}
+
+	cfg := *config
+	if cfg.BufferSize <= 0{
+		cfg.BufferSize = 1024
+	}
+	if cfg.MaxRetries <= 0{
+		cfg.MaxRetries = defaultMaxRetries
+	}
+	if cfg.RetryDelay <= 0{
+		cfg.RetryDelay = defaultRetryDelay
+	}
+
+	queue := &MemoryQueue{
+		config:		cfg,
+		messages:	make(chan *memoryMessage, cfg.BufferSize),
+	}
+	memoryQueues[config.QueueName] = queue
+
+	return queue
+}
+
+// Publish 发布消息，队列已满时返回错误
+func (q *MemoryQueue) Publish(body []byte) error{
+	// 复制一份，避免调用方之后修改body
+	data := make([]byte, len(body))
+	copy(data, body)
+
+	select{
+	case q.messages <- &memoryMessage{body: data}:
+		return nil
+	default:
+		return fmt.Errorf("发布消息失败：内存队列%s已满", q.config.QueueName)
+	}
+}
+
+// Subscribe 启动一个协程消费消息
+func (q *MemoryQueue) Subscribe(handler func([]byte) error) error{
+	go func(){
+		for msg := range q.messages{
+			err := handler(msg.body)
+			if err == nil{
+				continue
+			}
+
+			msg.retryCount++
+			if msg.retryCount > q.config.MaxRetries{
+				log.Printf("处理消息失败且超过最大重试次数，转入死信队列：%v, 消息：%s", err, msg.body)
+				q.deadLetter(msg, err)
+				continue
+			}
+
+			log.Printf("处理消息失败：%v, 已重试次数：%d", err, msg.retryCount - 1)
+
+			// 延迟后重新入队，延迟时间指数增长
+			delay := time.Duration(q.config.RetryDelay << (msg.retryCount - 1)) * time.Millisecond
+			handleErr := err
+			time.AfterFunc(delay, func(){
+				select{
+				case q.messages <- msg:
+				default:
+					log.Printf("重试消息入队失败，转入死信队列：内存队列%s已满, 消息：%s", q.config.QueueName, msg.body)
+					q.deadLetter(msg, handleErr)
+				}
+			})
+		}
+	}()
+
+	log.Printf("内存队列消费者成功启动，等待消息中...")
+
+	return nil
+}
+
+// OnDeadLetter 设置消息进入死信队列后的回调
+func (q *MemoryQueue) OnDeadLetter(callback DeadLetterCallback){
+	q.deadLettersMu.Lock()
+	defer q.deadLettersMu.Unlock()
+
+	q.onDeadLetter = callback
+}
+
+// deadLetter 将消息放入死信队列，超出上限时丢弃最早的死信
+func (q *MemoryQueue) deadLetter(msg *memoryMessage, handleErr error){
+	q.deadLettersMu.Lock()
+
+	if len(q.deadLetters) >= memoryDeadLetterLimit{
+		log.Printf("内存死信队列%s已满，丢弃最早的死信消息：%s", q.config.QueueName, q.deadLetters[0].Body)
+		q.deadLetters = q.deadLetters[1:]
+	}
+
+	q.deadLetters = append(q.deadLetters, &DeadLetter{
+		Body:		msg.body,
+		RetryCount:	msg.retryCount,
+		LastError:	handleErr.Error(),
+		Timestamp:	time.Now(),
+	})
+	callback := q.onDeadLetter
+
+	q.deadLettersMu.Unlock()
+
+	if callback != nil{
+		callback(msg.body, handleErr)
+	}
+}
+
+// ListDeadLetters 查看最早的limit条死信消息，不会移除消息
+func (q *MemoryQueue) ListDeadLetters(limit int) ([]*DeadLetter, error){
+	q.deadLettersMu.Lock()
+	defer q.deadLettersMu.Unlock()
+
+	if limit > len(q.deadLetters){
+		limit = len(q.deadLetters)
+	}
+
+	letters := make([]*DeadLetter, limit)
+	copy(letters, q.deadLetters[:limit])
+
+	return letters, nil
+}
+
+// ReplayDeadLetters 将最早的limit条死信消息重新投递到队列，重试次数清零，返回重新投递的消息数
+func (q *MemoryQueue) ReplayDeadLetters(limit int) (int, error){
+	q.deadLettersMu.Lock()
+	defer q.deadLettersMu.Unlock()
+
+	replayed := 0
+	for replayed < limit && replayed < len(q.deadLetters){
+		err := q.Publish(q.deadLetters[replayed].Body)
+		if err != nil{
+			q.deadLetters = q.deadLetters[replayed:]
+			return replayed, fmt.Errorf("重新投递死信消息失败：%w", err)
+		}
+
+		replayed++
+	}
+	q.deadLetters = q.deadLetters[replayed:]
+
+	return replayed, nil
+}
+
+// PurgeDeadLetters 清空死信队列，返回删除的消息数
+func (q *MemoryQueue) PurgeDeadLetters() (int, error){
+	q.deadLettersMu.Lock()
+	defer q.deadLettersMu.Unlock()
+
+	count := len(q.deadLetters)
+	q.deadLetters = nil
+
+	return count, nil
+}
+
+// Close 内存队列在进程内共享，关闭时不做任何操作
+func (q *MemoryQueue) Close(){
+}
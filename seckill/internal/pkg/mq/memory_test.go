@@ -0,0 +1,139 @@
+package mq
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemoryQueueShared(t *testing.T){
+	config := &MemoryConfig{QueueName: "test_shared"}
+
+	publisher, err := NewPublisher(&MQConfig{Type: "memory", Memory: *config})
+	if err != nil{
+		t.Fatalf("创建发布者失败：%v", err)
+	}
+	subscriber, err := NewSubscriber(&MQConfig{Type: "memory", Memory: *config})
+	if err != nil{
+		t.Fatalf("创建订阅者失败：%v", err)
+	}
+
+	received := make(chan string, 1)
+	subscriber.Subscribe(func(body []byte) error{
+		received <- string(body)
+		return nil
+	})
+
+	if err := publisher.Publish([]byte("hello")); err != nil{
+		t.Fatalf("发布消息失败：%v", err)
+	}
+
+	select{
+	case body := <- received:
+		if body != "hello"{
+			t.Fatalf("收到的消息为%q，期望hello", body)
+		}
+	case <- time.After(time.Second):
+		t.Fatal("没有收到消息")
+	}
+}
+
+func TestMemoryQueueRetry(t *testing.T){
+	queue := NewMemoryQueue(&MemoryConfig{QueueName: "test_retry", MaxRetries: 2, RetryDelay: 1})
+
+	var attempts atomic.Int32
+	queue.Subscribe(func(body []byte) error{
+		attempts.Add(1)
+		return errors.New("处理失败")
+	})
+
+	queue.Publish([]byte("retry"))
+
+	// 第一次处理加上2次重试
+	time.Sleep(100 * time.Millisecond)
+	if got := attempts.Load(); got != 3{
+		t.Fatalf("处理次数为%d，期望3", got)
+	}
+}
+
+// 超过最大重试次数的消息进入死信队列，可以重新投递和清空
+func TestMemoryQueueDeadLetter(t *testing.T){
+	// 同名队列在进程内共享，每次运行使用新的队列
+	queue := NewMemoryQueue(&MemoryConfig{QueueName: fmt.Sprintf("test_dead_letter_%d", time.Now().UnixNano()), MaxRetries: 1, RetryDelay: 1})
+
+	var fail atomic.Bool
+	fail.Store(true)
+	received := make(chan string, 10)
+	var callbacks atomic.Int32
+	queue.OnDeadLetter(func(body []byte, err error){
+		callbacks.Add(1)
+	})
+	queue.Subscribe(func(body []byte) error{
+		if fail.Load(){
+			return errors.New("处理失败")
+		}
+		received <- string(body)
+		return nil
+	})
+
+	queue.Publish([]byte("dead"))
+
+	deadline := time.Now().Add(time.Second)
+	var letters []*DeadLetter
+	for time.Now().Before(deadline){
+		letters, _ = queue.ListDeadLetters(10)
+		if len(letters) > 0 && callbacks.Load() > 0{
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if len(letters) != 1 || string(letters[0].Body) != "dead" || letters[0].RetryCount != 2 || letters[0].LastError != "处理失败"{
+		t.Fatalf("死信消息为%+v，期望1条重试2次的dead", letters)
+	}
+	if got := callbacks.Load(); got != 1{
+		t.Fatalf("死信回调调用了%d次，期望1次", got)
+	}
+
+	fail.Store(false)
+	replayed, err := queue.ReplayDeadLetters(10)
+	if err != nil || replayed != 1{
+		t.Fatalf("重新投递%d条，错误：%v，期望1条", replayed, err)
+	}
+	select{
+	case body := <- received:
+		if body != "dead"{
+			t.Fatalf("收到的消息为%q，期望dead", body)
+		}
+	case <- time.After(time.Second):
+		t.Fatal("没有收到重新投递的消息")
+	}
+
+	queue.deadLetter(&memoryMessage{body: []byte("purge")}, errors.New("处理失败"))
+	purged, err := queue.PurgeDeadLetters()
+	if err != nil || purged != 1{
+		t.Fatalf("清空%d条，错误：%v，期望1条", purged, err)
+	}
+	if letters, _ := queue.ListDeadLetters(10); len(letters) != 0{
+		t.Fatalf("清空后还有%d条死信", len(letters))
+	}
+}
+
+func TestMemoryQueueFull(t *testing.T){
+	queue := NewMemoryQueue(&MemoryConfig{QueueName: "test_full", BufferSize: 1})
+
+	if err := queue.Publish([]byte("1")); err != nil{
+		t.Fatalf("发布消息失败：%v", err)
+	}
+	if err := queue.Publish([]byte("2")); err == nil{
+		t.Fatal("队列已满时应该发布失败")
+	}
+}
+
+func TestUnknownType(t *testing.T){
+	_, err := NewPublisher(&MQConfig{Type: "kafka"})
+	if err == nil{
+		t.Fatal("不支持的类型应该返回错误")
+	}
+}
@@ -0,0 +1,105 @@
+package mq
+
+import (
+	"fmt"
+
+	myRedis "Redrock/seckill/internal/pkg/redis"
+)
+
+// Publisher 消息发布者
+type Publisher interface{
+	Publish(body []byte) error
+	Close()
+}
+
+// DeadLetterCallback 消息进入死信后的回调，err为最后一次处理失败的原因
+type DeadLetterCallback func(body []byte, err error)
+
+// Subscriber 消息订阅者
+// handler返回错误时消息会被重试，超过最大重试次数后进入死信(不同实现的处理方式不同)
+// OnDeadLetter需要在Subscribe之前设置，消息写入死信后调用，用于释放消息占用的资源
+type Subscriber interface{
+	Subscribe(handler func([]byte) error) error
+	OnDeadLetter(callback DeadLetterCallback)
+	Close()
+}
+
+// DeadLetterQueue 死信队列的运维操作：查看、重新投递到业务队列和清空
+type DeadLetterQueue interface{
+	ListDeadLetters(limit int) ([]*DeadLetter, error)
+	ReplayDeadLetters(limit int) (int, error)
+	PurgeDeadLetters() (int, error)
+	Close()
+}
+
+// backend 同时实现Publisher和Subscriber的消息队列
+type backend interface{
+	Publisher
+	Subscriber
+}
+
+// newBackend 根据配置的type创建消息队列
+func newBackend(config *MQConfig) (backend, error){
+	switch config.Type{
+	case "", "rabbitmq":
+		rabbit, err := NewRabbitMQ(&config.RabbitMQ)
+		if err != nil{
+			return nil, err
+		}
+		return rabbit, nil
+	case "memory":
+		return NewMemoryQueue(&config.Memory), nil
+	case "redis":
+		if config.Redis.Connection == nil{
+			stream, err := NewRedisStream(&config.Redis, myRedis.GetRedis())
+			if err != nil{
+				return nil, err
+			}
+			return stream, nil
+		}
+
+		// 使用独立的连接，关闭消息队列时一并关闭
+		client, err := myRedis.NewClient(config.Redis.Connection)
+		if err != nil{
+			return nil, err
+		}
+
+		stream, err := NewRedisStream(&config.Redis, client)
+		if err != nil{
+			client.Close()
+			return nil, err
+		}
+		stream.ownsClient = true
+
+		return stream, nil
+	default:
+		return nil, fmt.Errorf("不支持的消息队列类型：%s", config.Type)
+	}
+}
+
+// NewPublisher 根据配置的type创建消息发布者
+func NewPublisher(config *MQConfig) (Publisher, error){
+	return newBackend(config)
+}
+
+// NewSubscriber 根据配置的type创建消息订阅者
+func NewSubscriber(config *MQConfig) (Subscriber, error){
+	return newBackend(config)
+}
+
+// NewDeadLetterQueue 根据配置的type创建死信队列的运维入口
+func NewDeadLetterQueue(config *MQConfig) (DeadLetterQueue, error){
+	b, err := newBackend(config)
+	if err != nil{
+		return nil, err
+	}
+
+	dlq, ok := b.(DeadLetterQueue)
+	if !ok{
+		b.Close()
+
+		return nil, fmt.Errorf("消息队列类型%s不支持死信队列", config.Type)
+	}
+
+	return dlq, nil
+}
@@ -1,38 +1,221 @@
 package mq
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"sync"
+	"time"
 
 	"github.com/streadway/amqp"
 )
 
+const(
+	defaultReconnectDelay		= 1000	// 毫秒
+	defaultMaxReconnectDelay	= 30000	// 毫秒
+)
+
+// ErrNotConnected 连接断开(正在重连或已关闭)时发布消息返回的错误
+// 订单消息通过发件箱发布，发布失败的消息会在之后重新发布
+var ErrNotConnected = errors.New("RabbitMQ连接不可用")
+
+// ConnState 连接状态
+type ConnState int
+
+const(
+	StateConnected		ConnState = iota	// 已连接
+	StateDisconnected						// 连接断开
+	StateReconnecting						// 正在重连
+	StateClosed								// 已主动关闭
+)
+
+func (s ConnState) String() string{
+	switch s{
+	case StateConnected:
+		return "connected"
+	case StateDisconnected:
+		return "disconnected"
+	case StateReconnecting:
+		return "reconnecting"
+	case StateClosed:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
+
+// StateCallback 连接状态变化时的回调，err为导致状态变化的错误(可能为nil)
+type StateCallback func(state ConnState, err error)
+
 // 封装RabbitMQ
+// 连接或通道断开后会自动重连，重新声明交换机和队列，并重新注册消费者
 type RabbitMQ struct{
+	mu			sync.RWMutex
 	connection 	*amqp.Connection
 	channel		*amqp.Channel
+	publisher	*confirmPublisher		// 独立的发布通道，开启了发布确认
+	state		ConnState
 	config		*RabbitMQConfig
+
+	handlers	[]func([]byte) error	// 已注册的消费者，重连后重新注册
+	onState		StateCallback
+	onDeadLetter	DeadLetterCallback
+	done		chan struct{}
 }
 
 // NewRabbitMQ 创建RabbitMQ实例
 func NewRabbitMQ(config *RabbitMQConfig) (*RabbitMQ, error){
+	// 未配置重试参数时使用默认值
+	cfg := *config
+	if cfg.MaxRetries <= 0{
+		cfg.MaxRetries = defaultMaxRetries
+	}
+	if cfg.RetryDelay <= 0{
+		cfg.RetryDelay = defaultRetryDelay
+	}
+	if cfg.ReconnectDelay <= 0{
+		cfg.ReconnectDelay = defaultReconnectDelay
+	}
+	if cfg.MaxReconnectDelay < cfg.ReconnectDelay{
+		cfg.MaxReconnectDelay = max(defaultMaxReconnectDelay, cfg.ReconnectDelay)
+	}
+	if cfg.ConfirmTimeout <= 0{
+		cfg.ConfirmTimeout = defaultConfirmTimeout
+	}
+
+	r := &RabbitMQ{
+		config:		&cfg,
+		onState:	logState,
+		done:		make(chan struct{}),
+	}
+
+	// 首次连接失败直接返回错误，由调用方决定是否退出
+	err := r.connect()
+	if err != nil{
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// logState 默认的状态回调，只记录日志
+func logState(state ConnState, err error){
+	if err != nil{
+		log.Printf("RabbitMQ连接状态变为%s：%v", state, err)
+	}else{
+		log.Printf("RabbitMQ连接状态变为%s", state)
+	}
+}
+
+// OnStateChange 设置连接状态变化的回调
+func (r *RabbitMQ) OnStateChange(callback StateCallback){
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.onState = callback
+}
+
+// OnDeadLetter 设置消息进入死信队列后的回调
+func (r *RabbitMQ) OnDeadLetter(callback DeadLetterCallback){
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.onDeadLetter = callback
+}
+
+// State 返回当前的连接状态
+func (r *RabbitMQ) State() ConnState{
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.state
+}
+
+func (r *RabbitMQ) setState(state ConnState, err error){
+	r.mu.Lock()
+	r.state = state
+	callback := r.onState
+	r.mu.Unlock()
+
+	if callback != nil{
+		callback(state, err)
+	}
+}
+
+// connect 建立连接和通道，声明拓扑，重新注册消费者并开始监听连接断开
+func (r *RabbitMQ) connect() error{
+	config := r.config
+
 	// 连接RabbitMQ
 	conn, err := amqp.Dial(fmt.Sprintf("amqp://%s:%s@%s:%d/", config.User, config.Password, config.Host, config.Port))
 	if err != nil{
 
-		return nil, fmt.Errorf("连接RabbitMQ失败: %w", err)
+		return fmt.Errorf("连接RabbitMQ失败: %w", err)
 	}
 
 	// 创建通道
 	ch, err := conn.Channel()
 	if err != nil{
 		conn.Close()
-		
-		return nil, fmt.Errorf("创建通道失败: %w", err)
+
+		return fmt.Errorf("创建通道失败: %w", err)
 	}
 
+	err = declareTopology(ch, config)
+	if err != nil{
+		ch.Close()
+		conn.Close()
+
+		return err
+	}
+
+	// 创建发布通道，发布确认和消费不共用通道
+	pubCh, err := conn.Channel()
+	if err != nil{
+		conn.Close()
+
+		return fmt.Errorf("创建发布通道失败: %w", err)
+	}
+
+	publisher, err := newConfirmPublisher(pubCh, time.Duration(config.ConfirmTimeout) * time.Millisecond)
+	if err != nil{
+		conn.Close()
+
+		return err
+	}
+
+	r.mu.Lock()
+	r.connection = conn
+	r.channel = ch
+	r.publisher = publisher
+	r.state = StateConnected
+	handlers := r.handlers
+	r.mu.Unlock()
+
+	// 重新注册断开前的消费者
+	for _, handler := range handlers{
+		err = r.consume(ch, handler)
+		if err != nil{
+			ch.Close()
+			conn.Close()
+
+			return err
+		}
+	}
+
+	go r.watch(
+		conn.NotifyClose(make(chan *amqp.Error, 1)),
+		ch.NotifyClose(make(chan *amqp.Error, 1)),
+		pubCh.NotifyClose(make(chan *amqp.Error, 1)),
+	)
+
+	return nil
+}
+
+// declareTopology 声明交换机、队列、绑定以及重试和死信队列
+func declareTopology(ch *amqp.Channel, config *RabbitMQConfig) error{
 	// 声明交换机
-	err = ch.ExchangeDeclare(
+	err := ch.ExchangeDeclare(
 		config.ExchangeName,	// 交换机名称
 		"direct",				// 交换机类型
 		true,					// 是否持久化
@@ -42,10 +225,7 @@ func NewRabbitMQ(config *RabbitMQConfig) (*RabbitMQ, error){
 		nil,					// 其他参数
 	)
 	if err != nil{
-		ch.Close()
-		conn.Close()
-
-		return nil, fmt.Errorf("声明交换机失败: %w", err)
+		return fmt.Errorf("声明交换机失败: %w", err)
 	}
 
 	// 声明队列
@@ -58,10 +238,7 @@ func NewRabbitMQ(config *RabbitMQConfig) (*RabbitMQ, error){
 		nil,				// 其他参数
 	)
 	if err != nil{
-		ch.Close()
-		conn.Close()
-
-		return nil, fmt.Errorf("声明队列失败: %w", err)
+		return fmt.Errorf("声明队列失败: %w", err)
 	}
 
 	// 将队列绑定到交换机
@@ -73,21 +250,118 @@ func NewRabbitMQ(config *RabbitMQConfig) (*RabbitMQ, error){
 		nil,					// 其他参数
 	)
 	if err != nil{
-		ch.Close()
-		conn.Close()
+		return fmt.Errorf("绑定队列到交换机失败: %w", err)
+	}
+
+	// 声明延迟重试队列和死信队列
+	return declareRetryAndDeadLetter(ch, config)
+}
+
+// watch 等待连接或任一通道断开，断开后重连
+func (r *RabbitMQ) watch(connClosed <-chan *amqp.Error, chClosed <-chan *amqp.Error, pubClosed <-chan *amqp.Error){
+	var closeErr *amqp.Error
 
-		return nil, fmt.Errorf("绑定队列到交换机失败: %w", err)
+	select{
+	case closeErr = <- connClosed:
+	case closeErr = <- chClosed:
+	case closeErr = <- pubClosed:
+	case <- r.done:
+		return
 	}
 
-	return &RabbitMQ{
-		connection:	conn,
-		channel:	ch,
-		config:		config,
-	}, nil
+	// 主动关闭时通知通道会被直接关闭，没有错误
+	if r.isClosed(){
+		return
+	}
+
+	var err error
+	if closeErr != nil{
+		err = closeErr
+	}
+	r.setState(StateDisconnected, err)
+
+	// 通道单独断开时连接可能仍然可用，关闭旧连接后统一重建
+	r.mu.Lock()
+	if r.connection != nil{
+		r.connection.Close()
+	}
+	r.mu.Unlock()
+
+	r.reconnect()
 }
 
-// Close 关闭连接
+// reconnect 按指数退避不断重连，直到成功或被关闭
+func (r *RabbitMQ) reconnect(){
+	delay := time.Duration(r.config.ReconnectDelay) * time.Millisecond
+	maxDelay := time.Duration(r.config.MaxReconnectDelay) * time.Millisecond
+
+	for {
+		select{
+		case <- time.After(delay):
+		case <- r.done:
+			return
+		}
+
+		r.setState(StateReconnecting, nil)
+
+		err := r.connect()
+		if err == nil{
+			r.setState(StateConnected, nil)
+			return
+		}
+
+		if r.isClosed(){
+			return
+		}
+
+		r.setState(StateDisconnected, err)
+
+		delay = min(delay * 2, maxDelay)
+	}
+}
+
+func (r *RabbitMQ) isClosed() bool{
+	select{
+	case <- r.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// getChannel 返回当前可用的通道，连接断开时返回ErrNotConnected
+func (r *RabbitMQ) getChannel() (*amqp.Channel, error){
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.state != StateConnected || r.channel == nil{
+		return nil, ErrNotConnected
+	}
+
+	return r.channel, nil
+}
+
+// getPublisher 返回当前可用的发布通道，连接断开时返回ErrNotConnected
+func (r *RabbitMQ) getPublisher() (*confirmPublisher, error){
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.state != StateConnected || r.publisher == nil{
+		return nil, ErrNotConnected
+	}
+
+	return r.publisher, nil
+}
+
+// Close 关闭连接，之后不再重连
 func (r *RabbitMQ) Close(){
+	r.mu.Lock()
+	if r.isClosed(){
+		r.mu.Unlock()
+		return
+	}
+	close(r.done)
+
 	if r.channel != nil{
 		r.channel.Close()
 	}
@@ -95,22 +369,29 @@ func (r *RabbitMQ) Close(){
 	if r.connection != nil{
 		r.connection.Close()
 	}
+	r.mu.Unlock()
+
+	r.setState(StateClosed, nil)
 }
 
-// PublishMessage 发布消息
+// PublishMessage 发布消息，返回nil表示消息已经被RabbitMQ确认并路由到队列
+// 重连期间直接返回ErrNotConnected，不缓存消息
 func (r *RabbitMQ) PublishMessage(body []byte) error{
-	err := r.channel.Publish(
-		r.config.ExchangeName,	// 交换机名称
-		r.config.RoutingKey,	// 路由键名称
-		false,					// 是否强制发送
-		false,					// 是否立即发送
-		amqp.Publishing{
-			DeliveryMode: amqp.Persistent, // 持久化消息
-			ContentType:  "application/json",
-			Body:		  body,
-		},
-	)
+	return r.publish(amqp.Publishing{
+		DeliveryMode: amqp.Persistent, // 持久化消息
+		ContentType:  "application/json",
+		Body:		  body,
+	})
+}
+
+// publish 通过发布通道将消息发送到业务交换机并等待确认
+func (r *RabbitMQ) publish(msg amqp.Publishing) error{
+	publisher, err := r.getPublisher()
+	if err != nil{
+		return fmt.Errorf("发布消息失败：%w", err)
+	}
 
+	err = publisher.publish(r.config.ExchangeName, r.config.RoutingKey, msg)
 	if err != nil{
 		return fmt.Errorf("发布消息失败：%w", err)
 	}
@@ -118,17 +399,48 @@ func (r *RabbitMQ) PublishMessage(body []byte) error{
 	return nil
 }
 
-// ConsumeMessage 消费消息
+// Publish 实现Publisher接口
+func (r *RabbitMQ) Publish(body []byte) error{
+	return r.PublishMessage(body)
+}
+
+// Subscribe 实现Subscriber接口
+func (r *RabbitMQ) Subscribe(handler func([]byte) error) error{
+	return r.ConsumeMessage(handler)
+}
+
+// ConsumeMessage 消费消息，重连后会自动重新注册
 func (r *RabbitMQ) ConsumeMessage(handler func([]byte) error) error{
+	ch, err := r.getChannel()
+	if err != nil{
+		return fmt.Errorf("注册消费者失败：%w", err)
+	}
+
+	err = r.consume(ch, handler)
+	if err != nil{
+		return err
+	}
+
+	r.mu.Lock()
+	r.handlers = append(r.handlers, handler)
+	r.mu.Unlock()
+
+	log.Printf("消费者成功启动，等待消息中...")
+
+	return nil
+}
+
+// consume 在指定通道上注册消费者，通道关闭后消费协程退出
+func (r *RabbitMQ) consume(ch *amqp.Channel, handler func([]byte) error) error{
 	// Quality of Service 服务质量
 	// 设置QoS为1，表示每次只处理一条消息
-	err := r.channel.Qos(1, 0, false) // 预取数量，大小限制，是否全局
+	err := ch.Qos(1, 0, false) // 预取数量，大小限制，是否全局
 	if err != nil{
 		return fmt.Errorf("设置QoS失败: %w", err)
 	}
 
 	// 注册消费者
-	msgs, err := r.channel.Consume(
+	msgs, err := ch.Consume(
 		r.config.QueueName,	// 队列名称
 		"",					// 消费者名称(自动生成)
 		false,				// 是否自动应答
@@ -148,18 +460,37 @@ func (r *RabbitMQ) ConsumeMessage(handler func([]byte) error) error{
 
 			err := handler(msg.Body)
 			if err != nil{
-				log.Printf("处理消息失败：%v", err)
-				
-				// 如果处理消息失败，则拒绝消息重新入对，重新尝试处理消息
-				msg.Nack(false,true)
+				log.Printf("处理消息失败：%v, 已重试次数：%d", err, getRetryCount(msg.Headers))
+
+				// 处理失败的消息投递到延迟队列等待重试，超过最大重试次数后进入死信队列
+				// 投递被确认后才确认原消息，否则原消息重新入队
+				deadLettered, publishErr := r.retryOrDeadLetter(msg, err)
+				if publishErr != nil{
+					// 投递失败时重新入队，避免丢失消息
+					log.Printf("投递重试消息失败：%v", publishErr)
+					msg.Nack(false, true)
+					continue
+				}
+
+				if deadLettered{
+					r.mu.RLock()
+					callback := r.onDeadLetter
+					r.mu.RUnlock()
+
+					if callback != nil{
+						callback(msg.Body, err)
+					}
+				}
+				msg.Ack(false)
 			}else{
 				// 处理消息成功, 确认消息
 				msg.Ack(false)
 			}
 		}
-	}()
 
-	log.Printf("消费者成功启动，等待消息中...")
+		// 通道关闭时未确认的消息会被RabbitMQ重新投递，重连后由新的消费者处理
+		log.Printf("消费者通道已关闭，停止消费")
+	}()
 
 	return nil
 }
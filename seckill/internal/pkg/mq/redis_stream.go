@@ -0,0 +1,364 @@
+package mq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const(
+	// stream消息中的字段
+	streamBodyField		= "body"
+	streamRetryField	= "retry"
+	streamErrorField	= "error"
+
+	// 消费者崩溃后，未确认的消息超过该时间会被其他消费者认领
+	streamClaimIdle		= time.Minute
+
+	// 每次读取和转移的消息数
+	streamBatchSize		= 10
+)
+
+// streamPromoteScript 将到期的重试消息从有序集合转移回stream
+// 有序集合的成员为"重试次数:唯一ID:消息体"，分数为到期时间(毫秒)，转移和删除在同一个脚本中完成，不会丢失或重复
+// KEYS: 1重试有序集合 2stream
+// ARGV: 1当前时间(毫秒) 2最多转移的数量 3stream的最大长度(0表示不限制)
+const streamPromoteScript = `
+local due = redis.call("ZRANGEBYSCORE", KEYS[1], "-inf", ARGV[1], "LIMIT", 0, ARGV[2])
+for _, member in ipairs(due) do
+	redis.call("ZREM", KEYS[1], member)
+
+	local retry, body = string.match(member, "^(%d+):[^:]*:(.*)$")
+	if tonumber(ARGV[3]) > 0 then
+		redis.call("XADD", KEYS[2], "MAXLEN", "~", ARGV[3], "*", "body", body, "retry", retry)
+	else
+		redis.call("XADD", KEYS[2], "*", "body", body, "retry", retry)
+	end
+end
+return #due
+`
+
+// RedisStream 基于Redis Streams消费者组的消息队列
+// 处理失败的消息按重试次数延迟后重新写入stream(等待期间保存在有序集合<stream>.retry中)，超过最大重试次数后写入死信stream(<stream>.dlq)
+// 消息确认后从stream中删除，stream只保留未处理的消息
+type RedisStream struct{
+	client			*redis.Client
+	config			RedisStreamConfig
+	cancel			context.CancelFunc
+	promoteScript	*redis.Script
+	ownsClient		bool	// 使用独立的连接时关闭消息队列时一并关闭
+	onDeadLetter	DeadLetterCallback
+}
+
+// NewRedisStream 创建Redis Streams消息队列，并确保消费者组存在
+func NewRedisStream(config *RedisStreamConfig, client *redis.Client) (*RedisStream, error){
+	cfg := *config
+	if cfg.Stream == ""{
+		return nil, fmt.Errorf("stream名称不能为空")
+	}
+	if cfg.Group == ""{
+		cfg.Group = cfg.Stream + ".group"
+	}
+	if cfg.Consumer == ""{
+		cfg.Consumer = fmt.Sprintf("consumer-%d", time.Now().UnixNano())
+	}
+	if cfg.MaxRetries <= 0{
+		cfg.MaxRetries = defaultMaxRetries
+	}
+	if cfg.RetryDelay <= 0{
+		cfg.RetryDelay = defaultRetryDelay
+	}
+
+	// 创建消费者组，stream不存在时一并创建
+	err := client.XGroupCreateMkStream(context.Background(), cfg.Stream, cfg.Group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP"){
+		return nil, fmt.Errorf("创建消费者组失败：%w", err)
+	}
+
+	return &RedisStream{
+		client:			client,
+		config:			cfg,
+		promoteScript:	redis.NewScript(streamPromoteScript),
+	}, nil
+}
+
+// retryKey 等待重试的消息所在的有序集合
+func (s *RedisStream) retryKey() string{
+	return s.config.Stream + ".retry"
+}
+
+// deadLetterKey 死信stream
+func (s *RedisStream) deadLetterKey() string{
+	return s.config.Stream + ".dlq"
+}
+
+// retryDelay 第attempt次重试的延迟，指数增长
+func (s *RedisStream) retryDelay(attempt int) time.Duration{
+	return time.Duration(s.config.RetryDelay << (attempt - 1)) * time.Millisecond
+}
+
+// Publish 发布消息
+func (s *RedisStream) Publish(body []byte) error{
+	return s.add(context.Background(), s.config.Stream, map[string]any{
+		streamBodyField:	body,
+		streamRetryField:	0,
+	})
+}
+
+func (s *RedisStream) add(ctx context.Context, stream string, values map[string]any) error{
+	args := &redis.XAddArgs{
+		Stream:	stream,
+		Values:	values,
+	}
+
+	if s.config.MaxLen > 0{
+		args.MaxLen = s.config.MaxLen
+		args.Approx = true
+	}
+
+	err := s.client.XAdd(ctx, args).Err()
+	if err != nil{
+		return fmt.Errorf("发布消息失败：%w", err)
+	}
+
+	return nil
+}
+
+// OnDeadLetter 设置消息写入死信stream后的回调，需要在Subscribe之前设置
+func (s *RedisStream) OnDeadLetter(callback DeadLetterCallback){
+	s.onDeadLetter = callback
+}
+
+// Subscribe 启动一个协程以消费者组的方式消费消息
+func (s *RedisStream) Subscribe(handler func([]byte) error) error{
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	go func(){
+		for {
+			if ctx.Err() != nil{
+				return
+			}
+
+			// 先转移到期的重试消息、认领其他消费者崩溃后遗留的消息，再读取新消息
+			s.promote(ctx)
+			messages := s.claim(ctx)
+
+			streams, err := s.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+				Group:		s.config.Group,
+				Consumer:	s.config.Consumer,
+				Streams:	[]string{s.config.Stream, ">"},
+				Count:		streamBatchSize,
+				Block:		time.Second,	// 阻塞时间决定重试消息转移的间隔
+			}).Result()
+			if err != nil && !errors.Is(err, redis.Nil){
+				if ctx.Err() != nil{
+					return
+				}
+
+				log.Printf("读取stream消息失败：%v", err)
+				time.Sleep(time.Second)
+			}
+
+			for _, stream := range streams{
+				messages = append(messages, stream.Messages...)
+			}
+
+			for _, msg := range messages{
+				s.handle(ctx, msg, handler)
+			}
+		}
+	}()
+
+	log.Printf("Redis Streams消费者成功启动，等待消息中...")
+
+	return nil
+}
+
+// promote 将到期的重试消息转移回stream
+func (s *RedisStream) promote(ctx context.Context){
+	err := s.promoteScript.Run(ctx, s.client, []string{s.retryKey(), s.config.Stream},
+		time.Now().UnixMilli(), streamBatchSize, s.config.MaxLen).Err()
+	if err != nil && ctx.Err() == nil{
+		log.Printf("转移重试消息失败：%v", err)
+	}
+}
+
+// claim 认领空闲时间过长的未确认消息
+func (s *RedisStream) claim(ctx context.Context) []redis.XMessage{
+	messages, _, err := s.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:		s.config.Stream,
+		Group:		s.config.Group,
+		Consumer:	s.config.Consumer,
+		MinIdle:	streamClaimIdle,
+		Start:		"0",
+		Count:		streamBatchSize,
+	}).Result()
+	if err != nil{
+		if ctx.Err() == nil{
+			log.Printf("认领stream消息失败：%v", err)
+		}
+		return nil
+	}
+
+	return messages
+}
+
+// handle 处理一条消息，处理完成后确认并删除
+func (s *RedisStream) handle(ctx context.Context, msg redis.XMessage, handler func([]byte) error){
+	body, _ := msg.Values[streamBodyField].(string)
+	retryCount := 0
+	if retry, ok := msg.Values[streamRetryField].(string); ok{
+		retryCount, _ = strconv.Atoi(retry)
+	}
+
+	err := handler([]byte(body))
+	if err != nil{
+		log.Printf("处理消息失败：%v, 已重试次数：%d", err, retryCount)
+
+		// 延迟后重试，超过最大重试次数后写入死信stream
+		handleErr := err
+		deadLettered, err := s.retryOrDeadLetter(ctx, msg.ID, body, retryCount + 1, handleErr)
+		if err != nil{
+			// 不确认消息，等待之后被重新认领
+			log.Printf("投递重试消息失败：%v", err)
+			return
+		}
+
+		if deadLettered && s.onDeadLetter != nil{
+			s.onDeadLetter([]byte(body), handleErr)
+		}
+	}
+
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error{
+		pipe.XAck(ctx, s.config.Stream, s.config.Group, msg.ID)
+		pipe.XDel(ctx, s.config.Stream, msg.ID)
+		return nil
+	})
+	if err != nil{
+		log.Printf("确认stream消息失败：%v", err)
+	}
+}
+
+// retryOrDeadLetter 未超过最大重试次数时放入重试有序集合，到期后转移回stream，否则写入死信stream，返回是否写入了死信stream
+func (s *RedisStream) retryOrDeadLetter(ctx context.Context, id string, body string, retryCount int, handleErr error) (bool, error){
+	if retryCount > s.config.MaxRetries{
+		lastError := handleErr.Error()
+		if len(lastError) > 255{
+			lastError = lastError[:255]
+		}
+
+		err := s.add(ctx, s.deadLetterKey(), map[string]any{
+			streamBodyField:	body,
+			streamRetryField:	retryCount,
+			streamErrorField:	lastError,
+		})
+		if err != nil{
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	// 消息ID保证成员唯一，相同消息体的不同消息不会互相覆盖
+	member := fmt.Sprintf("%d:%s:%s", retryCount, id, body)
+	due := time.Now().Add(s.retryDelay(retryCount)).UnixMilli()
+
+	return false, s.client.ZAdd(ctx, s.retryKey(), redis.Z{Score: float64(due), Member: member}).Err()
+}
+
+// ListDeadLetters 查看死信stream中最早的limit条消息，不会移除消息
+func (s *RedisStream) ListDeadLetters(limit int) ([]*DeadLetter, error){
+	messages, err := s.client.XRangeN(context.Background(), s.deadLetterKey(), "-", "+", int64(limit)).Result()
+	if err != nil{
+		return nil, fmt.Errorf("获取死信消息失败：%w", err)
+	}
+
+	letters := make([]*DeadLetter, 0, len(messages))
+	for _, msg := range messages{
+		letters = append(letters, streamDeadLetter(msg))
+	}
+
+	return letters, nil
+}
+
+// streamDeadLetter 将死信stream中的消息转换为DeadLetter，消息ID的前半部分为写入时间(毫秒)
+func streamDeadLetter(msg redis.XMessage) *DeadLetter{
+	body, _ := msg.Values[streamBodyField].(string)
+	lastError, _ := msg.Values[streamErrorField].(string)
+	retry, _ := msg.Values[streamRetryField].(string)
+	retryCount, _ := strconv.Atoi(retry)
+
+	millis, _ := strconv.ParseInt(strings.SplitN(msg.ID, "-", 2)[0], 10, 64)
+
+	return &DeadLetter{
+		Body:		[]byte(body),
+		RetryCount:	retryCount,
+		LastError:	lastError,
+		Timestamp:	time.UnixMilli(millis),
+	}
+}
+
+// ReplayDeadLetters 将死信stream中最早的limit条消息重新写入业务stream，重试次数清零，返回重新投递的消息数
+func (s *RedisStream) ReplayDeadLetters(limit int) (int, error){
+	ctx := context.Background()
+
+	messages, err := s.client.XRangeN(ctx, s.deadLetterKey(), "-", "+", int64(limit)).Result()
+	if err != nil{
+		return 0, fmt.Errorf("获取死信消息失败：%w", err)
+	}
+
+	replayed := 0
+	for _, msg := range messages{
+		body, _ := msg.Values[streamBodyField].(string)
+
+		// 先写入业务stream再删除死信，删除失败时消息可能被重复投递，消费者需要保证幂等
+		err = s.Publish([]byte(body))
+		if err != nil{
+			return replayed, fmt.Errorf("重新投递死信消息失败：%w", err)
+		}
+
+		err = s.client.XDel(ctx, s.deadLetterKey(), msg.ID).Err()
+		if err != nil{
+			return replayed, fmt.Errorf("删除已重新投递的死信消息失败：%w", err)
+		}
+
+		replayed++
+	}
+
+	return replayed, nil
+}
+
+// PurgeDeadLetters 清空死信stream，返回删除的消息数
+func (s *RedisStream) PurgeDeadLetters() (int, error){
+	ctx := context.Background()
+
+	count, err := s.client.XLen(ctx, s.deadLetterKey()).Result()
+	if err != nil{
+		return 0, fmt.Errorf("清空死信队列失败：%w", err)
+	}
+
+	err = s.client.Del(ctx, s.deadLetterKey()).Err()
+	if err != nil{
+		return 0, fmt.Errorf("清空死信队列失败：%w", err)
+	}
+
+	return int(count), nil
+}
+
+// Close 停止消费，使用服务的共享连接时由服务统一关闭连接
+func (s *RedisStream) Close(){
+	if s.cancel != nil{
+		s.cancel()
+	}
+
+	if s.ownsClient{
+		s.client.Close()
+	}
+}
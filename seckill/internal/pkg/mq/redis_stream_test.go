@@ -0,0 +1,166 @@
+package mq
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestStream(t *testing.T, config *RedisStreamConfig) (*RedisStream, *redis.Client){
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func(){ client.Close() })
+
+	stream, err := NewRedisStream(config, client)
+	if err != nil{
+		t.Fatalf("创建Redis Streams消息队列失败：%v", err)
+	}
+	t.Cleanup(stream.Close)
+
+	return stream, client
+}
+
+// waitFor 轮询直到条件满足或超时
+func waitFor(t *testing.T, timeout time.Duration, cond func() bool){
+	t.Helper()
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline){
+		if cond(){
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("等待超时")
+}
+
+// 确认后的消息从stream中删除
+func TestRedisStreamAckDeletes(t *testing.T){
+	stream, client := newTestStream(t, &RedisStreamConfig{Stream: "test_stream"})
+	ctx := context.Background()
+
+	received := make(chan string, 1)
+	stream.Subscribe(func(body []byte) error{
+		received <- string(body)
+		return nil
+	})
+
+	if err := stream.Publish([]byte("hello")); err != nil{
+		t.Fatalf("发布消息失败：%v", err)
+	}
+
+	select{
+	case body := <- received:
+		if body != "hello"{
+			t.Fatalf("收到的消息为%q，期望hello", body)
+		}
+	case <- time.After(3 * time.Second):
+		t.Fatal("没有收到消息")
+	}
+
+	waitFor(t, time.Second, func() bool{
+		return client.XLen(ctx, "test_stream").Val() == 0
+	})
+}
+
+// 失败的消息延迟后重试，超过最大重试次数后写入死信stream
+func TestRedisStreamRetryAndDeadLetter(t *testing.T){
+	stream, client := newTestStream(t, &RedisStreamConfig{Stream: "test_stream", MaxRetries: 2, RetryDelay: 50})
+	ctx := context.Background()
+
+	var attempts atomic.Int32
+	var mu sync.Mutex
+	var times []time.Time
+	deadLetters := make(chan string, 1)
+	stream.OnDeadLetter(func(body []byte, err error){
+		deadLetters <- string(body)
+	})
+	stream.Subscribe(func(body []byte) error{
+		attempts.Add(1)
+		mu.Lock()
+		times = append(times, time.Now())
+		mu.Unlock()
+		return errors.New("处理失败")
+	})
+
+	stream.Publish([]byte("retry"))
+
+	// 第一次处理加上2次重试，之后进入死信stream
+	waitFor(t, 10 * time.Second, func() bool{
+		return client.XLen(ctx, "test_stream.dlq").Val() == 1
+	})
+	if got := attempts.Load(); got != 3{
+		t.Fatalf("处理次数为%d，期望3", got)
+	}
+	select{
+	case body := <- deadLetters:
+		if body != "retry"{
+			t.Fatalf("死信回调收到的消息为%q，期望retry", body)
+		}
+	case <- time.After(time.Second):
+		t.Fatal("写入死信后没有调用回调")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if gap := times[1].Sub(times[0]); gap < 50 * time.Millisecond{
+		t.Fatalf("第一次重试间隔%v，期望至少50ms", gap)
+	}
+	if gap := times[2].Sub(times[1]); gap < 100 * time.Millisecond{
+		t.Fatalf("第二次重试间隔%v，期望至少100ms", gap)
+	}
+
+	if n := client.XLen(ctx, "test_stream").Val(); n != 0{
+		t.Fatalf("stream中还有%d条消息，期望已删除", n)
+	}
+	if n := client.ZCard(ctx, "test_stream.retry").Val(); n != 0{
+		t.Fatalf("重试集合中还有%d条消息", n)
+	}
+
+	letters, err := stream.ListDeadLetters(10)
+	if err != nil{
+		t.Fatalf("获取死信消息失败：%v", err)
+	}
+	if len(letters) != 1 || string(letters[0].Body) != "retry" || letters[0].RetryCount != 3 || letters[0].LastError != "处理失败"{
+		t.Fatalf("死信消息为%+v，期望1条重试3次的retry", letters)
+	}
+}
+
+// 死信可以重新投递到业务stream和清空
+func TestRedisStreamReplayAndPurge(t *testing.T){
+	stream, client := newTestStream(t, &RedisStreamConfig{Stream: "test_stream"})
+	ctx := context.Background()
+
+	for _, body := range []string{"a", "b", "c"}{
+		deadLettered, err := stream.retryOrDeadLetter(ctx, "0-1", body, stream.config.MaxRetries + 1, errors.New("处理失败"))
+		if err != nil || !deadLettered{
+			t.Fatalf("写入死信失败：%v", err)
+		}
+	}
+
+	replayed, err := stream.ReplayDeadLetters(2)
+	if err != nil || replayed != 2{
+		t.Fatalf("重新投递%d条，错误：%v，期望2条", replayed, err)
+	}
+
+	messages := client.XRange(ctx, "test_stream", "-", "+").Val()
+	if len(messages) != 2 || messages[0].Values[streamBodyField] != "a" || messages[0].Values[streamRetryField] != "0"{
+		t.Fatalf("业务stream中的消息为%+v，期望重试次数清零的a和b", messages)
+	}
+
+	purged, err := stream.PurgeDeadLetters()
+	if err != nil || purged != 1{
+		t.Fatalf("清空%d条，错误：%v，期望1条", purged, err)
+	}
+	if n := client.Exists(ctx, "test_stream.dlq").Val(); n != 0{
+		t.Fatal("清空后死信stream应该被删除")
+	}
+}
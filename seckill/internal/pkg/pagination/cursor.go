@@ -0,0 +1,113 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const(
+	DefaultPageSize	= 20	// 未指定每页数量时的默认值
+	MaxPageSize		= 100	// 每页数量的上限
+)
+
+var ErrInvalidCursor = errors.New("分页游标无效")
+
+// Cursor 基于排序字段的游标，记录上一页最后一条记录的排序值和ID
+// 下一页从该记录之后开始，翻页期间插入或删除记录不会导致重复或遗漏
+type Cursor struct{
+	Sort	string	`json:"s"`		// 排序方式，翻页时排序方式需要和生成游标时一致
+	Value	string	`json:"v"`		// 排序字段的值
+	ID		uint	`json:"id"`		// 排序字段相同时按ID排序
+}
+
+// Encode 将游标编码为URL安全的字符串
+func (c *Cursor) Encode() string{
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// Decode 解析游标，空字符串表示第一页，返回nil
+// sort为本次请求的排序方式，和游标中的不一致时返回错误
+func Decode(s string, sort string) (*Cursor, error){
+	if s == ""{
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil{
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == 0{
+		return nil, ErrInvalidCursor
+	}
+	if c.Sort != sort{
+		return nil, fmt.Errorf("%w：排序方式和上一页不一致", ErrInvalidCursor)
+	}
+
+	return &c, nil
+}
+
+// PageSize 将请求中的每页数量限制在合法范围内
+func PageSize(size int32) int{
+	if size <= 0{
+		return DefaultPageSize
+	}
+
+	return min(int(size), MaxPageSize)
+}
+
+// TimeValue 将时间编码为游标中的排序值
+func TimeValue(t time.Time) string{
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// FloatValue 将数值编码为游标中的排序值
+func FloatValue(f float64) string{
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// ParseTime 解析游标中的时间排序值
+func (c *Cursor) ParseTime() (time.Time, error){
+	t, err := time.Parse(time.RFC3339Nano, c.Value)
+	if err != nil{
+		return time.Time{}, ErrInvalidCursor
+	}
+
+	return t, nil
+}
+
+// ParseFloat 解析游标中的数值排序值
+func (c *Cursor) ParseFloat() (float64, error){
+	f, err := strconv.ParseFloat(c.Value, 64)
+	if err != nil{
+		return 0, ErrInvalidCursor
+	}
+
+	return f, nil
+}
+
+// Seek 按排序字段和ID排序，并只查询游标之后的记录
+// column和idColumn需要带表名，value为游标中解析出的排序值，cursor为nil时从第一页开始
+func Seek(query *gorm.DB, column string, idColumn string, asc bool, cursor *Cursor, value any) *gorm.DB{
+	direction, compare := "DESC", "<"
+	if asc{
+		direction, compare = "ASC", ">"
+	}
+
+	if cursor != nil{
+		query = query.Where(
+			fmt.Sprintf("((%s %s ?) OR (%s = ? AND %s %s ?))", column, compare, column, idColumn, compare),
+			value, value, cursor.ID,
+		)
+	}
+
+	return query.Order(fmt.Sprintf("%s %s, %s %s", column, direction, idColumn, direction))
+}
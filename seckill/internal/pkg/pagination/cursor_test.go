@@ -0,0 +1,50 @@
+package pagination
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 678000000, time.UTC)
+	cursor := &Cursor{Sort: "created:desc", Value: TimeValue(created), ID: 42}
+
+	decoded, err := Decode(cursor.Encode(), "created:desc")
+	if err != nil {
+		t.Fatalf("解析游标失败：%v", err)
+	}
+	if *decoded != *cursor {
+		t.Fatalf("解析得到%+v，期望%+v", decoded, cursor)
+	}
+
+	value, err := decoded.ParseTime()
+	if err != nil || !value.Equal(created) {
+		t.Fatalf("游标中的时间为%v, %v，期望%v", value, err, created)
+	}
+}
+
+func TestDecodeRejectsInvalidCursor(t *testing.T) {
+	if c, err := Decode("", "created:desc"); c != nil || err != nil {
+		t.Fatalf("空游标解析结果为%v, %v，期望第一页", c, err)
+	}
+
+	if _, err := Decode("not-a-cursor", "created:desc"); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("非法游标的错误为%v，期望ErrInvalidCursor", err)
+	}
+
+	// 翻页时修改了排序方式
+	cursor := &Cursor{Sort: "price:asc", Value: FloatValue(9.9), ID: 1}
+	if _, err := Decode(cursor.Encode(), "price:desc"); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("排序方式不一致时的错误为%v，期望ErrInvalidCursor", err)
+	}
+}
+
+func TestPageSize(t *testing.T) {
+	cases := map[int32]int{0: DefaultPageSize, -1: DefaultPageSize, 10: 10, 1000: MaxPageSize}
+	for size, want := range cases {
+		if got := PageSize(size); got != want {
+			t.Fatalf("PageSize(%d) = %d，期望%d", size, got, want)
+		}
+	}
+}
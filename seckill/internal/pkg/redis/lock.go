@@ -36,7 +36,7 @@ func (l *DistributedLock) TryLock(ctx context.Context) (bool, error){
 func (l *DistributedLock) Unlock(ctx context.Context) error{
 	const script =
 	`
-	if redis.call("GET", KEY[1]) == ARGV[1] then
+	if redis.call("GET", KEYS[1]) == ARGV[1] then
 		return redis.call("DEL", KEYS[1])
 	else
 		return 0
@@ -12,8 +12,19 @@ var Client *redis.Client
 
 // 此处为共享的初始化Redis函数
 func InitRedis(config *RedisConfig) error{
-	var err error
-	Client = redis.NewClient(&redis.Options{
+	client, err := NewClient(config)
+	if err != nil{
+		return err
+	}
+	Client = client
+
+	log.Printf("Redis连接成功")
+	return nil
+}
+
+// NewClient 创建独立的Redis连接并测试连通性，用于和服务的共享连接使用不同的库
+func NewClient(config *RedisConfig) (*redis.Client, error){
+	client := redis.NewClient(&redis.Options{
 		Addr 		: fmt.Sprintf("%s:%d",config.Host,config.Port),
 		Password 	: config.Password,
 		DB 			: config.DB,
@@ -22,13 +33,13 @@ func InitRedis(config *RedisConfig) error{
 	
 	// 接着连接并测试连通性
 	ctx := context.Background()
-	_,err = Client.Ping(ctx).Result()
+	_, err := client.Ping(ctx).Result()
 	if err != nil{
-		return fmt.Errorf("Redis连接失败：%w",err)
+		client.Close()
+		return nil, fmt.Errorf("Redis连接失败：%w",err)
 	}
 
-	log.Printf("Redis连接成功")
-	return nil
+	return client, nil
 }
 
 // GetRedis 用于返回Redis client的实例
@@ -0,0 +1,142 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+)
+
+const(
+	// saga状态常量
+	StatusRunning		= 0 // 执行中
+	StatusDone			= 1 // 所有步骤执行成功
+	StatusCompensating	= 2 // 补偿中
+	StatusCompensated	= 3 // 补偿完成
+)
+
+// Step saga中的一个步骤
+type Step struct{
+	Name		string
+	Action		func(ctx context.Context) error
+	// Compensate 撤销Action的影响，可以为nil
+	// 恢复时无法确定中断的步骤是否已执行，补偿可能被重复调用或在Action未执行时调用，因此必须是幂等的
+	Compensate	func(ctx context.Context) error
+}
+
+// abortError 确定没有产生任何影响的步骤错误
+type abortError struct{
+	err error
+}
+
+func (e *abortError) Error() string{
+	return e.err.Error()
+}
+
+func (e *abortError) Unwrap() error{
+	return e.err
+}
+
+// Abort 包装一个确定没有产生任何影响的错误(例如库存不足被拒绝)，saga失败时不再补偿当前步骤
+func Abort(err error) error{
+	return &abortError{err: err}
+}
+
+// Store 持久化saga的执行进度
+type Store interface{
+	// UpdateProgress 保存可能已生效的步骤数和当前状态
+	UpdateProgress(ctx context.Context, sagaID string, step int, status int) error
+}
+
+// Saga 按顺序执行的一组步骤，任一步骤失败时倒序执行补偿
+type Saga struct{
+	ID		string
+	Steps	[]Step
+	store	Store
+}
+
+// New 创建saga
+func New(id string, store Store, steps ...Step) *Saga{
+	return &Saga{
+		ID:		id,
+		Steps:	steps,
+		store:	store,
+	}
+}
+
+// Execute 执行saga
+func (s *Saga) Execute(ctx context.Context) error{
+	for i, step := range s.Steps{
+		err := step.Action(ctx)
+		if err != nil{
+			// 失败的步骤可能已部分生效(例如RPC超时但对方已执行)，因此默认从当前步骤开始补偿
+			affected := i + 1
+
+			var abortErr *abortError
+			if errors.As(err, &abortErr){
+				affected = i
+			}
+
+			compensateErr := s.compensate(ctx, affected)
+			if compensateErr != nil{
+				return fmt.Errorf("步骤%s失败：%w，%v", step.Name, err, compensateErr)
+			}
+
+			return fmt.Errorf("步骤%s失败：%w", step.Name, err)
+		}
+
+		// 进度保存失败不影响执行，恢复时依赖补偿的幂等性
+		s.saveProgress(ctx, i+1, StatusRunning)
+	}
+
+	s.saveProgress(ctx, len(s.Steps), StatusDone)
+
+	return nil
+}
+
+// Recover 根据持久化的进度恢复中断的saga
+// 未完成的saga会从中断的步骤开始倒序补偿
+func (s *Saga) Recover(ctx context.Context, step int, status int) error{
+	switch status{
+	case StatusDone, StatusCompensated:
+		return nil
+	case StatusRunning:
+		if step >= len(s.Steps){
+			s.saveProgress(ctx, len(s.Steps), StatusDone)
+			return nil
+		}
+
+		// 中断时下一步可能已执行但未保存进度，需要一并补偿
+		return s.compensate(ctx, step+1)
+	default:
+		return s.compensate(ctx, min(step, len(s.Steps)))
+	}
+}
+
+// compensate 倒序补偿前affected个步骤
+func (s *Saga) compensate(ctx context.Context, affected int) error{
+	s.saveProgress(ctx, affected, StatusCompensating)
+
+	for i := affected - 1; i >= 0; i--{
+		if s.Steps[i].Compensate == nil{
+			continue
+		}
+
+		err := s.Steps[i].Compensate(ctx)
+		if err != nil{
+			// 保持补偿中的状态，等待恢复任务重试
+			return fmt.Errorf("补偿步骤%s失败：%w", s.Steps[i].Name, err)
+		}
+	}
+
+	s.saveProgress(ctx, affected, StatusCompensated)
+
+	return nil
+}
+
+func (s *Saga) saveProgress(ctx context.Context, step int, status int){
+	err := s.store.UpdateProgress(ctx, s.ID, step, status)
+	if err != nil{
+		log.Printf("保存saga进度失败：%v, sagaID：%v", err, s.ID)
+	}
+}
@@ -0,0 +1,153 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type memoryStore struct {
+	step   int
+	status int
+}
+
+func (m *memoryStore) UpdateProgress(ctx context.Context, sagaID string, step int, status int) error {
+	m.step = step
+	m.status = status
+	return nil
+}
+
+// newTestSaga 创建3个步骤的saga，failAt为执行失败的步骤(-1表示不失败)，calls记录调用顺序
+func newTestSaga(store Store, failAt int, calls *[]string) *Saga {
+	names := []string{"deduct", "query", "create"}
+	steps := make([]Step, 0, len(names))
+
+	for i, name := range names {
+		i, name := i, name
+		steps = append(steps, Step{
+			Name: name,
+			Action: func(ctx context.Context) error {
+				*calls = append(*calls, name)
+				if i == failAt {
+					return errors.New("注入的错误")
+				}
+				return nil
+			},
+			Compensate: func(ctx context.Context) error {
+				*calls = append(*calls, "undo-"+name)
+				return nil
+			},
+		})
+	}
+
+	return New("test", store, steps...)
+}
+
+func TestExecuteFailAtEachStep(t *testing.T) {
+	cases := []struct {
+		failAt int
+		calls  []string
+	}{
+		{0, []string{"deduct", "undo-deduct"}},
+		{1, []string{"deduct", "query", "undo-query", "undo-deduct"}},
+		{2, []string{"deduct", "query", "create", "undo-create", "undo-query", "undo-deduct"}},
+	}
+
+	for _, c := range cases {
+		var calls []string
+		store := &memoryStore{}
+
+		err := newTestSaga(store, c.failAt, &calls).Execute(context.Background())
+		if err == nil {
+			t.Errorf("第%d步失败时应返回错误", c.failAt)
+		}
+
+		if !reflect.DeepEqual(calls, c.calls) {
+			t.Errorf("第%d步失败，期待调用 %v, 但实际调用 %v", c.failAt, c.calls, calls)
+		}
+
+		if store.status != StatusCompensated {
+			t.Errorf("第%d步失败，期待状态 %d, 但实际状态 %d", c.failAt, StatusCompensated, store.status)
+		}
+	}
+}
+
+func TestExecuteAbort(t *testing.T) {
+	var calls []string
+	store := &memoryStore{}
+	s := New("test", store,
+		Step{
+			Name:       "deduct",
+			Action:     func(ctx context.Context) error { return Abort(errors.New("库存不足")) },
+			Compensate: func(ctx context.Context) error { calls = append(calls, "undo-deduct"); return nil },
+		},
+	)
+
+	if err := s.Execute(context.Background()); err == nil {
+		t.Fatalf("步骤失败时应返回错误")
+	}
+
+	// 确定没有生效的步骤不需要补偿
+	if len(calls) != 0 {
+		t.Errorf("不应执行补偿，实际调用 %v", calls)
+	}
+}
+
+func TestExecuteSuccess(t *testing.T) {
+	var calls []string
+	store := &memoryStore{}
+
+	if err := newTestSaga(store, -1, &calls).Execute(context.Background()); err != nil {
+		t.Fatalf("执行saga失败：%v", err)
+	}
+
+	if store.status != StatusDone || store.step != 3 {
+		t.Errorf("期待进度 3/%d, 但实际进度 %d/%d", StatusDone, store.step, store.status)
+	}
+}
+
+func TestCompensateFail(t *testing.T) {
+	store := &memoryStore{}
+	s := New("test", store,
+		Step{
+			Name:       "deduct",
+			Action:     func(ctx context.Context) error { return nil },
+			Compensate: func(ctx context.Context) error { return errors.New("归还库存失败") },
+		},
+		Step{
+			Name:   "create",
+			Action: func(ctx context.Context) error { return errors.New("创建订单失败") },
+		},
+	)
+
+	if err := s.Execute(context.Background()); err == nil {
+		t.Fatalf("补偿失败时应返回错误")
+	}
+
+	// 补偿失败时保持补偿中的状态，等待恢复
+	if store.status != StatusCompensating {
+		t.Errorf("期待状态 %d, 但实际状态 %d", StatusCompensating, store.status)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	// 崩溃时已完成1步，恢复时从第2步开始倒序补偿
+	var calls []string
+	store := &memoryStore{}
+
+	if err := newTestSaga(store, -1, &calls).Recover(context.Background(), 1, StatusRunning); err != nil {
+		t.Fatalf("恢复saga失败：%v", err)
+	}
+
+	expected := []string{"undo-query", "undo-deduct"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("期待调用 %v, 但实际调用 %v", expected, calls)
+	}
+
+	// 已完成的saga不需要恢复
+	calls = nil
+	if err := newTestSaga(store, -1, &calls).Recover(context.Background(), 3, StatusDone); err != nil || len(calls) != 0 {
+		t.Errorf("已完成的saga不应执行任何步骤，实际调用 %v", calls)
+	}
+}
@@ -0,0 +1,7 @@
+package snowflake
+
+// 每个服务实例的 datacenter_id + worker_id 组合必须唯一
+type SnowflakeConfig struct{
+	DatacenterID	int64	`mapstructure:"datacenter_id"`	// 数据中心ID 0~31
+	WorkerID		int64	`mapstructure:"worker_id"`		// 机器ID 0~31
+}
@@ -0,0 +1,111 @@
+package snowflake
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ID结构(共63位，保证为正数)：
+// 41位毫秒时间戳 | 5位数据中心ID | 5位机器ID | 12位序列号
+const(
+	datacenterBits	= 5
+	workerBits		= 5
+	sequenceBits	= 12
+
+	maxDatacenterID	= -1 ^ (-1 << datacenterBits)
+	maxWorkerID		= -1 ^ (-1 << workerBits)
+	maxSequence		= -1 ^ (-1 << sequenceBits)
+
+	workerShift		= sequenceBits
+	datacenterShift	= sequenceBits + workerBits
+	timestampShift	= sequenceBits + workerBits + datacenterBits
+
+	// 起始时间 2025-01-01 00:00:00 UTC(毫秒)，可以使用约69年
+	epoch int64 = 1735689600000
+
+	// 允许等待的最大时钟回拨时间(毫秒)，超过则直接返回错误
+	maxBackwardMillis int64 = 10
+)
+
+// Generator 雪花算法ID生成器
+type Generator struct{
+	mu				sync.Mutex
+	datacenterID	int64
+	workerID		int64
+	lastTimestamp	int64
+	sequence		int64
+	now				func() int64	// 获取当前毫秒时间戳，方便测试时模拟时钟回拨
+}
+
+// NewGenerator 创建ID生成器
+func NewGenerator(config *SnowflakeConfig) (*Generator, error){
+	if config.DatacenterID < 0 || config.DatacenterID > maxDatacenterID{
+		return nil, fmt.Errorf("数据中心ID必须在0~%d之间", maxDatacenterID)
+	}
+
+	if config.WorkerID < 0 || config.WorkerID > maxWorkerID{
+		return nil, fmt.Errorf("机器ID必须在0~%d之间", maxWorkerID)
+	}
+
+	return &Generator{
+		datacenterID:	config.DatacenterID,
+		workerID:		config.WorkerID,
+		now:			func() int64{ return time.Now().UnixMilli() },
+	}, nil
+}
+
+// NextID 生成下一个ID
+func (g *Generator) NextID() (int64, error){
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	timestamp := g.now()
+
+	// 处理时钟回拨：回拨时间较短时等待时钟追上，否则返回错误，避免生成重复ID
+	if timestamp < g.lastTimestamp{
+		offset := g.lastTimestamp - timestamp
+		if offset > maxBackwardMillis{
+			return 0, fmt.Errorf("时钟回拨%d毫秒，拒绝生成ID", offset)
+		}
+
+		time.Sleep(time.Duration(offset) * time.Millisecond)
+
+		timestamp = g.now()
+		if timestamp < g.lastTimestamp{
+			return 0, fmt.Errorf("时钟回拨%d毫秒，拒绝生成ID", g.lastTimestamp - timestamp)
+		}
+	}
+
+	if timestamp == g.lastTimestamp{
+		// 同一毫秒内序列号自增，用完后等待下一毫秒
+		g.sequence = (g.sequence + 1) & maxSequence
+		if g.sequence == 0{
+			for timestamp <= g.lastTimestamp{
+				timestamp = g.now()
+			}
+		}
+	}else{
+		g.sequence = 0
+	}
+
+	g.lastTimestamp = timestamp
+
+	id := (timestamp - epoch) << timestampShift |
+		g.datacenterID << datacenterShift |
+		g.workerID << workerShift |
+		g.sequence
+
+	return id, nil
+}
+
+// NextSn 生成下一个订单号
+// 固定为19位数字，保证按字符串排序和按生成时间排序一致
+func (g *Generator) NextSn() (string, error){
+	id, err := g.NextID()
+	if err != nil{
+		return "", err
+	}
+
+	return fmt.Sprintf("%019d", id), nil
+}
@@ -0,0 +1,92 @@
+package snowflake
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestNextSnUnique(t *testing.T) {
+	g, err := NewGenerator(&SnowflakeConfig{DatacenterID: 1, WorkerID: 1})
+	if err != nil {
+		t.Fatalf("创建生成器失败：%v", err)
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	seen := make(map[string]bool)
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10000; j++ {
+				sn, err := g.NextSn()
+				if err != nil {
+					t.Errorf("生成订单号失败：%v", err)
+					return
+				}
+
+				mu.Lock()
+				if seen[sn] {
+					t.Errorf("订单号重复：%s", sn)
+				}
+				seen[sn] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestNextSnSortable(t *testing.T) {
+	g, _ := NewGenerator(&SnowflakeConfig{})
+
+	last := ""
+	for i := 0; i < 10000; i++ {
+		sn, err := g.NextSn()
+		if err != nil {
+			t.Fatalf("生成订单号失败：%v", err)
+		}
+		if len(sn) != 19 || sn <= last {
+			t.Fatalf("订单号不是递增的19位字符串：%s -> %s", last, sn)
+		}
+		last = sn
+	}
+}
+
+func TestDifferentWorkers(t *testing.T) {
+	g1, _ := NewGenerator(&SnowflakeConfig{WorkerID: 1})
+	g2, _ := NewGenerator(&SnowflakeConfig{WorkerID: 2})
+
+	// 两个实例在同一毫秒生成的ID也不会重复
+	g1.now = func() int64 { return epoch + 1000 }
+	g2.now = func() int64 { return epoch + 1000 }
+
+	id1, _ := g1.NextID()
+	id2, _ := g2.NextID()
+	if id1 == id2 {
+		t.Errorf("不同机器生成了相同的ID：%d", id1)
+	}
+}
+
+func TestClockBackward(t *testing.T) {
+	g, _ := NewGenerator(&SnowflakeConfig{})
+
+	now := epoch + 1000
+	g.now = func() int64 { return now }
+	if _, err := g.NextID(); err != nil {
+		t.Fatalf("生成ID失败：%v", err)
+	}
+
+	// 回拨较大时直接返回错误
+	now -= 1000
+	if _, err := g.NextID(); err == nil {
+		t.Errorf("时钟回拨时应返回错误")
+	}
+}
+
+func TestInvalidConfig(t *testing.T) {
+	if _, err := NewGenerator(&SnowflakeConfig{WorkerID: 32}); err == nil {
+		t.Errorf("机器ID超出范围时应返回错误")
+	}
+}
@@ -0,0 +1,28 @@
+package testenv
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	myRedis "Redrock/seckill/internal/pkg/redis"
+)
+
+// NewRedis 启动进程内的miniredis并返回连接，同时替换redis.Client，测试结束后恢复
+// miniredis支持Lua脚本、Streams和过期时间，测试不依赖外部的Redis
+func NewRedis(t testing.TB) *redis.Client{
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+
+	old := myRedis.Client
+	myRedis.Client = client
+	t.Cleanup(func(){
+		myRedis.Client = old
+		client.Close()
+	})
+
+	return client
+}
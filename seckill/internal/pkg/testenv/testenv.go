@@ -0,0 +1,49 @@
+// Package testenv 提供测试使用的数据库，测试不依赖外部的MySQL
+package testenv
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"Redrock/seckill/internal/pkg/database"
+)
+
+var dbSeq atomic.Int64
+
+// NewDB 创建内存中的SQLite数据库并迁移models，同时替换database.DB，测试结束后恢复
+// 只使用一个连接，保证所有查询看到同一个内存数据库；事务中不能再使用事务外的连接
+func NewDB(t testing.TB, models ...any) *gorm.DB{
+	t.Helper()
+
+	dsn := fmt.Sprintf("file:testenv%d?mode=memory&cache=shared&_pragma=busy_timeout(5000)", dbSeq.Add(1))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil{
+		t.Fatalf("打开测试数据库失败：%v", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil{
+		t.Fatalf("获取测试数据库连接失败：%v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	if err := db.AutoMigrate(models...); err != nil{
+		t.Fatalf("迁移测试数据库失败：%v", err)
+	}
+
+	old := database.DB
+	database.DB = db
+	t.Cleanup(func(){
+		database.DB = old
+		sqlDB.Close()
+	})
+
+	return db
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"Redrock/seckill/internal/pkg/database"
+)
+
+// Config 定义了商品服务所需的配置
+type Config struct{
+	Server		ServerConfig			`mapstructure:"server"`
+	Database	database.DatabaseConfig	`mapstructure:"database"`
+	ActivityRPC	ActivityRPCConfig		`mapstructure:"activity_rpc"`
+}
+
+// ServerConfig 定义了Kitex服务器的配置
+type ServerConfig struct{
+	ServiceName	string	`mapstructure:"service_name"`
+	Host		string	`mapstructure:"host"`
+	Port		int		`mapstructure:"port"`
+	LogLevel	string	`mapstructure:"log_level"`
+}
+
+// ActivityRPCConfig 活动服务客户端配置，删除商品时检查是否有未结束的活动
+type ActivityRPCConfig struct{
+	Host		string	`mapstructure:"host"`
+	Port		int		`mapstructure:"port"`
+	Timeout		int		`mapstructure:"timeout"`	// 毫秒
+}
@@ -0,0 +1,109 @@
+package data
+
+import (
+	"context"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"Redrock/seckill/internal/pkg/database"
+	"Redrock/seckill/internal/pkg/models"
+)
+
+// ProductData 商品数据访问层
+type ProductData struct{
+	db *gorm.DB
+}
+
+// NewProductData 创建商品数据访问对象
+func NewProductData() *ProductData{
+	return &ProductData{
+		db: database.GetDB(),
+	}
+}
+
+// Create 创建商品
+func (d *ProductData) Create(ctx context.Context, product *models.Product) error{
+	return d.db.WithContext(ctx).Create(product).Error
+}
+
+// GetByID 通过productID获取商品
+func (d *ProductData) GetByID(ctx context.Context, id uint) (*models.Product, error){
+	var product models.Product
+	err := d.db.WithContext(ctx).First(&product, id).Error
+	if err != nil{
+		return nil, err
+	}
+	return &product, nil
+}
+
+// List 按分类和商家分页获取商品，category为空、merchantID为0时不筛选
+func (d *ProductData) List(ctx context.Context, category string, merchantID uint, page int, pageSize int) ([]*models.Product, int64, error){
+	var products []*models.Product
+	var count int64
+
+	query := d.db.WithContext(ctx).Model(&models.Product{})
+	if category != ""{
+		query = query.Where("category = ?", category)
+	}
+	if merchantID != 0{
+		query = query.Where("merchant_id = ?", merchantID)
+	}
+
+	if err := query.Count(&count).Error; err != nil{
+		return nil, 0, err
+	}
+
+	err := query.Order("id DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&products).Error
+	if err != nil{
+		return nil, 0, err
+	}
+
+	return products, count, nil
+}
+
+// Update 修改商品
+func (d *ProductData) Update(ctx context.Context, id uint, fields map[string]any) error{
+	return d.db.WithContext(ctx).Model(&models.Product{}).Where("id = ?", id).Updates(fields).Error
+}
+
+// Delete 删除商品(软删除)
+func (d *ProductData) Delete(ctx context.Context, id uint) error{
+	return d.db.WithContext(ctx).Delete(&models.Product{}, id).Error
+}
+
+// Restore 恢复软删除的商品
+func (d *ProductData) Restore(ctx context.Context, id uint) error{
+	return d.db.WithContext(ctx).Unscoped().Model(&models.Product{}).Where("id = ?", id).Update("deleted_at", nil).Error
+}
+
+// ListByIDs 获取ids中的商品，不存在或已删除的商品不返回
+func (d *ProductData) ListByIDs(ctx context.Context, ids []uint) ([]*models.Product, error){
+	var products []*models.Product
+
+	err := d.db.WithContext(ctx).Where("id IN ?", ids).Find(&products).Error
+	if err != nil{
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// SearchIDs 按名称模糊查询商品ID，最多返回limit个
+func (d *ProductData) SearchIDs(ctx context.Context, name string, limit int) ([]uint, error){
+	var ids []uint
+
+	err := d.db.WithContext(ctx).Model(&models.Product{}).
+		Where("name LIKE ? ESCAPE '!'", "%" + escapeLike(name) + "%").
+		Order("id DESC").Limit(limit).Pluck("id", &ids).Error
+	if err != nil{
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// escapeLike 转义LIKE中的通配符，使用!作为转义字符，MySQL和SQLite的写法相同
+func escapeLike(s string) string{
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
+}
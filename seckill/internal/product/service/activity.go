@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudwego/kitex/client"
+
+	"Redrock/seckill/internal/product/config"
+	activity "Redrock/seckill/kitex_gen/activity"
+	"Redrock/seckill/kitex_gen/activity/internalactivityservice"
+)
+
+// newActivityClient 创建内部活动服务客户端
+func newActivityClient(cfg config.ActivityRPCConfig) internalactivityservice.Client{
+	activityClient, err := internalactivityservice.NewClient(
+		"activity_service",
+		client.WithHostPorts(fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)),
+		client.WithRPCTimeout(time.Duration(cfg.Timeout) * time.Millisecond),
+	)
+	if err != nil{
+		panic(fmt.Sprintf("创建活动客户端失败：%v", err))
+	}
+
+	return activityClient
+}
+
+// countUnfinishedActivities 通过活动服务统计使用该商品且还没有结束的活动数量
+func (s *ProductServiceImpl) countUnfinishedActivities(ctx context.Context, productID uint) (int64, error){
+	resp, err := s.activityClient.CountProductActivities(ctx, &activity.CountProductActivitiesRequest{
+		ProductID: int64(productID),
+	})
+	if err != nil{
+		return 0, err
+	}
+	if resp.BaseResponse.Code != 0{
+		return 0, fmt.Errorf("%s", resp.BaseResponse.Msg)
+	}
+
+	return resp.Count, nil
+}
@@ -0,0 +1,373 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"gorm.io/gorm"
+
+	"Redrock/seckill/internal/pkg/models"
+	"Redrock/seckill/internal/product/config"
+	"Redrock/seckill/internal/product/data"
+	"Redrock/seckill/kitex_gen/activity/internalactivityservice"
+	product "Redrock/seckill/kitex_gen/product"
+)
+
+const(
+	// 商品列表每页的最大数量，也是批量获取商品的最大数量
+	maxPageSize = 100
+
+	// 按名称查询商品ID时最多返回的数量
+	maxSearchIDs = 1000
+)
+
+// ProductServiceImpl implements the last service interface defined in the IDL.
+type ProductServiceImpl struct{
+	productData		*data.ProductData
+	activityClient	internalactivityservice.Client
+}
+
+// NewProductServiceImpl 创建商品服务实例
+func NewProductServiceImpl(config *config.Config) *ProductServiceImpl{
+	return &ProductServiceImpl{
+		productData:	data.NewProductData(),
+		activityClient:	newActivityClient(config.ActivityRPC),
+	}
+}
+
+// toProductInfo 将商品转换为响应中的商品信息
+func toProductInfo(p *models.Product) *product.ProductInfo{
+	return &product.ProductInfo{
+		Id:				int64(p.ID),
+		Name:			p.Name,
+		Description:	p.Description,
+		Price:			p.Price,
+		ImageUrl:		p.ImageURL,
+		Category:		p.Category,
+		CreateTime:		p.CreatedAt.Unix(),
+		MerchantID:		int64(p.MerchantID),
+	}
+}
+
+// loadProduct 获取商品，失败时设置响应的错误码并返回nil
+func (s *ProductServiceImpl) loadProduct(ctx context.Context, id int64, baseResponse *product.BaseResponse) *models.Product{
+	if id <= 0{
+		baseResponse.Code = 400
+		baseResponse.Msg  = "商品ID不能为空"
+
+		return nil
+	}
+
+	localProduct, err := s.productData.GetByID(ctx, uint(id))
+	if err != nil{
+		if errors.Is(err, gorm.ErrRecordNotFound){
+			baseResponse.Code = 404
+			baseResponse.Msg  = "商品不存在"
+
+			return nil
+		}
+
+		baseResponse.Code = 500
+		baseResponse.Msg  = "获取商品信息失败：" + err.Error()
+
+		return nil
+	}
+
+	return localProduct
+}
+
+// CreateProduct 创建商品
+func (s *ProductServiceImpl) CreateProduct(ctx context.Context, req *product.CreateProductRequest) (*product.CreateProductResponse, error){
+	response := &product.CreateProductResponse{
+		BaseResponse: &product.BaseResponse{},
+	}
+
+	if req.Name == "" || req.Price < 0 || req.MerchantID < 0{
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "商品名称不能为空，价格不能小于0"
+
+		return response, nil
+	}
+
+	newProduct := &models.Product{
+		Name:			req.Name,
+		Description:	req.Description,
+		Price:			req.Price,
+		ImageURL:		req.ImageUrl,
+		Category:		req.Category,
+		MerchantID:		uint(req.MerchantID),
+	}
+
+	err := s.productData.Create(ctx, newProduct)
+	if err != nil{
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "创建商品失败：" + err.Error()
+
+		return response, nil
+	}
+
+	response.ProductID = int64(newProduct.ID)
+	response.BaseResponse.Code = 0
+	response.BaseResponse.Msg  = "创建商品成功"
+
+	return response, nil
+}
+
+// UpdateProduct 修改商品
+// 已缓存的活动信息中的商品信息不会更新，活动详情中的商品信息以商品服务为准
+func (s *ProductServiceImpl) UpdateProduct(ctx context.Context, req *product.UpdateProductRequest) (*product.UpdateProductResponse, error){
+	response := &product.UpdateProductResponse{
+		BaseResponse: &product.BaseResponse{},
+	}
+
+	localProduct := s.loadProduct(ctx, req.ProductID, response.BaseResponse)
+	if localProduct == nil{
+		return response, nil
+	}
+
+	fields := map[string]any{}
+
+	if req.Name != nil{
+		if *req.Name == ""{
+			response.BaseResponse.Code = 400
+			response.BaseResponse.Msg  = "商品名称不能为空"
+
+			return response, nil
+		}
+		fields["name"] = *req.Name
+	}
+
+	if req.Price != nil{
+		if *req.Price < 0{
+			response.BaseResponse.Code = 400
+			response.BaseResponse.Msg  = "商品价格不能小于0"
+
+			return response, nil
+		}
+		fields["price"] = *req.Price
+	}
+
+	if req.Description != nil{
+		fields["description"] = *req.Description
+	}
+
+	if req.ImageUrl != nil{
+		fields["image_url"] = *req.ImageUrl
+	}
+
+	if req.Category != nil{
+		fields["category"] = *req.Category
+	}
+
+	if len(fields) == 0{
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "没有需要修改的内容"
+
+		return response, nil
+	}
+
+	err := s.productData.Update(ctx, localProduct.ID, fields)
+	if err != nil{
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "修改商品失败：" + err.Error()
+
+		return response, nil
+	}
+
+	localProduct, err = s.productData.GetByID(ctx, localProduct.ID)
+	if err != nil{
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "获取商品信息失败：" + err.Error()
+
+		return response, nil
+	}
+
+	response.Product = toProductInfo(localProduct)
+	response.BaseResponse.Code = 0
+	response.BaseResponse.Msg  = "修改商品成功"
+
+	return response, nil
+}
+
+// GetProduct 获取商品详情
+func (s *ProductServiceImpl) GetProduct(ctx context.Context, req *product.GetProductRequest) (*product.GetProductResponse, error){
+	response := &product.GetProductResponse{
+		BaseResponse: &product.BaseResponse{},
+	}
+
+	localProduct := s.loadProduct(ctx, req.ProductID, response.BaseResponse)
+	if localProduct == nil{
+		return response, nil
+	}
+
+	response.Product = toProductInfo(localProduct)
+	response.BaseResponse.Code = 0
+	response.BaseResponse.Msg  = "查询商品信息成功"
+
+	return response, nil
+}
+
+// ListProducts 分页获取商品列表
+func (s *ProductServiceImpl) ListProducts(ctx context.Context, req *product.ListProductsRequest) (*product.ListProductsResponse, error){
+	response := &product.ListProductsResponse{
+		BaseResponse:	&product.BaseResponse{},
+		Products:		[]*product.ProductInfo{},
+	}
+
+	page := int(req.Page)
+	if page <= 0{
+		page = 1
+	}
+
+	pageSize := int(req.PageSize)
+	if pageSize <= 0{
+		pageSize = 20
+	}
+	pageSize = min(pageSize, maxPageSize)
+
+	products, total, err := s.productData.List(ctx, req.Category, uint(max(req.MerchantID, 0)), page, pageSize)
+	if err != nil{
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "查询商品列表失败：" + err.Error()
+
+		return response, nil
+	}
+
+	for _, p := range products{
+		response.Products = append(response.Products, toProductInfo(p))
+	}
+
+	response.Total = total
+	response.BaseResponse.Code = 0
+	response.BaseResponse.Msg  = "查询商品列表成功"
+
+	return response, nil
+}
+
+// DeleteProduct 删除商品，有未结束的活动使用该商品时不能删除
+// 先删除商品再通过活动服务检查活动，有活动时恢复商品；活动服务创建活动时写入活动后会再检查一次商品，
+// 并发创建活动时两边至少有一方能看到对方的修改
+func (s *ProductServiceImpl) DeleteProduct(ctx context.Context, req *product.DeleteProductRequest) (*product.DeleteProductResponse, error){
+	response := &product.DeleteProductResponse{
+		BaseResponse: &product.BaseResponse{},
+	}
+
+	localProduct := s.loadProduct(ctx, req.ProductID, response.BaseResponse)
+	if localProduct == nil{
+		return response, nil
+	}
+
+	err := s.productData.Delete(ctx, localProduct.ID)
+	if err != nil{
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "删除商品失败：" + err.Error()
+
+		return response, nil
+	}
+
+	count, err := s.countUnfinishedActivities(ctx, localProduct.ID)
+	if err != nil || count > 0{
+		if restoreErr := s.productData.Restore(ctx, localProduct.ID); restoreErr != nil{
+			log.Printf("恢复商品%d失败：%v", localProduct.ID, restoreErr)
+		}
+
+		if err != nil{
+			response.BaseResponse.Code = 500
+			response.BaseResponse.Msg  = "查询商品的活动失败：" + err.Error()
+
+			return response, nil
+		}
+
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "有未结束的活动使用该商品，不能删除"
+
+		return response, nil
+	}
+
+	response.BaseResponse.Code = 0
+	response.BaseResponse.Msg  = "删除商品成功"
+
+	return response, nil
+}
+
+// BatchGetProducts 批量获取商品，不存在或已删除的商品不返回
+func (s *ProductServiceImpl) BatchGetProducts(ctx context.Context, req *product.BatchGetProductsRequest) (*product.BatchGetProductsResponse, error){
+	response := &product.BatchGetProductsResponse{
+		BaseResponse:	&product.BaseResponse{},
+		Products:		[]*product.ProductInfo{},
+	}
+
+	if len(req.ProductIDs) > maxPageSize{
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = fmt.Sprintf("每次最多获取%d个商品", maxPageSize)
+
+		return response, nil
+	}
+
+	ids := make([]uint, 0, len(req.ProductIDs))
+	for _, id := range req.ProductIDs{
+		if id > 0{
+			ids = append(ids, uint(id))
+		}
+	}
+
+	if len(ids) > 0{
+		products, err := s.productData.ListByIDs(ctx, ids)
+		if err != nil{
+			response.BaseResponse.Code = 500
+			response.BaseResponse.Msg  = "查询商品失败：" + err.Error()
+
+			return response, nil
+		}
+
+		for _, p := range products{
+			response.Products = append(response.Products, toProductInfo(p))
+		}
+	}
+
+	response.BaseResponse.Code = 0
+	response.BaseResponse.Msg  = "查询商品成功"
+
+	return response, nil
+}
+
+// SearchProductIDs 按名称模糊查询商品ID，匹配的商品过多时返回400
+func (s *ProductServiceImpl) SearchProductIDs(ctx context.Context, req *product.SearchProductIDsRequest) (*product.SearchProductIDsResponse, error){
+	response := &product.SearchProductIDsResponse{
+		BaseResponse:	&product.BaseResponse{},
+		ProductIDs:		[]int64{},
+	}
+
+	if req.Name == ""{
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "商品名称不能为空"
+
+		return response, nil
+	}
+
+	// 多查询一个判断是否超过上限
+	ids, err := s.productData.SearchIDs(ctx, req.Name, maxSearchIDs + 1)
+	if err != nil{
+		response.BaseResponse.Code = 500
+		response.BaseResponse.Msg  = "查询商品失败：" + err.Error()
+
+		return response, nil
+	}
+	if len(ids) > maxSearchIDs{
+		response.BaseResponse.Code = 400
+		response.BaseResponse.Msg  = "商品名称匹配的商品过多，请输入更具体的名称"
+
+		return response, nil
+	}
+
+	for _, id := range ids{
+		response.ProductIDs = append(response.ProductIDs, int64(id))
+	}
+
+	response.BaseResponse.Code = 0
+	response.BaseResponse.Msg  = "查询商品成功"
+
+	return response, nil
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/kitex/client/callopt"
+
+	"Redrock/seckill/internal/pkg/models"
+	"Redrock/seckill/internal/pkg/testenv"
+	"Redrock/seckill/internal/product/data"
+	activity "Redrock/seckill/kitex_gen/activity"
+	"Redrock/seckill/kitex_gen/activity/internalactivityservice"
+	product "Redrock/seckill/kitex_gen/product"
+)
+
+// fakeActivityClient 模拟活动服务，counts为商品ID到未结束活动数量的映射
+type fakeActivityClient struct{
+	internalactivityservice.Client
+	counts	map[int64]int64
+	err		error
+}
+
+func (f *fakeActivityClient) CountProductActivities(ctx context.Context, req *activity.CountProductActivitiesRequest, callOptions ...callopt.Option) (*activity.CountProductActivitiesResponse, error){
+	if f.err != nil{
+		return nil, f.err
+	}
+
+	return &activity.CountProductActivitiesResponse{
+		BaseResponse:	&activity.BaseResponse{},
+		Count:			f.counts[req.ProductID],
+	}, nil
+}
+
+func newTestService(t *testing.T) (*ProductServiceImpl, *fakeActivityClient){
+	t.Helper()
+
+	testenv.NewDB(t, &models.Product{})
+	activityClient := &fakeActivityClient{counts: map[int64]int64{}}
+
+	return &ProductServiceImpl{
+		productData:	data.NewProductData(),
+		activityClient:	activityClient,
+	}, activityClient
+}
+
+func createTestProduct(t *testing.T, s *ProductServiceImpl, name string) int64{
+	t.Helper()
+
+	resp, _ := s.CreateProduct(context.Background(), &product.CreateProductRequest{Name: name, Price: 10, MerchantID: 100})
+	if resp.BaseResponse.Code != 0{
+		t.Fatalf("创建商品失败：%+v", resp.BaseResponse)
+	}
+
+	return resp.ProductID
+}
+
+func getProductCode(s *ProductServiceImpl, id int64) int32{
+	resp, _ := s.GetProduct(context.Background(), &product.GetProductRequest{ProductID: id})
+	return resp.BaseResponse.Code
+}
+
+func TestCreateUpdateGetProduct(t *testing.T){
+	s, _ := newTestService(t)
+
+	resp, _ := s.CreateProduct(context.Background(), &product.CreateProductRequest{Price: 10})
+	if resp.BaseResponse.Code != 400{
+		t.Fatalf("商品名称为空时的结果为%+v，期望400", resp.BaseResponse)
+	}
+
+	id := createTestProduct(t, s, "apple")
+
+	name, price := "banana", 20.0
+	updateResp, _ := s.UpdateProduct(context.Background(), &product.UpdateProductRequest{ProductID: id, Name: &name, Price: &price})
+	if updateResp.BaseResponse.Code != 0 || updateResp.Product.Name != name || updateResp.Product.Price != price{
+		t.Fatalf("修改商品的结果为%+v, %+v", updateResp.BaseResponse, updateResp.Product)
+	}
+
+	getResp, _ := s.GetProduct(context.Background(), &product.GetProductRequest{ProductID: id})
+	if getResp.BaseResponse.Code != 0 || getResp.Product.Name != name || getResp.Product.MerchantID != 100{
+		t.Fatalf("获取商品的结果为%+v, %+v", getResp.BaseResponse, getResp.Product)
+	}
+
+	if code := getProductCode(s, id + 1); code != 404{
+		t.Fatalf("获取不存在的商品的结果为%d，期望404", code)
+	}
+}
+
+func TestDeleteProduct(t *testing.T){
+	s, _ := newTestService(t)
+	id := createTestProduct(t, s, "apple")
+
+	resp, _ := s.DeleteProduct(context.Background(), &product.DeleteProductRequest{ProductID: id})
+	if resp.BaseResponse.Code != 0{
+		t.Fatalf("删除商品的结果为%+v，期望成功", resp.BaseResponse)
+	}
+
+	if code := getProductCode(s, id); code != 404{
+		t.Fatalf("删除后获取商品的结果为%d，期望404", code)
+	}
+}
+
+// 有未结束的活动或活动服务不可用时恢复已删除的商品
+func TestDeleteProductRestores(t *testing.T){
+	s, activityClient := newTestService(t)
+	id := createTestProduct(t, s, "apple")
+
+	activityClient.counts[id] = 1
+	resp, _ := s.DeleteProduct(context.Background(), &product.DeleteProductRequest{ProductID: id})
+	if resp.BaseResponse.Code != 400{
+		t.Fatalf("有未结束的活动时删除商品的结果为%+v，期望400", resp.BaseResponse)
+	}
+	if code := getProductCode(s, id); code != 0{
+		t.Fatalf("删除失败后获取商品的结果为%d，期望商品被恢复", code)
+	}
+
+	activityClient.counts[id] = 0
+	activityClient.err = errors.New("activity service unavailable")
+	resp, _ = s.DeleteProduct(context.Background(), &product.DeleteProductRequest{ProductID: id})
+	if resp.BaseResponse.Code != 500{
+		t.Fatalf("活动服务不可用时删除商品的结果为%+v，期望500", resp.BaseResponse)
+	}
+	if code := getProductCode(s, id); code != 0{
+		t.Fatalf("删除失败后获取商品的结果为%d，期望商品被恢复", code)
+	}
+}
+
+func TestBatchGetProducts(t *testing.T){
+	s, _ := newTestService(t)
+	apple := createTestProduct(t, s, "apple")
+	banana := createTestProduct(t, s, "banana")
+	s.DeleteProduct(context.Background(), &product.DeleteProductRequest{ProductID: banana})
+
+	resp, _ := s.BatchGetProducts(context.Background(), &product.BatchGetProductsRequest{ProductIDs: []int64{apple, banana, 0, 999}})
+	if resp.BaseResponse.Code != 0 || len(resp.Products) != 1 || resp.Products[0].Id != apple{
+		t.Fatalf("批量获取商品的结果为%+v, %+v，期望只有未删除的商品", resp.BaseResponse, resp.Products)
+	}
+
+	resp, _ = s.BatchGetProducts(context.Background(), &product.BatchGetProductsRequest{ProductIDs: make([]int64, maxPageSize + 1)})
+	if resp.BaseResponse.Code != 400{
+		t.Fatalf("超过数量上限时的结果为%+v，期望400", resp.BaseResponse)
+	}
+}
+
+func TestSearchProductIDs(t *testing.T){
+	s, _ := newTestService(t)
+	apple := createTestProduct(t, s, "apple")
+	createTestProduct(t, s, "banana")
+	createTestProduct(t, s, "100%")
+
+	resp, _ := s.SearchProductIDs(context.Background(), &product.SearchProductIDsRequest{Name: "app"})
+	if resp.BaseResponse.Code != 0 || len(resp.ProductIDs) != 1 || resp.ProductIDs[0] != apple{
+		t.Fatalf("按名称查询的结果为%+v, %v，期望只有apple", resp.BaseResponse, resp.ProductIDs)
+	}
+
+	// 通配符按普通字符匹配
+	resp, _ = s.SearchProductIDs(context.Background(), &product.SearchProductIDsRequest{Name: "%"})
+	if resp.BaseResponse.Code != 0 || len(resp.ProductIDs) != 1{
+		t.Fatalf("查询%%的结果为%+v, %v，期望只有100%%", resp.BaseResponse, resp.ProductIDs)
+	}
+
+	resp, _ = s.SearchProductIDs(context.Background(), &product.SearchProductIDsRequest{})
+	if resp.BaseResponse.Code != 400{
+		t.Fatalf("名称为空时的结果为%+v，期望400", resp.BaseResponse)
+	}
+}
@@ -1,13 +1,33 @@
 package config
 
 import (
+	"Redrock/seckill/internal/pkg/auth"
 	"Redrock/seckill/internal/pkg/database"
+	"Redrock/seckill/internal/pkg/redis"
+	"Redrock/seckill/internal/user/password"
 )
 
 // Config 定义了用户服务所需的配置
 type Config struct {
 	Server   ServerConfig      			`mapstructure:"server"`
 	Database database.DatabaseConfig 	`mapstructure:"database"`
+	Auth     auth.AuthConfig			`mapstructure:"auth"`
+	Admin    AdminConfig				`mapstructure:"admin"`
+	Password password.PasswordConfig	`mapstructure:"password"`
+	Redis    redis.RedisConfig			`mapstructure:"redis"`		// 令牌撤销记录，需要和网关使用同一个Redis库
+	OrderRPC OrderRPCConfig				`mapstructure:"order_rpc"`	// 注销账户时取消未支付的订单
+}
+
+// OrderRPCConfig 定义了订单服务客户端的配置
+type OrderRPCConfig struct {
+	Host    string `mapstructure:"host"`
+	Port    int    `mapstructure:"port"`
+	Timeout int    `mapstructure:"timeout"` // 毫秒
+}
+
+// AdminConfig 定义了初始管理员
+type AdminConfig struct {
+	Usernames []string `mapstructure:"usernames"` // 启动时将这些用户设置为管理员，之后通过AdminUserService管理角色
 }
 
 // ServerConfig 定义了Kitex服务器的配置
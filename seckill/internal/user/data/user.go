@@ -2,33 +2,37 @@ package data
 
 import (
 	"context"
-	"crypto/md5"
 	"errors"
 	"fmt"
+	"log"
 
 	"gorm.io/gorm"
 
+	"Redrock/seckill/internal/pkg/auth"
 	"Redrock/seckill/internal/pkg/database"
 	"Redrock/seckill/internal/pkg/models"
+	"Redrock/seckill/internal/user/password"
 )
 
 // UserData 用户数据访问层
 type UserData struct {
-	db *gorm.DB
+	db     *gorm.DB
+	hasher *password.Hasher
 }
 
 // NewUserData 创建用户数据访问对象
-func NewUserData() *UserData {
+func NewUserData(hasher *password.Hasher) *UserData {
 	return &UserData{
-		db: database.GetDB(),
+		db:     database.GetDB(),
+		hasher: hasher,
 	}
 }
 
 // Create 创建用户
 func (d *UserData) Create(ctx context.Context, user *models.User) error {
-	// 先检查用户名是否已存在
+	// 先检查用户名是否已存在，已注销的用户名也不能再次注册
 	var count int64
-	err := d.db.WithContext(ctx).Model(&models.User{}).Where("username = ?", user.Username).Count(&count).Error
+	err := d.db.WithContext(ctx).Unscoped().Model(&models.User{}).Where("username = ?", user.Username).Count(&count).Error
 	if err != nil {
 		return err
 	}
@@ -36,15 +40,25 @@ func (d *UserData) Create(ctx context.Context, user *models.User) error {
 		return fmt.Errorf("用户名已存在")
 	}
 
+	// 新用户默认为普通用户
+	if user.Role == "" {
+		user.Role = auth.RoleUser
+	}
+
 	// 加密密码
-	user.Password = fmt.Sprintf("%x", md5.Sum([]byte(user.Password)))
+	hashed, err := d.hasher.Hash(user.Password)
+	if err != nil {
+		return err
+	}
+	user.Password = hashed
 
 	// 创建用户
 	return d.db.WithContext(ctx).Create(user).Error
 }
 
 // CheckPassword 检查密码是否正确
-func (d *UserData) CheckPassword(ctx context.Context, username, password string) (*models.User, error) {
+// 早期的md5密码或计算成本过期的密码在验证通过后会重新哈希保存
+func (d *UserData) CheckPassword(ctx context.Context, username, plain string) (*models.User, error) {
 	var user models.User
 	err := d.db.WithContext(ctx).Where("username = ?", username).First(&user).Error
 	if err != nil {
@@ -55,10 +69,119 @@ func (d *UserData) CheckPassword(ctx context.Context, username, password string)
 	}
 
 	// 验证密码
-	encryptedPassword := fmt.Sprintf("%x", md5.Sum([]byte(password)))
-	if user.Password != encryptedPassword {
+	ok, rehash, err := d.hasher.Verify(user.Password, plain)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
 		return nil, fmt.Errorf("密码错误")
 	}
 
+	// 重新哈希失败不影响登录，下次登录时再重试
+	if rehash {
+		err = d.rehashPassword(ctx, &user, plain)
+		if err != nil {
+			log.Printf("重新哈希用户%d的密码失败: %v", user.ID, err)
+		}
+	}
+
+	return &user, nil
+}
+
+// rehashPassword 用当前的哈希方式重新哈希密码
+// 只在密码没有被其他请求修改时保存
+func (d *UserData) rehashPassword(ctx context.Context, user *models.User, plain string) error {
+	hashed, err := d.hasher.Hash(plain)
+	if err != nil {
+		return err
+	}
+
+	result := d.db.WithContext(ctx).Model(&models.User{}).
+		Where("id = ? AND password = ?", user.ID, user.Password).
+		Update("password", hashed)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected > 0 {
+		user.Password = hashed
+	}
+
+	return nil
+}
+
+// VerifyPassword 检查用户的密码是否正确，不会重新哈希密码
+func (d *UserData) VerifyPassword(user *models.User, plain string) error {
+	ok, _, err := d.hasher.Verify(user.Password, plain)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("密码错误")
+	}
+
+	return nil
+}
+
+// UpdatePassword 用户的密码仍为读取时的密码时修改为新密码，返回是否修改
+func (d *UserData) UpdatePassword(ctx context.Context, user *models.User, plain string) (bool, error) {
+	hashed, err := d.hasher.Hash(plain)
+	if err != nil {
+		return false, err
+	}
+
+	result := d.db.WithContext(ctx).Model(&models.User{}).
+		Where("id = ? AND password = ?", user.ID, user.Password).
+		Update("password", hashed)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// Delete 软删除用户，删除后无法登录，用户名也不能再次注册
+func (d *UserData) Delete(ctx context.Context, id uint) (bool, error) {
+	result := d.db.WithContext(ctx).Delete(&models.User{}, id)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// GetByID 通过用户ID获取用户
+func (d *UserData) GetByID(ctx context.Context, id uint) (*models.User, error) {
+	var user models.User
+	err := d.db.WithContext(ctx).First(&user, id).Error
+	if err != nil {
+		return nil, err
+	}
+
 	return &user, nil
 }
+
+// UpdateRole 用户的角色仍为from时修改为to，返回是否修改
+func (d *UserData) UpdateRole(ctx context.Context, id uint, from string, to string) (bool, error) {
+	result := d.db.WithContext(ctx).Model(&models.User{}).
+		Where("id = ? AND role = ?", id, from).
+		Update("role", to)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// PromoteAdmins 将指定用户名的用户设置为管理员，返回修改的用户数
+func (d *UserData) PromoteAdmins(ctx context.Context, usernames []string) (int64, error) {
+	if len(usernames) == 0 {
+		return 0, nil
+	}
+
+	result := d.db.WithContext(ctx).Model(&models.User{}).
+		Where("username IN ? AND role != ?", usernames, auth.RoleAdmin).
+		Update("role", auth.RoleAdmin)
+
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,5 @@
+package password
+
+type PasswordConfig struct{
+	BcryptCost	int	`mapstructure:"bcrypt_cost"`	// bcrypt计算成本 4~31，每加1耗时翻倍，0表示默认值10
+}
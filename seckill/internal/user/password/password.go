@@ -0,0 +1,83 @@
+package password
+
+import (
+	"crypto/md5"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// 早期版本保存的是不加盐的md5十六进制摘要，长度固定为32
+const legacyMD5Length = md5.Size * 2
+
+// Hasher 使用bcrypt哈希密码，盐由bcrypt为每个密码随机生成并保存在哈希中
+// 同时兼容早期的md5密码，验证通过后由调用方重新哈希
+type Hasher struct{
+	cost int
+}
+
+// NewHasher 创建密码哈希器
+func NewHasher(config *PasswordConfig) (*Hasher, error){
+	cost := config.BcryptCost
+	if cost == 0{
+		cost = bcrypt.DefaultCost
+	}
+
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost{
+		return nil, fmt.Errorf("bcrypt计算成本必须在%d~%d之间", bcrypt.MinCost, bcrypt.MaxCost)
+	}
+
+	return &Hasher{cost: cost}, nil
+}
+
+// Hash 哈希密码，bcrypt只使用密码的前72个字节，超过时返回错误
+func (h *Hasher) Hash(password string) (string, error){
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil{
+		if errors.Is(err, bcrypt.ErrPasswordTooLong){
+			return "", fmt.Errorf("密码不能超过72个字节")
+		}
+		return "", err
+	}
+
+	return string(hashed), nil
+}
+
+// Verify 验证密码是否和哈希匹配
+// rehash表示哈希是早期的md5格式或计算成本和当前配置不同，验证通过后应该用Hash重新哈希并保存
+func (h *Hasher) Verify(hashed string, password string) (ok bool, rehash bool, err error){
+	if isLegacyMD5(hashed){
+		sum := md5.Sum([]byte(password))
+		ok = subtle.ConstantTimeCompare([]byte(hex.EncodeToString(sum[:])), []byte(hashed)) == 1
+
+		return ok, ok, nil
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(hashed), []byte(password))
+	if err != nil{
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword){
+			return false, false, nil
+		}
+		return false, false, err
+	}
+
+	cost, err := bcrypt.Cost([]byte(hashed))
+	if err != nil{
+		return true, false, nil
+	}
+
+	return true, cost != h.cost, nil
+}
+
+// isLegacyMD5 是否为早期的md5十六进制摘要
+func isLegacyMD5(hashed string) bool{
+	if len(hashed) != legacyMD5Length{
+		return false
+	}
+
+	_, err := hex.DecodeString(hashed)
+	return err == nil
+}
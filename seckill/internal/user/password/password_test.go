@@ -0,0 +1,77 @@
+package password
+
+import (
+	"crypto/md5"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func newTestHasher(t *testing.T, cost int) *Hasher {
+	h, err := NewHasher(&PasswordConfig{BcryptCost: cost})
+	if err != nil {
+		t.Fatalf("创建密码哈希器失败：%v", err)
+	}
+	return h
+}
+
+func TestHashAndVerify(t *testing.T) {
+	h := newTestHasher(t, 4)
+
+	hashed, err := h.Hash("secret123")
+	if err != nil {
+		t.Fatalf("哈希密码失败：%v", err)
+	}
+	if !strings.HasPrefix(hashed, "$2a$04$") {
+		t.Fatalf("哈希格式为%s，期望bcrypt且成本为4", hashed)
+	}
+
+	// 每个密码使用不同的盐
+	again, _ := h.Hash("secret123")
+	if again == hashed {
+		t.Fatalf("同一密码两次哈希结果相同")
+	}
+
+	ok, rehash, err := h.Verify(hashed, "secret123")
+	if err != nil || !ok || rehash {
+		t.Fatalf("验证正确密码结果为%v, %v, %v，期望通过且不需要重新哈希", ok, rehash, err)
+	}
+
+	ok, _, err = h.Verify(hashed, "wrong")
+	if err != nil || ok {
+		t.Fatalf("验证错误密码结果为%v, %v，期望不通过", ok, err)
+	}
+}
+
+func TestVerifyLegacyMD5(t *testing.T) {
+	h := newTestHasher(t, 4)
+	legacy := fmt.Sprintf("%x", md5.Sum([]byte("secret123")))
+
+	ok, rehash, err := h.Verify(legacy, "secret123")
+	if err != nil || !ok || !rehash {
+		t.Fatalf("验证md5密码结果为%v, %v, %v，期望通过且需要重新哈希", ok, rehash, err)
+	}
+
+	ok, rehash, err = h.Verify(legacy, "wrong")
+	if err != nil || ok || rehash {
+		t.Fatalf("验证错误的md5密码结果为%v, %v, %v，期望不通过", ok, rehash, err)
+	}
+}
+
+func TestVerifyRehashOnCostChange(t *testing.T) {
+	hashed, err := newTestHasher(t, 4).Hash("secret123")
+	if err != nil {
+		t.Fatalf("哈希密码失败：%v", err)
+	}
+
+	ok, rehash, err := newTestHasher(t, 5).Verify(hashed, "secret123")
+	if err != nil || !ok || !rehash {
+		t.Fatalf("计算成本变化后验证结果为%v, %v, %v，期望通过且需要重新哈希", ok, rehash, err)
+	}
+}
+
+func TestNewHasherRejectsInvalidCost(t *testing.T) {
+	if _, err := NewHasher(&PasswordConfig{BcryptCost: 32}); err == nil {
+		t.Fatalf("计算成本为32时期望返回错误")
+	}
+}
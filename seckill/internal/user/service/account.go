@@ -0,0 +1,330 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/cloudwego/kitex/client"
+
+	"Redrock/seckill/internal/pkg/auth"
+	"Redrock/seckill/internal/pkg/models"
+	"Redrock/seckill/internal/user/config"
+	order "Redrock/seckill/kitex_gen/order"
+	"Redrock/seckill/kitex_gen/order/orderservice"
+	user "Redrock/seckill/kitex_gen/user"
+)
+
+// newOrderClient 创建订单服务客户端
+func newOrderClient(cfg config.OrderRPCConfig) orderservice.Client {
+	orderClient, err := orderservice.NewClient(
+		"order_service",
+		client.WithHostPorts(fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)),
+		client.WithRPCTimeout(time.Duration(cfg.Timeout)*time.Millisecond),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("创建订单客户端失败：%v", err))
+	}
+
+	return orderClient
+}
+
+// authenticate 校验访问令牌，失败时设置响应的错误码并返回nil
+// 账户相关的接口只操作令牌所属的用户，不信任请求中的其他身份信息
+func (s *UserServiceImpl) authenticate(ctx context.Context, token string, baseResp *user.BaseResp) *auth.Claims {
+	if token == "" {
+		baseResp.Code = 401
+		baseResp.Message = "请先登录"
+		return nil
+	}
+
+	claims, err := s.tokens.Parse(token)
+	if err != nil {
+		baseResp.Code = 401
+		baseResp.Message = "登录凭证无效，请重新登录"
+		if errors.Is(err, auth.ErrTokenExpired) {
+			baseResp.Message = "登录已过期，请重新登录"
+		}
+		return nil
+	}
+
+	revoked, err := s.revoker.IsRevoked(ctx, claims)
+	if err != nil {
+		baseResp.Code = 500
+		baseResp.Message = "校验登录状态失败: " + err.Error()
+		return nil
+	}
+	if revoked {
+		baseResp.Code = 401
+		baseResp.Message = "登录已失效，请重新登录"
+		return nil
+	}
+
+	return claims
+}
+
+// loadCurrentUser 校验令牌并获取令牌所属的用户，失败时设置响应的错误码并返回nil
+func (s *UserServiceImpl) loadCurrentUser(ctx context.Context, token string, baseResp *user.BaseResp) (*auth.Claims, *models.User) {
+	claims := s.authenticate(ctx, token, baseResp)
+	if claims == nil {
+		return nil, nil
+	}
+
+	// 用户已注销但令牌还未撤销时按未登录处理
+	localUser := s.loadUser(ctx, int64(claims.UserID), baseResp)
+	if localUser == nil {
+		if baseResp.Code == 404 {
+			baseResp.Code = 401
+			baseResp.Message = "用户不存在，请重新登录"
+		}
+		return nil, nil
+	}
+
+	return claims, localUser
+}
+
+// revokeAll 撤销用户已签发的所有令牌，包括当前请求使用的令牌
+func (s *UserServiceImpl) revokeAll(ctx context.Context, claims *auth.Claims) error {
+	err := s.revoker.RevokeUser(ctx, claims.UserID)
+	if err != nil {
+		return err
+	}
+
+	return s.revoker.Revoke(ctx, claims)
+}
+
+// GetUserInfo 获取当前用户信息
+func (s *UserServiceImpl) GetUserInfo(ctx context.Context, req *user.GetUserInfoRequest) (resp *user.GetUserInfoResponse, err error) {
+	response := &user.GetUserInfoResponse{
+		BaseResp: &user.BaseResp{},
+	}
+
+	_, localUser := s.loadCurrentUser(ctx, req.AccessToken, response.BaseResp)
+	if localUser == nil {
+		return response, nil
+	}
+
+	response.BaseResp.Code = 0
+	response.BaseResp.Message = "获取用户信息成功"
+	response.User = toUserInfo(localUser)
+
+	return response, nil
+}
+
+// UpdatePassword 修改密码，修改后撤销该用户已签发的所有令牌，撤销失败时返回错误
+func (s *UserServiceImpl) UpdatePassword(ctx context.Context, req *user.UpdatePasswordRequest) (resp *user.UpdatePasswordResponse, err error) {
+	response := &user.UpdatePasswordResponse{
+		BaseResp: &user.BaseResp{},
+	}
+
+	if req.OldPassword == "" || req.Password == "" {
+		response.BaseResp.Code = 400
+		response.BaseResp.Message = "原密码和新密码不能为空"
+		return response, nil
+	}
+	if req.OldPassword == req.Password {
+		response.BaseResp.Code = 400
+		response.BaseResp.Message = "新密码不能和原密码相同"
+		return response, nil
+	}
+
+	claims, localUser := s.loadCurrentUser(ctx, req.AccessToken, response.BaseResp)
+	if localUser == nil {
+		return response, nil
+	}
+
+	err = s.userData.VerifyPassword(localUser, req.OldPassword)
+	if err != nil {
+		response.BaseResp.Code = 403
+		response.BaseResp.Message = "原密码错误"
+		return response, nil
+	}
+
+	updated, err := s.userData.UpdatePassword(ctx, localUser, req.Password)
+	if err != nil {
+		response.BaseResp.Code = 500
+		response.BaseResp.Message = "修改密码失败: " + err.Error()
+		return response, nil
+	}
+	if !updated {
+		response.BaseResp.Code = 409
+		response.BaseResp.Message = "密码已被修改，请重试"
+		return response, nil
+	}
+
+	// 密码已经修改，撤销令牌失败时旧令牌在过期前仍然有效，需要告知用户
+	err = s.revokeAll(ctx, claims)
+	if err != nil {
+		log.Printf("撤销用户%d的令牌失败: %v", localUser.ID, err)
+		response.BaseResp.Code = 500
+		response.BaseResp.Message = "密码已修改，但其他设备上的登录未能退出，请稍后再次修改密码: " + err.Error()
+		return response, nil
+	}
+
+	response.BaseResp.Code = 0
+	response.BaseResp.Message = "修改密码成功，请重新登录"
+
+	return response, nil
+}
+
+// Logout 退出登录，撤销当前请求使用的令牌，其他设备上的登录不受影响
+func (s *UserServiceImpl) Logout(ctx context.Context, req *user.LogoutRequest) (resp *user.LogoutResponse, err error) {
+	response := &user.LogoutResponse{
+		BaseResp: &user.BaseResp{},
+	}
+
+	claims := s.authenticate(ctx, req.AccessToken, response.BaseResp)
+	if claims == nil {
+		return response, nil
+	}
+
+	err = s.revoker.Revoke(ctx, claims)
+	if err != nil {
+		response.BaseResp.Code = 500
+		response.BaseResp.Message = "退出登录失败: " + err.Error()
+		return response, nil
+	}
+
+	response.BaseResp.Code = 0
+	response.BaseResp.Message = "退出登录成功"
+
+	return response, nil
+}
+
+// cancelUnpaidOrders 取消用户所有未支付的订单，归还库存
+// 已支付、已取消和失败的订单作为历史记录保留
+func (s *UserServiceImpl) cancelUnpaidOrders(ctx context.Context, userID uint) (int32, error) {
+	var cancelled int32
+	for _, status := range []order.OrderStatus{order.OrderStatus_PENDING, order.OrderStatus_CREATED} {
+		cursor := ""
+		for {
+			listResp, err := s.orderClient.ListOrders(ctx, &order.ListOrdersRequest{
+				UserID:   int64(userID),
+				Status:   status,
+				Cursor:   cursor,
+				PageSize: 100,
+			})
+			if err != nil {
+				return cancelled, err
+			}
+			if listResp.BaseResponse.Code != 0 {
+				return cancelled, errors.New(listResp.BaseResponse.Msg)
+			}
+
+			for _, o := range listResp.Orders {
+				cancelResp, err := s.orderClient.CancelOrder(ctx, &order.CancelOrderRequest{
+					UserID:  int64(userID),
+					OrderSn: o.OrderSn,
+				})
+				if err != nil {
+					return cancelled, fmt.Errorf("取消订单%s失败: %w", o.OrderSn, err)
+				}
+				if cancelResp.BaseResponse.Code != 0 {
+					return cancelled, fmt.Errorf("取消订单%s失败: %s", o.OrderSn, cancelResp.BaseResponse.Msg)
+				}
+
+				cancelled++
+			}
+
+			// 游标记录的是上一页最后一条订单，取消订单不会影响之后的翻页
+			if !listResp.HasMore {
+				break
+			}
+			cursor = listResp.NextCursor
+		}
+	}
+
+	return cancelled, nil
+}
+
+// setOrdersClosed 通过订单服务关闭或重新开放用户的下单
+func (s *UserServiceImpl) setOrdersClosed(ctx context.Context, userID uint, closed bool) error {
+	resp, err := s.orderClient.SetUserOrdersClosed(ctx, &order.SetUserOrdersClosedRequest{
+		UserID: int64(userID),
+		Closed: closed,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.BaseResponse.Code != 0 {
+		return errors.New(resp.BaseResponse.Msg)
+	}
+
+	return nil
+}
+
+// reopenOrders 注销失败时重新开放用户的下单，失败时只记录日志
+func (s *UserServiceImpl) reopenOrders(ctx context.Context, userID uint) {
+	err := s.setOrdersClosed(ctx, userID, false)
+	if err != nil {
+		log.Printf("重新开放用户%d的下单失败: %v", userID, err)
+	}
+}
+
+// DeleteAccount 注销账户
+// 1. 关闭用户的下单，排队中的秒杀请求不再创建订单，关闭前已经开始下单的订单创建后由订单服务取消
+// 2. 取消未支付的订单 3. 撤销所有令牌 4. 软删除用户
+// 任一步骤失败时重新开放下单并返回错误，不删除用户，可以重新登录后重试
+func (s *UserServiceImpl) DeleteAccount(ctx context.Context, req *user.DeleteAccountRequest) (resp *user.DeleteAccountResponse, err error) {
+	response := &user.DeleteAccountResponse{
+		BaseResp: &user.BaseResp{},
+	}
+
+	if req.Password == "" {
+		response.BaseResp.Code = 400
+		response.BaseResp.Message = "密码不能为空"
+		return response, nil
+	}
+
+	claims, localUser := s.loadCurrentUser(ctx, req.AccessToken, response.BaseResp)
+	if localUser == nil {
+		return response, nil
+	}
+
+	err = s.userData.VerifyPassword(localUser, req.Password)
+	if err != nil {
+		response.BaseResp.Code = 403
+		response.BaseResp.Message = "密码错误"
+		return response, nil
+	}
+
+	err = s.setOrdersClosed(ctx, localUser.ID, true)
+	if err != nil {
+		response.BaseResp.Code = 500
+		response.BaseResp.Message = "关闭下单失败，请稍后重试: " + err.Error()
+		return response, nil
+	}
+
+	cancelled, err := s.cancelUnpaidOrders(ctx, localUser.ID)
+	response.CancelledOrders = cancelled
+	if err != nil {
+		s.reopenOrders(ctx, localUser.ID)
+		response.BaseResp.Code = 500
+		response.BaseResp.Message = "取消未支付的订单失败，请稍后重试: " + err.Error()
+		return response, nil
+	}
+
+	// 先撤销令牌再删除用户，删除后不会留下仍然有效的令牌
+	err = s.revokeAll(ctx, claims)
+	if err != nil {
+		s.reopenOrders(ctx, localUser.ID)
+		response.BaseResp.Code = 500
+		response.BaseResp.Message = "撤销登录凭证失败，请稍后重试: " + err.Error()
+		return response, nil
+	}
+
+	_, err = s.userData.Delete(ctx, localUser.ID)
+	if err != nil {
+		s.reopenOrders(ctx, localUser.ID)
+		response.BaseResp.Code = 500
+		response.BaseResp.Message = "注销账户失败，请重新登录后重试: " + err.Error()
+		return response, nil
+	}
+
+	response.BaseResp.Code = 0
+	response.BaseResp.Message = "注销账户成功"
+
+	return response, nil
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/cloudwego/kitex/client/callopt"
+	"gorm.io/gorm"
+
+	"Redrock/seckill/internal/pkg/database"
+	order "Redrock/seckill/kitex_gen/order"
+	"Redrock/seckill/kitex_gen/order/orderservice"
+	user "Redrock/seckill/kitex_gen/user"
+)
+
+// fakeOrderClient 模拟订单服务，orders为订单号到订单状态的映射，closed记录每次修改的下单状态
+type fakeOrderClient struct {
+	orderservice.Client
+	orders    map[string]order.OrderStatus
+	closed    []bool
+	cancelErr error
+	onClose   func() // 关闭下单后调用
+}
+
+func (f *fakeOrderClient) ListOrders(ctx context.Context, req *order.ListOrdersRequest, callOptions ...callopt.Option) (*order.ListOrdersResponse, error) {
+	resp := &order.ListOrdersResponse{BaseResponse: &order.BaseResponse{}}
+	for sn, status := range f.orders {
+		if status == req.Status {
+			resp.Orders = append(resp.Orders, &order.OrderInfo{OrderSn: sn, Status: status})
+		}
+	}
+
+	return resp, nil
+}
+
+func (f *fakeOrderClient) CancelOrder(ctx context.Context, req *order.CancelOrderRequest, callOptions ...callopt.Option) (*order.CancelOrderResponse, error) {
+	if f.cancelErr != nil {
+		return nil, f.cancelErr
+	}
+
+	f.orders[req.OrderSn] = order.OrderStatus_CANCELLED
+	return &order.CancelOrderResponse{BaseResponse: &order.BaseResponse{}}, nil
+}
+
+func (f *fakeOrderClient) SetUserOrdersClosed(ctx context.Context, req *order.SetUserOrdersClosedRequest, callOptions ...callopt.Option) (*order.SetUserOrdersClosedResponse, error) {
+	f.closed = append(f.closed, req.Closed)
+	if req.Closed && f.onClose != nil {
+		f.onClose()
+	}
+
+	return &order.SetUserOrdersClosedResponse{BaseResponse: &order.BaseResponse{}}, nil
+}
+
+func login(t *testing.T, s *UserServiceImpl, username string, password string) string {
+	t.Helper()
+
+	resp, _ := s.Login(context.Background(), &user.LoginRequest{Username: username, Password: password})
+	if resp.BaseResp.Code != 0 {
+		t.Fatalf("登录失败：%+v", resp.BaseResp)
+	}
+
+	return resp.AccessToken
+}
+
+func userInfoCode(s *UserServiceImpl, token string) int32 {
+	resp, _ := s.GetUserInfo(context.Background(), &user.GetUserInfoRequest{AccessToken: token})
+	return resp.BaseResp.Code
+}
+
+func TestGetUserInfo(t *testing.T) {
+	s, _ := newTestService(t)
+	u := createTestUser(t, s, "alice")
+	token := login(t, s, "alice", "password")
+
+	resp, _ := s.GetUserInfo(context.Background(), &user.GetUserInfoRequest{AccessToken: token})
+	if resp.BaseResp.Code != 0 || resp.User.Username != "alice" || resp.User.Id != int64(u.ID) {
+		t.Fatalf("获取用户信息的结果为%+v, %+v", resp.BaseResp, resp.User)
+	}
+
+	if code := userInfoCode(s, ""); code != 401 {
+		t.Fatalf("没有令牌时的结果为%d，期望401", code)
+	}
+	if code := userInfoCode(s, "invalid"); code != 401 {
+		t.Fatalf("令牌无效时的结果为%d，期望401", code)
+	}
+}
+
+// 修改密码后旧令牌失效，只能用新密码登录
+func TestUpdatePassword(t *testing.T) {
+	s, _ := newTestService(t)
+	createTestUser(t, s, "alice")
+	token := login(t, s, "alice", "password")
+
+	resp, _ := s.UpdatePassword(context.Background(), &user.UpdatePasswordRequest{AccessToken: token, OldPassword: "wrong", Password: "new-password"})
+	if resp.BaseResp.Code != 403 {
+		t.Fatalf("原密码错误时的结果为%+v，期望403", resp.BaseResp)
+	}
+
+	resp, _ = s.UpdatePassword(context.Background(), &user.UpdatePasswordRequest{AccessToken: token, OldPassword: "password", Password: "new-password"})
+	if resp.BaseResp.Code != 0 {
+		t.Fatalf("修改密码的结果为%+v，期望成功", resp.BaseResp)
+	}
+
+	if code := userInfoCode(s, token); code != 401 {
+		t.Fatalf("修改密码后旧令牌的结果为%d，期望401", code)
+	}
+
+	loginResp, _ := s.Login(context.Background(), &user.LoginRequest{Username: "alice", Password: "password"})
+	if loginResp.BaseResp.Code != 401 {
+		t.Fatalf("用旧密码登录的结果为%+v，期望401", loginResp.BaseResp)
+	}
+	login(t, s, "alice", "new-password")
+}
+
+// 修改密码后撤销令牌失败时返回错误，不报告成功
+func TestUpdatePasswordRevokeFailure(t *testing.T) {
+	s, client := newTestService(t)
+	createTestUser(t, s, "alice")
+	token := login(t, s, "alice", "password")
+
+	// 密码写入数据库后Redis不可用
+	err := database.GetDB().Callback().Update().After("gorm:update").Register("test:close_redis", func(db *gorm.DB) {
+		if db.Statement.Table == "users" {
+			client.Close()
+		}
+	})
+	if err != nil {
+		t.Fatalf("注册回调失败：%v", err)
+	}
+
+	resp, _ := s.UpdatePassword(context.Background(), &user.UpdatePasswordRequest{AccessToken: token, OldPassword: "password", Password: "new-password"})
+	if resp.BaseResp.Code != 500 {
+		t.Fatalf("撤销令牌失败时的结果为%+v，期望500", resp.BaseResp)
+	}
+
+	login(t, s, "alice", "new-password")
+}
+
+// 退出登录只撤销当前令牌
+func TestLogout(t *testing.T) {
+	s, _ := newTestService(t)
+	createTestUser(t, s, "alice")
+	token := login(t, s, "alice", "password")
+	other := login(t, s, "alice", "password")
+
+	resp, _ := s.Logout(context.Background(), &user.LogoutRequest{AccessToken: token})
+	if resp.BaseResp.Code != 0 {
+		t.Fatalf("退出登录的结果为%+v，期望成功", resp.BaseResp)
+	}
+
+	if code := userInfoCode(s, token); code != 401 {
+		t.Fatalf("退出登录后令牌的结果为%d，期望401", code)
+	}
+	if code := userInfoCode(s, other); code != 0 {
+		t.Fatalf("其他令牌的结果为%d，期望仍然有效", code)
+	}
+}
+
+// 注销账户时先关闭下单，再取消未支付的订单、撤销令牌并删除用户
+func TestDeleteAccount(t *testing.T) {
+	s, _ := newTestService(t)
+	orderClient := &fakeOrderClient{orders: map[string]order.OrderStatus{
+		"pending": order.OrderStatus_PENDING,
+		"created": order.OrderStatus_CREATED,
+		"paid":    order.OrderStatus_PAID,
+	}}
+	s.orderClient = orderClient
+
+	u := createTestUser(t, s, "alice")
+	token := login(t, s, "alice", "password")
+
+	resp, _ := s.DeleteAccount(context.Background(), &user.DeleteAccountRequest{AccessToken: token, Password: "wrong"})
+	if resp.BaseResp.Code != 403 || len(orderClient.closed) != 0 {
+		t.Fatalf("密码错误时的结果为%+v，期望403且不关闭下单", resp.BaseResp)
+	}
+
+	resp, _ = s.DeleteAccount(context.Background(), &user.DeleteAccountRequest{AccessToken: token, Password: "password"})
+	if resp.BaseResp.Code != 0 || resp.CancelledOrders != 2 {
+		t.Fatalf("注销账户的结果为%+v，取消了%d个订单，期望成功并取消2个订单", resp.BaseResp, resp.CancelledOrders)
+	}
+	if !slices.Equal(orderClient.closed, []bool{true}) {
+		t.Fatalf("下单状态的修改为%v，期望只关闭一次", orderClient.closed)
+	}
+	if orderClient.orders["paid"] != order.OrderStatus_PAID {
+		t.Fatalf("已支付的订单状态为%v，期望保留", orderClient.orders["paid"])
+	}
+
+	if _, err := s.userData.GetByID(context.Background(), u.ID); err == nil {
+		t.Fatalf("注销后仍然可以获取用户")
+	}
+	if code := userInfoCode(s, token); code != 401 {
+		t.Fatalf("注销后令牌的结果为%d，期望401", code)
+	}
+}
+
+// 取消订单或撤销令牌失败时不删除用户，并重新开放下单
+func TestDeleteAccountFailures(t *testing.T) {
+	cases := []struct {
+		name  string
+		setup func(s *UserServiceImpl, orderClient *fakeOrderClient, closeRedis func() error)
+	}{
+		{"取消订单失败", func(s *UserServiceImpl, orderClient *fakeOrderClient, closeRedis func() error) {
+			orderClient.cancelErr = errors.New("order service unavailable")
+		}},
+		{"撤销令牌失败", func(s *UserServiceImpl, orderClient *fakeOrderClient, closeRedis func() error) {
+			orderClient.onClose = func() { closeRedis() }
+		}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s, client := newTestService(t)
+			orderClient := &fakeOrderClient{orders: map[string]order.OrderStatus{"pending": order.OrderStatus_PENDING}}
+			s.orderClient = orderClient
+			c.setup(s, orderClient, client.Close)
+
+			u := createTestUser(t, s, "alice")
+			token := login(t, s, "alice", "password")
+
+			resp, _ := s.DeleteAccount(context.Background(), &user.DeleteAccountRequest{AccessToken: token, Password: "password"})
+			if resp.BaseResp.Code != 500 {
+				t.Fatalf("注销账户的结果为%+v，期望500", resp.BaseResp)
+			}
+			if !slices.Equal(orderClient.closed, []bool{true, false}) {
+				t.Fatalf("下单状态的修改为%v，期望关闭后重新开放", orderClient.closed)
+			}
+			if _, err := s.userData.GetByID(context.Background(), u.ID); err != nil {
+				t.Fatalf("注销失败后获取用户失败：%v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"gorm.io/gorm"
+
+	user "Redrock/seckill/kitex_gen/user"
+	"Redrock/seckill/internal/pkg/auth"
+	"Redrock/seckill/internal/pkg/models"
+)
+
+// PromoteAdmins 将配置中的用户设置为管理员
+func (s *UserServiceImpl) PromoteAdmins(ctx context.Context, usernames []string) (int64, error) {
+	return s.userData.PromoteAdmins(ctx, usernames)
+}
+
+// toUserInfo 将用户转换为响应中的用户信息
+func toUserInfo(u *models.User) *user.UserInfo {
+	return &user.UserInfo{
+		Id:       int64(u.ID),
+		Username: u.Username,
+		Role:     u.Role,
+	}
+}
+
+// loadUser 获取用户，失败时设置响应的错误码并返回nil
+func (s *UserServiceImpl) loadUser(ctx context.Context, id int64, baseResp *user.BaseResp) *models.User {
+	if id <= 0 {
+		baseResp.Code = 400
+		baseResp.Message = "用户ID不能为空"
+		return nil
+	}
+
+	localUser, err := s.userData.GetByID(ctx, uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			baseResp.Code = 404
+			baseResp.Message = "用户不存在"
+			return nil
+		}
+
+		baseResp.Code = 500
+		baseResp.Message = "获取用户信息失败: " + err.Error()
+		return nil
+	}
+
+	return localUser
+}
+
+// changeRole 将用户的角色从from修改为to，并撤销用户已签发的令牌，使旧令牌中的角色立即失效
+// 撤销令牌失败时恢复原来的角色，失败时设置响应的错误码并返回false
+func (s *UserServiceImpl) changeRole(ctx context.Context, localUser *models.User, from string, to string, action string, baseResp *user.BaseResp) bool {
+	updated, err := s.userData.UpdateRole(ctx, localUser.ID, from, to)
+	if err != nil {
+		baseResp.Code = 500
+		baseResp.Message = action + "失败: " + err.Error()
+		return false
+	}
+	if !updated {
+		baseResp.Code = 409
+		baseResp.Message = "用户角色已变化，请重试"
+		return false
+	}
+
+	err = s.revoker.RevokeUser(ctx, localUser.ID)
+	if err != nil {
+		_, rollbackErr := s.userData.UpdateRole(ctx, localUser.ID, to, from)
+		if rollbackErr != nil {
+			log.Printf("撤销用户%d的令牌失败后恢复角色失败：%v", localUser.ID, rollbackErr)
+		}
+
+		baseResp.Code = 500
+		baseResp.Message = action + "失败: 撤销用户令牌失败: " + err.Error()
+		return false
+	}
+
+	localUser.Role = to
+	return true
+}
+
+// GrantRole 授予用户角色，替换原来的角色，同时撤销用户已签发的令牌，用户重新登录后生效
+func (s *UserServiceImpl) GrantRole(ctx context.Context, req *user.GrantRoleRequest) (resp *user.GrantRoleResponse, err error) {
+	response := &user.GrantRoleResponse{
+		BaseResp: &user.BaseResp{},
+	}
+
+	if !auth.ValidRole(req.Role) {
+		response.BaseResp.Code = 400
+		response.BaseResp.Message = "角色无效"
+		return response, nil
+	}
+
+	localUser := s.loadUser(ctx, req.UserId, response.BaseResp)
+	if localUser == nil {
+		return response, nil
+	}
+
+	if localUser.Role != req.Role {
+		if !s.changeRole(ctx, localUser, localUser.Role, req.Role, "授予角色", response.BaseResp) {
+			return response, nil
+		}
+	}
+
+	response.BaseResp.Code = 0
+	response.BaseResp.Message = "授予角色成功"
+	response.User = toUserInfo(localUser)
+
+	return response, nil
+}
+
+// RevokeRole 撤销用户的角色，恢复为普通用户，同时撤销用户已签发的令牌，用户重新登录后生效
+func (s *UserServiceImpl) RevokeRole(ctx context.Context, req *user.RevokeRoleRequest) (resp *user.RevokeRoleResponse, err error) {
+	response := &user.RevokeRoleResponse{
+		BaseResp: &user.BaseResp{},
+	}
+
+	if req.Role != auth.RoleMerchant && req.Role != auth.RoleAdmin {
+		response.BaseResp.Code = 400
+		response.BaseResp.Message = "只能撤销商家或管理员角色"
+		return response, nil
+	}
+
+	localUser := s.loadUser(ctx, req.UserId, response.BaseResp)
+	if localUser == nil {
+		return response, nil
+	}
+
+	if localUser.Role != req.Role {
+		response.BaseResp.Code = 400
+		response.BaseResp.Message = "用户没有该角色"
+		return response, nil
+	}
+
+	if !s.changeRole(ctx, localUser, req.Role, auth.RoleUser, "撤销角色", response.BaseResp) {
+		return response, nil
+	}
+
+	response.BaseResp.Code = 0
+	response.BaseResp.Message = "撤销角色成功"
+	response.User = toUserInfo(localUser)
+
+	return response, nil
+}
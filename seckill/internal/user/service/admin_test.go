@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
+
+	"Redrock/seckill/internal/pkg/auth"
+	"Redrock/seckill/internal/pkg/models"
+	"Redrock/seckill/internal/pkg/testenv"
+	"Redrock/seckill/internal/user/data"
+	"Redrock/seckill/internal/user/password"
+	user "Redrock/seckill/kitex_gen/user"
+)
+
+func newTestService(t *testing.T) (*UserServiceImpl, *redis.Client) {
+	t.Helper()
+
+	testenv.NewDB(t, &models.User{})
+	client := testenv.NewRedis(t)
+
+	hasher, err := password.NewHasher(&password.PasswordConfig{BcryptCost: 4})
+	if err != nil {
+		t.Fatalf("创建密码哈希器失败：%v", err)
+	}
+
+	tokens, err := auth.NewTokenManager(&auth.AuthConfig{Secret: "test-secret", AccessTTL: 60})
+	if err != nil {
+		t.Fatalf("创建令牌管理器失败：%v", err)
+	}
+
+	return &UserServiceImpl{
+		userData: data.NewUserData(hasher),
+		tokens:   tokens,
+		revoker:  auth.NewRevoker(client, &auth.AuthConfig{AccessTTL: 60}),
+	}, client
+}
+
+func createTestUser(t *testing.T, s *UserServiceImpl, username string) *models.User {
+	t.Helper()
+
+	u := &models.User{Username: username, Password: "password"}
+	if err := s.userData.Create(context.Background(), u); err != nil {
+		t.Fatalf("创建用户失败：%v", err)
+	}
+
+	return u
+}
+
+// issuedBefore 构造一个在修改角色之前签发的令牌
+func issuedBefore(u *models.User, role string) *auth.Claims {
+	return &auth.Claims{
+		UserID: u.ID,
+		Role:   role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "before",
+			IssuedAt:  jwt.NewNumericDate(time.Now().Add(-10 * time.Second)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+	}
+}
+
+func userRole(t *testing.T, s *UserServiceImpl, id uint) string {
+	t.Helper()
+
+	u, err := s.userData.GetByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("获取用户失败：%v", err)
+	}
+
+	return u.Role
+}
+
+// 授予和撤销角色后，旧令牌中的角色立即失效
+func TestChangeRoleRevokesTokens(t *testing.T) {
+	s, _ := newTestService(t)
+	ctx := context.Background()
+
+	merchant := createTestUser(t, s, "merchant")
+	old := issuedBefore(merchant, auth.RoleUser)
+
+	grant, err := s.GrantRole(ctx, &user.GrantRoleRequest{UserId: int64(merchant.ID), Role: auth.RoleMerchant})
+	if err != nil || grant.BaseResp.Code != 0 {
+		t.Fatalf("授予角色的结果为%+v, %v，期望成功", grant.BaseResp, err)
+	}
+	if revoked, err := s.revoker.IsRevoked(ctx, old); err != nil || !revoked {
+		t.Fatalf("授予角色后旧令牌是否撤销为%v, %v，期望已撤销", revoked, err)
+	}
+
+	// 撤销角色前重新登录获得的商家令牌，换一个Redis排除授予角色时留下的撤销记录
+	s.revoker = auth.NewRevoker(testenv.NewRedis(t), &auth.AuthConfig{AccessTTL: 60})
+	old = issuedBefore(merchant, auth.RoleMerchant)
+
+	revoke, err := s.RevokeRole(ctx, &user.RevokeRoleRequest{UserId: int64(merchant.ID), Role: auth.RoleMerchant})
+	if err != nil || revoke.BaseResp.Code != 0 {
+		t.Fatalf("撤销角色的结果为%+v, %v，期望成功", revoke.BaseResp, err)
+	}
+	if revoked, err := s.revoker.IsRevoked(ctx, old); err != nil || !revoked {
+		t.Fatalf("撤销角色后商家令牌是否撤销为%v, %v，期望已撤销", revoked, err)
+	}
+	if role := userRole(t, s, merchant.ID); role != auth.RoleUser {
+		t.Fatalf("撤销角色后用户的角色为%s，期望user", role)
+	}
+}
+
+// 撤销令牌失败时恢复原来的角色，避免旧令牌继续使用原来的权限
+func TestChangeRoleRollbackOnRevokeFailure(t *testing.T) {
+	s, client := newTestService(t)
+	ctx := context.Background()
+
+	admin := createTestUser(t, s, "admin")
+	if _, err := s.userData.UpdateRole(ctx, admin.ID, auth.RoleUser, auth.RoleAdmin); err != nil {
+		t.Fatalf("设置管理员失败：%v", err)
+	}
+
+	client.Close()
+
+	resp, err := s.RevokeRole(ctx, &user.RevokeRoleRequest{UserId: int64(admin.ID), Role: auth.RoleAdmin})
+	if err != nil || resp.BaseResp.Code != 500 {
+		t.Fatalf("撤销角色的结果为%+v, %v，期望500", resp.BaseResp, err)
+	}
+	if role := userRole(t, s, admin.ID); role != auth.RoleAdmin {
+		t.Fatalf("撤销令牌失败后用户的角色为%s，期望恢复为admin", role)
+	}
+
+	grant, err := s.GrantRole(ctx, &user.GrantRoleRequest{UserId: int64(admin.ID), Role: auth.RoleMerchant})
+	if err != nil || grant.BaseResp.Code != 500 {
+		t.Fatalf("授予角色的结果为%+v, %v，期望500", grant.BaseResp, err)
+	}
+	if role := userRole(t, s, admin.ID); role != auth.RoleAdmin {
+		t.Fatalf("撤销令牌失败后用户的角色为%s，期望恢复为admin", role)
+	}
+}
@@ -2,20 +2,42 @@ package service
 
 import (
 	"context"
+	"fmt"
 
 	user "Redrock/seckill/kitex_gen/user"
+	"Redrock/seckill/kitex_gen/order/orderservice"
+	"Redrock/seckill/internal/pkg/auth"
 	"Redrock/seckill/internal/pkg/models"
+	"Redrock/seckill/internal/pkg/redis"
+	"Redrock/seckill/internal/user/config"
 	"Redrock/seckill/internal/user/data"
+	"Redrock/seckill/internal/user/password"
 )
 
 // UserServiceImpl implements the last service interface defined in the IDL.
 type UserServiceImpl struct{
-	userData *data.UserData
+	userData    *data.UserData
+	tokens      *auth.TokenManager
+	revoker     *auth.Revoker
+	orderClient orderservice.Client
 }
 
-func NewUserServiceImpl() *UserServiceImpl{
+func NewUserServiceImpl(cfg *config.Config) *UserServiceImpl{
+	tokens, err := auth.NewTokenManager(&cfg.Auth)
+	if err != nil{
+		panic(fmt.Sprintf("创建令牌管理器失败：%v", err))
+	}
+
+	hasher, err := password.NewHasher(&cfg.Password)
+	if err != nil{
+		panic(fmt.Sprintf("创建密码哈希器失败：%v", err))
+	}
+
 	return &UserServiceImpl{
-		userData: data.NewUserData(),
+		userData:    data.NewUserData(hasher),
+		tokens:      tokens,
+		revoker:     auth.NewRevoker(redis.GetRedis(), &cfg.Auth),
+		orderClient: newOrderClient(cfg.OrderRPC),
 	}
 }
 
@@ -74,9 +96,20 @@ func (s *UserServiceImpl) Login(ctx context.Context, req *user.LoginRequest) (re
 		return response, nil
 	}
 
+	// 签发访问令牌，之后的请求通过令牌识别用户
+	token, claims, err := s.tokens.Issue(loginUser.ID, loginUser.Role)
+	if err != nil {
+		response.BaseResp.Code = 500
+		response.BaseResp.Message = "登录失败: " + err.Error()
+		return response, nil
+	}
+
 	response.BaseResp.Code = 0
 	response.BaseResp.Message = "登录成功"
 	response.UserId = int64(loginUser.ID)
+	response.AccessToken = token
+	response.ExpiresAt = claims.ExpiresAt.Unix()
+	response.Role = loginUser.Role
 
 	return response, nil
 }
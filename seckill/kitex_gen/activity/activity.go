@@ -58,6 +58,7 @@ type ActivityInfo struct {
 	AvailableStock int64   `thrift:"availableStock,10" frugal:"10,default,i64" json:"availableStock"`
 	IsAvailable    bool    `thrift:"isAvailable,11" frugal:"11,default,bool" json:"isAvailable"`
 	Status         int32   `thrift:"status,12" frugal:"12,default,i32" json:"status"`
+	PerUserLimit   int64   `thrift:"perUserLimit,13" frugal:"13,default,i64" json:"perUserLimit"`
 }
 
 func NewActivityInfo() *ActivityInfo {
@@ -110,6 +111,14 @@ func (p *ActivityInfo) GetAvailableStock() (v int64) {
 func (p *ActivityInfo) GetIsAvailable() (v bool) {
 	return p.IsAvailable
 }
+
+func (p *ActivityInfo) GetStatus() (v int32) {
+	return p.Status
+}
+
+func (p *ActivityInfo) GetPerUserLimit() (v int64) {
+	return p.PerUserLimit
+}
 func (p *ActivityInfo) SetId(val int64) {
 	p.Id = val
 }
@@ -143,6 +152,12 @@ func (p *ActivityInfo) SetAvailableStock(val int64) {
 func (p *ActivityInfo) SetIsAvailable(val bool) {
 	p.IsAvailable = val
 }
+func (p *ActivityInfo) SetStatus(val int32) {
+	p.Status = val
+}
+func (p *ActivityInfo) SetPerUserLimit(val int64) {
+	p.PerUserLimit = val
+}
 
 func (p *ActivityInfo) String() string {
 	if p == nil {
@@ -163,6 +178,8 @@ var fieldIDToName_ActivityInfo = map[int16]string{
 	9:  "totalStock",
 	10: "availableStock",
 	11: "isAvailable",
+	12: "status",
+	13: "perUserLimit",
 }
 
 type CreateActivityRequest struct {
@@ -172,6 +189,7 @@ type CreateActivityRequest struct {
 	StartTime    int64   `thrift:"startTime,4" frugal:"4,default,i64" json:"startTime"`
 	EndTime      int64   `thrift:"endTime,5" frugal:"5,default,i64" json:"endTime"`
 	TotalStock   int64   `thrift:"totalStock,6" frugal:"6,default,i64" json:"totalStock"`
+	PerUserLimit *int64  `thrift:"perUserLimit,7,optional" frugal:"7,optional,i64" json:"perUserLimit,omitempty"`
 }
 
 func NewCreateActivityRequest() *CreateActivityRequest {
@@ -204,6 +222,15 @@ func (p *CreateActivityRequest) GetEndTime() (v int64) {
 func (p *CreateActivityRequest) GetTotalStock() (v int64) {
 	return p.TotalStock
 }
+
+var CreateActivityRequest_PerUserLimit_DEFAULT int64
+
+func (p *CreateActivityRequest) GetPerUserLimit() (v int64) {
+	if !p.IsSetPerUserLimit() {
+		return CreateActivityRequest_PerUserLimit_DEFAULT
+	}
+	return *p.PerUserLimit
+}
 func (p *CreateActivityRequest) SetName(val string) {
 	p.Name = val
 }
@@ -222,6 +249,13 @@ func (p *CreateActivityRequest) SetEndTime(val int64) {
 func (p *CreateActivityRequest) SetTotalStock(val int64) {
 	p.TotalStock = val
 }
+func (p *CreateActivityRequest) SetPerUserLimit(val *int64) {
+	p.PerUserLimit = val
+}
+
+func (p *CreateActivityRequest) IsSetPerUserLimit() bool {
+	return p.PerUserLimit != nil
+}
 
 func (p *CreateActivityRequest) String() string {
 	if p == nil {
@@ -237,6 +271,7 @@ var fieldIDToName_CreateActivityRequest = map[int16]string{
 	4: "startTime",
 	5: "endTime",
 	6: "totalStock",
+	7: "perUserLimit",
 }
 
 type CreateActivityResponse struct {
@@ -287,14 +322,133 @@ var fieldIDToName_CreateActivityResponse = map[int16]string{
 }
 
 type GetActivityListRequest struct {
-	Status int32 `thrift:"status,1" frugal:"1,default,i32" json:"status"`
+	Status      int32    `thrift:"status,1" frugal:"1,default,i32" json:"status"`
+	Cursor      string   `thrift:"cursor,2" frugal:"2,default,string" json:"cursor"`
+	PageSize    int32    `thrift:"pageSize,3" frugal:"3,default,i32" json:"pageSize"`
+	TimeFrom    *int64   `thrift:"timeFrom,4,optional" frugal:"4,optional,i64" json:"timeFrom,omitempty"`
+	TimeTo      *int64   `thrift:"timeTo,5,optional" frugal:"5,optional,i64" json:"timeTo,omitempty"`
+	MinPrice    *float64 `thrift:"minPrice,6,optional" frugal:"6,optional,double" json:"minPrice,omitempty"`
+	MaxPrice    *float64 `thrift:"maxPrice,7,optional" frugal:"7,optional,double" json:"maxPrice,omitempty"`
+	ProductName string   `thrift:"productName,8" frugal:"8,default,string" json:"productName"`
+	SortBy      string   `thrift:"sortBy,9" frugal:"9,default,string" json:"sortBy"`
+	Asc         bool     `thrift:"asc,10" frugal:"10,default,bool" json:"asc"`
 }
 
 func NewGetActivityListRequest() *GetActivityListRequest {
-	return &GetActivityListRequest{}
+	return &GetActivityListRequest{
+
+		Status: -1,
+	}
 }
 
 func (p *GetActivityListRequest) InitDefault() {
+	p.Status = -1
+}
+
+func (p *GetActivityListRequest) GetStatus() (v int32) {
+	return p.Status
+}
+
+func (p *GetActivityListRequest) GetCursor() (v string) {
+	return p.Cursor
+}
+
+func (p *GetActivityListRequest) GetPageSize() (v int32) {
+	return p.PageSize
+}
+
+var GetActivityListRequest_TimeFrom_DEFAULT int64
+
+func (p *GetActivityListRequest) GetTimeFrom() (v int64) {
+	if !p.IsSetTimeFrom() {
+		return GetActivityListRequest_TimeFrom_DEFAULT
+	}
+	return *p.TimeFrom
+}
+
+var GetActivityListRequest_TimeTo_DEFAULT int64
+
+func (p *GetActivityListRequest) GetTimeTo() (v int64) {
+	if !p.IsSetTimeTo() {
+		return GetActivityListRequest_TimeTo_DEFAULT
+	}
+	return *p.TimeTo
+}
+
+var GetActivityListRequest_MinPrice_DEFAULT float64
+
+func (p *GetActivityListRequest) GetMinPrice() (v float64) {
+	if !p.IsSetMinPrice() {
+		return GetActivityListRequest_MinPrice_DEFAULT
+	}
+	return *p.MinPrice
+}
+
+var GetActivityListRequest_MaxPrice_DEFAULT float64
+
+func (p *GetActivityListRequest) GetMaxPrice() (v float64) {
+	if !p.IsSetMaxPrice() {
+		return GetActivityListRequest_MaxPrice_DEFAULT
+	}
+	return *p.MaxPrice
+}
+
+func (p *GetActivityListRequest) GetProductName() (v string) {
+	return p.ProductName
+}
+
+func (p *GetActivityListRequest) GetSortBy() (v string) {
+	return p.SortBy
+}
+
+func (p *GetActivityListRequest) GetAsc() (v bool) {
+	return p.Asc
+}
+func (p *GetActivityListRequest) SetStatus(val int32) {
+	p.Status = val
+}
+func (p *GetActivityListRequest) SetCursor(val string) {
+	p.Cursor = val
+}
+func (p *GetActivityListRequest) SetPageSize(val int32) {
+	p.PageSize = val
+}
+func (p *GetActivityListRequest) SetTimeFrom(val *int64) {
+	p.TimeFrom = val
+}
+func (p *GetActivityListRequest) SetTimeTo(val *int64) {
+	p.TimeTo = val
+}
+func (p *GetActivityListRequest) SetMinPrice(val *float64) {
+	p.MinPrice = val
+}
+func (p *GetActivityListRequest) SetMaxPrice(val *float64) {
+	p.MaxPrice = val
+}
+func (p *GetActivityListRequest) SetProductName(val string) {
+	p.ProductName = val
+}
+func (p *GetActivityListRequest) SetSortBy(val string) {
+	p.SortBy = val
+}
+func (p *GetActivityListRequest) SetAsc(val bool) {
+	p.Asc = val
+}
+
+func (p *GetActivityListRequest) IsSetTimeFrom() bool {
+	return p.TimeFrom != nil
+}
+
+func (p *GetActivityListRequest) IsSetTimeTo() bool {
+	return p.TimeTo != nil
+}
+
+func (p *GetActivityListRequest) IsSetMinPrice() bool {
+	return p.MinPrice != nil
+}
+
+func (p *GetActivityListRequest) IsSetMaxPrice() bool {
+	return p.MaxPrice != nil
 }
 
 func (p *GetActivityListRequest) String() string {
@@ -304,12 +458,25 @@ func (p *GetActivityListRequest) String() string {
 	return fmt.Sprintf("GetActivityListRequest(%+v)", *p)
 }
 
-var fieldIDToName_GetActivityListRequest = map[int16]string{}
+var fieldIDToName_GetActivityListRequest = map[int16]string{
+	1:  "status",
+	2:  "cursor",
+	3:  "pageSize",
+	4:  "timeFrom",
+	5:  "timeTo",
+	6:  "minPrice",
+	7:  "maxPrice",
+	8:  "productName",
+	9:  "sortBy",
+	10: "asc",
+}
 
 type GetActivityListResponse struct {
 	BaseResponse *BaseResponse   `thrift:"baseResponse,1" frugal:"1,default,BaseResponse" json:"baseResponse"`
 	Activities   []*ActivityInfo `thrift:"activities,2" frugal:"2,default,list<ActivityInfo>" json:"activities"`
 	Total        int64           `thrift:"total,3" frugal:"3,default,i64" json:"total"`
+	NextCursor   string          `thrift:"nextCursor,4" frugal:"4,default,string" json:"nextCursor"`
+	HasMore      bool            `thrift:"hasMore,5" frugal:"5,default,bool" json:"hasMore"`
 }
 
 func NewGetActivityListResponse() *GetActivityListResponse {
@@ -335,6 +502,14 @@ func (p *GetActivityListResponse) GetActivities() (v []*ActivityInfo) {
 func (p *GetActivityListResponse) GetTotal() (v int64) {
 	return p.Total
 }
+
+func (p *GetActivityListResponse) GetNextCursor() (v string) {
+	return p.NextCursor
+}
+
+func (p *GetActivityListResponse) GetHasMore() (v bool) {
+	return p.HasMore
+}
 func (p *GetActivityListResponse) SetBaseResponse(val *BaseResponse) {
 	p.BaseResponse = val
 }
@@ -344,6 +519,12 @@ func (p *GetActivityListResponse) SetActivities(val []*ActivityInfo) {
 func (p *GetActivityListResponse) SetTotal(val int64) {
 	p.Total = val
 }
+func (p *GetActivityListResponse) SetNextCursor(val string) {
+	p.NextCursor = val
+}
+func (p *GetActivityListResponse) SetHasMore(val bool) {
+	p.HasMore = val
+}
 
 func (p *GetActivityListResponse) IsSetBaseResponse() bool {
 	return p.BaseResponse != nil
@@ -360,6 +541,8 @@ var fieldIDToName_GetActivityListResponse = map[int16]string{
 	1: "baseResponse",
 	2: "activities",
 	3: "total",
+	4: "nextCursor",
+	5: "hasMore",
 }
 
 type GetActivityRequest struct {
@@ -447,416 +630,2234 @@ var fieldIDToName_GetActivityResponse = map[int16]string{
 	2: "activity",
 }
 
-type DeductStockRequest struct {
-	ActivityID int64 `thrift:"activityID,1" frugal:"1,default,i64" json:"activityID"`
-	UserID     int64 `thrift:"userID,2" frugal:"2,default,i64" json:"userID"`
-	Count      int64 `thrift:"count,3" frugal:"3,default,i64" json:"count"`
+type UpdateActivityRequest struct {
+	ActivityID   int64    `thrift:"activityID,1" frugal:"1,default,i64" json:"activityID"`
+	Name         *string  `thrift:"name,2,optional" frugal:"2,optional,string" json:"name,omitempty"`
+	SeckillPrice *float64 `thrift:"seckillPrice,3,optional" frugal:"3,optional,double" json:"seckillPrice,omitempty"`
+	StartTime    *int64   `thrift:"startTime,4,optional" frugal:"4,optional,i64" json:"startTime,omitempty"`
+	EndTime      *int64   `thrift:"endTime,5,optional" frugal:"5,optional,i64" json:"endTime,omitempty"`
+	PerUserLimit *int64   `thrift:"perUserLimit,6,optional" frugal:"6,optional,i64" json:"perUserLimit,omitempty"`
 }
 
-func NewDeductStockRequest() *DeductStockRequest {
-	return &DeductStockRequest{
+func NewUpdateActivityRequest() *UpdateActivityRequest {
+	return &UpdateActivityRequest{}
+}
 
-		Count: 1,
+func (p *UpdateActivityRequest) InitDefault() {
+}
+
+func (p *UpdateActivityRequest) GetActivityID() (v int64) {
+	return p.ActivityID
+}
+
+var UpdateActivityRequest_Name_DEFAULT string
+
+func (p *UpdateActivityRequest) GetName() (v string) {
+	if !p.IsSetName() {
+		return UpdateActivityRequest_Name_DEFAULT
 	}
+	return *p.Name
 }
 
-func (p *DeductStockRequest) InitDefault() {
-	p.Count = 1
+var UpdateActivityRequest_SeckillPrice_DEFAULT float64
+
+func (p *UpdateActivityRequest) GetSeckillPrice() (v float64) {
+	if !p.IsSetSeckillPrice() {
+		return UpdateActivityRequest_SeckillPrice_DEFAULT
+	}
+	return *p.SeckillPrice
 }
 
-func (p *DeductStockRequest) GetActivityID() (v int64) {
-	return p.ActivityID
+var UpdateActivityRequest_StartTime_DEFAULT int64
+
+func (p *UpdateActivityRequest) GetStartTime() (v int64) {
+	if !p.IsSetStartTime() {
+		return UpdateActivityRequest_StartTime_DEFAULT
+	}
+	return *p.StartTime
 }
 
-func (p *DeductStockRequest) GetUserID() (v int64) {
-	return p.UserID
+var UpdateActivityRequest_EndTime_DEFAULT int64
+
+func (p *UpdateActivityRequest) GetEndTime() (v int64) {
+	if !p.IsSetEndTime() {
+		return UpdateActivityRequest_EndTime_DEFAULT
+	}
+	return *p.EndTime
 }
 
-func (p *DeductStockRequest) GetCount() (v int64) {
-	return p.Count
+var UpdateActivityRequest_PerUserLimit_DEFAULT int64
+
+func (p *UpdateActivityRequest) GetPerUserLimit() (v int64) {
+	if !p.IsSetPerUserLimit() {
+		return UpdateActivityRequest_PerUserLimit_DEFAULT
+	}
+	return *p.PerUserLimit
 }
-func (p *DeductStockRequest) SetActivityID(val int64) {
+func (p *UpdateActivityRequest) SetActivityID(val int64) {
 	p.ActivityID = val
 }
-func (p *DeductStockRequest) SetUserID(val int64) {
-	p.UserID = val
+func (p *UpdateActivityRequest) SetName(val *string) {
+	p.Name = val
 }
-func (p *DeductStockRequest) SetCount(val int64) {
-	p.Count = val
+func (p *UpdateActivityRequest) SetSeckillPrice(val *float64) {
+	p.SeckillPrice = val
+}
+func (p *UpdateActivityRequest) SetStartTime(val *int64) {
+	p.StartTime = val
+}
+func (p *UpdateActivityRequest) SetEndTime(val *int64) {
+	p.EndTime = val
+}
+func (p *UpdateActivityRequest) SetPerUserLimit(val *int64) {
+	p.PerUserLimit = val
 }
 
-func (p *DeductStockRequest) String() string {
+func (p *UpdateActivityRequest) IsSetName() bool {
+	return p.Name != nil
+}
+
+func (p *UpdateActivityRequest) IsSetSeckillPrice() bool {
+	return p.SeckillPrice != nil
+}
+
+func (p *UpdateActivityRequest) IsSetStartTime() bool {
+	return p.StartTime != nil
+}
+
+func (p *UpdateActivityRequest) IsSetEndTime() bool {
+	return p.EndTime != nil
+}
+
+func (p *UpdateActivityRequest) IsSetPerUserLimit() bool {
+	return p.PerUserLimit != nil
+}
+
+func (p *UpdateActivityRequest) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("DeductStockRequest(%+v)", *p)
+	return fmt.Sprintf("UpdateActivityRequest(%+v)", *p)
 }
 
-var fieldIDToName_DeductStockRequest = map[int16]string{
+var fieldIDToName_UpdateActivityRequest = map[int16]string{
 	1: "activityID",
-	2: "userID",
-	3: "count",
+	2: "name",
+	3: "seckillPrice",
+	4: "startTime",
+	5: "endTime",
+	6: "perUserLimit",
 }
 
-type DeductStockResponse struct {
+type UpdateActivityResponse struct {
 	BaseResponse *BaseResponse `thrift:"baseResponse,1" frugal:"1,default,BaseResponse" json:"baseResponse"`
-	Success      bool          `thrift:"success,2" frugal:"2,default,bool" json:"success"`
+	Activity     *ActivityInfo `thrift:"activity,2" frugal:"2,default,ActivityInfo" json:"activity"`
 }
 
-func NewDeductStockResponse() *DeductStockResponse {
-	return &DeductStockResponse{}
+func NewUpdateActivityResponse() *UpdateActivityResponse {
+	return &UpdateActivityResponse{}
 }
 
-func (p *DeductStockResponse) InitDefault() {
+func (p *UpdateActivityResponse) InitDefault() {
 }
 
-var DeductStockResponse_BaseResponse_DEFAULT *BaseResponse
+var UpdateActivityResponse_BaseResponse_DEFAULT *BaseResponse
 
-func (p *DeductStockResponse) GetBaseResponse() (v *BaseResponse) {
+func (p *UpdateActivityResponse) GetBaseResponse() (v *BaseResponse) {
 	if !p.IsSetBaseResponse() {
-		return DeductStockResponse_BaseResponse_DEFAULT
+		return UpdateActivityResponse_BaseResponse_DEFAULT
 	}
 	return p.BaseResponse
 }
 
-func (p *DeductStockResponse) GetSuccess() (v bool) {
-	return p.Success
+var UpdateActivityResponse_Activity_DEFAULT *ActivityInfo
+
+func (p *UpdateActivityResponse) GetActivity() (v *ActivityInfo) {
+	if !p.IsSetActivity() {
+		return UpdateActivityResponse_Activity_DEFAULT
+	}
+	return p.Activity
 }
-func (p *DeductStockResponse) SetBaseResponse(val *BaseResponse) {
+func (p *UpdateActivityResponse) SetBaseResponse(val *BaseResponse) {
 	p.BaseResponse = val
 }
-func (p *DeductStockResponse) SetSuccess(val bool) {
-	p.Success = val
+func (p *UpdateActivityResponse) SetActivity(val *ActivityInfo) {
+	p.Activity = val
 }
 
-func (p *DeductStockResponse) IsSetBaseResponse() bool {
+func (p *UpdateActivityResponse) IsSetBaseResponse() bool {
 	return p.BaseResponse != nil
 }
 
-func (p *DeductStockResponse) String() string {
+func (p *UpdateActivityResponse) IsSetActivity() bool {
+	return p.Activity != nil
+}
+
+func (p *UpdateActivityResponse) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("DeductStockResponse(%+v)", *p)
+	return fmt.Sprintf("UpdateActivityResponse(%+v)", *p)
 }
 
-var fieldIDToName_DeductStockResponse = map[int16]string{
+var fieldIDToName_UpdateActivityResponse = map[int16]string{
 	1: "baseResponse",
-	2: "success",
-}
-
-type ActivityService interface {
-	CreateActivity(ctx context.Context, req *CreateActivityRequest) (r *CreateActivityResponse, err error)
-
-	GetActivityList(ctx context.Context, req *GetActivityListRequest) (r *GetActivityListResponse, err error)
-
-	GetActivity(ctx context.Context, req *GetActivityRequest) (r *GetActivityResponse, err error)
+	2: "activity",
 }
 
-type ActivityServiceCreateActivityArgs struct {
-	Req *CreateActivityRequest `thrift:"req,1" frugal:"1,default,CreateActivityRequest" json:"req"`
+type PauseActivityRequest struct {
+	ActivityID int64 `thrift:"activityID,1" frugal:"1,default,i64" json:"activityID"`
 }
 
-func NewActivityServiceCreateActivityArgs() *ActivityServiceCreateActivityArgs {
-	return &ActivityServiceCreateActivityArgs{}
+func NewPauseActivityRequest() *PauseActivityRequest {
+	return &PauseActivityRequest{}
 }
 
-func (p *ActivityServiceCreateActivityArgs) InitDefault() {
+func (p *PauseActivityRequest) InitDefault() {
 }
 
-var ActivityServiceCreateActivityArgs_Req_DEFAULT *CreateActivityRequest
-
-func (p *ActivityServiceCreateActivityArgs) GetReq() (v *CreateActivityRequest) {
-	if !p.IsSetReq() {
-		return ActivityServiceCreateActivityArgs_Req_DEFAULT
-	}
-	return p.Req
-}
-func (p *ActivityServiceCreateActivityArgs) SetReq(val *CreateActivityRequest) {
-	p.Req = val
+func (p *PauseActivityRequest) GetActivityID() (v int64) {
+	return p.ActivityID
 }
-
-func (p *ActivityServiceCreateActivityArgs) IsSetReq() bool {
-	return p.Req != nil
+func (p *PauseActivityRequest) SetActivityID(val int64) {
+	p.ActivityID = val
 }
 
-func (p *ActivityServiceCreateActivityArgs) String() string {
+func (p *PauseActivityRequest) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("ActivityServiceCreateActivityArgs(%+v)", *p)
+	return fmt.Sprintf("PauseActivityRequest(%+v)", *p)
 }
 
-var fieldIDToName_ActivityServiceCreateActivityArgs = map[int16]string{
-	1: "req",
+var fieldIDToName_PauseActivityRequest = map[int16]string{
+	1: "activityID",
 }
 
-type ActivityServiceCreateActivityResult struct {
-	Success *CreateActivityResponse `thrift:"success,0,optional" frugal:"0,optional,CreateActivityResponse" json:"success,omitempty"`
+type PauseActivityResponse struct {
+	BaseResponse *BaseResponse `thrift:"baseResponse,1" frugal:"1,default,BaseResponse" json:"baseResponse"`
+	Activity     *ActivityInfo `thrift:"activity,2" frugal:"2,default,ActivityInfo" json:"activity"`
 }
 
-func NewActivityServiceCreateActivityResult() *ActivityServiceCreateActivityResult {
-	return &ActivityServiceCreateActivityResult{}
+func NewPauseActivityResponse() *PauseActivityResponse {
+	return &PauseActivityResponse{}
 }
 
-func (p *ActivityServiceCreateActivityResult) InitDefault() {
+func (p *PauseActivityResponse) InitDefault() {
 }
 
-var ActivityServiceCreateActivityResult_Success_DEFAULT *CreateActivityResponse
+var PauseActivityResponse_BaseResponse_DEFAULT *BaseResponse
 
-func (p *ActivityServiceCreateActivityResult) GetSuccess() (v *CreateActivityResponse) {
-	if !p.IsSetSuccess() {
-		return ActivityServiceCreateActivityResult_Success_DEFAULT
+func (p *PauseActivityResponse) GetBaseResponse() (v *BaseResponse) {
+	if !p.IsSetBaseResponse() {
+		return PauseActivityResponse_BaseResponse_DEFAULT
 	}
-	return p.Success
+	return p.BaseResponse
 }
-func (p *ActivityServiceCreateActivityResult) SetSuccess(x interface{}) {
-	p.Success = x.(*CreateActivityResponse)
+
+var PauseActivityResponse_Activity_DEFAULT *ActivityInfo
+
+func (p *PauseActivityResponse) GetActivity() (v *ActivityInfo) {
+	if !p.IsSetActivity() {
+		return PauseActivityResponse_Activity_DEFAULT
+	}
+	return p.Activity
+}
+func (p *PauseActivityResponse) SetBaseResponse(val *BaseResponse) {
+	p.BaseResponse = val
+}
+func (p *PauseActivityResponse) SetActivity(val *ActivityInfo) {
+	p.Activity = val
 }
 
-func (p *ActivityServiceCreateActivityResult) IsSetSuccess() bool {
-	return p.Success != nil
+func (p *PauseActivityResponse) IsSetBaseResponse() bool {
+	return p.BaseResponse != nil
 }
 
-func (p *ActivityServiceCreateActivityResult) String() string {
+func (p *PauseActivityResponse) IsSetActivity() bool {
+	return p.Activity != nil
+}
+
+func (p *PauseActivityResponse) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("ActivityServiceCreateActivityResult(%+v)", *p)
+	return fmt.Sprintf("PauseActivityResponse(%+v)", *p)
 }
 
-var fieldIDToName_ActivityServiceCreateActivityResult = map[int16]string{
-	0: "success",
+var fieldIDToName_PauseActivityResponse = map[int16]string{
+	1: "baseResponse",
+	2: "activity",
 }
 
-type ActivityServiceGetActivityListArgs struct {
-	Req *GetActivityListRequest `thrift:"req,1" frugal:"1,default,GetActivityListRequest" json:"req"`
+type ResumeActivityRequest struct {
+	ActivityID int64 `thrift:"activityID,1" frugal:"1,default,i64" json:"activityID"`
 }
 
-func NewActivityServiceGetActivityListArgs() *ActivityServiceGetActivityListArgs {
-	return &ActivityServiceGetActivityListArgs{}
+func NewResumeActivityRequest() *ResumeActivityRequest {
+	return &ResumeActivityRequest{}
 }
 
-func (p *ActivityServiceGetActivityListArgs) InitDefault() {
+func (p *ResumeActivityRequest) InitDefault() {
 }
 
-var ActivityServiceGetActivityListArgs_Req_DEFAULT *GetActivityListRequest
-
-func (p *ActivityServiceGetActivityListArgs) GetReq() (v *GetActivityListRequest) {
-	if !p.IsSetReq() {
-		return ActivityServiceGetActivityListArgs_Req_DEFAULT
-	}
-	return p.Req
-}
-func (p *ActivityServiceGetActivityListArgs) SetReq(val *GetActivityListRequest) {
-	p.Req = val
+func (p *ResumeActivityRequest) GetActivityID() (v int64) {
+	return p.ActivityID
 }
-
-func (p *ActivityServiceGetActivityListArgs) IsSetReq() bool {
-	return p.Req != nil
+func (p *ResumeActivityRequest) SetActivityID(val int64) {
+	p.ActivityID = val
 }
 
-func (p *ActivityServiceGetActivityListArgs) String() string {
+func (p *ResumeActivityRequest) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("ActivityServiceGetActivityListArgs(%+v)", *p)
+	return fmt.Sprintf("ResumeActivityRequest(%+v)", *p)
 }
 
-var fieldIDToName_ActivityServiceGetActivityListArgs = map[int16]string{
-	1: "req",
+var fieldIDToName_ResumeActivityRequest = map[int16]string{
+	1: "activityID",
 }
 
-type ActivityServiceGetActivityListResult struct {
-	Success *GetActivityListResponse `thrift:"success,0,optional" frugal:"0,optional,GetActivityListResponse" json:"success,omitempty"`
-}
+type ResumeActivityResponse struct {
+	BaseResponse *BaseResponse `thrift:"baseResponse,1" frugal:"1,default,BaseResponse" json:"baseResponse"`
+	Activity     *ActivityInfo `thrift:"activity,2" frugal:"2,default,ActivityInfo" json:"activity"`
+}
+
+func NewResumeActivityResponse() *ResumeActivityResponse {
+	return &ResumeActivityResponse{}
+}
+
+func (p *ResumeActivityResponse) InitDefault() {
+}
+
+var ResumeActivityResponse_BaseResponse_DEFAULT *BaseResponse
+
+func (p *ResumeActivityResponse) GetBaseResponse() (v *BaseResponse) {
+	if !p.IsSetBaseResponse() {
+		return ResumeActivityResponse_BaseResponse_DEFAULT
+	}
+	return p.BaseResponse
+}
+
+var ResumeActivityResponse_Activity_DEFAULT *ActivityInfo
+
+func (p *ResumeActivityResponse) GetActivity() (v *ActivityInfo) {
+	if !p.IsSetActivity() {
+		return ResumeActivityResponse_Activity_DEFAULT
+	}
+	return p.Activity
+}
+func (p *ResumeActivityResponse) SetBaseResponse(val *BaseResponse) {
+	p.BaseResponse = val
+}
+func (p *ResumeActivityResponse) SetActivity(val *ActivityInfo) {
+	p.Activity = val
+}
+
+func (p *ResumeActivityResponse) IsSetBaseResponse() bool {
+	return p.BaseResponse != nil
+}
+
+func (p *ResumeActivityResponse) IsSetActivity() bool {
+	return p.Activity != nil
+}
+
+func (p *ResumeActivityResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ResumeActivityResponse(%+v)", *p)
+}
+
+var fieldIDToName_ResumeActivityResponse = map[int16]string{
+	1: "baseResponse",
+	2: "activity",
+}
+
+type DeleteActivityRequest struct {
+	ActivityID int64 `thrift:"activityID,1" frugal:"1,default,i64" json:"activityID"`
+}
+
+func NewDeleteActivityRequest() *DeleteActivityRequest {
+	return &DeleteActivityRequest{}
+}
+
+func (p *DeleteActivityRequest) InitDefault() {
+}
+
+func (p *DeleteActivityRequest) GetActivityID() (v int64) {
+	return p.ActivityID
+}
+func (p *DeleteActivityRequest) SetActivityID(val int64) {
+	p.ActivityID = val
+}
+
+func (p *DeleteActivityRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DeleteActivityRequest(%+v)", *p)
+}
+
+var fieldIDToName_DeleteActivityRequest = map[int16]string{
+	1: "activityID",
+}
+
+type DeleteActivityResponse struct {
+	BaseResponse *BaseResponse `thrift:"baseResponse,1" frugal:"1,default,BaseResponse" json:"baseResponse"`
+}
+
+func NewDeleteActivityResponse() *DeleteActivityResponse {
+	return &DeleteActivityResponse{}
+}
+
+func (p *DeleteActivityResponse) InitDefault() {
+}
+
+var DeleteActivityResponse_BaseResponse_DEFAULT *BaseResponse
+
+func (p *DeleteActivityResponse) GetBaseResponse() (v *BaseResponse) {
+	if !p.IsSetBaseResponse() {
+		return DeleteActivityResponse_BaseResponse_DEFAULT
+	}
+	return p.BaseResponse
+}
+func (p *DeleteActivityResponse) SetBaseResponse(val *BaseResponse) {
+	p.BaseResponse = val
+}
+
+func (p *DeleteActivityResponse) IsSetBaseResponse() bool {
+	return p.BaseResponse != nil
+}
+
+func (p *DeleteActivityResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DeleteActivityResponse(%+v)", *p)
+}
+
+var fieldIDToName_DeleteActivityResponse = map[int16]string{
+	1: "baseResponse",
+}
+
+type AdjustStockRequest struct {
+	ActivityID int64 `thrift:"activityID,1" frugal:"1,default,i64" json:"activityID"`
+	Delta      int64 `thrift:"delta,2" frugal:"2,default,i64" json:"delta"`
+}
+
+func NewAdjustStockRequest() *AdjustStockRequest {
+	return &AdjustStockRequest{}
+}
+
+func (p *AdjustStockRequest) InitDefault() {
+}
+
+func (p *AdjustStockRequest) GetActivityID() (v int64) {
+	return p.ActivityID
+}
+
+func (p *AdjustStockRequest) GetDelta() (v int64) {
+	return p.Delta
+}
+func (p *AdjustStockRequest) SetActivityID(val int64) {
+	p.ActivityID = val
+}
+func (p *AdjustStockRequest) SetDelta(val int64) {
+	p.Delta = val
+}
+
+func (p *AdjustStockRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AdjustStockRequest(%+v)", *p)
+}
+
+var fieldIDToName_AdjustStockRequest = map[int16]string{
+	1: "activityID",
+	2: "delta",
+}
+
+type AdjustStockResponse struct {
+	BaseResponse *BaseResponse `thrift:"baseResponse,1" frugal:"1,default,BaseResponse" json:"baseResponse"`
+	Activity     *ActivityInfo `thrift:"activity,2" frugal:"2,default,ActivityInfo" json:"activity"`
+}
+
+func NewAdjustStockResponse() *AdjustStockResponse {
+	return &AdjustStockResponse{}
+}
+
+func (p *AdjustStockResponse) InitDefault() {
+}
+
+var AdjustStockResponse_BaseResponse_DEFAULT *BaseResponse
+
+func (p *AdjustStockResponse) GetBaseResponse() (v *BaseResponse) {
+	if !p.IsSetBaseResponse() {
+		return AdjustStockResponse_BaseResponse_DEFAULT
+	}
+	return p.BaseResponse
+}
+
+var AdjustStockResponse_Activity_DEFAULT *ActivityInfo
+
+func (p *AdjustStockResponse) GetActivity() (v *ActivityInfo) {
+	if !p.IsSetActivity() {
+		return AdjustStockResponse_Activity_DEFAULT
+	}
+	return p.Activity
+}
+func (p *AdjustStockResponse) SetBaseResponse(val *BaseResponse) {
+	p.BaseResponse = val
+}
+func (p *AdjustStockResponse) SetActivity(val *ActivityInfo) {
+	p.Activity = val
+}
+
+func (p *AdjustStockResponse) IsSetBaseResponse() bool {
+	return p.BaseResponse != nil
+}
+
+func (p *AdjustStockResponse) IsSetActivity() bool {
+	return p.Activity != nil
+}
+
+func (p *AdjustStockResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AdjustStockResponse(%+v)", *p)
+}
+
+var fieldIDToName_AdjustStockResponse = map[int16]string{
+	1: "baseResponse",
+	2: "activity",
+}
+
+type DeductStockRequest struct {
+	ActivityID int64  `thrift:"activityID,1" frugal:"1,default,i64" json:"activityID"`
+	UserID     int64  `thrift:"userID,2" frugal:"2,default,i64" json:"userID"`
+	Count      int64  `thrift:"count,3" frugal:"3,default,i64" json:"count"`
+	OrderSn    string `thrift:"orderSn,4" frugal:"4,default,string" json:"orderSn"`
+}
+
+func NewDeductStockRequest() *DeductStockRequest {
+	return &DeductStockRequest{
+
+		Count: 1,
+	}
+}
+
+func (p *DeductStockRequest) InitDefault() {
+	p.Count = 1
+}
+
+func (p *DeductStockRequest) GetActivityID() (v int64) {
+	return p.ActivityID
+}
+
+func (p *DeductStockRequest) GetUserID() (v int64) {
+	return p.UserID
+}
+
+func (p *DeductStockRequest) GetCount() (v int64) {
+	return p.Count
+}
+
+func (p *DeductStockRequest) GetOrderSn() (v string) {
+	return p.OrderSn
+}
+func (p *DeductStockRequest) SetActivityID(val int64) {
+	p.ActivityID = val
+}
+func (p *DeductStockRequest) SetUserID(val int64) {
+	p.UserID = val
+}
+func (p *DeductStockRequest) SetCount(val int64) {
+	p.Count = val
+}
+func (p *DeductStockRequest) SetOrderSn(val string) {
+	p.OrderSn = val
+}
+
+func (p *DeductStockRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DeductStockRequest(%+v)", *p)
+}
+
+var fieldIDToName_DeductStockRequest = map[int16]string{
+	1: "activityID",
+	2: "userID",
+	3: "count",
+	4: "orderSn",
+}
+
+type DeductStockResponse struct {
+	BaseResponse *BaseResponse `thrift:"baseResponse,1" frugal:"1,default,BaseResponse" json:"baseResponse"`
+	Success      bool          `thrift:"success,2" frugal:"2,default,bool" json:"success"`
+}
+
+func NewDeductStockResponse() *DeductStockResponse {
+	return &DeductStockResponse{}
+}
+
+func (p *DeductStockResponse) InitDefault() {
+}
+
+var DeductStockResponse_BaseResponse_DEFAULT *BaseResponse
+
+func (p *DeductStockResponse) GetBaseResponse() (v *BaseResponse) {
+	if !p.IsSetBaseResponse() {
+		return DeductStockResponse_BaseResponse_DEFAULT
+	}
+	return p.BaseResponse
+}
+
+func (p *DeductStockResponse) GetSuccess() (v bool) {
+	return p.Success
+}
+func (p *DeductStockResponse) SetBaseResponse(val *BaseResponse) {
+	p.BaseResponse = val
+}
+func (p *DeductStockResponse) SetSuccess(val bool) {
+	p.Success = val
+}
+
+func (p *DeductStockResponse) IsSetBaseResponse() bool {
+	return p.BaseResponse != nil
+}
+
+func (p *DeductStockResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DeductStockResponse(%+v)", *p)
+}
+
+var fieldIDToName_DeductStockResponse = map[int16]string{
+	1: "baseResponse",
+	2: "success",
+}
+
+type ReturnStockRequest struct {
+	ActivityID int64  `thrift:"activityID,1" frugal:"1,default,i64" json:"activityID"`
+	UserID     int64  `thrift:"userID,2" frugal:"2,default,i64" json:"userID"`
+	Count      int64  `thrift:"count,3" frugal:"3,default,i64" json:"count"`
+	OrderSn    string `thrift:"orderSn,4" frugal:"4,default,string" json:"orderSn"`
+}
+
+func NewReturnStockRequest() *ReturnStockRequest {
+	return &ReturnStockRequest{
+
+		Count: 1,
+	}
+}
+
+func (p *ReturnStockRequest) InitDefault() {
+	p.Count = 1
+}
+
+func (p *ReturnStockRequest) GetActivityID() (v int64) {
+	return p.ActivityID
+}
+
+func (p *ReturnStockRequest) GetUserID() (v int64) {
+	return p.UserID
+}
+
+func (p *ReturnStockRequest) GetCount() (v int64) {
+	return p.Count
+}
+
+func (p *ReturnStockRequest) GetOrderSn() (v string) {
+	return p.OrderSn
+}
+func (p *ReturnStockRequest) SetActivityID(val int64) {
+	p.ActivityID = val
+}
+func (p *ReturnStockRequest) SetUserID(val int64) {
+	p.UserID = val
+}
+func (p *ReturnStockRequest) SetCount(val int64) {
+	p.Count = val
+}
+func (p *ReturnStockRequest) SetOrderSn(val string) {
+	p.OrderSn = val
+}
+
+func (p *ReturnStockRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ReturnStockRequest(%+v)", *p)
+}
+
+var fieldIDToName_ReturnStockRequest = map[int16]string{
+	1: "activityID",
+	2: "userID",
+	3: "count",
+	4: "orderSn",
+}
+
+type ReturnStockResponse struct {
+	BaseResponse *BaseResponse `thrift:"baseResponse,1" frugal:"1,default,BaseResponse" json:"baseResponse"`
+	Success      bool          `thrift:"success,2" frugal:"2,default,bool" json:"success"`
+}
+
+func NewReturnStockResponse() *ReturnStockResponse {
+	return &ReturnStockResponse{}
+}
+
+func (p *ReturnStockResponse) InitDefault() {
+}
+
+var ReturnStockResponse_BaseResponse_DEFAULT *BaseResponse
+
+func (p *ReturnStockResponse) GetBaseResponse() (v *BaseResponse) {
+	if !p.IsSetBaseResponse() {
+		return ReturnStockResponse_BaseResponse_DEFAULT
+	}
+	return p.BaseResponse
+}
+
+func (p *ReturnStockResponse) GetSuccess() (v bool) {
+	return p.Success
+}
+func (p *ReturnStockResponse) SetBaseResponse(val *BaseResponse) {
+	p.BaseResponse = val
+}
+func (p *ReturnStockResponse) SetSuccess(val bool) {
+	p.Success = val
+}
+
+func (p *ReturnStockResponse) IsSetBaseResponse() bool {
+	return p.BaseResponse != nil
+}
+
+func (p *ReturnStockResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ReturnStockResponse(%+v)", *p)
+}
+
+var fieldIDToName_ReturnStockResponse = map[int16]string{
+	1: "baseResponse",
+	2: "success",
+}
+
+type CountProductActivitiesRequest struct {
+	ProductID int64 `thrift:"productID,1" frugal:"1,default,i64" json:"productID"`
+}
+
+func NewCountProductActivitiesRequest() *CountProductActivitiesRequest {
+	return &CountProductActivitiesRequest{}
+}
+
+func (p *CountProductActivitiesRequest) InitDefault() {
+}
+
+func (p *CountProductActivitiesRequest) GetProductID() (v int64) {
+	return p.ProductID
+}
+func (p *CountProductActivitiesRequest) SetProductID(val int64) {
+	p.ProductID = val
+}
+
+func (p *CountProductActivitiesRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CountProductActivitiesRequest(%+v)", *p)
+}
+
+var fieldIDToName_CountProductActivitiesRequest = map[int16]string{
+	1: "productID",
+}
+
+type CountProductActivitiesResponse struct {
+	BaseResponse *BaseResponse `thrift:"baseResponse,1" frugal:"1,default,BaseResponse" json:"baseResponse"`
+	Count        int64         `thrift:"count,2" frugal:"2,default,i64" json:"count"`
+}
+
+func NewCountProductActivitiesResponse() *CountProductActivitiesResponse {
+	return &CountProductActivitiesResponse{}
+}
+
+func (p *CountProductActivitiesResponse) InitDefault() {
+}
+
+var CountProductActivitiesResponse_BaseResponse_DEFAULT *BaseResponse
+
+func (p *CountProductActivitiesResponse) GetBaseResponse() (v *BaseResponse) {
+	if !p.IsSetBaseResponse() {
+		return CountProductActivitiesResponse_BaseResponse_DEFAULT
+	}
+	return p.BaseResponse
+}
+
+func (p *CountProductActivitiesResponse) GetCount() (v int64) {
+	return p.Count
+}
+func (p *CountProductActivitiesResponse) SetBaseResponse(val *BaseResponse) {
+	p.BaseResponse = val
+}
+func (p *CountProductActivitiesResponse) SetCount(val int64) {
+	p.Count = val
+}
+
+func (p *CountProductActivitiesResponse) IsSetBaseResponse() bool {
+	return p.BaseResponse != nil
+}
+
+func (p *CountProductActivitiesResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CountProductActivitiesResponse(%+v)", *p)
+}
+
+var fieldIDToName_CountProductActivitiesResponse = map[int16]string{
+	1: "baseResponse",
+	2: "count",
+}
+
+type WarmUpActivityRequest struct {
+	ActivityID int64 `thrift:"activityID,1" frugal:"1,default,i64" json:"activityID"`
+}
+
+func NewWarmUpActivityRequest() *WarmUpActivityRequest {
+	return &WarmUpActivityRequest{}
+}
+
+func (p *WarmUpActivityRequest) InitDefault() {
+}
+
+func (p *WarmUpActivityRequest) GetActivityID() (v int64) {
+	return p.ActivityID
+}
+func (p *WarmUpActivityRequest) SetActivityID(val int64) {
+	p.ActivityID = val
+}
+
+func (p *WarmUpActivityRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("WarmUpActivityRequest(%+v)", *p)
+}
+
+var fieldIDToName_WarmUpActivityRequest = map[int16]string{
+	1: "activityID",
+}
+
+type WarmUpActivityResponse struct {
+	BaseResponse *BaseResponse `thrift:"baseResponse,1" frugal:"1,default,BaseResponse" json:"baseResponse"`
+	Stock        int64         `thrift:"stock,2" frugal:"2,default,i64" json:"stock"`
+}
+
+func NewWarmUpActivityResponse() *WarmUpActivityResponse {
+	return &WarmUpActivityResponse{}
+}
+
+func (p *WarmUpActivityResponse) InitDefault() {
+}
+
+var WarmUpActivityResponse_BaseResponse_DEFAULT *BaseResponse
+
+func (p *WarmUpActivityResponse) GetBaseResponse() (v *BaseResponse) {
+	if !p.IsSetBaseResponse() {
+		return WarmUpActivityResponse_BaseResponse_DEFAULT
+	}
+	return p.BaseResponse
+}
+
+func (p *WarmUpActivityResponse) GetStock() (v int64) {
+	return p.Stock
+}
+func (p *WarmUpActivityResponse) SetBaseResponse(val *BaseResponse) {
+	p.BaseResponse = val
+}
+func (p *WarmUpActivityResponse) SetStock(val int64) {
+	p.Stock = val
+}
+
+func (p *WarmUpActivityResponse) IsSetBaseResponse() bool {
+	return p.BaseResponse != nil
+}
+
+func (p *WarmUpActivityResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("WarmUpActivityResponse(%+v)", *p)
+}
+
+var fieldIDToName_WarmUpActivityResponse = map[int16]string{
+	1: "baseResponse",
+	2: "stock",
+}
+
+type StockReport struct {
+	ActivityID int64 `thrift:"activityID,1" frugal:"1,default,i64" json:"activityID"`
+	RedisStock int64 `thrift:"redisStock,2" frugal:"2,default,i64" json:"redisStock"`
+	DbStock    int64 `thrift:"dbStock,3" frugal:"3,default,i64" json:"dbStock"`
+	OrderStock int64 `thrift:"orderStock,4" frugal:"4,default,i64" json:"orderStock"`
+	Drift      bool  `thrift:"drift,5" frugal:"5,default,bool" json:"drift"`
+	Corrected  bool  `thrift:"corrected,6" frugal:"6,default,bool" json:"corrected"`
+	CheckTime  int64 `thrift:"checkTime,7" frugal:"7,default,i64" json:"checkTime"`
+	Inflight   int64 `thrift:"inflight,8" frugal:"8,default,i64" json:"inflight"`
+}
+
+func NewStockReport() *StockReport {
+	return &StockReport{}
+}
+
+func (p *StockReport) InitDefault() {
+}
+
+func (p *StockReport) GetActivityID() (v int64) {
+	return p.ActivityID
+}
+
+func (p *StockReport) GetRedisStock() (v int64) {
+	return p.RedisStock
+}
+
+func (p *StockReport) GetDbStock() (v int64) {
+	return p.DbStock
+}
+
+func (p *StockReport) GetOrderStock() (v int64) {
+	return p.OrderStock
+}
+
+func (p *StockReport) GetDrift() (v bool) {
+	return p.Drift
+}
+
+func (p *StockReport) GetCorrected() (v bool) {
+	return p.Corrected
+}
+
+func (p *StockReport) GetCheckTime() (v int64) {
+	return p.CheckTime
+}
+
+func (p *StockReport) GetInflight() (v int64) {
+	return p.Inflight
+}
+func (p *StockReport) SetActivityID(val int64) {
+	p.ActivityID = val
+}
+func (p *StockReport) SetRedisStock(val int64) {
+	p.RedisStock = val
+}
+func (p *StockReport) SetDbStock(val int64) {
+	p.DbStock = val
+}
+func (p *StockReport) SetOrderStock(val int64) {
+	p.OrderStock = val
+}
+func (p *StockReport) SetDrift(val bool) {
+	p.Drift = val
+}
+func (p *StockReport) SetCorrected(val bool) {
+	p.Corrected = val
+}
+func (p *StockReport) SetCheckTime(val int64) {
+	p.CheckTime = val
+}
+func (p *StockReport) SetInflight(val int64) {
+	p.Inflight = val
+}
+
+func (p *StockReport) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("StockReport(%+v)", *p)
+}
+
+var fieldIDToName_StockReport = map[int16]string{
+	1: "activityID",
+	2: "redisStock",
+	3: "dbStock",
+	4: "orderStock",
+	5: "drift",
+	6: "corrected",
+	7: "checkTime",
+	8: "inflight",
+}
+
+type GetStockReportsRequest struct {
+	ActivityID int64 `thrift:"activityID,1" frugal:"1,default,i64" json:"activityID"`
+	OnlyDrift  bool  `thrift:"onlyDrift,2" frugal:"2,default,bool" json:"onlyDrift"`
+}
+
+func NewGetStockReportsRequest() *GetStockReportsRequest {
+	return &GetStockReportsRequest{}
+}
+
+func (p *GetStockReportsRequest) InitDefault() {
+}
+
+func (p *GetStockReportsRequest) GetActivityID() (v int64) {
+	return p.ActivityID
+}
+
+func (p *GetStockReportsRequest) GetOnlyDrift() (v bool) {
+	return p.OnlyDrift
+}
+func (p *GetStockReportsRequest) SetActivityID(val int64) {
+	p.ActivityID = val
+}
+func (p *GetStockReportsRequest) SetOnlyDrift(val bool) {
+	p.OnlyDrift = val
+}
+
+func (p *GetStockReportsRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GetStockReportsRequest(%+v)", *p)
+}
+
+var fieldIDToName_GetStockReportsRequest = map[int16]string{
+	1: "activityID",
+	2: "onlyDrift",
+}
+
+type GetStockReportsResponse struct {
+	BaseResponse *BaseResponse  `thrift:"baseResponse,1" frugal:"1,default,BaseResponse" json:"baseResponse"`
+	Reports      []*StockReport `thrift:"reports,2" frugal:"2,default,list<StockReport>" json:"reports"`
+}
+
+func NewGetStockReportsResponse() *GetStockReportsResponse {
+	return &GetStockReportsResponse{}
+}
+
+func (p *GetStockReportsResponse) InitDefault() {
+}
+
+var GetStockReportsResponse_BaseResponse_DEFAULT *BaseResponse
+
+func (p *GetStockReportsResponse) GetBaseResponse() (v *BaseResponse) {
+	if !p.IsSetBaseResponse() {
+		return GetStockReportsResponse_BaseResponse_DEFAULT
+	}
+	return p.BaseResponse
+}
+
+func (p *GetStockReportsResponse) GetReports() (v []*StockReport) {
+	return p.Reports
+}
+func (p *GetStockReportsResponse) SetBaseResponse(val *BaseResponse) {
+	p.BaseResponse = val
+}
+func (p *GetStockReportsResponse) SetReports(val []*StockReport) {
+	p.Reports = val
+}
+
+func (p *GetStockReportsResponse) IsSetBaseResponse() bool {
+	return p.BaseResponse != nil
+}
+
+func (p *GetStockReportsResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GetStockReportsResponse(%+v)", *p)
+}
+
+var fieldIDToName_GetStockReportsResponse = map[int16]string{
+	1: "baseResponse",
+	2: "reports",
+}
+
+type ReconcileStockRequest struct {
+	ActivityID int64 `thrift:"activityID,1" frugal:"1,default,i64" json:"activityID"`
+}
+
+func NewReconcileStockRequest() *ReconcileStockRequest {
+	return &ReconcileStockRequest{}
+}
+
+func (p *ReconcileStockRequest) InitDefault() {
+}
+
+func (p *ReconcileStockRequest) GetActivityID() (v int64) {
+	return p.ActivityID
+}
+func (p *ReconcileStockRequest) SetActivityID(val int64) {
+	p.ActivityID = val
+}
+
+func (p *ReconcileStockRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ReconcileStockRequest(%+v)", *p)
+}
+
+var fieldIDToName_ReconcileStockRequest = map[int16]string{
+	1: "activityID",
+}
+
+type ReconcileStockResponse struct {
+	BaseResponse *BaseResponse `thrift:"baseResponse,1" frugal:"1,default,BaseResponse" json:"baseResponse"`
+	Report       *StockReport  `thrift:"report,2" frugal:"2,default,StockReport" json:"report"`
+}
+
+func NewReconcileStockResponse() *ReconcileStockResponse {
+	return &ReconcileStockResponse{}
+}
+
+func (p *ReconcileStockResponse) InitDefault() {
+}
+
+var ReconcileStockResponse_BaseResponse_DEFAULT *BaseResponse
+
+func (p *ReconcileStockResponse) GetBaseResponse() (v *BaseResponse) {
+	if !p.IsSetBaseResponse() {
+		return ReconcileStockResponse_BaseResponse_DEFAULT
+	}
+	return p.BaseResponse
+}
+
+var ReconcileStockResponse_Report_DEFAULT *StockReport
+
+func (p *ReconcileStockResponse) GetReport() (v *StockReport) {
+	if !p.IsSetReport() {
+		return ReconcileStockResponse_Report_DEFAULT
+	}
+	return p.Report
+}
+func (p *ReconcileStockResponse) SetBaseResponse(val *BaseResponse) {
+	p.BaseResponse = val
+}
+func (p *ReconcileStockResponse) SetReport(val *StockReport) {
+	p.Report = val
+}
+
+func (p *ReconcileStockResponse) IsSetBaseResponse() bool {
+	return p.BaseResponse != nil
+}
+
+func (p *ReconcileStockResponse) IsSetReport() bool {
+	return p.Report != nil
+}
+
+func (p *ReconcileStockResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ReconcileStockResponse(%+v)", *p)
+}
+
+var fieldIDToName_ReconcileStockResponse = map[int16]string{
+	1: "baseResponse",
+	2: "report",
+}
+
+type ActivityService interface {
+	CreateActivity(ctx context.Context, req *CreateActivityRequest) (r *CreateActivityResponse, err error)
+
+	GetActivityList(ctx context.Context, req *GetActivityListRequest) (r *GetActivityListResponse, err error)
+
+	GetActivity(ctx context.Context, req *GetActivityRequest) (r *GetActivityResponse, err error)
+
+	UpdateActivity(ctx context.Context, req *UpdateActivityRequest) (r *UpdateActivityResponse, err error)
+
+	PauseActivity(ctx context.Context, req *PauseActivityRequest) (r *PauseActivityResponse, err error)
+
+	ResumeActivity(ctx context.Context, req *ResumeActivityRequest) (r *ResumeActivityResponse, err error)
+
+	DeleteActivity(ctx context.Context, req *DeleteActivityRequest) (r *DeleteActivityResponse, err error)
+
+	AdjustStock(ctx context.Context, req *AdjustStockRequest) (r *AdjustStockResponse, err error)
+}
+
+type ActivityServiceCreateActivityArgs struct {
+	Req *CreateActivityRequest `thrift:"req,1" frugal:"1,default,CreateActivityRequest" json:"req"`
+}
+
+func NewActivityServiceCreateActivityArgs() *ActivityServiceCreateActivityArgs {
+	return &ActivityServiceCreateActivityArgs{}
+}
+
+func (p *ActivityServiceCreateActivityArgs) InitDefault() {
+}
+
+var ActivityServiceCreateActivityArgs_Req_DEFAULT *CreateActivityRequest
+
+func (p *ActivityServiceCreateActivityArgs) GetReq() (v *CreateActivityRequest) {
+	if !p.IsSetReq() {
+		return ActivityServiceCreateActivityArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *ActivityServiceCreateActivityArgs) SetReq(val *CreateActivityRequest) {
+	p.Req = val
+}
+
+func (p *ActivityServiceCreateActivityArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *ActivityServiceCreateActivityArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ActivityServiceCreateActivityArgs(%+v)", *p)
+}
+
+var fieldIDToName_ActivityServiceCreateActivityArgs = map[int16]string{
+	1: "req",
+}
+
+type ActivityServiceCreateActivityResult struct {
+	Success *CreateActivityResponse `thrift:"success,0,optional" frugal:"0,optional,CreateActivityResponse" json:"success,omitempty"`
+}
+
+func NewActivityServiceCreateActivityResult() *ActivityServiceCreateActivityResult {
+	return &ActivityServiceCreateActivityResult{}
+}
+
+func (p *ActivityServiceCreateActivityResult) InitDefault() {
+}
+
+var ActivityServiceCreateActivityResult_Success_DEFAULT *CreateActivityResponse
+
+func (p *ActivityServiceCreateActivityResult) GetSuccess() (v *CreateActivityResponse) {
+	if !p.IsSetSuccess() {
+		return ActivityServiceCreateActivityResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *ActivityServiceCreateActivityResult) SetSuccess(x interface{}) {
+	p.Success = x.(*CreateActivityResponse)
+}
+
+func (p *ActivityServiceCreateActivityResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *ActivityServiceCreateActivityResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ActivityServiceCreateActivityResult(%+v)", *p)
+}
+
+var fieldIDToName_ActivityServiceCreateActivityResult = map[int16]string{
+	0: "success",
+}
+
+type ActivityServiceGetActivityListArgs struct {
+	Req *GetActivityListRequest `thrift:"req,1" frugal:"1,default,GetActivityListRequest" json:"req"`
+}
+
+func NewActivityServiceGetActivityListArgs() *ActivityServiceGetActivityListArgs {
+	return &ActivityServiceGetActivityListArgs{}
+}
+
+func (p *ActivityServiceGetActivityListArgs) InitDefault() {
+}
+
+var ActivityServiceGetActivityListArgs_Req_DEFAULT *GetActivityListRequest
+
+func (p *ActivityServiceGetActivityListArgs) GetReq() (v *GetActivityListRequest) {
+	if !p.IsSetReq() {
+		return ActivityServiceGetActivityListArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *ActivityServiceGetActivityListArgs) SetReq(val *GetActivityListRequest) {
+	p.Req = val
+}
+
+func (p *ActivityServiceGetActivityListArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *ActivityServiceGetActivityListArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ActivityServiceGetActivityListArgs(%+v)", *p)
+}
+
+var fieldIDToName_ActivityServiceGetActivityListArgs = map[int16]string{
+	1: "req",
+}
+
+type ActivityServiceGetActivityListResult struct {
+	Success *GetActivityListResponse `thrift:"success,0,optional" frugal:"0,optional,GetActivityListResponse" json:"success,omitempty"`
+}
+
+func NewActivityServiceGetActivityListResult() *ActivityServiceGetActivityListResult {
+	return &ActivityServiceGetActivityListResult{}
+}
+
+func (p *ActivityServiceGetActivityListResult) InitDefault() {
+}
+
+var ActivityServiceGetActivityListResult_Success_DEFAULT *GetActivityListResponse
+
+func (p *ActivityServiceGetActivityListResult) GetSuccess() (v *GetActivityListResponse) {
+	if !p.IsSetSuccess() {
+		return ActivityServiceGetActivityListResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *ActivityServiceGetActivityListResult) SetSuccess(x interface{}) {
+	p.Success = x.(*GetActivityListResponse)
+}
+
+func (p *ActivityServiceGetActivityListResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *ActivityServiceGetActivityListResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ActivityServiceGetActivityListResult(%+v)", *p)
+}
+
+var fieldIDToName_ActivityServiceGetActivityListResult = map[int16]string{
+	0: "success",
+}
+
+type ActivityServiceGetActivityArgs struct {
+	Req *GetActivityRequest `thrift:"req,1" frugal:"1,default,GetActivityRequest" json:"req"`
+}
+
+func NewActivityServiceGetActivityArgs() *ActivityServiceGetActivityArgs {
+	return &ActivityServiceGetActivityArgs{}
+}
+
+func (p *ActivityServiceGetActivityArgs) InitDefault() {
+}
+
+var ActivityServiceGetActivityArgs_Req_DEFAULT *GetActivityRequest
+
+func (p *ActivityServiceGetActivityArgs) GetReq() (v *GetActivityRequest) {
+	if !p.IsSetReq() {
+		return ActivityServiceGetActivityArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *ActivityServiceGetActivityArgs) SetReq(val *GetActivityRequest) {
+	p.Req = val
+}
+
+func (p *ActivityServiceGetActivityArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *ActivityServiceGetActivityArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ActivityServiceGetActivityArgs(%+v)", *p)
+}
+
+var fieldIDToName_ActivityServiceGetActivityArgs = map[int16]string{
+	1: "req",
+}
+
+type ActivityServiceGetActivityResult struct {
+	Success *GetActivityResponse `thrift:"success,0,optional" frugal:"0,optional,GetActivityResponse" json:"success,omitempty"`
+}
+
+func NewActivityServiceGetActivityResult() *ActivityServiceGetActivityResult {
+	return &ActivityServiceGetActivityResult{}
+}
+
+func (p *ActivityServiceGetActivityResult) InitDefault() {
+}
+
+var ActivityServiceGetActivityResult_Success_DEFAULT *GetActivityResponse
+
+func (p *ActivityServiceGetActivityResult) GetSuccess() (v *GetActivityResponse) {
+	if !p.IsSetSuccess() {
+		return ActivityServiceGetActivityResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *ActivityServiceGetActivityResult) SetSuccess(x interface{}) {
+	p.Success = x.(*GetActivityResponse)
+}
+
+func (p *ActivityServiceGetActivityResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *ActivityServiceGetActivityResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ActivityServiceGetActivityResult(%+v)", *p)
+}
+
+var fieldIDToName_ActivityServiceGetActivityResult = map[int16]string{
+	0: "success",
+}
+
+type ActivityServiceUpdateActivityArgs struct {
+	Req *UpdateActivityRequest `thrift:"req,1" frugal:"1,default,UpdateActivityRequest" json:"req"`
+}
+
+func NewActivityServiceUpdateActivityArgs() *ActivityServiceUpdateActivityArgs {
+	return &ActivityServiceUpdateActivityArgs{}
+}
+
+func (p *ActivityServiceUpdateActivityArgs) InitDefault() {
+}
+
+var ActivityServiceUpdateActivityArgs_Req_DEFAULT *UpdateActivityRequest
+
+func (p *ActivityServiceUpdateActivityArgs) GetReq() (v *UpdateActivityRequest) {
+	if !p.IsSetReq() {
+		return ActivityServiceUpdateActivityArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *ActivityServiceUpdateActivityArgs) SetReq(val *UpdateActivityRequest) {
+	p.Req = val
+}
+
+func (p *ActivityServiceUpdateActivityArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *ActivityServiceUpdateActivityArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ActivityServiceUpdateActivityArgs(%+v)", *p)
+}
+
+var fieldIDToName_ActivityServiceUpdateActivityArgs = map[int16]string{
+	1: "req",
+}
+
+type ActivityServiceUpdateActivityResult struct {
+	Success *UpdateActivityResponse `thrift:"success,0,optional" frugal:"0,optional,UpdateActivityResponse" json:"success,omitempty"`
+}
+
+func NewActivityServiceUpdateActivityResult() *ActivityServiceUpdateActivityResult {
+	return &ActivityServiceUpdateActivityResult{}
+}
+
+func (p *ActivityServiceUpdateActivityResult) InitDefault() {
+}
+
+var ActivityServiceUpdateActivityResult_Success_DEFAULT *UpdateActivityResponse
+
+func (p *ActivityServiceUpdateActivityResult) GetSuccess() (v *UpdateActivityResponse) {
+	if !p.IsSetSuccess() {
+		return ActivityServiceUpdateActivityResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *ActivityServiceUpdateActivityResult) SetSuccess(x interface{}) {
+	p.Success = x.(*UpdateActivityResponse)
+}
+
+func (p *ActivityServiceUpdateActivityResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *ActivityServiceUpdateActivityResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ActivityServiceUpdateActivityResult(%+v)", *p)
+}
+
+var fieldIDToName_ActivityServiceUpdateActivityResult = map[int16]string{
+	0: "success",
+}
+
+type ActivityServicePauseActivityArgs struct {
+	Req *PauseActivityRequest `thrift:"req,1" frugal:"1,default,PauseActivityRequest" json:"req"`
+}
+
+func NewActivityServicePauseActivityArgs() *ActivityServicePauseActivityArgs {
+	return &ActivityServicePauseActivityArgs{}
+}
+
+func (p *ActivityServicePauseActivityArgs) InitDefault() {
+}
+
+var ActivityServicePauseActivityArgs_Req_DEFAULT *PauseActivityRequest
+
+func (p *ActivityServicePauseActivityArgs) GetReq() (v *PauseActivityRequest) {
+	if !p.IsSetReq() {
+		return ActivityServicePauseActivityArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *ActivityServicePauseActivityArgs) SetReq(val *PauseActivityRequest) {
+	p.Req = val
+}
+
+func (p *ActivityServicePauseActivityArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *ActivityServicePauseActivityArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ActivityServicePauseActivityArgs(%+v)", *p)
+}
+
+var fieldIDToName_ActivityServicePauseActivityArgs = map[int16]string{
+	1: "req",
+}
+
+type ActivityServicePauseActivityResult struct {
+	Success *PauseActivityResponse `thrift:"success,0,optional" frugal:"0,optional,PauseActivityResponse" json:"success,omitempty"`
+}
+
+func NewActivityServicePauseActivityResult() *ActivityServicePauseActivityResult {
+	return &ActivityServicePauseActivityResult{}
+}
+
+func (p *ActivityServicePauseActivityResult) InitDefault() {
+}
+
+var ActivityServicePauseActivityResult_Success_DEFAULT *PauseActivityResponse
+
+func (p *ActivityServicePauseActivityResult) GetSuccess() (v *PauseActivityResponse) {
+	if !p.IsSetSuccess() {
+		return ActivityServicePauseActivityResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *ActivityServicePauseActivityResult) SetSuccess(x interface{}) {
+	p.Success = x.(*PauseActivityResponse)
+}
+
+func (p *ActivityServicePauseActivityResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *ActivityServicePauseActivityResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ActivityServicePauseActivityResult(%+v)", *p)
+}
+
+var fieldIDToName_ActivityServicePauseActivityResult = map[int16]string{
+	0: "success",
+}
+
+type ActivityServiceResumeActivityArgs struct {
+	Req *ResumeActivityRequest `thrift:"req,1" frugal:"1,default,ResumeActivityRequest" json:"req"`
+}
+
+func NewActivityServiceResumeActivityArgs() *ActivityServiceResumeActivityArgs {
+	return &ActivityServiceResumeActivityArgs{}
+}
+
+func (p *ActivityServiceResumeActivityArgs) InitDefault() {
+}
+
+var ActivityServiceResumeActivityArgs_Req_DEFAULT *ResumeActivityRequest
+
+func (p *ActivityServiceResumeActivityArgs) GetReq() (v *ResumeActivityRequest) {
+	if !p.IsSetReq() {
+		return ActivityServiceResumeActivityArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *ActivityServiceResumeActivityArgs) SetReq(val *ResumeActivityRequest) {
+	p.Req = val
+}
+
+func (p *ActivityServiceResumeActivityArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *ActivityServiceResumeActivityArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ActivityServiceResumeActivityArgs(%+v)", *p)
+}
+
+var fieldIDToName_ActivityServiceResumeActivityArgs = map[int16]string{
+	1: "req",
+}
+
+type ActivityServiceResumeActivityResult struct {
+	Success *ResumeActivityResponse `thrift:"success,0,optional" frugal:"0,optional,ResumeActivityResponse" json:"success,omitempty"`
+}
+
+func NewActivityServiceResumeActivityResult() *ActivityServiceResumeActivityResult {
+	return &ActivityServiceResumeActivityResult{}
+}
+
+func (p *ActivityServiceResumeActivityResult) InitDefault() {
+}
+
+var ActivityServiceResumeActivityResult_Success_DEFAULT *ResumeActivityResponse
+
+func (p *ActivityServiceResumeActivityResult) GetSuccess() (v *ResumeActivityResponse) {
+	if !p.IsSetSuccess() {
+		return ActivityServiceResumeActivityResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *ActivityServiceResumeActivityResult) SetSuccess(x interface{}) {
+	p.Success = x.(*ResumeActivityResponse)
+}
+
+func (p *ActivityServiceResumeActivityResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *ActivityServiceResumeActivityResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ActivityServiceResumeActivityResult(%+v)", *p)
+}
+
+var fieldIDToName_ActivityServiceResumeActivityResult = map[int16]string{
+	0: "success",
+}
+
+type ActivityServiceDeleteActivityArgs struct {
+	Req *DeleteActivityRequest `thrift:"req,1" frugal:"1,default,DeleteActivityRequest" json:"req"`
+}
+
+func NewActivityServiceDeleteActivityArgs() *ActivityServiceDeleteActivityArgs {
+	return &ActivityServiceDeleteActivityArgs{}
+}
+
+func (p *ActivityServiceDeleteActivityArgs) InitDefault() {
+}
+
+var ActivityServiceDeleteActivityArgs_Req_DEFAULT *DeleteActivityRequest
+
+func (p *ActivityServiceDeleteActivityArgs) GetReq() (v *DeleteActivityRequest) {
+	if !p.IsSetReq() {
+		return ActivityServiceDeleteActivityArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *ActivityServiceDeleteActivityArgs) SetReq(val *DeleteActivityRequest) {
+	p.Req = val
+}
+
+func (p *ActivityServiceDeleteActivityArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *ActivityServiceDeleteActivityArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ActivityServiceDeleteActivityArgs(%+v)", *p)
+}
+
+var fieldIDToName_ActivityServiceDeleteActivityArgs = map[int16]string{
+	1: "req",
+}
+
+type ActivityServiceDeleteActivityResult struct {
+	Success *DeleteActivityResponse `thrift:"success,0,optional" frugal:"0,optional,DeleteActivityResponse" json:"success,omitempty"`
+}
+
+func NewActivityServiceDeleteActivityResult() *ActivityServiceDeleteActivityResult {
+	return &ActivityServiceDeleteActivityResult{}
+}
+
+func (p *ActivityServiceDeleteActivityResult) InitDefault() {
+}
+
+var ActivityServiceDeleteActivityResult_Success_DEFAULT *DeleteActivityResponse
+
+func (p *ActivityServiceDeleteActivityResult) GetSuccess() (v *DeleteActivityResponse) {
+	if !p.IsSetSuccess() {
+		return ActivityServiceDeleteActivityResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *ActivityServiceDeleteActivityResult) SetSuccess(x interface{}) {
+	p.Success = x.(*DeleteActivityResponse)
+}
+
+func (p *ActivityServiceDeleteActivityResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *ActivityServiceDeleteActivityResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ActivityServiceDeleteActivityResult(%+v)", *p)
+}
+
+var fieldIDToName_ActivityServiceDeleteActivityResult = map[int16]string{
+	0: "success",
+}
+
+type ActivityServiceAdjustStockArgs struct {
+	Req *AdjustStockRequest `thrift:"req,1" frugal:"1,default,AdjustStockRequest" json:"req"`
+}
+
+func NewActivityServiceAdjustStockArgs() *ActivityServiceAdjustStockArgs {
+	return &ActivityServiceAdjustStockArgs{}
+}
+
+func (p *ActivityServiceAdjustStockArgs) InitDefault() {
+}
+
+var ActivityServiceAdjustStockArgs_Req_DEFAULT *AdjustStockRequest
+
+func (p *ActivityServiceAdjustStockArgs) GetReq() (v *AdjustStockRequest) {
+	if !p.IsSetReq() {
+		return ActivityServiceAdjustStockArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *ActivityServiceAdjustStockArgs) SetReq(val *AdjustStockRequest) {
+	p.Req = val
+}
+
+func (p *ActivityServiceAdjustStockArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *ActivityServiceAdjustStockArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ActivityServiceAdjustStockArgs(%+v)", *p)
+}
+
+var fieldIDToName_ActivityServiceAdjustStockArgs = map[int16]string{
+	1: "req",
+}
+
+type ActivityServiceAdjustStockResult struct {
+	Success *AdjustStockResponse `thrift:"success,0,optional" frugal:"0,optional,AdjustStockResponse" json:"success,omitempty"`
+}
+
+func NewActivityServiceAdjustStockResult() *ActivityServiceAdjustStockResult {
+	return &ActivityServiceAdjustStockResult{}
+}
+
+func (p *ActivityServiceAdjustStockResult) InitDefault() {
+}
+
+var ActivityServiceAdjustStockResult_Success_DEFAULT *AdjustStockResponse
+
+func (p *ActivityServiceAdjustStockResult) GetSuccess() (v *AdjustStockResponse) {
+	if !p.IsSetSuccess() {
+		return ActivityServiceAdjustStockResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *ActivityServiceAdjustStockResult) SetSuccess(x interface{}) {
+	p.Success = x.(*AdjustStockResponse)
+}
+
+func (p *ActivityServiceAdjustStockResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *ActivityServiceAdjustStockResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ActivityServiceAdjustStockResult(%+v)", *p)
+}
+
+var fieldIDToName_ActivityServiceAdjustStockResult = map[int16]string{
+	0: "success",
+}
+
+type InternalActivityService interface {
+	DeductStock(ctx context.Context, req *DeductStockRequest) (r *DeductStockResponse, err error)
+
+	ReturnStock(ctx context.Context, req *ReturnStockRequest) (r *ReturnStockResponse, err error)
+
+	CountProductActivities(ctx context.Context, req *CountProductActivitiesRequest) (r *CountProductActivitiesResponse, err error)
+}
+
+type InternalActivityServiceDeductStockArgs struct {
+	Req *DeductStockRequest `thrift:"req,1" frugal:"1,default,DeductStockRequest" json:"req"`
+}
+
+func NewInternalActivityServiceDeductStockArgs() *InternalActivityServiceDeductStockArgs {
+	return &InternalActivityServiceDeductStockArgs{}
+}
+
+func (p *InternalActivityServiceDeductStockArgs) InitDefault() {
+}
+
+var InternalActivityServiceDeductStockArgs_Req_DEFAULT *DeductStockRequest
+
+func (p *InternalActivityServiceDeductStockArgs) GetReq() (v *DeductStockRequest) {
+	if !p.IsSetReq() {
+		return InternalActivityServiceDeductStockArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *InternalActivityServiceDeductStockArgs) SetReq(val *DeductStockRequest) {
+	p.Req = val
+}
 
-func NewActivityServiceGetActivityListResult() *ActivityServiceGetActivityListResult {
-	return &ActivityServiceGetActivityListResult{}
+func (p *InternalActivityServiceDeductStockArgs) IsSetReq() bool {
+	return p.Req != nil
 }
 
-func (p *ActivityServiceGetActivityListResult) InitDefault() {
+func (p *InternalActivityServiceDeductStockArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("InternalActivityServiceDeductStockArgs(%+v)", *p)
 }
 
-var ActivityServiceGetActivityListResult_Success_DEFAULT *GetActivityListResponse
+var fieldIDToName_InternalActivityServiceDeductStockArgs = map[int16]string{
+	1: "req",
+}
 
-func (p *ActivityServiceGetActivityListResult) GetSuccess() (v *GetActivityListResponse) {
+type InternalActivityServiceDeductStockResult struct {
+	Success *DeductStockResponse `thrift:"success,0,optional" frugal:"0,optional,DeductStockResponse" json:"success,omitempty"`
+}
+
+func NewInternalActivityServiceDeductStockResult() *InternalActivityServiceDeductStockResult {
+	return &InternalActivityServiceDeductStockResult{}
+}
+
+func (p *InternalActivityServiceDeductStockResult) InitDefault() {
+}
+
+var InternalActivityServiceDeductStockResult_Success_DEFAULT *DeductStockResponse
+
+func (p *InternalActivityServiceDeductStockResult) GetSuccess() (v *DeductStockResponse) {
 	if !p.IsSetSuccess() {
-		return ActivityServiceGetActivityListResult_Success_DEFAULT
+		return InternalActivityServiceDeductStockResult_Success_DEFAULT
 	}
 	return p.Success
 }
-func (p *ActivityServiceGetActivityListResult) SetSuccess(x interface{}) {
-	p.Success = x.(*GetActivityListResponse)
+func (p *InternalActivityServiceDeductStockResult) SetSuccess(x interface{}) {
+	p.Success = x.(*DeductStockResponse)
 }
 
-func (p *ActivityServiceGetActivityListResult) IsSetSuccess() bool {
+func (p *InternalActivityServiceDeductStockResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *ActivityServiceGetActivityListResult) String() string {
+func (p *InternalActivityServiceDeductStockResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("ActivityServiceGetActivityListResult(%+v)", *p)
+	return fmt.Sprintf("InternalActivityServiceDeductStockResult(%+v)", *p)
 }
 
-var fieldIDToName_ActivityServiceGetActivityListResult = map[int16]string{
+var fieldIDToName_InternalActivityServiceDeductStockResult = map[int16]string{
 	0: "success",
 }
 
-type ActivityServiceGetActivityArgs struct {
-	Req *GetActivityRequest `thrift:"req,1" frugal:"1,default,GetActivityRequest" json:"req"`
+type InternalActivityServiceReturnStockArgs struct {
+	Req *ReturnStockRequest `thrift:"req,1" frugal:"1,default,ReturnStockRequest" json:"req"`
 }
 
-func NewActivityServiceGetActivityArgs() *ActivityServiceGetActivityArgs {
-	return &ActivityServiceGetActivityArgs{}
+func NewInternalActivityServiceReturnStockArgs() *InternalActivityServiceReturnStockArgs {
+	return &InternalActivityServiceReturnStockArgs{}
 }
 
-func (p *ActivityServiceGetActivityArgs) InitDefault() {
+func (p *InternalActivityServiceReturnStockArgs) InitDefault() {
 }
 
-var ActivityServiceGetActivityArgs_Req_DEFAULT *GetActivityRequest
+var InternalActivityServiceReturnStockArgs_Req_DEFAULT *ReturnStockRequest
 
-func (p *ActivityServiceGetActivityArgs) GetReq() (v *GetActivityRequest) {
+func (p *InternalActivityServiceReturnStockArgs) GetReq() (v *ReturnStockRequest) {
 	if !p.IsSetReq() {
-		return ActivityServiceGetActivityArgs_Req_DEFAULT
+		return InternalActivityServiceReturnStockArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *ActivityServiceGetActivityArgs) SetReq(val *GetActivityRequest) {
+func (p *InternalActivityServiceReturnStockArgs) SetReq(val *ReturnStockRequest) {
 	p.Req = val
 }
 
-func (p *ActivityServiceGetActivityArgs) IsSetReq() bool {
+func (p *InternalActivityServiceReturnStockArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *ActivityServiceGetActivityArgs) String() string {
+func (p *InternalActivityServiceReturnStockArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("ActivityServiceGetActivityArgs(%+v)", *p)
+	return fmt.Sprintf("InternalActivityServiceReturnStockArgs(%+v)", *p)
 }
 
-var fieldIDToName_ActivityServiceGetActivityArgs = map[int16]string{
+var fieldIDToName_InternalActivityServiceReturnStockArgs = map[int16]string{
 	1: "req",
 }
 
-type ActivityServiceGetActivityResult struct {
-	Success *GetActivityResponse `thrift:"success,0,optional" frugal:"0,optional,GetActivityResponse" json:"success,omitempty"`
+type InternalActivityServiceReturnStockResult struct {
+	Success *ReturnStockResponse `thrift:"success,0,optional" frugal:"0,optional,ReturnStockResponse" json:"success,omitempty"`
 }
 
-func NewActivityServiceGetActivityResult() *ActivityServiceGetActivityResult {
-	return &ActivityServiceGetActivityResult{}
+func NewInternalActivityServiceReturnStockResult() *InternalActivityServiceReturnStockResult {
+	return &InternalActivityServiceReturnStockResult{}
 }
 
-func (p *ActivityServiceGetActivityResult) InitDefault() {
+func (p *InternalActivityServiceReturnStockResult) InitDefault() {
 }
 
-var ActivityServiceGetActivityResult_Success_DEFAULT *GetActivityResponse
+var InternalActivityServiceReturnStockResult_Success_DEFAULT *ReturnStockResponse
 
-func (p *ActivityServiceGetActivityResult) GetSuccess() (v *GetActivityResponse) {
+func (p *InternalActivityServiceReturnStockResult) GetSuccess() (v *ReturnStockResponse) {
 	if !p.IsSetSuccess() {
-		return ActivityServiceGetActivityResult_Success_DEFAULT
+		return InternalActivityServiceReturnStockResult_Success_DEFAULT
 	}
 	return p.Success
 }
-func (p *ActivityServiceGetActivityResult) SetSuccess(x interface{}) {
-	p.Success = x.(*GetActivityResponse)
+func (p *InternalActivityServiceReturnStockResult) SetSuccess(x interface{}) {
+	p.Success = x.(*ReturnStockResponse)
 }
 
-func (p *ActivityServiceGetActivityResult) IsSetSuccess() bool {
+func (p *InternalActivityServiceReturnStockResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *ActivityServiceGetActivityResult) String() string {
+func (p *InternalActivityServiceReturnStockResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("ActivityServiceGetActivityResult(%+v)", *p)
+	return fmt.Sprintf("InternalActivityServiceReturnStockResult(%+v)", *p)
 }
 
-var fieldIDToName_ActivityServiceGetActivityResult = map[int16]string{
+var fieldIDToName_InternalActivityServiceReturnStockResult = map[int16]string{
 	0: "success",
 }
 
-type InternalActivityService interface {
-	DeductStock(ctx context.Context, req *DeductStockRequest) (r *DeductStockResponse, err error)
+type InternalActivityServiceCountProductActivitiesArgs struct {
+	Req *CountProductActivitiesRequest `thrift:"req,1" frugal:"1,default,CountProductActivitiesRequest" json:"req"`
 }
 
-type InternalActivityServiceDeductStockArgs struct {
-	Req *DeductStockRequest `thrift:"req,1" frugal:"1,default,DeductStockRequest" json:"req"`
+func NewInternalActivityServiceCountProductActivitiesArgs() *InternalActivityServiceCountProductActivitiesArgs {
+	return &InternalActivityServiceCountProductActivitiesArgs{}
 }
 
-func NewInternalActivityServiceDeductStockArgs() *InternalActivityServiceDeductStockArgs {
-	return &InternalActivityServiceDeductStockArgs{}
+func (p *InternalActivityServiceCountProductActivitiesArgs) InitDefault() {
 }
 
-func (p *InternalActivityServiceDeductStockArgs) InitDefault() {
+var InternalActivityServiceCountProductActivitiesArgs_Req_DEFAULT *CountProductActivitiesRequest
+
+func (p *InternalActivityServiceCountProductActivitiesArgs) GetReq() (v *CountProductActivitiesRequest) {
+	if !p.IsSetReq() {
+		return InternalActivityServiceCountProductActivitiesArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *InternalActivityServiceCountProductActivitiesArgs) SetReq(val *CountProductActivitiesRequest) {
+	p.Req = val
 }
 
-var InternalActivityServiceDeductStockArgs_Req_DEFAULT *DeductStockRequest
+func (p *InternalActivityServiceCountProductActivitiesArgs) IsSetReq() bool {
+	return p.Req != nil
+}
 
-func (p *InternalActivityServiceDeductStockArgs) GetReq() (v *DeductStockRequest) {
+func (p *InternalActivityServiceCountProductActivitiesArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("InternalActivityServiceCountProductActivitiesArgs(%+v)", *p)
+}
+
+var fieldIDToName_InternalActivityServiceCountProductActivitiesArgs = map[int16]string{
+	1: "req",
+}
+
+type InternalActivityServiceCountProductActivitiesResult struct {
+	Success *CountProductActivitiesResponse `thrift:"success,0,optional" frugal:"0,optional,CountProductActivitiesResponse" json:"success,omitempty"`
+}
+
+func NewInternalActivityServiceCountProductActivitiesResult() *InternalActivityServiceCountProductActivitiesResult {
+	return &InternalActivityServiceCountProductActivitiesResult{}
+}
+
+func (p *InternalActivityServiceCountProductActivitiesResult) InitDefault() {
+}
+
+var InternalActivityServiceCountProductActivitiesResult_Success_DEFAULT *CountProductActivitiesResponse
+
+func (p *InternalActivityServiceCountProductActivitiesResult) GetSuccess() (v *CountProductActivitiesResponse) {
+	if !p.IsSetSuccess() {
+		return InternalActivityServiceCountProductActivitiesResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *InternalActivityServiceCountProductActivitiesResult) SetSuccess(x interface{}) {
+	p.Success = x.(*CountProductActivitiesResponse)
+}
+
+func (p *InternalActivityServiceCountProductActivitiesResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *InternalActivityServiceCountProductActivitiesResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("InternalActivityServiceCountProductActivitiesResult(%+v)", *p)
+}
+
+var fieldIDToName_InternalActivityServiceCountProductActivitiesResult = map[int16]string{
+	0: "success",
+}
+
+type AdminActivityService interface {
+	WarmUpActivity(ctx context.Context, req *WarmUpActivityRequest) (r *WarmUpActivityResponse, err error)
+
+	GetStockReports(ctx context.Context, req *GetStockReportsRequest) (r *GetStockReportsResponse, err error)
+
+	ReconcileStock(ctx context.Context, req *ReconcileStockRequest) (r *ReconcileStockResponse, err error)
+}
+
+type AdminActivityServiceWarmUpActivityArgs struct {
+	Req *WarmUpActivityRequest `thrift:"req,1" frugal:"1,default,WarmUpActivityRequest" json:"req"`
+}
+
+func NewAdminActivityServiceWarmUpActivityArgs() *AdminActivityServiceWarmUpActivityArgs {
+	return &AdminActivityServiceWarmUpActivityArgs{}
+}
+
+func (p *AdminActivityServiceWarmUpActivityArgs) InitDefault() {
+}
+
+var AdminActivityServiceWarmUpActivityArgs_Req_DEFAULT *WarmUpActivityRequest
+
+func (p *AdminActivityServiceWarmUpActivityArgs) GetReq() (v *WarmUpActivityRequest) {
 	if !p.IsSetReq() {
-		return InternalActivityServiceDeductStockArgs_Req_DEFAULT
+		return AdminActivityServiceWarmUpActivityArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *InternalActivityServiceDeductStockArgs) SetReq(val *DeductStockRequest) {
+func (p *AdminActivityServiceWarmUpActivityArgs) SetReq(val *WarmUpActivityRequest) {
 	p.Req = val
 }
 
-func (p *InternalActivityServiceDeductStockArgs) IsSetReq() bool {
+func (p *AdminActivityServiceWarmUpActivityArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *InternalActivityServiceDeductStockArgs) String() string {
+func (p *AdminActivityServiceWarmUpActivityArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("InternalActivityServiceDeductStockArgs(%+v)", *p)
+	return fmt.Sprintf("AdminActivityServiceWarmUpActivityArgs(%+v)", *p)
 }
 
-var fieldIDToName_InternalActivityServiceDeductStockArgs = map[int16]string{
+var fieldIDToName_AdminActivityServiceWarmUpActivityArgs = map[int16]string{
 	1: "req",
 }
 
-type InternalActivityServiceDeductStockResult struct {
-	Success *DeductStockResponse `thrift:"success,0,optional" frugal:"0,optional,DeductStockResponse" json:"success,omitempty"`
+type AdminActivityServiceWarmUpActivityResult struct {
+	Success *WarmUpActivityResponse `thrift:"success,0,optional" frugal:"0,optional,WarmUpActivityResponse" json:"success,omitempty"`
 }
 
-func NewInternalActivityServiceDeductStockResult() *InternalActivityServiceDeductStockResult {
-	return &InternalActivityServiceDeductStockResult{}
+func NewAdminActivityServiceWarmUpActivityResult() *AdminActivityServiceWarmUpActivityResult {
+	return &AdminActivityServiceWarmUpActivityResult{}
 }
 
-func (p *InternalActivityServiceDeductStockResult) InitDefault() {
+func (p *AdminActivityServiceWarmUpActivityResult) InitDefault() {
 }
 
-var InternalActivityServiceDeductStockResult_Success_DEFAULT *DeductStockResponse
+var AdminActivityServiceWarmUpActivityResult_Success_DEFAULT *WarmUpActivityResponse
 
-func (p *InternalActivityServiceDeductStockResult) GetSuccess() (v *DeductStockResponse) {
+func (p *AdminActivityServiceWarmUpActivityResult) GetSuccess() (v *WarmUpActivityResponse) {
 	if !p.IsSetSuccess() {
-		return InternalActivityServiceDeductStockResult_Success_DEFAULT
+		return AdminActivityServiceWarmUpActivityResult_Success_DEFAULT
 	}
 	return p.Success
 }
-func (p *InternalActivityServiceDeductStockResult) SetSuccess(x interface{}) {
-	p.Success = x.(*DeductStockResponse)
+func (p *AdminActivityServiceWarmUpActivityResult) SetSuccess(x interface{}) {
+	p.Success = x.(*WarmUpActivityResponse)
 }
 
-func (p *InternalActivityServiceDeductStockResult) IsSetSuccess() bool {
+func (p *AdminActivityServiceWarmUpActivityResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *InternalActivityServiceDeductStockResult) String() string {
+func (p *AdminActivityServiceWarmUpActivityResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("InternalActivityServiceDeductStockResult(%+v)", *p)
+	return fmt.Sprintf("AdminActivityServiceWarmUpActivityResult(%+v)", *p)
 }
 
-var fieldIDToName_InternalActivityServiceDeductStockResult = map[int16]string{
+var fieldIDToName_AdminActivityServiceWarmUpActivityResult = map[int16]string{
+	0: "success",
+}
+
+type AdminActivityServiceGetStockReportsArgs struct {
+	Req *GetStockReportsRequest `thrift:"req,1" frugal:"1,default,GetStockReportsRequest" json:"req"`
+}
+
+func NewAdminActivityServiceGetStockReportsArgs() *AdminActivityServiceGetStockReportsArgs {
+	return &AdminActivityServiceGetStockReportsArgs{}
+}
+
+func (p *AdminActivityServiceGetStockReportsArgs) InitDefault() {
+}
+
+var AdminActivityServiceGetStockReportsArgs_Req_DEFAULT *GetStockReportsRequest
+
+func (p *AdminActivityServiceGetStockReportsArgs) GetReq() (v *GetStockReportsRequest) {
+	if !p.IsSetReq() {
+		return AdminActivityServiceGetStockReportsArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *AdminActivityServiceGetStockReportsArgs) SetReq(val *GetStockReportsRequest) {
+	p.Req = val
+}
+
+func (p *AdminActivityServiceGetStockReportsArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *AdminActivityServiceGetStockReportsArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AdminActivityServiceGetStockReportsArgs(%+v)", *p)
+}
+
+var fieldIDToName_AdminActivityServiceGetStockReportsArgs = map[int16]string{
+	1: "req",
+}
+
+type AdminActivityServiceGetStockReportsResult struct {
+	Success *GetStockReportsResponse `thrift:"success,0,optional" frugal:"0,optional,GetStockReportsResponse" json:"success,omitempty"`
+}
+
+func NewAdminActivityServiceGetStockReportsResult() *AdminActivityServiceGetStockReportsResult {
+	return &AdminActivityServiceGetStockReportsResult{}
+}
+
+func (p *AdminActivityServiceGetStockReportsResult) InitDefault() {
+}
+
+var AdminActivityServiceGetStockReportsResult_Success_DEFAULT *GetStockReportsResponse
+
+func (p *AdminActivityServiceGetStockReportsResult) GetSuccess() (v *GetStockReportsResponse) {
+	if !p.IsSetSuccess() {
+		return AdminActivityServiceGetStockReportsResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *AdminActivityServiceGetStockReportsResult) SetSuccess(x interface{}) {
+	p.Success = x.(*GetStockReportsResponse)
+}
+
+func (p *AdminActivityServiceGetStockReportsResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *AdminActivityServiceGetStockReportsResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AdminActivityServiceGetStockReportsResult(%+v)", *p)
+}
+
+var fieldIDToName_AdminActivityServiceGetStockReportsResult = map[int16]string{
+	0: "success",
+}
+
+type AdminActivityServiceReconcileStockArgs struct {
+	Req *ReconcileStockRequest `thrift:"req,1" frugal:"1,default,ReconcileStockRequest" json:"req"`
+}
+
+func NewAdminActivityServiceReconcileStockArgs() *AdminActivityServiceReconcileStockArgs {
+	return &AdminActivityServiceReconcileStockArgs{}
+}
+
+func (p *AdminActivityServiceReconcileStockArgs) InitDefault() {
+}
+
+var AdminActivityServiceReconcileStockArgs_Req_DEFAULT *ReconcileStockRequest
+
+func (p *AdminActivityServiceReconcileStockArgs) GetReq() (v *ReconcileStockRequest) {
+	if !p.IsSetReq() {
+		return AdminActivityServiceReconcileStockArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *AdminActivityServiceReconcileStockArgs) SetReq(val *ReconcileStockRequest) {
+	p.Req = val
+}
+
+func (p *AdminActivityServiceReconcileStockArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *AdminActivityServiceReconcileStockArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AdminActivityServiceReconcileStockArgs(%+v)", *p)
+}
+
+var fieldIDToName_AdminActivityServiceReconcileStockArgs = map[int16]string{
+	1: "req",
+}
+
+type AdminActivityServiceReconcileStockResult struct {
+	Success *ReconcileStockResponse `thrift:"success,0,optional" frugal:"0,optional,ReconcileStockResponse" json:"success,omitempty"`
+}
+
+func NewAdminActivityServiceReconcileStockResult() *AdminActivityServiceReconcileStockResult {
+	return &AdminActivityServiceReconcileStockResult{}
+}
+
+func (p *AdminActivityServiceReconcileStockResult) InitDefault() {
+}
+
+var AdminActivityServiceReconcileStockResult_Success_DEFAULT *ReconcileStockResponse
+
+func (p *AdminActivityServiceReconcileStockResult) GetSuccess() (v *ReconcileStockResponse) {
+	if !p.IsSetSuccess() {
+		return AdminActivityServiceReconcileStockResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *AdminActivityServiceReconcileStockResult) SetSuccess(x interface{}) {
+	p.Success = x.(*ReconcileStockResponse)
+}
+
+func (p *AdminActivityServiceReconcileStockResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *AdminActivityServiceReconcileStockResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AdminActivityServiceReconcileStockResult(%+v)", *p)
+}
+
+var fieldIDToName_AdminActivityServiceReconcileStockResult = map[int16]string{
 	0: "success",
 }
@@ -34,6 +34,41 @@ var serviceMethods = map[string]kitex.MethodInfo{
 		false,
 		kitex.WithStreamingMode(kitex.StreamingNone),
 	),
+	"UpdateActivity": kitex.NewMethodInfo(
+		updateActivityHandler,
+		newActivityServiceUpdateActivityArgs,
+		newActivityServiceUpdateActivityResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"PauseActivity": kitex.NewMethodInfo(
+		pauseActivityHandler,
+		newActivityServicePauseActivityArgs,
+		newActivityServicePauseActivityResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"ResumeActivity": kitex.NewMethodInfo(
+		resumeActivityHandler,
+		newActivityServiceResumeActivityArgs,
+		newActivityServiceResumeActivityResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"DeleteActivity": kitex.NewMethodInfo(
+		deleteActivityHandler,
+		newActivityServiceDeleteActivityArgs,
+		newActivityServiceDeleteActivityResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"AdjustStock": kitex.NewMethodInfo(
+		adjustStockHandler,
+		newActivityServiceAdjustStockArgs,
+		newActivityServiceAdjustStockResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
 }
 
 var (
@@ -154,6 +189,96 @@ func newActivityServiceGetActivityResult() interface{} {
 	return activity.NewActivityServiceGetActivityResult()
 }
 
+func updateActivityHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*activity.ActivityServiceUpdateActivityArgs)
+	realResult := result.(*activity.ActivityServiceUpdateActivityResult)
+	success, err := handler.(activity.ActivityService).UpdateActivity(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newActivityServiceUpdateActivityArgs() interface{} {
+	return activity.NewActivityServiceUpdateActivityArgs()
+}
+
+func newActivityServiceUpdateActivityResult() interface{} {
+	return activity.NewActivityServiceUpdateActivityResult()
+}
+
+func pauseActivityHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*activity.ActivityServicePauseActivityArgs)
+	realResult := result.(*activity.ActivityServicePauseActivityResult)
+	success, err := handler.(activity.ActivityService).PauseActivity(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newActivityServicePauseActivityArgs() interface{} {
+	return activity.NewActivityServicePauseActivityArgs()
+}
+
+func newActivityServicePauseActivityResult() interface{} {
+	return activity.NewActivityServicePauseActivityResult()
+}
+
+func resumeActivityHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*activity.ActivityServiceResumeActivityArgs)
+	realResult := result.(*activity.ActivityServiceResumeActivityResult)
+	success, err := handler.(activity.ActivityService).ResumeActivity(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newActivityServiceResumeActivityArgs() interface{} {
+	return activity.NewActivityServiceResumeActivityArgs()
+}
+
+func newActivityServiceResumeActivityResult() interface{} {
+	return activity.NewActivityServiceResumeActivityResult()
+}
+
+func deleteActivityHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*activity.ActivityServiceDeleteActivityArgs)
+	realResult := result.(*activity.ActivityServiceDeleteActivityResult)
+	success, err := handler.(activity.ActivityService).DeleteActivity(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newActivityServiceDeleteActivityArgs() interface{} {
+	return activity.NewActivityServiceDeleteActivityArgs()
+}
+
+func newActivityServiceDeleteActivityResult() interface{} {
+	return activity.NewActivityServiceDeleteActivityResult()
+}
+
+func adjustStockHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*activity.ActivityServiceAdjustStockArgs)
+	realResult := result.(*activity.ActivityServiceAdjustStockResult)
+	success, err := handler.(activity.ActivityService).AdjustStock(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newActivityServiceAdjustStockArgs() interface{} {
+	return activity.NewActivityServiceAdjustStockArgs()
+}
+
+func newActivityServiceAdjustStockResult() interface{} {
+	return activity.NewActivityServiceAdjustStockResult()
+}
+
 type kClient struct {
 	c client.Client
 }
@@ -193,3 +318,53 @@ func (p *kClient) GetActivity(ctx context.Context, req *activity.GetActivityRequ
 	}
 	return _result.GetSuccess(), nil
 }
+
+func (p *kClient) UpdateActivity(ctx context.Context, req *activity.UpdateActivityRequest) (r *activity.UpdateActivityResponse, err error) {
+	var _args activity.ActivityServiceUpdateActivityArgs
+	_args.Req = req
+	var _result activity.ActivityServiceUpdateActivityResult
+	if err = p.c.Call(ctx, "UpdateActivity", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) PauseActivity(ctx context.Context, req *activity.PauseActivityRequest) (r *activity.PauseActivityResponse, err error) {
+	var _args activity.ActivityServicePauseActivityArgs
+	_args.Req = req
+	var _result activity.ActivityServicePauseActivityResult
+	if err = p.c.Call(ctx, "PauseActivity", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) ResumeActivity(ctx context.Context, req *activity.ResumeActivityRequest) (r *activity.ResumeActivityResponse, err error) {
+	var _args activity.ActivityServiceResumeActivityArgs
+	_args.Req = req
+	var _result activity.ActivityServiceResumeActivityResult
+	if err = p.c.Call(ctx, "ResumeActivity", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) DeleteActivity(ctx context.Context, req *activity.DeleteActivityRequest) (r *activity.DeleteActivityResponse, err error) {
+	var _args activity.ActivityServiceDeleteActivityArgs
+	_args.Req = req
+	var _result activity.ActivityServiceDeleteActivityResult
+	if err = p.c.Call(ctx, "DeleteActivity", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) AdjustStock(ctx context.Context, req *activity.AdjustStockRequest) (r *activity.AdjustStockResponse, err error) {
+	var _args activity.ActivityServiceAdjustStockArgs
+	_args.Req = req
+	var _result activity.ActivityServiceAdjustStockResult
+	if err = p.c.Call(ctx, "AdjustStock", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
@@ -14,6 +14,11 @@ type Client interface {
 	CreateActivity(ctx context.Context, req *activity.CreateActivityRequest, callOptions ...callopt.Option) (r *activity.CreateActivityResponse, err error)
 	GetActivityList(ctx context.Context, req *activity.GetActivityListRequest, callOptions ...callopt.Option) (r *activity.GetActivityListResponse, err error)
 	GetActivity(ctx context.Context, req *activity.GetActivityRequest, callOptions ...callopt.Option) (r *activity.GetActivityResponse, err error)
+	UpdateActivity(ctx context.Context, req *activity.UpdateActivityRequest, callOptions ...callopt.Option) (r *activity.UpdateActivityResponse, err error)
+	PauseActivity(ctx context.Context, req *activity.PauseActivityRequest, callOptions ...callopt.Option) (r *activity.PauseActivityResponse, err error)
+	ResumeActivity(ctx context.Context, req *activity.ResumeActivityRequest, callOptions ...callopt.Option) (r *activity.ResumeActivityResponse, err error)
+	DeleteActivity(ctx context.Context, req *activity.DeleteActivityRequest, callOptions ...callopt.Option) (r *activity.DeleteActivityResponse, err error)
+	AdjustStock(ctx context.Context, req *activity.AdjustStockRequest, callOptions ...callopt.Option) (r *activity.AdjustStockResponse, err error)
 }
 
 // NewClient creates a client for the service defined in IDL.
@@ -59,3 +64,28 @@ func (p *kActivityServiceClient) GetActivity(ctx context.Context, req *activity.
 	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
 	return p.kClient.GetActivity(ctx, req)
 }
+
+func (p *kActivityServiceClient) UpdateActivity(ctx context.Context, req *activity.UpdateActivityRequest, callOptions ...callopt.Option) (r *activity.UpdateActivityResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.UpdateActivity(ctx, req)
+}
+
+func (p *kActivityServiceClient) PauseActivity(ctx context.Context, req *activity.PauseActivityRequest, callOptions ...callopt.Option) (r *activity.PauseActivityResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.PauseActivity(ctx, req)
+}
+
+func (p *kActivityServiceClient) ResumeActivity(ctx context.Context, req *activity.ResumeActivityRequest, callOptions ...callopt.Option) (r *activity.ResumeActivityResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.ResumeActivity(ctx, req)
+}
+
+func (p *kActivityServiceClient) DeleteActivity(ctx context.Context, req *activity.DeleteActivityRequest, callOptions ...callopt.Option) (r *activity.DeleteActivityResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.DeleteActivity(ctx, req)
+}
+
+func (p *kActivityServiceClient) AdjustStock(ctx context.Context, req *activity.AdjustStockRequest, callOptions ...callopt.Option) (r *activity.AdjustStockResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.AdjustStock(ctx, req)
+}
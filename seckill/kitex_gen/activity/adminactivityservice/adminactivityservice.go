@@ -0,0 +1,195 @@
+// Code generated by Kitex v0.13.1. DO NOT EDIT.
+
+package adminactivityservice
+
+import (
+	activity "Redrock/seckill/kitex_gen/activity"
+	"context"
+	"errors"
+	client "github.com/cloudwego/kitex/client"
+	kitex "github.com/cloudwego/kitex/pkg/serviceinfo"
+)
+
+var errInvalidMessageType = errors.New("invalid message type for service method handler")
+
+var serviceMethods = map[string]kitex.MethodInfo{
+	"WarmUpActivity": kitex.NewMethodInfo(
+		warmUpActivityHandler,
+		newAdminActivityServiceWarmUpActivityArgs,
+		newAdminActivityServiceWarmUpActivityResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"GetStockReports": kitex.NewMethodInfo(
+		getStockReportsHandler,
+		newAdminActivityServiceGetStockReportsArgs,
+		newAdminActivityServiceGetStockReportsResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"ReconcileStock": kitex.NewMethodInfo(
+		reconcileStockHandler,
+		newAdminActivityServiceReconcileStockArgs,
+		newAdminActivityServiceReconcileStockResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+}
+
+var (
+	adminActivityServiceServiceInfo                = NewServiceInfo()
+	adminActivityServiceServiceInfoForClient       = NewServiceInfoForClient()
+	adminActivityServiceServiceInfoForStreamClient = NewServiceInfoForStreamClient()
+)
+
+// for server
+func serviceInfo() *kitex.ServiceInfo {
+	return adminActivityServiceServiceInfo
+}
+
+// for stream client
+func serviceInfoForStreamClient() *kitex.ServiceInfo {
+	return adminActivityServiceServiceInfoForStreamClient
+}
+
+// for client
+func serviceInfoForClient() *kitex.ServiceInfo {
+	return adminActivityServiceServiceInfoForClient
+}
+
+// NewServiceInfo creates a new ServiceInfo containing all methods
+func NewServiceInfo() *kitex.ServiceInfo {
+	return newServiceInfo(false, true, true)
+}
+
+// NewServiceInfo creates a new ServiceInfo containing non-streaming methods
+func NewServiceInfoForClient() *kitex.ServiceInfo {
+	return newServiceInfo(false, false, true)
+}
+func NewServiceInfoForStreamClient() *kitex.ServiceInfo {
+	return newServiceInfo(true, true, false)
+}
+
+func newServiceInfo(hasStreaming bool, keepStreamingMethods bool, keepNonStreamingMethods bool) *kitex.ServiceInfo {
+	serviceName := "AdminActivityService"
+	handlerType := (*activity.AdminActivityService)(nil)
+	methods := map[string]kitex.MethodInfo{}
+	for name, m := range serviceMethods {
+		if m.IsStreaming() && !keepStreamingMethods {
+			continue
+		}
+		if !m.IsStreaming() && !keepNonStreamingMethods {
+			continue
+		}
+		methods[name] = m
+	}
+	extra := map[string]interface{}{
+		"PackageName": "activity",
+	}
+	if hasStreaming {
+		extra["streaming"] = hasStreaming
+	}
+	svcInfo := &kitex.ServiceInfo{
+		ServiceName:     serviceName,
+		HandlerType:     handlerType,
+		Methods:         methods,
+		PayloadCodec:    kitex.Thrift,
+		KiteXGenVersion: "v0.13.1",
+		Extra:           extra,
+	}
+	return svcInfo
+}
+
+func warmUpActivityHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*activity.AdminActivityServiceWarmUpActivityArgs)
+	realResult := result.(*activity.AdminActivityServiceWarmUpActivityResult)
+	success, err := handler.(activity.AdminActivityService).WarmUpActivity(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newAdminActivityServiceWarmUpActivityArgs() interface{} {
+	return activity.NewAdminActivityServiceWarmUpActivityArgs()
+}
+
+func newAdminActivityServiceWarmUpActivityResult() interface{} {
+	return activity.NewAdminActivityServiceWarmUpActivityResult()
+}
+
+func getStockReportsHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*activity.AdminActivityServiceGetStockReportsArgs)
+	realResult := result.(*activity.AdminActivityServiceGetStockReportsResult)
+	success, err := handler.(activity.AdminActivityService).GetStockReports(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newAdminActivityServiceGetStockReportsArgs() interface{} {
+	return activity.NewAdminActivityServiceGetStockReportsArgs()
+}
+
+func newAdminActivityServiceGetStockReportsResult() interface{} {
+	return activity.NewAdminActivityServiceGetStockReportsResult()
+}
+
+func reconcileStockHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*activity.AdminActivityServiceReconcileStockArgs)
+	realResult := result.(*activity.AdminActivityServiceReconcileStockResult)
+	success, err := handler.(activity.AdminActivityService).ReconcileStock(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newAdminActivityServiceReconcileStockArgs() interface{} {
+	return activity.NewAdminActivityServiceReconcileStockArgs()
+}
+
+func newAdminActivityServiceReconcileStockResult() interface{} {
+	return activity.NewAdminActivityServiceReconcileStockResult()
+}
+
+type kClient struct {
+	c client.Client
+}
+
+func newServiceClient(c client.Client) *kClient {
+	return &kClient{
+		c: c,
+	}
+}
+
+func (p *kClient) WarmUpActivity(ctx context.Context, req *activity.WarmUpActivityRequest) (r *activity.WarmUpActivityResponse, err error) {
+	var _args activity.AdminActivityServiceWarmUpActivityArgs
+	_args.Req = req
+	var _result activity.AdminActivityServiceWarmUpActivityResult
+	if err = p.c.Call(ctx, "WarmUpActivity", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) GetStockReports(ctx context.Context, req *activity.GetStockReportsRequest) (r *activity.GetStockReportsResponse, err error) {
+	var _args activity.AdminActivityServiceGetStockReportsArgs
+	_args.Req = req
+	var _result activity.AdminActivityServiceGetStockReportsResult
+	if err = p.c.Call(ctx, "GetStockReports", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) ReconcileStock(ctx context.Context, req *activity.ReconcileStockRequest) (r *activity.ReconcileStockResponse, err error) {
+	var _args activity.AdminActivityServiceReconcileStockArgs
+	_args.Req = req
+	var _result activity.AdminActivityServiceReconcileStockResult
+	if err = p.c.Call(ctx, "ReconcileStock", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
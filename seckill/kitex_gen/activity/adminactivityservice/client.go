@@ -0,0 +1,61 @@
+// Code generated by Kitex v0.13.1. DO NOT EDIT.
+
+package adminactivityservice
+
+import (
+	activity "Redrock/seckill/kitex_gen/activity"
+	"context"
+	client "github.com/cloudwego/kitex/client"
+	callopt "github.com/cloudwego/kitex/client/callopt"
+)
+
+// Client is designed to provide IDL-compatible methods with call-option parameter for kitex framework.
+type Client interface {
+	WarmUpActivity(ctx context.Context, req *activity.WarmUpActivityRequest, callOptions ...callopt.Option) (r *activity.WarmUpActivityResponse, err error)
+	GetStockReports(ctx context.Context, req *activity.GetStockReportsRequest, callOptions ...callopt.Option) (r *activity.GetStockReportsResponse, err error)
+	ReconcileStock(ctx context.Context, req *activity.ReconcileStockRequest, callOptions ...callopt.Option) (r *activity.ReconcileStockResponse, err error)
+}
+
+// NewClient creates a client for the service defined in IDL.
+func NewClient(destService string, opts ...client.Option) (Client, error) {
+	var options []client.Option
+	options = append(options, client.WithDestService(destService))
+
+	options = append(options, opts...)
+
+	kc, err := client.NewClient(serviceInfoForClient(), options...)
+	if err != nil {
+		return nil, err
+	}
+	return &kAdminActivityServiceClient{
+		kClient: newServiceClient(kc),
+	}, nil
+}
+
+// MustNewClient creates a client for the service defined in IDL. It panics if any error occurs.
+func MustNewClient(destService string, opts ...client.Option) Client {
+	kc, err := NewClient(destService, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return kc
+}
+
+type kAdminActivityServiceClient struct {
+	*kClient
+}
+
+func (p *kAdminActivityServiceClient) WarmUpActivity(ctx context.Context, req *activity.WarmUpActivityRequest, callOptions ...callopt.Option) (r *activity.WarmUpActivityResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.WarmUpActivity(ctx, req)
+}
+
+func (p *kAdminActivityServiceClient) GetStockReports(ctx context.Context, req *activity.GetStockReportsRequest, callOptions ...callopt.Option) (r *activity.GetStockReportsResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.GetStockReports(ctx, req)
+}
+
+func (p *kAdminActivityServiceClient) ReconcileStock(ctx context.Context, req *activity.ReconcileStockRequest, callOptions ...callopt.Option) (r *activity.ReconcileStockResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.ReconcileStock(ctx, req)
+}
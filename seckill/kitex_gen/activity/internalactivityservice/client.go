@@ -12,6 +12,8 @@ import (
 // Client is designed to provide IDL-compatible methods with call-option parameter for kitex framework.
 type Client interface {
 	DeductStock(ctx context.Context, req *activity.DeductStockRequest, callOptions ...callopt.Option) (r *activity.DeductStockResponse, err error)
+	ReturnStock(ctx context.Context, req *activity.ReturnStockRequest, callOptions ...callopt.Option) (r *activity.ReturnStockResponse, err error)
+	CountProductActivities(ctx context.Context, req *activity.CountProductActivitiesRequest, callOptions ...callopt.Option) (r *activity.CountProductActivitiesResponse, err error)
 }
 
 // NewClient creates a client for the service defined in IDL.
@@ -47,3 +49,13 @@ func (p *kInternalActivityServiceClient) DeductStock(ctx context.Context, req *a
 	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
 	return p.kClient.DeductStock(ctx, req)
 }
+
+func (p *kInternalActivityServiceClient) ReturnStock(ctx context.Context, req *activity.ReturnStockRequest, callOptions ...callopt.Option) (r *activity.ReturnStockResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.ReturnStock(ctx, req)
+}
+
+func (p *kInternalActivityServiceClient) CountProductActivities(ctx context.Context, req *activity.CountProductActivitiesRequest, callOptions ...callopt.Option) (r *activity.CountProductActivitiesResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.CountProductActivities(ctx, req)
+}
@@ -20,6 +20,20 @@ var serviceMethods = map[string]kitex.MethodInfo{
 		false,
 		kitex.WithStreamingMode(kitex.StreamingNone),
 	),
+	"ReturnStock": kitex.NewMethodInfo(
+		returnStockHandler,
+		newInternalActivityServiceReturnStockArgs,
+		newInternalActivityServiceReturnStockResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"CountProductActivities": kitex.NewMethodInfo(
+		countProductActivitiesHandler,
+		newInternalActivityServiceCountProductActivitiesArgs,
+		newInternalActivityServiceCountProductActivitiesResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
 }
 
 var (
@@ -104,6 +118,42 @@ func newInternalActivityServiceDeductStockResult() interface{} {
 	return activity.NewInternalActivityServiceDeductStockResult()
 }
 
+func returnStockHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*activity.InternalActivityServiceReturnStockArgs)
+	realResult := result.(*activity.InternalActivityServiceReturnStockResult)
+	success, err := handler.(activity.InternalActivityService).ReturnStock(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newInternalActivityServiceReturnStockArgs() interface{} {
+	return activity.NewInternalActivityServiceReturnStockArgs()
+}
+
+func newInternalActivityServiceReturnStockResult() interface{} {
+	return activity.NewInternalActivityServiceReturnStockResult()
+}
+
+func countProductActivitiesHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*activity.InternalActivityServiceCountProductActivitiesArgs)
+	realResult := result.(*activity.InternalActivityServiceCountProductActivitiesResult)
+	success, err := handler.(activity.InternalActivityService).CountProductActivities(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newInternalActivityServiceCountProductActivitiesArgs() interface{} {
+	return activity.NewInternalActivityServiceCountProductActivitiesArgs()
+}
+
+func newInternalActivityServiceCountProductActivitiesResult() interface{} {
+	return activity.NewInternalActivityServiceCountProductActivitiesResult()
+}
+
 type kClient struct {
 	c client.Client
 }
@@ -123,3 +173,23 @@ func (p *kClient) DeductStock(ctx context.Context, req *activity.DeductStockRequ
 	}
 	return _result.GetSuccess(), nil
 }
+
+func (p *kClient) ReturnStock(ctx context.Context, req *activity.ReturnStockRequest) (r *activity.ReturnStockResponse, err error) {
+	var _args activity.InternalActivityServiceReturnStockArgs
+	_args.Req = req
+	var _result activity.InternalActivityServiceReturnStockResult
+	if err = p.c.Call(ctx, "ReturnStock", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) CountProductActivities(ctx context.Context, req *activity.CountProductActivitiesRequest) (r *activity.CountProductActivitiesResponse, err error) {
+	var _args activity.InternalActivityServiceCountProductActivitiesArgs
+	_args.Req = req
+	var _result activity.InternalActivityServiceCountProductActivitiesResult
+	if err = p.c.Call(ctx, "CountProductActivities", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
@@ -17,6 +17,7 @@ const (
 	OrderStatus_PAID      OrderStatus = 2
 	OrderStatus_FAILED    OrderStatus = 3
 	OrderStatus_CANCELLED OrderStatus = 4
+	OrderStatus_PAYING    OrderStatus = 5
 )
 
 func (p OrderStatus) String() string {
@@ -31,6 +32,8 @@ func (p OrderStatus) String() string {
 		return "FAILED"
 	case OrderStatus_CANCELLED:
 		return "CANCELLED"
+	case OrderStatus_PAYING:
+		return "PAYING"
 	}
 	return "<UNSET>"
 }
@@ -47,6 +50,8 @@ func OrderStatusFromString(s string) (OrderStatus, error) {
 		return OrderStatus_FAILED, nil
 	case "CANCELLED":
 		return OrderStatus_CANCELLED, nil
+	case "PAYING":
+		return OrderStatus_PAYING, nil
 	}
 	return OrderStatus(0), fmt.Errorf("not a valid OrderStatus string")
 }
@@ -66,6 +71,53 @@ func (p *OrderStatus) Value() (driver.Value, error) {
 	return int64(*p), nil
 }
 
+type SeckillStatus int64
+
+const (
+	SeckillStatus_QUEUED  SeckillStatus = 0
+	SeckillStatus_SUCCESS SeckillStatus = 1
+	SeckillStatus_FAILED  SeckillStatus = 2
+)
+
+func (p SeckillStatus) String() string {
+	switch p {
+	case SeckillStatus_QUEUED:
+		return "QUEUED"
+	case SeckillStatus_SUCCESS:
+		return "SUCCESS"
+	case SeckillStatus_FAILED:
+		return "FAILED"
+	}
+	return "<UNSET>"
+}
+
+func SeckillStatusFromString(s string) (SeckillStatus, error) {
+	switch s {
+	case "QUEUED":
+		return SeckillStatus_QUEUED, nil
+	case "SUCCESS":
+		return SeckillStatus_SUCCESS, nil
+	case "FAILED":
+		return SeckillStatus_FAILED, nil
+	}
+	return SeckillStatus(0), fmt.Errorf("not a valid SeckillStatus string")
+}
+
+func SeckillStatusPtr(v SeckillStatus) *SeckillStatus { return &v }
+func (p *SeckillStatus) Scan(value interface{}) (err error) {
+	var result sql.NullInt64
+	err = result.Scan(value)
+	*p = SeckillStatus(result.Int64)
+	return
+}
+
+func (p *SeckillStatus) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, nil
+	}
+	return int64(*p), nil
+}
+
 type BaseResponse struct {
 	Code int32  `thrift:"code,1" frugal:"1,default,i32" json:"code"`
 	Msg  string `thrift:"msg,2" frugal:"2,default,string" json:"msg"`
@@ -116,6 +168,8 @@ type OrderInfo struct {
 	CreateTime  int64       `thrift:"createTime,9" frugal:"9,default,i64" json:"createTime"`
 	PayTime     int64       `thrift:"payTime,10" frugal:"10,default,i64" json:"payTime"`
 	ExpireTime  int64       `thrift:"expireTime,11" frugal:"11,default,i64" json:"expireTime"`
+	Quantity    int32       `thrift:"quantity,12" frugal:"12,default,i32" json:"quantity"`
+	Price       float64     `thrift:"price,13" frugal:"13,default,double" json:"price"`
 }
 
 func NewOrderInfo() *OrderInfo {
@@ -168,6 +222,14 @@ func (p *OrderInfo) GetPayTime() (v int64) {
 func (p *OrderInfo) GetExpireTime() (v int64) {
 	return p.ExpireTime
 }
+
+func (p *OrderInfo) GetQuantity() (v int32) {
+	return p.Quantity
+}
+
+func (p *OrderInfo) GetPrice() (v float64) {
+	return p.Price
+}
 func (p *OrderInfo) SetId(val int64) {
 	p.Id = val
 }
@@ -201,6 +263,12 @@ func (p *OrderInfo) SetPayTime(val int64) {
 func (p *OrderInfo) SetExpireTime(val int64) {
 	p.ExpireTime = val
 }
+func (p *OrderInfo) SetQuantity(val int32) {
+	p.Quantity = val
+}
+func (p *OrderInfo) SetPrice(val float64) {
+	p.Price = val
+}
 
 func (p *OrderInfo) String() string {
 	if p == nil {
@@ -221,11 +289,14 @@ var fieldIDToName_OrderInfo = map[int16]string{
 	9:  "createTime",
 	10: "payTime",
 	11: "expireTime",
+	12: "quantity",
+	13: "price",
 }
 
 type CreateOrderRequest struct {
 	UserID     int64 `thrift:"userID,1" frugal:"1,default,i64" json:"userID"`
 	ActivityID int64 `thrift:"activityID,2" frugal:"2,default,i64" json:"activityID"`
+	Quantity   int32 `thrift:"quantity,3" frugal:"3,default,i32" json:"quantity"`
 }
 
 func NewCreateOrderRequest() *CreateOrderRequest {
@@ -242,12 +313,19 @@ func (p *CreateOrderRequest) GetUserID() (v int64) {
 func (p *CreateOrderRequest) GetActivityID() (v int64) {
 	return p.ActivityID
 }
+
+func (p *CreateOrderRequest) GetQuantity() (v int32) {
+	return p.Quantity
+}
 func (p *CreateOrderRequest) SetUserID(val int64) {
 	p.UserID = val
 }
 func (p *CreateOrderRequest) SetActivityID(val int64) {
 	p.ActivityID = val
 }
+func (p *CreateOrderRequest) SetQuantity(val int32) {
+	p.Quantity = val
+}
 
 func (p *CreateOrderRequest) String() string {
 	if p == nil {
@@ -259,6 +337,7 @@ func (p *CreateOrderRequest) String() string {
 var fieldIDToName_CreateOrderRequest = map[int16]string{
 	1: "userID",
 	2: "activityID",
+	3: "quantity",
 }
 
 type CreateOrderResponse struct {
@@ -412,8 +491,13 @@ var fieldIDToName_GetOrderResponse = map[int16]string{
 }
 
 type ListOrdersRequest struct {
-	UserID int64       `thrift:"userID,1" frugal:"1,default,i64" json:"userID"`
-	Status OrderStatus `thrift:"status,2" frugal:"2,default,OrderStatus" json:"status"`
+	UserID   int64       `thrift:"userID,1" frugal:"1,default,i64" json:"userID"`
+	Status   OrderStatus `thrift:"status,2" frugal:"2,default,OrderStatus" json:"status"`
+	Cursor   string      `thrift:"cursor,3" frugal:"3,default,string" json:"cursor"`
+	PageSize int32       `thrift:"pageSize,4" frugal:"4,default,i32" json:"pageSize"`
+	TimeFrom *int64      `thrift:"timeFrom,5,optional" frugal:"5,optional,i64" json:"timeFrom,omitempty"`
+	TimeTo   *int64      `thrift:"timeTo,6,optional" frugal:"6,optional,i64" json:"timeTo,omitempty"`
+	Asc      bool        `thrift:"asc,7" frugal:"7,default,bool" json:"asc"`
 }
 
 func NewListOrdersRequest() *ListOrdersRequest {
@@ -434,12 +518,65 @@ func (p *ListOrdersRequest) GetUserID() (v int64) {
 func (p *ListOrdersRequest) GetStatus() (v OrderStatus) {
 	return p.Status
 }
+
+func (p *ListOrdersRequest) GetCursor() (v string) {
+	return p.Cursor
+}
+
+func (p *ListOrdersRequest) GetPageSize() (v int32) {
+	return p.PageSize
+}
+
+var ListOrdersRequest_TimeFrom_DEFAULT int64
+
+func (p *ListOrdersRequest) GetTimeFrom() (v int64) {
+	if !p.IsSetTimeFrom() {
+		return ListOrdersRequest_TimeFrom_DEFAULT
+	}
+	return *p.TimeFrom
+}
+
+var ListOrdersRequest_TimeTo_DEFAULT int64
+
+func (p *ListOrdersRequest) GetTimeTo() (v int64) {
+	if !p.IsSetTimeTo() {
+		return ListOrdersRequest_TimeTo_DEFAULT
+	}
+	return *p.TimeTo
+}
+
+func (p *ListOrdersRequest) GetAsc() (v bool) {
+	return p.Asc
+}
 func (p *ListOrdersRequest) SetUserID(val int64) {
 	p.UserID = val
 }
 func (p *ListOrdersRequest) SetStatus(val OrderStatus) {
 	p.Status = val
 }
+func (p *ListOrdersRequest) SetCursor(val string) {
+	p.Cursor = val
+}
+func (p *ListOrdersRequest) SetPageSize(val int32) {
+	p.PageSize = val
+}
+func (p *ListOrdersRequest) SetTimeFrom(val *int64) {
+	p.TimeFrom = val
+}
+func (p *ListOrdersRequest) SetTimeTo(val *int64) {
+	p.TimeTo = val
+}
+func (p *ListOrdersRequest) SetAsc(val bool) {
+	p.Asc = val
+}
+
+func (p *ListOrdersRequest) IsSetTimeFrom() bool {
+	return p.TimeFrom != nil
+}
+
+func (p *ListOrdersRequest) IsSetTimeTo() bool {
+	return p.TimeTo != nil
+}
 
 func (p *ListOrdersRequest) String() string {
 	if p == nil {
@@ -451,12 +588,19 @@ func (p *ListOrdersRequest) String() string {
 var fieldIDToName_ListOrdersRequest = map[int16]string{
 	1: "userID",
 	2: "status",
+	3: "cursor",
+	4: "pageSize",
+	5: "timeFrom",
+	6: "timeTo",
+	7: "asc",
 }
 
 type ListOrdersResponse struct {
 	BaseResponse *BaseResponse `thrift:"baseResponse,1" frugal:"1,default,BaseResponse" json:"baseResponse"`
 	Orders       []*OrderInfo  `thrift:"orders,2" frugal:"2,default,list<OrderInfo>" json:"orders"`
 	Total        int64         `thrift:"total,3" frugal:"3,default,i64" json:"total"`
+	NextCursor   string        `thrift:"nextCursor,4" frugal:"4,default,string" json:"nextCursor"`
+	HasMore      bool          `thrift:"hasMore,5" frugal:"5,default,bool" json:"hasMore"`
 }
 
 func NewListOrdersResponse() *ListOrdersResponse {
@@ -482,6 +626,14 @@ func (p *ListOrdersResponse) GetOrders() (v []*OrderInfo) {
 func (p *ListOrdersResponse) GetTotal() (v int64) {
 	return p.Total
 }
+
+func (p *ListOrdersResponse) GetNextCursor() (v string) {
+	return p.NextCursor
+}
+
+func (p *ListOrdersResponse) GetHasMore() (v bool) {
+	return p.HasMore
+}
 func (p *ListOrdersResponse) SetBaseResponse(val *BaseResponse) {
 	p.BaseResponse = val
 }
@@ -491,6 +643,12 @@ func (p *ListOrdersResponse) SetOrders(val []*OrderInfo) {
 func (p *ListOrdersResponse) SetTotal(val int64) {
 	p.Total = val
 }
+func (p *ListOrdersResponse) SetNextCursor(val string) {
+	p.NextCursor = val
+}
+func (p *ListOrdersResponse) SetHasMore(val bool) {
+	p.HasMore = val
+}
 
 func (p *ListOrdersResponse) IsSetBaseResponse() bool {
 	return p.BaseResponse != nil
@@ -507,6 +665,375 @@ var fieldIDToName_ListOrdersResponse = map[int16]string{
 	1: "baseResponse",
 	2: "orders",
 	3: "total",
+	4: "nextCursor",
+	5: "hasMore",
+}
+
+type PayOrderRequest struct {
+	UserID  int64  `thrift:"userID,1" frugal:"1,default,i64" json:"userID"`
+	OrderSn string `thrift:"orderSn,2" frugal:"2,default,string" json:"orderSn"`
+}
+
+func NewPayOrderRequest() *PayOrderRequest {
+	return &PayOrderRequest{}
+}
+
+func (p *PayOrderRequest) InitDefault() {
+}
+
+func (p *PayOrderRequest) GetUserID() (v int64) {
+	return p.UserID
+}
+
+func (p *PayOrderRequest) GetOrderSn() (v string) {
+	return p.OrderSn
+}
+func (p *PayOrderRequest) SetUserID(val int64) {
+	p.UserID = val
+}
+func (p *PayOrderRequest) SetOrderSn(val string) {
+	p.OrderSn = val
+}
+
+func (p *PayOrderRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("PayOrderRequest(%+v)", *p)
+}
+
+var fieldIDToName_PayOrderRequest = map[int16]string{
+	1: "userID",
+	2: "orderSn",
+}
+
+type PayOrderResponse struct {
+	BaseResponse *BaseResponse `thrift:"baseResponse,1" frugal:"1,default,BaseResponse" json:"baseResponse"`
+	OrderInfo    *OrderInfo    `thrift:"orderInfo,2" frugal:"2,default,OrderInfo" json:"orderInfo"`
+}
+
+func NewPayOrderResponse() *PayOrderResponse {
+	return &PayOrderResponse{}
+}
+
+func (p *PayOrderResponse) InitDefault() {
+}
+
+var PayOrderResponse_BaseResponse_DEFAULT *BaseResponse
+
+func (p *PayOrderResponse) GetBaseResponse() (v *BaseResponse) {
+	if !p.IsSetBaseResponse() {
+		return PayOrderResponse_BaseResponse_DEFAULT
+	}
+	return p.BaseResponse
+}
+
+var PayOrderResponse_OrderInfo_DEFAULT *OrderInfo
+
+func (p *PayOrderResponse) GetOrderInfo() (v *OrderInfo) {
+	if !p.IsSetOrderInfo() {
+		return PayOrderResponse_OrderInfo_DEFAULT
+	}
+	return p.OrderInfo
+}
+func (p *PayOrderResponse) SetBaseResponse(val *BaseResponse) {
+	p.BaseResponse = val
+}
+func (p *PayOrderResponse) SetOrderInfo(val *OrderInfo) {
+	p.OrderInfo = val
+}
+
+func (p *PayOrderResponse) IsSetBaseResponse() bool {
+	return p.BaseResponse != nil
+}
+
+func (p *PayOrderResponse) IsSetOrderInfo() bool {
+	return p.OrderInfo != nil
+}
+
+func (p *PayOrderResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("PayOrderResponse(%+v)", *p)
+}
+
+var fieldIDToName_PayOrderResponse = map[int16]string{
+	1: "baseResponse",
+	2: "orderInfo",
+}
+
+type CancelOrderRequest struct {
+	UserID  int64  `thrift:"userID,1" frugal:"1,default,i64" json:"userID"`
+	OrderSn string `thrift:"orderSn,2" frugal:"2,default,string" json:"orderSn"`
+}
+
+func NewCancelOrderRequest() *CancelOrderRequest {
+	return &CancelOrderRequest{}
+}
+
+func (p *CancelOrderRequest) InitDefault() {
+}
+
+func (p *CancelOrderRequest) GetUserID() (v int64) {
+	return p.UserID
+}
+
+func (p *CancelOrderRequest) GetOrderSn() (v string) {
+	return p.OrderSn
+}
+func (p *CancelOrderRequest) SetUserID(val int64) {
+	p.UserID = val
+}
+func (p *CancelOrderRequest) SetOrderSn(val string) {
+	p.OrderSn = val
+}
+
+func (p *CancelOrderRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CancelOrderRequest(%+v)", *p)
+}
+
+var fieldIDToName_CancelOrderRequest = map[int16]string{
+	1: "userID",
+	2: "orderSn",
+}
+
+type CancelOrderResponse struct {
+	BaseResponse *BaseResponse `thrift:"baseResponse,1" frugal:"1,default,BaseResponse" json:"baseResponse"`
+	OrderInfo    *OrderInfo    `thrift:"orderInfo,2" frugal:"2,default,OrderInfo" json:"orderInfo"`
+}
+
+func NewCancelOrderResponse() *CancelOrderResponse {
+	return &CancelOrderResponse{}
+}
+
+func (p *CancelOrderResponse) InitDefault() {
+}
+
+var CancelOrderResponse_BaseResponse_DEFAULT *BaseResponse
+
+func (p *CancelOrderResponse) GetBaseResponse() (v *BaseResponse) {
+	if !p.IsSetBaseResponse() {
+		return CancelOrderResponse_BaseResponse_DEFAULT
+	}
+	return p.BaseResponse
+}
+
+var CancelOrderResponse_OrderInfo_DEFAULT *OrderInfo
+
+func (p *CancelOrderResponse) GetOrderInfo() (v *OrderInfo) {
+	if !p.IsSetOrderInfo() {
+		return CancelOrderResponse_OrderInfo_DEFAULT
+	}
+	return p.OrderInfo
+}
+func (p *CancelOrderResponse) SetBaseResponse(val *BaseResponse) {
+	p.BaseResponse = val
+}
+func (p *CancelOrderResponse) SetOrderInfo(val *OrderInfo) {
+	p.OrderInfo = val
+}
+
+func (p *CancelOrderResponse) IsSetBaseResponse() bool {
+	return p.BaseResponse != nil
+}
+
+func (p *CancelOrderResponse) IsSetOrderInfo() bool {
+	return p.OrderInfo != nil
+}
+
+func (p *CancelOrderResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CancelOrderResponse(%+v)", *p)
+}
+
+var fieldIDToName_CancelOrderResponse = map[int16]string{
+	1: "baseResponse",
+	2: "orderInfo",
+}
+
+type GetSeckillStatusRequest struct {
+	UserID int64  `thrift:"userID,1" frugal:"1,default,i64" json:"userID"`
+	Ticket string `thrift:"ticket,2" frugal:"2,default,string" json:"ticket"`
+}
+
+func NewGetSeckillStatusRequest() *GetSeckillStatusRequest {
+	return &GetSeckillStatusRequest{}
+}
+
+func (p *GetSeckillStatusRequest) InitDefault() {
+}
+
+func (p *GetSeckillStatusRequest) GetUserID() (v int64) {
+	return p.UserID
+}
+
+func (p *GetSeckillStatusRequest) GetTicket() (v string) {
+	return p.Ticket
+}
+func (p *GetSeckillStatusRequest) SetUserID(val int64) {
+	p.UserID = val
+}
+func (p *GetSeckillStatusRequest) SetTicket(val string) {
+	p.Ticket = val
+}
+
+func (p *GetSeckillStatusRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GetSeckillStatusRequest(%+v)", *p)
+}
+
+var fieldIDToName_GetSeckillStatusRequest = map[int16]string{
+	1: "userID",
+	2: "ticket",
+}
+
+type GetSeckillStatusResponse struct {
+	BaseResponse *BaseResponse `thrift:"baseResponse,1" frugal:"1,default,BaseResponse" json:"baseResponse"`
+	Status       SeckillStatus `thrift:"status,2" frugal:"2,default,SeckillStatus" json:"status"`
+	OrderSn      string        `thrift:"orderSn,3" frugal:"3,default,string" json:"orderSn"`
+	Reason       string        `thrift:"reason,4" frugal:"4,default,string" json:"reason"`
+}
+
+func NewGetSeckillStatusResponse() *GetSeckillStatusResponse {
+	return &GetSeckillStatusResponse{}
+}
+
+func (p *GetSeckillStatusResponse) InitDefault() {
+}
+
+var GetSeckillStatusResponse_BaseResponse_DEFAULT *BaseResponse
+
+func (p *GetSeckillStatusResponse) GetBaseResponse() (v *BaseResponse) {
+	if !p.IsSetBaseResponse() {
+		return GetSeckillStatusResponse_BaseResponse_DEFAULT
+	}
+	return p.BaseResponse
+}
+
+func (p *GetSeckillStatusResponse) GetStatus() (v SeckillStatus) {
+	return p.Status
+}
+
+func (p *GetSeckillStatusResponse) GetOrderSn() (v string) {
+	return p.OrderSn
+}
+
+func (p *GetSeckillStatusResponse) GetReason() (v string) {
+	return p.Reason
+}
+func (p *GetSeckillStatusResponse) SetBaseResponse(val *BaseResponse) {
+	p.BaseResponse = val
+}
+func (p *GetSeckillStatusResponse) SetStatus(val SeckillStatus) {
+	p.Status = val
+}
+func (p *GetSeckillStatusResponse) SetOrderSn(val string) {
+	p.OrderSn = val
+}
+func (p *GetSeckillStatusResponse) SetReason(val string) {
+	p.Reason = val
+}
+
+func (p *GetSeckillStatusResponse) IsSetBaseResponse() bool {
+	return p.BaseResponse != nil
+}
+
+func (p *GetSeckillStatusResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GetSeckillStatusResponse(%+v)", *p)
+}
+
+var fieldIDToName_GetSeckillStatusResponse = map[int16]string{
+	1: "baseResponse",
+	2: "status",
+	3: "orderSn",
+	4: "reason",
+}
+
+type SetUserOrdersClosedRequest struct {
+	UserID int64 `thrift:"userID,1" frugal:"1,default,i64" json:"userID"`
+	Closed bool  `thrift:"closed,2" frugal:"2,default,bool" json:"closed"`
+}
+
+func NewSetUserOrdersClosedRequest() *SetUserOrdersClosedRequest {
+	return &SetUserOrdersClosedRequest{}
+}
+
+func (p *SetUserOrdersClosedRequest) InitDefault() {
+}
+
+func (p *SetUserOrdersClosedRequest) GetUserID() (v int64) {
+	return p.UserID
+}
+
+func (p *SetUserOrdersClosedRequest) GetClosed() (v bool) {
+	return p.Closed
+}
+func (p *SetUserOrdersClosedRequest) SetUserID(val int64) {
+	p.UserID = val
+}
+func (p *SetUserOrdersClosedRequest) SetClosed(val bool) {
+	p.Closed = val
+}
+
+func (p *SetUserOrdersClosedRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SetUserOrdersClosedRequest(%+v)", *p)
+}
+
+var fieldIDToName_SetUserOrdersClosedRequest = map[int16]string{
+	1: "userID",
+	2: "closed",
+}
+
+type SetUserOrdersClosedResponse struct {
+	BaseResponse *BaseResponse `thrift:"baseResponse,1" frugal:"1,default,BaseResponse" json:"baseResponse"`
+}
+
+func NewSetUserOrdersClosedResponse() *SetUserOrdersClosedResponse {
+	return &SetUserOrdersClosedResponse{}
+}
+
+func (p *SetUserOrdersClosedResponse) InitDefault() {
+}
+
+var SetUserOrdersClosedResponse_BaseResponse_DEFAULT *BaseResponse
+
+func (p *SetUserOrdersClosedResponse) GetBaseResponse() (v *BaseResponse) {
+	if !p.IsSetBaseResponse() {
+		return SetUserOrdersClosedResponse_BaseResponse_DEFAULT
+	}
+	return p.BaseResponse
+}
+func (p *SetUserOrdersClosedResponse) SetBaseResponse(val *BaseResponse) {
+	p.BaseResponse = val
+}
+
+func (p *SetUserOrdersClosedResponse) IsSetBaseResponse() bool {
+	return p.BaseResponse != nil
+}
+
+func (p *SetUserOrdersClosedResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SetUserOrdersClosedResponse(%+v)", *p)
+}
+
+var fieldIDToName_SetUserOrdersClosedResponse = map[int16]string{
+	1: "baseResponse",
 }
 
 type OrderService interface {
@@ -515,6 +1042,14 @@ type OrderService interface {
 	GetOrder(ctx context.Context, req *GetOrderRequest) (r *GetOrderResponse, err error)
 
 	ListOrders(ctx context.Context, req *ListOrdersRequest) (r *ListOrdersResponse, err error)
+
+	PayOrder(ctx context.Context, req *PayOrderRequest) (r *PayOrderResponse, err error)
+
+	CancelOrder(ctx context.Context, req *CancelOrderRequest) (r *CancelOrderResponse, err error)
+
+	GetSeckillStatus(ctx context.Context, req *GetSeckillStatusRequest) (r *GetSeckillStatusResponse, err error)
+
+	SetUserOrdersClosed(ctx context.Context, req *SetUserOrdersClosedRequest) (r *SetUserOrdersClosedResponse, err error)
 }
 
 type OrderServiceCreateOrderArgs struct {
@@ -744,3 +1279,307 @@ func (p *OrderServiceListOrdersResult) String() string {
 var fieldIDToName_OrderServiceListOrdersResult = map[int16]string{
 	0: "success",
 }
+
+type OrderServicePayOrderArgs struct {
+	Req *PayOrderRequest `thrift:"req,1" frugal:"1,default,PayOrderRequest" json:"req"`
+}
+
+func NewOrderServicePayOrderArgs() *OrderServicePayOrderArgs {
+	return &OrderServicePayOrderArgs{}
+}
+
+func (p *OrderServicePayOrderArgs) InitDefault() {
+}
+
+var OrderServicePayOrderArgs_Req_DEFAULT *PayOrderRequest
+
+func (p *OrderServicePayOrderArgs) GetReq() (v *PayOrderRequest) {
+	if !p.IsSetReq() {
+		return OrderServicePayOrderArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *OrderServicePayOrderArgs) SetReq(val *PayOrderRequest) {
+	p.Req = val
+}
+
+func (p *OrderServicePayOrderArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *OrderServicePayOrderArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("OrderServicePayOrderArgs(%+v)", *p)
+}
+
+var fieldIDToName_OrderServicePayOrderArgs = map[int16]string{
+	1: "req",
+}
+
+type OrderServicePayOrderResult struct {
+	Success *PayOrderResponse `thrift:"success,0,optional" frugal:"0,optional,PayOrderResponse" json:"success,omitempty"`
+}
+
+func NewOrderServicePayOrderResult() *OrderServicePayOrderResult {
+	return &OrderServicePayOrderResult{}
+}
+
+func (p *OrderServicePayOrderResult) InitDefault() {
+}
+
+var OrderServicePayOrderResult_Success_DEFAULT *PayOrderResponse
+
+func (p *OrderServicePayOrderResult) GetSuccess() (v *PayOrderResponse) {
+	if !p.IsSetSuccess() {
+		return OrderServicePayOrderResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *OrderServicePayOrderResult) SetSuccess(x interface{}) {
+	p.Success = x.(*PayOrderResponse)
+}
+
+func (p *OrderServicePayOrderResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *OrderServicePayOrderResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("OrderServicePayOrderResult(%+v)", *p)
+}
+
+var fieldIDToName_OrderServicePayOrderResult = map[int16]string{
+	0: "success",
+}
+
+type OrderServiceCancelOrderArgs struct {
+	Req *CancelOrderRequest `thrift:"req,1" frugal:"1,default,CancelOrderRequest" json:"req"`
+}
+
+func NewOrderServiceCancelOrderArgs() *OrderServiceCancelOrderArgs {
+	return &OrderServiceCancelOrderArgs{}
+}
+
+func (p *OrderServiceCancelOrderArgs) InitDefault() {
+}
+
+var OrderServiceCancelOrderArgs_Req_DEFAULT *CancelOrderRequest
+
+func (p *OrderServiceCancelOrderArgs) GetReq() (v *CancelOrderRequest) {
+	if !p.IsSetReq() {
+		return OrderServiceCancelOrderArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *OrderServiceCancelOrderArgs) SetReq(val *CancelOrderRequest) {
+	p.Req = val
+}
+
+func (p *OrderServiceCancelOrderArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *OrderServiceCancelOrderArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("OrderServiceCancelOrderArgs(%+v)", *p)
+}
+
+var fieldIDToName_OrderServiceCancelOrderArgs = map[int16]string{
+	1: "req",
+}
+
+type OrderServiceCancelOrderResult struct {
+	Success *CancelOrderResponse `thrift:"success,0,optional" frugal:"0,optional,CancelOrderResponse" json:"success,omitempty"`
+}
+
+func NewOrderServiceCancelOrderResult() *OrderServiceCancelOrderResult {
+	return &OrderServiceCancelOrderResult{}
+}
+
+func (p *OrderServiceCancelOrderResult) InitDefault() {
+}
+
+var OrderServiceCancelOrderResult_Success_DEFAULT *CancelOrderResponse
+
+func (p *OrderServiceCancelOrderResult) GetSuccess() (v *CancelOrderResponse) {
+	if !p.IsSetSuccess() {
+		return OrderServiceCancelOrderResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *OrderServiceCancelOrderResult) SetSuccess(x interface{}) {
+	p.Success = x.(*CancelOrderResponse)
+}
+
+func (p *OrderServiceCancelOrderResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *OrderServiceCancelOrderResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("OrderServiceCancelOrderResult(%+v)", *p)
+}
+
+var fieldIDToName_OrderServiceCancelOrderResult = map[int16]string{
+	0: "success",
+}
+
+type OrderServiceGetSeckillStatusArgs struct {
+	Req *GetSeckillStatusRequest `thrift:"req,1" frugal:"1,default,GetSeckillStatusRequest" json:"req"`
+}
+
+func NewOrderServiceGetSeckillStatusArgs() *OrderServiceGetSeckillStatusArgs {
+	return &OrderServiceGetSeckillStatusArgs{}
+}
+
+func (p *OrderServiceGetSeckillStatusArgs) InitDefault() {
+}
+
+var OrderServiceGetSeckillStatusArgs_Req_DEFAULT *GetSeckillStatusRequest
+
+func (p *OrderServiceGetSeckillStatusArgs) GetReq() (v *GetSeckillStatusRequest) {
+	if !p.IsSetReq() {
+		return OrderServiceGetSeckillStatusArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *OrderServiceGetSeckillStatusArgs) SetReq(val *GetSeckillStatusRequest) {
+	p.Req = val
+}
+
+func (p *OrderServiceGetSeckillStatusArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *OrderServiceGetSeckillStatusArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("OrderServiceGetSeckillStatusArgs(%+v)", *p)
+}
+
+var fieldIDToName_OrderServiceGetSeckillStatusArgs = map[int16]string{
+	1: "req",
+}
+
+type OrderServiceGetSeckillStatusResult struct {
+	Success *GetSeckillStatusResponse `thrift:"success,0,optional" frugal:"0,optional,GetSeckillStatusResponse" json:"success,omitempty"`
+}
+
+func NewOrderServiceGetSeckillStatusResult() *OrderServiceGetSeckillStatusResult {
+	return &OrderServiceGetSeckillStatusResult{}
+}
+
+func (p *OrderServiceGetSeckillStatusResult) InitDefault() {
+}
+
+var OrderServiceGetSeckillStatusResult_Success_DEFAULT *GetSeckillStatusResponse
+
+func (p *OrderServiceGetSeckillStatusResult) GetSuccess() (v *GetSeckillStatusResponse) {
+	if !p.IsSetSuccess() {
+		return OrderServiceGetSeckillStatusResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *OrderServiceGetSeckillStatusResult) SetSuccess(x interface{}) {
+	p.Success = x.(*GetSeckillStatusResponse)
+}
+
+func (p *OrderServiceGetSeckillStatusResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *OrderServiceGetSeckillStatusResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("OrderServiceGetSeckillStatusResult(%+v)", *p)
+}
+
+var fieldIDToName_OrderServiceGetSeckillStatusResult = map[int16]string{
+	0: "success",
+}
+
+type OrderServiceSetUserOrdersClosedArgs struct {
+	Req *SetUserOrdersClosedRequest `thrift:"req,1" frugal:"1,default,SetUserOrdersClosedRequest" json:"req"`
+}
+
+func NewOrderServiceSetUserOrdersClosedArgs() *OrderServiceSetUserOrdersClosedArgs {
+	return &OrderServiceSetUserOrdersClosedArgs{}
+}
+
+func (p *OrderServiceSetUserOrdersClosedArgs) InitDefault() {
+}
+
+var OrderServiceSetUserOrdersClosedArgs_Req_DEFAULT *SetUserOrdersClosedRequest
+
+func (p *OrderServiceSetUserOrdersClosedArgs) GetReq() (v *SetUserOrdersClosedRequest) {
+	if !p.IsSetReq() {
+		return OrderServiceSetUserOrdersClosedArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *OrderServiceSetUserOrdersClosedArgs) SetReq(val *SetUserOrdersClosedRequest) {
+	p.Req = val
+}
+
+func (p *OrderServiceSetUserOrdersClosedArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *OrderServiceSetUserOrdersClosedArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("OrderServiceSetUserOrdersClosedArgs(%+v)", *p)
+}
+
+var fieldIDToName_OrderServiceSetUserOrdersClosedArgs = map[int16]string{
+	1: "req",
+}
+
+type OrderServiceSetUserOrdersClosedResult struct {
+	Success *SetUserOrdersClosedResponse `thrift:"success,0,optional" frugal:"0,optional,SetUserOrdersClosedResponse" json:"success,omitempty"`
+}
+
+func NewOrderServiceSetUserOrdersClosedResult() *OrderServiceSetUserOrdersClosedResult {
+	return &OrderServiceSetUserOrdersClosedResult{}
+}
+
+func (p *OrderServiceSetUserOrdersClosedResult) InitDefault() {
+}
+
+var OrderServiceSetUserOrdersClosedResult_Success_DEFAULT *SetUserOrdersClosedResponse
+
+func (p *OrderServiceSetUserOrdersClosedResult) GetSuccess() (v *SetUserOrdersClosedResponse) {
+	if !p.IsSetSuccess() {
+		return OrderServiceSetUserOrdersClosedResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *OrderServiceSetUserOrdersClosedResult) SetSuccess(x interface{}) {
+	p.Success = x.(*SetUserOrdersClosedResponse)
+}
+
+func (p *OrderServiceSetUserOrdersClosedResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *OrderServiceSetUserOrdersClosedResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("OrderServiceSetUserOrdersClosedResult(%+v)", *p)
+}
+
+var fieldIDToName_OrderServiceSetUserOrdersClosedResult = map[int16]string{
+	0: "success",
+}
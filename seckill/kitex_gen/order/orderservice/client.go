@@ -14,6 +14,10 @@ type Client interface {
 	CreateOrder(ctx context.Context, req *order.CreateOrderRequest, callOptions ...callopt.Option) (r *order.CreateOrderResponse, err error)
 	GetOrder(ctx context.Context, req *order.GetOrderRequest, callOptions ...callopt.Option) (r *order.GetOrderResponse, err error)
 	ListOrders(ctx context.Context, req *order.ListOrdersRequest, callOptions ...callopt.Option) (r *order.ListOrdersResponse, err error)
+	PayOrder(ctx context.Context, req *order.PayOrderRequest, callOptions ...callopt.Option) (r *order.PayOrderResponse, err error)
+	CancelOrder(ctx context.Context, req *order.CancelOrderRequest, callOptions ...callopt.Option) (r *order.CancelOrderResponse, err error)
+	GetSeckillStatus(ctx context.Context, req *order.GetSeckillStatusRequest, callOptions ...callopt.Option) (r *order.GetSeckillStatusResponse, err error)
+	SetUserOrdersClosed(ctx context.Context, req *order.SetUserOrdersClosedRequest, callOptions ...callopt.Option) (r *order.SetUserOrdersClosedResponse, err error)
 }
 
 // NewClient creates a client for the service defined in IDL.
@@ -59,3 +63,23 @@ func (p *kOrderServiceClient) ListOrders(ctx context.Context, req *order.ListOrd
 	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
 	return p.kClient.ListOrders(ctx, req)
 }
+
+func (p *kOrderServiceClient) PayOrder(ctx context.Context, req *order.PayOrderRequest, callOptions ...callopt.Option) (r *order.PayOrderResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.PayOrder(ctx, req)
+}
+
+func (p *kOrderServiceClient) CancelOrder(ctx context.Context, req *order.CancelOrderRequest, callOptions ...callopt.Option) (r *order.CancelOrderResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.CancelOrder(ctx, req)
+}
+
+func (p *kOrderServiceClient) GetSeckillStatus(ctx context.Context, req *order.GetSeckillStatusRequest, callOptions ...callopt.Option) (r *order.GetSeckillStatusResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.GetSeckillStatus(ctx, req)
+}
+
+func (p *kOrderServiceClient) SetUserOrdersClosed(ctx context.Context, req *order.SetUserOrdersClosedRequest, callOptions ...callopt.Option) (r *order.SetUserOrdersClosedResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.SetUserOrdersClosed(ctx, req)
+}
@@ -34,6 +34,34 @@ var serviceMethods = map[string]kitex.MethodInfo{
 		false,
 		kitex.WithStreamingMode(kitex.StreamingNone),
 	),
+	"PayOrder": kitex.NewMethodInfo(
+		payOrderHandler,
+		newOrderServicePayOrderArgs,
+		newOrderServicePayOrderResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"CancelOrder": kitex.NewMethodInfo(
+		cancelOrderHandler,
+		newOrderServiceCancelOrderArgs,
+		newOrderServiceCancelOrderResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"GetSeckillStatus": kitex.NewMethodInfo(
+		getSeckillStatusHandler,
+		newOrderServiceGetSeckillStatusArgs,
+		newOrderServiceGetSeckillStatusResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"SetUserOrdersClosed": kitex.NewMethodInfo(
+		setUserOrdersClosedHandler,
+		newOrderServiceSetUserOrdersClosedArgs,
+		newOrderServiceSetUserOrdersClosedResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
 }
 
 var (
@@ -154,6 +182,78 @@ func newOrderServiceListOrdersResult() interface{} {
 	return order.NewOrderServiceListOrdersResult()
 }
 
+func payOrderHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*order.OrderServicePayOrderArgs)
+	realResult := result.(*order.OrderServicePayOrderResult)
+	success, err := handler.(order.OrderService).PayOrder(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newOrderServicePayOrderArgs() interface{} {
+	return order.NewOrderServicePayOrderArgs()
+}
+
+func newOrderServicePayOrderResult() interface{} {
+	return order.NewOrderServicePayOrderResult()
+}
+
+func cancelOrderHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*order.OrderServiceCancelOrderArgs)
+	realResult := result.(*order.OrderServiceCancelOrderResult)
+	success, err := handler.(order.OrderService).CancelOrder(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newOrderServiceCancelOrderArgs() interface{} {
+	return order.NewOrderServiceCancelOrderArgs()
+}
+
+func newOrderServiceCancelOrderResult() interface{} {
+	return order.NewOrderServiceCancelOrderResult()
+}
+
+func getSeckillStatusHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*order.OrderServiceGetSeckillStatusArgs)
+	realResult := result.(*order.OrderServiceGetSeckillStatusResult)
+	success, err := handler.(order.OrderService).GetSeckillStatus(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newOrderServiceGetSeckillStatusArgs() interface{} {
+	return order.NewOrderServiceGetSeckillStatusArgs()
+}
+
+func newOrderServiceGetSeckillStatusResult() interface{} {
+	return order.NewOrderServiceGetSeckillStatusResult()
+}
+
+func setUserOrdersClosedHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*order.OrderServiceSetUserOrdersClosedArgs)
+	realResult := result.(*order.OrderServiceSetUserOrdersClosedResult)
+	success, err := handler.(order.OrderService).SetUserOrdersClosed(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newOrderServiceSetUserOrdersClosedArgs() interface{} {
+	return order.NewOrderServiceSetUserOrdersClosedArgs()
+}
+
+func newOrderServiceSetUserOrdersClosedResult() interface{} {
+	return order.NewOrderServiceSetUserOrdersClosedResult()
+}
+
 type kClient struct {
 	c client.Client
 }
@@ -193,3 +293,43 @@ func (p *kClient) ListOrders(ctx context.Context, req *order.ListOrdersRequest)
 	}
 	return _result.GetSuccess(), nil
 }
+
+func (p *kClient) PayOrder(ctx context.Context, req *order.PayOrderRequest) (r *order.PayOrderResponse, err error) {
+	var _args order.OrderServicePayOrderArgs
+	_args.Req = req
+	var _result order.OrderServicePayOrderResult
+	if err = p.c.Call(ctx, "PayOrder", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) CancelOrder(ctx context.Context, req *order.CancelOrderRequest) (r *order.CancelOrderResponse, err error) {
+	var _args order.OrderServiceCancelOrderArgs
+	_args.Req = req
+	var _result order.OrderServiceCancelOrderResult
+	if err = p.c.Call(ctx, "CancelOrder", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) GetSeckillStatus(ctx context.Context, req *order.GetSeckillStatusRequest) (r *order.GetSeckillStatusResponse, err error) {
+	var _args order.OrderServiceGetSeckillStatusArgs
+	_args.Req = req
+	var _result order.OrderServiceGetSeckillStatusResult
+	if err = p.c.Call(ctx, "GetSeckillStatus", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) SetUserOrdersClosed(ctx context.Context, req *order.SetUserOrdersClosedRequest) (r *order.SetUserOrdersClosedResponse, err error) {
+	var _args order.OrderServiceSetUserOrdersClosedArgs
+	_args.Req = req
+	var _result order.OrderServiceSetUserOrdersClosedResult
+	if err = p.c.Call(ctx, "SetUserOrdersClosed", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
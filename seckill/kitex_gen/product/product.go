@@ -0,0 +1,1404 @@
+// Code generated by thriftgo (0.4.1). DO NOT EDIT.
+
+package product
+
+import (
+	"context"
+	"fmt"
+)
+
+type BaseResponse struct {
+	Code int32  `thrift:"code,1" frugal:"1,default,i32" json:"code"`
+	Msg  string `thrift:"msg,2" frugal:"2,default,string" json:"msg"`
+}
+
+func NewBaseResponse() *BaseResponse {
+	return &BaseResponse{}
+}
+
+func (p *BaseResponse) InitDefault() {
+}
+
+func (p *BaseResponse) GetCode() (v int32) {
+	return p.Code
+}
+
+func (p *BaseResponse) GetMsg() (v string) {
+	return p.Msg
+}
+func (p *BaseResponse) SetCode(val int32) {
+	p.Code = val
+}
+func (p *BaseResponse) SetMsg(val string) {
+	p.Msg = val
+}
+
+func (p *BaseResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("BaseResponse(%+v)", *p)
+}
+
+var fieldIDToName_BaseResponse = map[int16]string{
+	1: "code",
+	2: "msg",
+}
+
+type ProductInfo struct {
+	Id          int64   `thrift:"id,1" frugal:"1,default,i64" json:"id"`
+	Name        string  `thrift:"name,2" frugal:"2,default,string" json:"name"`
+	Description string  `thrift:"description,3" frugal:"3,default,string" json:"description"`
+	Price       float64 `thrift:"price,4" frugal:"4,default,double" json:"price"`
+	ImageUrl    string  `thrift:"imageUrl,5" frugal:"5,default,string" json:"imageUrl"`
+	Category    string  `thrift:"category,6" frugal:"6,default,string" json:"category"`
+	CreateTime  int64   `thrift:"createTime,7" frugal:"7,default,i64" json:"createTime"`
+	MerchantID  int64   `thrift:"merchantID,8" frugal:"8,default,i64" json:"merchantID"`
+}
+
+func NewProductInfo() *ProductInfo {
+	return &ProductInfo{}
+}
+
+func (p *ProductInfo) InitDefault() {
+}
+
+func (p *ProductInfo) GetId() (v int64) {
+	return p.Id
+}
+
+func (p *ProductInfo) GetName() (v string) {
+	return p.Name
+}
+
+func (p *ProductInfo) GetDescription() (v string) {
+	return p.Description
+}
+
+func (p *ProductInfo) GetPrice() (v float64) {
+	return p.Price
+}
+
+func (p *ProductInfo) GetImageUrl() (v string) {
+	return p.ImageUrl
+}
+
+func (p *ProductInfo) GetCategory() (v string) {
+	return p.Category
+}
+
+func (p *ProductInfo) GetCreateTime() (v int64) {
+	return p.CreateTime
+}
+
+func (p *ProductInfo) GetMerchantID() (v int64) {
+	return p.MerchantID
+}
+func (p *ProductInfo) SetId(val int64) {
+	p.Id = val
+}
+func (p *ProductInfo) SetName(val string) {
+	p.Name = val
+}
+func (p *ProductInfo) SetDescription(val string) {
+	p.Description = val
+}
+func (p *ProductInfo) SetPrice(val float64) {
+	p.Price = val
+}
+func (p *ProductInfo) SetImageUrl(val string) {
+	p.ImageUrl = val
+}
+func (p *ProductInfo) SetCategory(val string) {
+	p.Category = val
+}
+func (p *ProductInfo) SetCreateTime(val int64) {
+	p.CreateTime = val
+}
+func (p *ProductInfo) SetMerchantID(val int64) {
+	p.MerchantID = val
+}
+
+func (p *ProductInfo) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ProductInfo(%+v)", *p)
+}
+
+var fieldIDToName_ProductInfo = map[int16]string{
+	1: "id",
+	2: "name",
+	3: "description",
+	4: "price",
+	5: "imageUrl",
+	6: "category",
+	7: "createTime",
+	8: "merchantID",
+}
+
+type CreateProductRequest struct {
+	Name        string  `thrift:"name,1" frugal:"1,default,string" json:"name"`
+	Description string  `thrift:"description,2" frugal:"2,default,string" json:"description"`
+	Price       float64 `thrift:"price,3" frugal:"3,default,double" json:"price"`
+	ImageUrl    string  `thrift:"imageUrl,4" frugal:"4,default,string" json:"imageUrl"`
+	Category    string  `thrift:"category,5" frugal:"5,default,string" json:"category"`
+	MerchantID  int64   `thrift:"merchantID,6" frugal:"6,default,i64" json:"merchantID"`
+}
+
+func NewCreateProductRequest() *CreateProductRequest {
+	return &CreateProductRequest{}
+}
+
+func (p *CreateProductRequest) InitDefault() {
+}
+
+func (p *CreateProductRequest) GetName() (v string) {
+	return p.Name
+}
+
+func (p *CreateProductRequest) GetDescription() (v string) {
+	return p.Description
+}
+
+func (p *CreateProductRequest) GetPrice() (v float64) {
+	return p.Price
+}
+
+func (p *CreateProductRequest) GetImageUrl() (v string) {
+	return p.ImageUrl
+}
+
+func (p *CreateProductRequest) GetCategory() (v string) {
+	return p.Category
+}
+
+func (p *CreateProductRequest) GetMerchantID() (v int64) {
+	return p.MerchantID
+}
+func (p *CreateProductRequest) SetName(val string) {
+	p.Name = val
+}
+func (p *CreateProductRequest) SetDescription(val string) {
+	p.Description = val
+}
+func (p *CreateProductRequest) SetPrice(val float64) {
+	p.Price = val
+}
+func (p *CreateProductRequest) SetImageUrl(val string) {
+	p.ImageUrl = val
+}
+func (p *CreateProductRequest) SetCategory(val string) {
+	p.Category = val
+}
+func (p *CreateProductRequest) SetMerchantID(val int64) {
+	p.MerchantID = val
+}
+
+func (p *CreateProductRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CreateProductRequest(%+v)", *p)
+}
+
+var fieldIDToName_CreateProductRequest = map[int16]string{
+	1: "name",
+	2: "description",
+	3: "price",
+	4: "imageUrl",
+	5: "category",
+	6: "merchantID",
+}
+
+type CreateProductResponse struct {
+	BaseResponse *BaseResponse `thrift:"baseResponse,1" frugal:"1,default,BaseResponse" json:"baseResponse"`
+	ProductID    int64         `thrift:"productID,2" frugal:"2,default,i64" json:"productID"`
+}
+
+func NewCreateProductResponse() *CreateProductResponse {
+	return &CreateProductResponse{}
+}
+
+func (p *CreateProductResponse) InitDefault() {
+}
+
+var CreateProductResponse_BaseResponse_DEFAULT *BaseResponse
+
+func (p *CreateProductResponse) GetBaseResponse() (v *BaseResponse) {
+	if !p.IsSetBaseResponse() {
+		return CreateProductResponse_BaseResponse_DEFAULT
+	}
+	return p.BaseResponse
+}
+
+func (p *CreateProductResponse) GetProductID() (v int64) {
+	return p.ProductID
+}
+func (p *CreateProductResponse) SetBaseResponse(val *BaseResponse) {
+	p.BaseResponse = val
+}
+func (p *CreateProductResponse) SetProductID(val int64) {
+	p.ProductID = val
+}
+
+func (p *CreateProductResponse) IsSetBaseResponse() bool {
+	return p.BaseResponse != nil
+}
+
+func (p *CreateProductResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CreateProductResponse(%+v)", *p)
+}
+
+var fieldIDToName_CreateProductResponse = map[int16]string{
+	1: "baseResponse",
+	2: "productID",
+}
+
+type UpdateProductRequest struct {
+	ProductID   int64    `thrift:"productID,1" frugal:"1,default,i64" json:"productID"`
+	Name        *string  `thrift:"name,2,optional" frugal:"2,optional,string" json:"name,omitempty"`
+	Description *string  `thrift:"description,3,optional" frugal:"3,optional,string" json:"description,omitempty"`
+	Price       *float64 `thrift:"price,4,optional" frugal:"4,optional,double" json:"price,omitempty"`
+	ImageUrl    *string  `thrift:"imageUrl,5,optional" frugal:"5,optional,string" json:"imageUrl,omitempty"`
+	Category    *string  `thrift:"category,6,optional" frugal:"6,optional,string" json:"category,omitempty"`
+}
+
+func NewUpdateProductRequest() *UpdateProductRequest {
+	return &UpdateProductRequest{}
+}
+
+func (p *UpdateProductRequest) InitDefault() {
+}
+
+func (p *UpdateProductRequest) GetProductID() (v int64) {
+	return p.ProductID
+}
+
+var UpdateProductRequest_Name_DEFAULT string
+
+func (p *UpdateProductRequest) GetName() (v string) {
+	if !p.IsSetName() {
+		return UpdateProductRequest_Name_DEFAULT
+	}
+	return *p.Name
+}
+
+var UpdateProductRequest_Description_DEFAULT string
+
+func (p *UpdateProductRequest) GetDescription() (v string) {
+	if !p.IsSetDescription() {
+		return UpdateProductRequest_Description_DEFAULT
+	}
+	return *p.Description
+}
+
+var UpdateProductRequest_Price_DEFAULT float64
+
+func (p *UpdateProductRequest) GetPrice() (v float64) {
+	if !p.IsSetPrice() {
+		return UpdateProductRequest_Price_DEFAULT
+	}
+	return *p.Price
+}
+
+var UpdateProductRequest_ImageUrl_DEFAULT string
+
+func (p *UpdateProductRequest) GetImageUrl() (v string) {
+	if !p.IsSetImageUrl() {
+		return UpdateProductRequest_ImageUrl_DEFAULT
+	}
+	return *p.ImageUrl
+}
+
+var UpdateProductRequest_Category_DEFAULT string
+
+func (p *UpdateProductRequest) GetCategory() (v string) {
+	if !p.IsSetCategory() {
+		return UpdateProductRequest_Category_DEFAULT
+	}
+	return *p.Category
+}
+func (p *UpdateProductRequest) SetProductID(val int64) {
+	p.ProductID = val
+}
+func (p *UpdateProductRequest) SetName(val *string) {
+	p.Name = val
+}
+func (p *UpdateProductRequest) SetDescription(val *string) {
+	p.Description = val
+}
+func (p *UpdateProductRequest) SetPrice(val *float64) {
+	p.Price = val
+}
+func (p *UpdateProductRequest) SetImageUrl(val *string) {
+	p.ImageUrl = val
+}
+func (p *UpdateProductRequest) SetCategory(val *string) {
+	p.Category = val
+}
+
+func (p *UpdateProductRequest) IsSetName() bool {
+	return p.Name != nil
+}
+
+func (p *UpdateProductRequest) IsSetDescription() bool {
+	return p.Description != nil
+}
+
+func (p *UpdateProductRequest) IsSetPrice() bool {
+	return p.Price != nil
+}
+
+func (p *UpdateProductRequest) IsSetImageUrl() bool {
+	return p.ImageUrl != nil
+}
+
+func (p *UpdateProductRequest) IsSetCategory() bool {
+	return p.Category != nil
+}
+
+func (p *UpdateProductRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("UpdateProductRequest(%+v)", *p)
+}
+
+var fieldIDToName_UpdateProductRequest = map[int16]string{
+	1: "productID",
+	2: "name",
+	3: "description",
+	4: "price",
+	5: "imageUrl",
+	6: "category",
+}
+
+type UpdateProductResponse struct {
+	BaseResponse *BaseResponse `thrift:"baseResponse,1" frugal:"1,default,BaseResponse" json:"baseResponse"`
+	Product      *ProductInfo  `thrift:"product,2" frugal:"2,default,ProductInfo" json:"product"`
+}
+
+func NewUpdateProductResponse() *UpdateProductResponse {
+	return &UpdateProductResponse{}
+}
+
+func (p *UpdateProductResponse) InitDefault() {
+}
+
+var UpdateProductResponse_BaseResponse_DEFAULT *BaseResponse
+
+func (p *UpdateProductResponse) GetBaseResponse() (v *BaseResponse) {
+	if !p.IsSetBaseResponse() {
+		return UpdateProductResponse_BaseResponse_DEFAULT
+	}
+	return p.BaseResponse
+}
+
+var UpdateProductResponse_Product_DEFAULT *ProductInfo
+
+func (p *UpdateProductResponse) GetProduct() (v *ProductInfo) {
+	if !p.IsSetProduct() {
+		return UpdateProductResponse_Product_DEFAULT
+	}
+	return p.Product
+}
+func (p *UpdateProductResponse) SetBaseResponse(val *BaseResponse) {
+	p.BaseResponse = val
+}
+func (p *UpdateProductResponse) SetProduct(val *ProductInfo) {
+	p.Product = val
+}
+
+func (p *UpdateProductResponse) IsSetBaseResponse() bool {
+	return p.BaseResponse != nil
+}
+
+func (p *UpdateProductResponse) IsSetProduct() bool {
+	return p.Product != nil
+}
+
+func (p *UpdateProductResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("UpdateProductResponse(%+v)", *p)
+}
+
+var fieldIDToName_UpdateProductResponse = map[int16]string{
+	1: "baseResponse",
+	2: "product",
+}
+
+type GetProductRequest struct {
+	ProductID int64 `thrift:"productID,1" frugal:"1,default,i64" json:"productID"`
+}
+
+func NewGetProductRequest() *GetProductRequest {
+	return &GetProductRequest{}
+}
+
+func (p *GetProductRequest) InitDefault() {
+}
+
+func (p *GetProductRequest) GetProductID() (v int64) {
+	return p.ProductID
+}
+func (p *GetProductRequest) SetProductID(val int64) {
+	p.ProductID = val
+}
+
+func (p *GetProductRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GetProductRequest(%+v)", *p)
+}
+
+var fieldIDToName_GetProductRequest = map[int16]string{
+	1: "productID",
+}
+
+type GetProductResponse struct {
+	BaseResponse *BaseResponse `thrift:"baseResponse,1" frugal:"1,default,BaseResponse" json:"baseResponse"`
+	Product      *ProductInfo  `thrift:"product,2" frugal:"2,default,ProductInfo" json:"product"`
+}
+
+func NewGetProductResponse() *GetProductResponse {
+	return &GetProductResponse{}
+}
+
+func (p *GetProductResponse) InitDefault() {
+}
+
+var GetProductResponse_BaseResponse_DEFAULT *BaseResponse
+
+func (p *GetProductResponse) GetBaseResponse() (v *BaseResponse) {
+	if !p.IsSetBaseResponse() {
+		return GetProductResponse_BaseResponse_DEFAULT
+	}
+	return p.BaseResponse
+}
+
+var GetProductResponse_Product_DEFAULT *ProductInfo
+
+func (p *GetProductResponse) GetProduct() (v *ProductInfo) {
+	if !p.IsSetProduct() {
+		return GetProductResponse_Product_DEFAULT
+	}
+	return p.Product
+}
+func (p *GetProductResponse) SetBaseResponse(val *BaseResponse) {
+	p.BaseResponse = val
+}
+func (p *GetProductResponse) SetProduct(val *ProductInfo) {
+	p.Product = val
+}
+
+func (p *GetProductResponse) IsSetBaseResponse() bool {
+	return p.BaseResponse != nil
+}
+
+func (p *GetProductResponse) IsSetProduct() bool {
+	return p.Product != nil
+}
+
+func (p *GetProductResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GetProductResponse(%+v)", *p)
+}
+
+var fieldIDToName_GetProductResponse = map[int16]string{
+	1: "baseResponse",
+	2: "product",
+}
+
+type ListProductsRequest struct {
+	Category   string `thrift:"category,1" frugal:"1,default,string" json:"category"`
+	Page       int32  `thrift:"page,2" frugal:"2,default,i32" json:"page"`
+	PageSize   int32  `thrift:"pageSize,3" frugal:"3,default,i32" json:"pageSize"`
+	MerchantID int64  `thrift:"merchantID,4" frugal:"4,default,i64" json:"merchantID"`
+}
+
+func NewListProductsRequest() *ListProductsRequest {
+	return &ListProductsRequest{
+
+		Page:     1,
+		PageSize: 20,
+	}
+}
+
+func (p *ListProductsRequest) InitDefault() {
+	p.Page = 1
+	p.PageSize = 20
+}
+
+func (p *ListProductsRequest) GetCategory() (v string) {
+	return p.Category
+}
+
+func (p *ListProductsRequest) GetPage() (v int32) {
+	return p.Page
+}
+
+func (p *ListProductsRequest) GetPageSize() (v int32) {
+	return p.PageSize
+}
+
+func (p *ListProductsRequest) GetMerchantID() (v int64) {
+	return p.MerchantID
+}
+func (p *ListProductsRequest) SetCategory(val string) {
+	p.Category = val
+}
+func (p *ListProductsRequest) SetPage(val int32) {
+	p.Page = val
+}
+func (p *ListProductsRequest) SetPageSize(val int32) {
+	p.PageSize = val
+}
+func (p *ListProductsRequest) SetMerchantID(val int64) {
+	p.MerchantID = val
+}
+
+func (p *ListProductsRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ListProductsRequest(%+v)", *p)
+}
+
+var fieldIDToName_ListProductsRequest = map[int16]string{
+	1: "category",
+	2: "page",
+	3: "pageSize",
+	4: "merchantID",
+}
+
+type ListProductsResponse struct {
+	BaseResponse *BaseResponse  `thrift:"baseResponse,1" frugal:"1,default,BaseResponse" json:"baseResponse"`
+	Products     []*ProductInfo `thrift:"products,2" frugal:"2,default,list<ProductInfo>" json:"products"`
+	Total        int64          `thrift:"total,3" frugal:"3,default,i64" json:"total"`
+}
+
+func NewListProductsResponse() *ListProductsResponse {
+	return &ListProductsResponse{}
+}
+
+func (p *ListProductsResponse) InitDefault() {
+}
+
+var ListProductsResponse_BaseResponse_DEFAULT *BaseResponse
+
+func (p *ListProductsResponse) GetBaseResponse() (v *BaseResponse) {
+	if !p.IsSetBaseResponse() {
+		return ListProductsResponse_BaseResponse_DEFAULT
+	}
+	return p.BaseResponse
+}
+
+func (p *ListProductsResponse) GetProducts() (v []*ProductInfo) {
+	return p.Products
+}
+
+func (p *ListProductsResponse) GetTotal() (v int64) {
+	return p.Total
+}
+func (p *ListProductsResponse) SetBaseResponse(val *BaseResponse) {
+	p.BaseResponse = val
+}
+func (p *ListProductsResponse) SetProducts(val []*ProductInfo) {
+	p.Products = val
+}
+func (p *ListProductsResponse) SetTotal(val int64) {
+	p.Total = val
+}
+
+func (p *ListProductsResponse) IsSetBaseResponse() bool {
+	return p.BaseResponse != nil
+}
+
+func (p *ListProductsResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ListProductsResponse(%+v)", *p)
+}
+
+var fieldIDToName_ListProductsResponse = map[int16]string{
+	1: "baseResponse",
+	2: "products",
+	3: "total",
+}
+
+type DeleteProductRequest struct {
+	ProductID int64 `thrift:"productID,1" frugal:"1,default,i64" json:"productID"`
+}
+
+func NewDeleteProductRequest() *DeleteProductRequest {
+	return &DeleteProductRequest{}
+}
+
+func (p *DeleteProductRequest) InitDefault() {
+}
+
+func (p *DeleteProductRequest) GetProductID() (v int64) {
+	return p.ProductID
+}
+func (p *DeleteProductRequest) SetProductID(val int64) {
+	p.ProductID = val
+}
+
+func (p *DeleteProductRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DeleteProductRequest(%+v)", *p)
+}
+
+var fieldIDToName_DeleteProductRequest = map[int16]string{
+	1: "productID",
+}
+
+type DeleteProductResponse struct {
+	BaseResponse *BaseResponse `thrift:"baseResponse,1" frugal:"1,default,BaseResponse" json:"baseResponse"`
+}
+
+func NewDeleteProductResponse() *DeleteProductResponse {
+	return &DeleteProductResponse{}
+}
+
+func (p *DeleteProductResponse) InitDefault() {
+}
+
+var DeleteProductResponse_BaseResponse_DEFAULT *BaseResponse
+
+func (p *DeleteProductResponse) GetBaseResponse() (v *BaseResponse) {
+	if !p.IsSetBaseResponse() {
+		return DeleteProductResponse_BaseResponse_DEFAULT
+	}
+	return p.BaseResponse
+}
+func (p *DeleteProductResponse) SetBaseResponse(val *BaseResponse) {
+	p.BaseResponse = val
+}
+
+func (p *DeleteProductResponse) IsSetBaseResponse() bool {
+	return p.BaseResponse != nil
+}
+
+func (p *DeleteProductResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DeleteProductResponse(%+v)", *p)
+}
+
+var fieldIDToName_DeleteProductResponse = map[int16]string{
+	1: "baseResponse",
+}
+
+type BatchGetProductsRequest struct {
+	ProductIDs []int64 `thrift:"productIDs,1" frugal:"1,default,list<i64>" json:"productIDs"`
+}
+
+func NewBatchGetProductsRequest() *BatchGetProductsRequest {
+	return &BatchGetProductsRequest{}
+}
+
+func (p *BatchGetProductsRequest) InitDefault() {
+}
+
+func (p *BatchGetProductsRequest) GetProductIDs() (v []int64) {
+	return p.ProductIDs
+}
+func (p *BatchGetProductsRequest) SetProductIDs(val []int64) {
+	p.ProductIDs = val
+}
+
+func (p *BatchGetProductsRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("BatchGetProductsRequest(%+v)", *p)
+}
+
+var fieldIDToName_BatchGetProductsRequest = map[int16]string{
+	1: "productIDs",
+}
+
+type BatchGetProductsResponse struct {
+	BaseResponse *BaseResponse  `thrift:"baseResponse,1" frugal:"1,default,BaseResponse" json:"baseResponse"`
+	Products     []*ProductInfo `thrift:"products,2" frugal:"2,default,list<ProductInfo>" json:"products"`
+}
+
+func NewBatchGetProductsResponse() *BatchGetProductsResponse {
+	return &BatchGetProductsResponse{}
+}
+
+func (p *BatchGetProductsResponse) InitDefault() {
+}
+
+var BatchGetProductsResponse_BaseResponse_DEFAULT *BaseResponse
+
+func (p *BatchGetProductsResponse) GetBaseResponse() (v *BaseResponse) {
+	if !p.IsSetBaseResponse() {
+		return BatchGetProductsResponse_BaseResponse_DEFAULT
+	}
+	return p.BaseResponse
+}
+
+func (p *BatchGetProductsResponse) GetProducts() (v []*ProductInfo) {
+	return p.Products
+}
+func (p *BatchGetProductsResponse) SetBaseResponse(val *BaseResponse) {
+	p.BaseResponse = val
+}
+func (p *BatchGetProductsResponse) SetProducts(val []*ProductInfo) {
+	p.Products = val
+}
+
+func (p *BatchGetProductsResponse) IsSetBaseResponse() bool {
+	return p.BaseResponse != nil
+}
+
+func (p *BatchGetProductsResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("BatchGetProductsResponse(%+v)", *p)
+}
+
+var fieldIDToName_BatchGetProductsResponse = map[int16]string{
+	1: "baseResponse",
+	2: "products",
+}
+
+type SearchProductIDsRequest struct {
+	Name string `thrift:"name,1" frugal:"1,default,string" json:"name"`
+}
+
+func NewSearchProductIDsRequest() *SearchProductIDsRequest {
+	return &SearchProductIDsRequest{}
+}
+
+func (p *SearchProductIDsRequest) InitDefault() {
+}
+
+func (p *SearchProductIDsRequest) GetName() (v string) {
+	return p.Name
+}
+func (p *SearchProductIDsRequest) SetName(val string) {
+	p.Name = val
+}
+
+func (p *SearchProductIDsRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SearchProductIDsRequest(%+v)", *p)
+}
+
+var fieldIDToName_SearchProductIDsRequest = map[int16]string{
+	1: "name",
+}
+
+type SearchProductIDsResponse struct {
+	BaseResponse *BaseResponse `thrift:"baseResponse,1" frugal:"1,default,BaseResponse" json:"baseResponse"`
+	ProductIDs   []int64       `thrift:"productIDs,2" frugal:"2,default,list<i64>" json:"productIDs"`
+}
+
+func NewSearchProductIDsResponse() *SearchProductIDsResponse {
+	return &SearchProductIDsResponse{}
+}
+
+func (p *SearchProductIDsResponse) InitDefault() {
+}
+
+var SearchProductIDsResponse_BaseResponse_DEFAULT *BaseResponse
+
+func (p *SearchProductIDsResponse) GetBaseResponse() (v *BaseResponse) {
+	if !p.IsSetBaseResponse() {
+		return SearchProductIDsResponse_BaseResponse_DEFAULT
+	}
+	return p.BaseResponse
+}
+
+func (p *SearchProductIDsResponse) GetProductIDs() (v []int64) {
+	return p.ProductIDs
+}
+func (p *SearchProductIDsResponse) SetBaseResponse(val *BaseResponse) {
+	p.BaseResponse = val
+}
+func (p *SearchProductIDsResponse) SetProductIDs(val []int64) {
+	p.ProductIDs = val
+}
+
+func (p *SearchProductIDsResponse) IsSetBaseResponse() bool {
+	return p.BaseResponse != nil
+}
+
+func (p *SearchProductIDsResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SearchProductIDsResponse(%+v)", *p)
+}
+
+var fieldIDToName_SearchProductIDsResponse = map[int16]string{
+	1: "baseResponse",
+	2: "productIDs",
+}
+
+type ProductService interface {
+	CreateProduct(ctx context.Context, req *CreateProductRequest) (r *CreateProductResponse, err error)
+
+	UpdateProduct(ctx context.Context, req *UpdateProductRequest) (r *UpdateProductResponse, err error)
+
+	GetProduct(ctx context.Context, req *GetProductRequest) (r *GetProductResponse, err error)
+
+	ListProducts(ctx context.Context, req *ListProductsRequest) (r *ListProductsResponse, err error)
+
+	DeleteProduct(ctx context.Context, req *DeleteProductRequest) (r *DeleteProductResponse, err error)
+
+	BatchGetProducts(ctx context.Context, req *BatchGetProductsRequest) (r *BatchGetProductsResponse, err error)
+
+	SearchProductIDs(ctx context.Context, req *SearchProductIDsRequest) (r *SearchProductIDsResponse, err error)
+}
+
+type ProductServiceCreateProductArgs struct {
+	Req *CreateProductRequest `thrift:"req,1" frugal:"1,default,CreateProductRequest" json:"req"`
+}
+
+func NewProductServiceCreateProductArgs() *ProductServiceCreateProductArgs {
+	return &ProductServiceCreateProductArgs{}
+}
+
+func (p *ProductServiceCreateProductArgs) InitDefault() {
+}
+
+var ProductServiceCreateProductArgs_Req_DEFAULT *CreateProductRequest
+
+func (p *ProductServiceCreateProductArgs) GetReq() (v *CreateProductRequest) {
+	if !p.IsSetReq() {
+		return ProductServiceCreateProductArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *ProductServiceCreateProductArgs) SetReq(val *CreateProductRequest) {
+	p.Req = val
+}
+
+func (p *ProductServiceCreateProductArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *ProductServiceCreateProductArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ProductServiceCreateProductArgs(%+v)", *p)
+}
+
+var fieldIDToName_ProductServiceCreateProductArgs = map[int16]string{
+	1: "req",
+}
+
+type ProductServiceCreateProductResult struct {
+	Success *CreateProductResponse `thrift:"success,0,optional" frugal:"0,optional,CreateProductResponse" json:"success,omitempty"`
+}
+
+func NewProductServiceCreateProductResult() *ProductServiceCreateProductResult {
+	return &ProductServiceCreateProductResult{}
+}
+
+func (p *ProductServiceCreateProductResult) InitDefault() {
+}
+
+var ProductServiceCreateProductResult_Success_DEFAULT *CreateProductResponse
+
+func (p *ProductServiceCreateProductResult) GetSuccess() (v *CreateProductResponse) {
+	if !p.IsSetSuccess() {
+		return ProductServiceCreateProductResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *ProductServiceCreateProductResult) SetSuccess(x interface{}) {
+	p.Success = x.(*CreateProductResponse)
+}
+
+func (p *ProductServiceCreateProductResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *ProductServiceCreateProductResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ProductServiceCreateProductResult(%+v)", *p)
+}
+
+var fieldIDToName_ProductServiceCreateProductResult = map[int16]string{
+	0: "success",
+}
+
+type ProductServiceUpdateProductArgs struct {
+	Req *UpdateProductRequest `thrift:"req,1" frugal:"1,default,UpdateProductRequest" json:"req"`
+}
+
+func NewProductServiceUpdateProductArgs() *ProductServiceUpdateProductArgs {
+	return &ProductServiceUpdateProductArgs{}
+}
+
+func (p *ProductServiceUpdateProductArgs) InitDefault() {
+}
+
+var ProductServiceUpdateProductArgs_Req_DEFAULT *UpdateProductRequest
+
+func (p *ProductServiceUpdateProductArgs) GetReq() (v *UpdateProductRequest) {
+	if !p.IsSetReq() {
+		return ProductServiceUpdateProductArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *ProductServiceUpdateProductArgs) SetReq(val *UpdateProductRequest) {
+	p.Req = val
+}
+
+func (p *ProductServiceUpdateProductArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *ProductServiceUpdateProductArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ProductServiceUpdateProductArgs(%+v)", *p)
+}
+
+var fieldIDToName_ProductServiceUpdateProductArgs = map[int16]string{
+	1: "req",
+}
+
+type ProductServiceUpdateProductResult struct {
+	Success *UpdateProductResponse `thrift:"success,0,optional" frugal:"0,optional,UpdateProductResponse" json:"success,omitempty"`
+}
+
+func NewProductServiceUpdateProductResult() *ProductServiceUpdateProductResult {
+	return &ProductServiceUpdateProductResult{}
+}
+
+func (p *ProductServiceUpdateProductResult) InitDefault() {
+}
+
+var ProductServiceUpdateProductResult_Success_DEFAULT *UpdateProductResponse
+
+func (p *ProductServiceUpdateProductResult) GetSuccess() (v *UpdateProductResponse) {
+	if !p.IsSetSuccess() {
+		return ProductServiceUpdateProductResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *ProductServiceUpdateProductResult) SetSuccess(x interface{}) {
+	p.Success = x.(*UpdateProductResponse)
+}
+
+func (p *ProductServiceUpdateProductResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *ProductServiceUpdateProductResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ProductServiceUpdateProductResult(%+v)", *p)
+}
+
+var fieldIDToName_ProductServiceUpdateProductResult = map[int16]string{
+	0: "success",
+}
+
+type ProductServiceGetProductArgs struct {
+	Req *GetProductRequest `thrift:"req,1" frugal:"1,default,GetProductRequest" json:"req"`
+}
+
+func NewProductServiceGetProductArgs() *ProductServiceGetProductArgs {
+	return &ProductServiceGetProductArgs{}
+}
+
+func (p *ProductServiceGetProductArgs) InitDefault() {
+}
+
+var ProductServiceGetProductArgs_Req_DEFAULT *GetProductRequest
+
+func (p *ProductServiceGetProductArgs) GetReq() (v *GetProductRequest) {
+	if !p.IsSetReq() {
+		return ProductServiceGetProductArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *ProductServiceGetProductArgs) SetReq(val *GetProductRequest) {
+	p.Req = val
+}
+
+func (p *ProductServiceGetProductArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *ProductServiceGetProductArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ProductServiceGetProductArgs(%+v)", *p)
+}
+
+var fieldIDToName_ProductServiceGetProductArgs = map[int16]string{
+	1: "req",
+}
+
+type ProductServiceGetProductResult struct {
+	Success *GetProductResponse `thrift:"success,0,optional" frugal:"0,optional,GetProductResponse" json:"success,omitempty"`
+}
+
+func NewProductServiceGetProductResult() *ProductServiceGetProductResult {
+	return &ProductServiceGetProductResult{}
+}
+
+func (p *ProductServiceGetProductResult) InitDefault() {
+}
+
+var ProductServiceGetProductResult_Success_DEFAULT *GetProductResponse
+
+func (p *ProductServiceGetProductResult) GetSuccess() (v *GetProductResponse) {
+	if !p.IsSetSuccess() {
+		return ProductServiceGetProductResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *ProductServiceGetProductResult) SetSuccess(x interface{}) {
+	p.Success = x.(*GetProductResponse)
+}
+
+func (p *ProductServiceGetProductResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *ProductServiceGetProductResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ProductServiceGetProductResult(%+v)", *p)
+}
+
+var fieldIDToName_ProductServiceGetProductResult = map[int16]string{
+	0: "success",
+}
+
+type ProductServiceListProductsArgs struct {
+	Req *ListProductsRequest `thrift:"req,1" frugal:"1,default,ListProductsRequest" json:"req"`
+}
+
+func NewProductServiceListProductsArgs() *ProductServiceListProductsArgs {
+	return &ProductServiceListProductsArgs{}
+}
+
+func (p *ProductServiceListProductsArgs) InitDefault() {
+}
+
+var ProductServiceListProductsArgs_Req_DEFAULT *ListProductsRequest
+
+func (p *ProductServiceListProductsArgs) GetReq() (v *ListProductsRequest) {
+	if !p.IsSetReq() {
+		return ProductServiceListProductsArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *ProductServiceListProductsArgs) SetReq(val *ListProductsRequest) {
+	p.Req = val
+}
+
+func (p *ProductServiceListProductsArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *ProductServiceListProductsArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ProductServiceListProductsArgs(%+v)", *p)
+}
+
+var fieldIDToName_ProductServiceListProductsArgs = map[int16]string{
+	1: "req",
+}
+
+type ProductServiceListProductsResult struct {
+	Success *ListProductsResponse `thrift:"success,0,optional" frugal:"0,optional,ListProductsResponse" json:"success,omitempty"`
+}
+
+func NewProductServiceListProductsResult() *ProductServiceListProductsResult {
+	return &ProductServiceListProductsResult{}
+}
+
+func (p *ProductServiceListProductsResult) InitDefault() {
+}
+
+var ProductServiceListProductsResult_Success_DEFAULT *ListProductsResponse
+
+func (p *ProductServiceListProductsResult) GetSuccess() (v *ListProductsResponse) {
+	if !p.IsSetSuccess() {
+		return ProductServiceListProductsResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *ProductServiceListProductsResult) SetSuccess(x interface{}) {
+	p.Success = x.(*ListProductsResponse)
+}
+
+func (p *ProductServiceListProductsResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *ProductServiceListProductsResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ProductServiceListProductsResult(%+v)", *p)
+}
+
+var fieldIDToName_ProductServiceListProductsResult = map[int16]string{
+	0: "success",
+}
+
+type ProductServiceDeleteProductArgs struct {
+	Req *DeleteProductRequest `thrift:"req,1" frugal:"1,default,DeleteProductRequest" json:"req"`
+}
+
+func NewProductServiceDeleteProductArgs() *ProductServiceDeleteProductArgs {
+	return &ProductServiceDeleteProductArgs{}
+}
+
+func (p *ProductServiceDeleteProductArgs) InitDefault() {
+}
+
+var ProductServiceDeleteProductArgs_Req_DEFAULT *DeleteProductRequest
+
+func (p *ProductServiceDeleteProductArgs) GetReq() (v *DeleteProductRequest) {
+	if !p.IsSetReq() {
+		return ProductServiceDeleteProductArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *ProductServiceDeleteProductArgs) SetReq(val *DeleteProductRequest) {
+	p.Req = val
+}
+
+func (p *ProductServiceDeleteProductArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *ProductServiceDeleteProductArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ProductServiceDeleteProductArgs(%+v)", *p)
+}
+
+var fieldIDToName_ProductServiceDeleteProductArgs = map[int16]string{
+	1: "req",
+}
+
+type ProductServiceDeleteProductResult struct {
+	Success *DeleteProductResponse `thrift:"success,0,optional" frugal:"0,optional,DeleteProductResponse" json:"success,omitempty"`
+}
+
+func NewProductServiceDeleteProductResult() *ProductServiceDeleteProductResult {
+	return &ProductServiceDeleteProductResult{}
+}
+
+func (p *ProductServiceDeleteProductResult) InitDefault() {
+}
+
+var ProductServiceDeleteProductResult_Success_DEFAULT *DeleteProductResponse
+
+func (p *ProductServiceDeleteProductResult) GetSuccess() (v *DeleteProductResponse) {
+	if !p.IsSetSuccess() {
+		return ProductServiceDeleteProductResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *ProductServiceDeleteProductResult) SetSuccess(x interface{}) {
+	p.Success = x.(*DeleteProductResponse)
+}
+
+func (p *ProductServiceDeleteProductResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *ProductServiceDeleteProductResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ProductServiceDeleteProductResult(%+v)", *p)
+}
+
+var fieldIDToName_ProductServiceDeleteProductResult = map[int16]string{
+	0: "success",
+}
+
+type ProductServiceBatchGetProductsArgs struct {
+	Req *BatchGetProductsRequest `thrift:"req,1" frugal:"1,default,BatchGetProductsRequest" json:"req"`
+}
+
+func NewProductServiceBatchGetProductsArgs() *ProductServiceBatchGetProductsArgs {
+	return &ProductServiceBatchGetProductsArgs{}
+}
+
+func (p *ProductServiceBatchGetProductsArgs) InitDefault() {
+}
+
+var ProductServiceBatchGetProductsArgs_Req_DEFAULT *BatchGetProductsRequest
+
+func (p *ProductServiceBatchGetProductsArgs) GetReq() (v *BatchGetProductsRequest) {
+	if !p.IsSetReq() {
+		return ProductServiceBatchGetProductsArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *ProductServiceBatchGetProductsArgs) SetReq(val *BatchGetProductsRequest) {
+	p.Req = val
+}
+
+func (p *ProductServiceBatchGetProductsArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *ProductServiceBatchGetProductsArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ProductServiceBatchGetProductsArgs(%+v)", *p)
+}
+
+var fieldIDToName_ProductServiceBatchGetProductsArgs = map[int16]string{
+	1: "req",
+}
+
+type ProductServiceBatchGetProductsResult struct {
+	Success *BatchGetProductsResponse `thrift:"success,0,optional" frugal:"0,optional,BatchGetProductsResponse" json:"success,omitempty"`
+}
+
+func NewProductServiceBatchGetProductsResult() *ProductServiceBatchGetProductsResult {
+	return &ProductServiceBatchGetProductsResult{}
+}
+
+func (p *ProductServiceBatchGetProductsResult) InitDefault() {
+}
+
+var ProductServiceBatchGetProductsResult_Success_DEFAULT *BatchGetProductsResponse
+
+func (p *ProductServiceBatchGetProductsResult) GetSuccess() (v *BatchGetProductsResponse) {
+	if !p.IsSetSuccess() {
+		return ProductServiceBatchGetProductsResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *ProductServiceBatchGetProductsResult) SetSuccess(x interface{}) {
+	p.Success = x.(*BatchGetProductsResponse)
+}
+
+func (p *ProductServiceBatchGetProductsResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *ProductServiceBatchGetProductsResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ProductServiceBatchGetProductsResult(%+v)", *p)
+}
+
+var fieldIDToName_ProductServiceBatchGetProductsResult = map[int16]string{
+	0: "success",
+}
+
+type ProductServiceSearchProductIDsArgs struct {
+	Req *SearchProductIDsRequest `thrift:"req,1" frugal:"1,default,SearchProductIDsRequest" json:"req"`
+}
+
+func NewProductServiceSearchProductIDsArgs() *ProductServiceSearchProductIDsArgs {
+	return &ProductServiceSearchProductIDsArgs{}
+}
+
+func (p *ProductServiceSearchProductIDsArgs) InitDefault() {
+}
+
+var ProductServiceSearchProductIDsArgs_Req_DEFAULT *SearchProductIDsRequest
+
+func (p *ProductServiceSearchProductIDsArgs) GetReq() (v *SearchProductIDsRequest) {
+	if !p.IsSetReq() {
+		return ProductServiceSearchProductIDsArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *ProductServiceSearchProductIDsArgs) SetReq(val *SearchProductIDsRequest) {
+	p.Req = val
+}
+
+func (p *ProductServiceSearchProductIDsArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *ProductServiceSearchProductIDsArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ProductServiceSearchProductIDsArgs(%+v)", *p)
+}
+
+var fieldIDToName_ProductServiceSearchProductIDsArgs = map[int16]string{
+	1: "req",
+}
+
+type ProductServiceSearchProductIDsResult struct {
+	Success *SearchProductIDsResponse `thrift:"success,0,optional" frugal:"0,optional,SearchProductIDsResponse" json:"success,omitempty"`
+}
+
+func NewProductServiceSearchProductIDsResult() *ProductServiceSearchProductIDsResult {
+	return &ProductServiceSearchProductIDsResult{}
+}
+
+func (p *ProductServiceSearchProductIDsResult) InitDefault() {
+}
+
+var ProductServiceSearchProductIDsResult_Success_DEFAULT *SearchProductIDsResponse
+
+func (p *ProductServiceSearchProductIDsResult) GetSuccess() (v *SearchProductIDsResponse) {
+	if !p.IsSetSuccess() {
+		return ProductServiceSearchProductIDsResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *ProductServiceSearchProductIDsResult) SetSuccess(x interface{}) {
+	p.Success = x.(*SearchProductIDsResponse)
+}
+
+func (p *ProductServiceSearchProductIDsResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *ProductServiceSearchProductIDsResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ProductServiceSearchProductIDsResult(%+v)", *p)
+}
+
+var fieldIDToName_ProductServiceSearchProductIDsResult = map[int16]string{
+	0: "success",
+}
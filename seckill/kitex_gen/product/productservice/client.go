@@ -0,0 +1,85 @@
+// Code generated by Kitex v0.13.1. DO NOT EDIT.
+
+package productservice
+
+import (
+	product "Redrock/seckill/kitex_gen/product"
+	"context"
+	client "github.com/cloudwego/kitex/client"
+	callopt "github.com/cloudwego/kitex/client/callopt"
+)
+
+// Client is designed to provide IDL-compatible methods with call-option parameter for kitex framework.
+type Client interface {
+	CreateProduct(ctx context.Context, req *product.CreateProductRequest, callOptions ...callopt.Option) (r *product.CreateProductResponse, err error)
+	UpdateProduct(ctx context.Context, req *product.UpdateProductRequest, callOptions ...callopt.Option) (r *product.UpdateProductResponse, err error)
+	GetProduct(ctx context.Context, req *product.GetProductRequest, callOptions ...callopt.Option) (r *product.GetProductResponse, err error)
+	ListProducts(ctx context.Context, req *product.ListProductsRequest, callOptions ...callopt.Option) (r *product.ListProductsResponse, err error)
+	DeleteProduct(ctx context.Context, req *product.DeleteProductRequest, callOptions ...callopt.Option) (r *product.DeleteProductResponse, err error)
+	BatchGetProducts(ctx context.Context, req *product.BatchGetProductsRequest, callOptions ...callopt.Option) (r *product.BatchGetProductsResponse, err error)
+	SearchProductIDs(ctx context.Context, req *product.SearchProductIDsRequest, callOptions ...callopt.Option) (r *product.SearchProductIDsResponse, err error)
+}
+
+// NewClient creates a client for the service defined in IDL.
+func NewClient(destService string, opts ...client.Option) (Client, error) {
+	var options []client.Option
+	options = append(options, client.WithDestService(destService))
+
+	options = append(options, opts...)
+
+	kc, err := client.NewClient(serviceInfoForClient(), options...)
+	if err != nil {
+		return nil, err
+	}
+	return &kProductServiceClient{
+		kClient: newServiceClient(kc),
+	}, nil
+}
+
+// MustNewClient creates a client for the service defined in IDL. It panics if any error occurs.
+func MustNewClient(destService string, opts ...client.Option) Client {
+	kc, err := NewClient(destService, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return kc
+}
+
+type kProductServiceClient struct {
+	*kClient
+}
+
+func (p *kProductServiceClient) CreateProduct(ctx context.Context, req *product.CreateProductRequest, callOptions ...callopt.Option) (r *product.CreateProductResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.CreateProduct(ctx, req)
+}
+
+func (p *kProductServiceClient) UpdateProduct(ctx context.Context, req *product.UpdateProductRequest, callOptions ...callopt.Option) (r *product.UpdateProductResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.UpdateProduct(ctx, req)
+}
+
+func (p *kProductServiceClient) GetProduct(ctx context.Context, req *product.GetProductRequest, callOptions ...callopt.Option) (r *product.GetProductResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.GetProduct(ctx, req)
+}
+
+func (p *kProductServiceClient) ListProducts(ctx context.Context, req *product.ListProductsRequest, callOptions ...callopt.Option) (r *product.ListProductsResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.ListProducts(ctx, req)
+}
+
+func (p *kProductServiceClient) DeleteProduct(ctx context.Context, req *product.DeleteProductRequest, callOptions ...callopt.Option) (r *product.DeleteProductResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.DeleteProduct(ctx, req)
+}
+
+func (p *kProductServiceClient) BatchGetProducts(ctx context.Context, req *product.BatchGetProductsRequest, callOptions ...callopt.Option) (r *product.BatchGetProductsResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.BatchGetProducts(ctx, req)
+}
+
+func (p *kProductServiceClient) SearchProductIDs(ctx context.Context, req *product.SearchProductIDsRequest, callOptions ...callopt.Option) (r *product.SearchProductIDsResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.SearchProductIDs(ctx, req)
+}
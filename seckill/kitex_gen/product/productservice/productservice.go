@@ -0,0 +1,335 @@
+// Code generated by Kitex v0.13.1. DO NOT EDIT.
+
+package productservice
+
+import (
+	product "Redrock/seckill/kitex_gen/product"
+	"context"
+	"errors"
+	client "github.com/cloudwego/kitex/client"
+	kitex "github.com/cloudwego/kitex/pkg/serviceinfo"
+)
+
+var errInvalidMessageType = errors.New("invalid message type for service method handler")
+
+var serviceMethods = map[string]kitex.MethodInfo{
+	"CreateProduct": kitex.NewMethodInfo(
+		createProductHandler,
+		newProductServiceCreateProductArgs,
+		newProductServiceCreateProductResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"UpdateProduct": kitex.NewMethodInfo(
+		updateProductHandler,
+		newProductServiceUpdateProductArgs,
+		newProductServiceUpdateProductResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"GetProduct": kitex.NewMethodInfo(
+		getProductHandler,
+		newProductServiceGetProductArgs,
+		newProductServiceGetProductResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"ListProducts": kitex.NewMethodInfo(
+		listProductsHandler,
+		newProductServiceListProductsArgs,
+		newProductServiceListProductsResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"DeleteProduct": kitex.NewMethodInfo(
+		deleteProductHandler,
+		newProductServiceDeleteProductArgs,
+		newProductServiceDeleteProductResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"BatchGetProducts": kitex.NewMethodInfo(
+		batchGetProductsHandler,
+		newProductServiceBatchGetProductsArgs,
+		newProductServiceBatchGetProductsResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"SearchProductIDs": kitex.NewMethodInfo(
+		searchProductIDsHandler,
+		newProductServiceSearchProductIDsArgs,
+		newProductServiceSearchProductIDsResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+}
+
+var (
+	productServiceServiceInfo                = NewServiceInfo()
+	productServiceServiceInfoForClient       = NewServiceInfoForClient()
+	productServiceServiceInfoForStreamClient = NewServiceInfoForStreamClient()
+)
+
+// for server
+func serviceInfo() *kitex.ServiceInfo {
+	return productServiceServiceInfo
+}
+
+// for stream client
+func serviceInfoForStreamClient() *kitex.ServiceInfo {
+	return productServiceServiceInfoForStreamClient
+}
+
+// for client
+func serviceInfoForClient() *kitex.ServiceInfo {
+	return productServiceServiceInfoForClient
+}
+
+// NewServiceInfo creates a new ServiceInfo containing all methods
+func NewServiceInfo() *kitex.ServiceInfo {
+	return newServiceInfo(false, true, true)
+}
+
+// NewServiceInfo creates a new ServiceInfo containing non-streaming methods
+func NewServiceInfoForClient() *kitex.ServiceInfo {
+	return newServiceInfo(false, false, true)
+}
+func NewServiceInfoForStreamClient() *kitex.ServiceInfo {
+	return newServiceInfo(true, true, false)
+}
+
+func newServiceInfo(hasStreaming bool, keepStreamingMethods bool, keepNonStreamingMethods bool) *kitex.ServiceInfo {
+	serviceName := "ProductService"
+	handlerType := (*product.ProductService)(nil)
+	methods := map[string]kitex.MethodInfo{}
+	for name, m := range serviceMethods {
+		if m.IsStreaming() && !keepStreamingMethods {
+			continue
+		}
+		if !m.IsStreaming() && !keepNonStreamingMethods {
+			continue
+		}
+		methods[name] = m
+	}
+	extra := map[string]interface{}{
+		"PackageName": "product",
+	}
+	if hasStreaming {
+		extra["streaming"] = hasStreaming
+	}
+	svcInfo := &kitex.ServiceInfo{
+		ServiceName:     serviceName,
+		HandlerType:     handlerType,
+		Methods:         methods,
+		PayloadCodec:    kitex.Thrift,
+		KiteXGenVersion: "v0.13.1",
+		Extra:           extra,
+	}
+	return svcInfo
+}
+
+func createProductHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*product.ProductServiceCreateProductArgs)
+	realResult := result.(*product.ProductServiceCreateProductResult)
+	success, err := handler.(product.ProductService).CreateProduct(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newProductServiceCreateProductArgs() interface{} {
+	return product.NewProductServiceCreateProductArgs()
+}
+
+func newProductServiceCreateProductResult() interface{} {
+	return product.NewProductServiceCreateProductResult()
+}
+
+func updateProductHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*product.ProductServiceUpdateProductArgs)
+	realResult := result.(*product.ProductServiceUpdateProductResult)
+	success, err := handler.(product.ProductService).UpdateProduct(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newProductServiceUpdateProductArgs() interface{} {
+	return product.NewProductServiceUpdateProductArgs()
+}
+
+func newProductServiceUpdateProductResult() interface{} {
+	return product.NewProductServiceUpdateProductResult()
+}
+
+func getProductHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*product.ProductServiceGetProductArgs)
+	realResult := result.(*product.ProductServiceGetProductResult)
+	success, err := handler.(product.ProductService).GetProduct(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newProductServiceGetProductArgs() interface{} {
+	return product.NewProductServiceGetProductArgs()
+}
+
+func newProductServiceGetProductResult() interface{} {
+	return product.NewProductServiceGetProductResult()
+}
+
+func listProductsHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*product.ProductServiceListProductsArgs)
+	realResult := result.(*product.ProductServiceListProductsResult)
+	success, err := handler.(product.ProductService).ListProducts(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newProductServiceListProductsArgs() interface{} {
+	return product.NewProductServiceListProductsArgs()
+}
+
+func newProductServiceListProductsResult() interface{} {
+	return product.NewProductServiceListProductsResult()
+}
+
+func deleteProductHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*product.ProductServiceDeleteProductArgs)
+	realResult := result.(*product.ProductServiceDeleteProductResult)
+	success, err := handler.(product.ProductService).DeleteProduct(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newProductServiceDeleteProductArgs() interface{} {
+	return product.NewProductServiceDeleteProductArgs()
+}
+
+func newProductServiceDeleteProductResult() interface{} {
+	return product.NewProductServiceDeleteProductResult()
+}
+
+func batchGetProductsHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*product.ProductServiceBatchGetProductsArgs)
+	realResult := result.(*product.ProductServiceBatchGetProductsResult)
+	success, err := handler.(product.ProductService).BatchGetProducts(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newProductServiceBatchGetProductsArgs() interface{} {
+	return product.NewProductServiceBatchGetProductsArgs()
+}
+
+func newProductServiceBatchGetProductsResult() interface{} {
+	return product.NewProductServiceBatchGetProductsResult()
+}
+
+func searchProductIDsHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*product.ProductServiceSearchProductIDsArgs)
+	realResult := result.(*product.ProductServiceSearchProductIDsResult)
+	success, err := handler.(product.ProductService).SearchProductIDs(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newProductServiceSearchProductIDsArgs() interface{} {
+	return product.NewProductServiceSearchProductIDsArgs()
+}
+
+func newProductServiceSearchProductIDsResult() interface{} {
+	return product.NewProductServiceSearchProductIDsResult()
+}
+
+type kClient struct {
+	c client.Client
+}
+
+func newServiceClient(c client.Client) *kClient {
+	return &kClient{
+		c: c,
+	}
+}
+
+func (p *kClient) CreateProduct(ctx context.Context, req *product.CreateProductRequest) (r *product.CreateProductResponse, err error) {
+	var _args product.ProductServiceCreateProductArgs
+	_args.Req = req
+	var _result product.ProductServiceCreateProductResult
+	if err = p.c.Call(ctx, "CreateProduct", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) UpdateProduct(ctx context.Context, req *product.UpdateProductRequest) (r *product.UpdateProductResponse, err error) {
+	var _args product.ProductServiceUpdateProductArgs
+	_args.Req = req
+	var _result product.ProductServiceUpdateProductResult
+	if err = p.c.Call(ctx, "UpdateProduct", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) GetProduct(ctx context.Context, req *product.GetProductRequest) (r *product.GetProductResponse, err error) {
+	var _args product.ProductServiceGetProductArgs
+	_args.Req = req
+	var _result product.ProductServiceGetProductResult
+	if err = p.c.Call(ctx, "GetProduct", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) ListProducts(ctx context.Context, req *product.ListProductsRequest) (r *product.ListProductsResponse, err error) {
+	var _args product.ProductServiceListProductsArgs
+	_args.Req = req
+	var _result product.ProductServiceListProductsResult
+	if err = p.c.Call(ctx, "ListProducts", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) DeleteProduct(ctx context.Context, req *product.DeleteProductRequest) (r *product.DeleteProductResponse, err error) {
+	var _args product.ProductServiceDeleteProductArgs
+	_args.Req = req
+	var _result product.ProductServiceDeleteProductResult
+	if err = p.c.Call(ctx, "DeleteProduct", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) BatchGetProducts(ctx context.Context, req *product.BatchGetProductsRequest) (r *product.BatchGetProductsResponse, err error) {
+	var _args product.ProductServiceBatchGetProductsArgs
+	_args.Req = req
+	var _result product.ProductServiceBatchGetProductsResult
+	if err = p.c.Call(ctx, "BatchGetProducts", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) SearchProductIDs(ctx context.Context, req *product.SearchProductIDsRequest) (r *product.SearchProductIDsResponse, err error) {
+	var _args product.ProductServiceSearchProductIDsArgs
+	_args.Req = req
+	var _result product.ProductServiceSearchProductIDsResult
+	if err = p.c.Call(ctx, "SearchProductIDs", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
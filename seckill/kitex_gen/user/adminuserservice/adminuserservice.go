@@ -0,0 +1,160 @@
+// Code generated by Kitex v0.13.1. DO NOT EDIT.
+
+package adminuserservice
+
+import (
+	user "Redrock/seckill/kitex_gen/user"
+	"context"
+	"errors"
+	client "github.com/cloudwego/kitex/client"
+	kitex "github.com/cloudwego/kitex/pkg/serviceinfo"
+)
+
+var errInvalidMessageType = errors.New("invalid message type for service method handler")
+
+var serviceMethods = map[string]kitex.MethodInfo{
+	"GrantRole": kitex.NewMethodInfo(
+		grantRoleHandler,
+		newAdminUserServiceGrantRoleArgs,
+		newAdminUserServiceGrantRoleResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"RevokeRole": kitex.NewMethodInfo(
+		revokeRoleHandler,
+		newAdminUserServiceRevokeRoleArgs,
+		newAdminUserServiceRevokeRoleResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+}
+
+var (
+	adminUserServiceServiceInfo                = NewServiceInfo()
+	adminUserServiceServiceInfoForClient       = NewServiceInfoForClient()
+	adminUserServiceServiceInfoForStreamClient = NewServiceInfoForStreamClient()
+)
+
+// for server
+func serviceInfo() *kitex.ServiceInfo {
+	return adminUserServiceServiceInfo
+}
+
+// for stream client
+func serviceInfoForStreamClient() *kitex.ServiceInfo {
+	return adminUserServiceServiceInfoForStreamClient
+}
+
+// for client
+func serviceInfoForClient() *kitex.ServiceInfo {
+	return adminUserServiceServiceInfoForClient
+}
+
+// NewServiceInfo creates a new ServiceInfo containing all methods
+func NewServiceInfo() *kitex.ServiceInfo {
+	return newServiceInfo(false, true, true)
+}
+
+// NewServiceInfo creates a new ServiceInfo containing non-streaming methods
+func NewServiceInfoForClient() *kitex.ServiceInfo {
+	return newServiceInfo(false, false, true)
+}
+func NewServiceInfoForStreamClient() *kitex.ServiceInfo {
+	return newServiceInfo(true, true, false)
+}
+
+func newServiceInfo(hasStreaming bool, keepStreamingMethods bool, keepNonStreamingMethods bool) *kitex.ServiceInfo {
+	serviceName := "AdminUserService"
+	handlerType := (*user.AdminUserService)(nil)
+	methods := map[string]kitex.MethodInfo{}
+	for name, m := range serviceMethods {
+		if m.IsStreaming() && !keepStreamingMethods {
+			continue
+		}
+		if !m.IsStreaming() && !keepNonStreamingMethods {
+			continue
+		}
+		methods[name] = m
+	}
+	extra := map[string]interface{}{
+		"PackageName": "user",
+	}
+	if hasStreaming {
+		extra["streaming"] = hasStreaming
+	}
+	svcInfo := &kitex.ServiceInfo{
+		ServiceName:     serviceName,
+		HandlerType:     handlerType,
+		Methods:         methods,
+		PayloadCodec:    kitex.Thrift,
+		KiteXGenVersion: "v0.13.1",
+		Extra:           extra,
+	}
+	return svcInfo
+}
+
+func grantRoleHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*user.AdminUserServiceGrantRoleArgs)
+	realResult := result.(*user.AdminUserServiceGrantRoleResult)
+	success, err := handler.(user.AdminUserService).GrantRole(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newAdminUserServiceGrantRoleArgs() interface{} {
+	return user.NewAdminUserServiceGrantRoleArgs()
+}
+
+func newAdminUserServiceGrantRoleResult() interface{} {
+	return user.NewAdminUserServiceGrantRoleResult()
+}
+
+func revokeRoleHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*user.AdminUserServiceRevokeRoleArgs)
+	realResult := result.(*user.AdminUserServiceRevokeRoleResult)
+	success, err := handler.(user.AdminUserService).RevokeRole(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newAdminUserServiceRevokeRoleArgs() interface{} {
+	return user.NewAdminUserServiceRevokeRoleArgs()
+}
+
+func newAdminUserServiceRevokeRoleResult() interface{} {
+	return user.NewAdminUserServiceRevokeRoleResult()
+}
+
+type kClient struct {
+	c client.Client
+}
+
+func newServiceClient(c client.Client) *kClient {
+	return &kClient{
+		c: c,
+	}
+}
+
+func (p *kClient) GrantRole(ctx context.Context, req *user.GrantRoleRequest) (r *user.GrantRoleResponse, err error) {
+	var _args user.AdminUserServiceGrantRoleArgs
+	_args.Req = req
+	var _result user.AdminUserServiceGrantRoleResult
+	if err = p.c.Call(ctx, "GrantRole", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) RevokeRole(ctx context.Context, req *user.RevokeRoleRequest) (r *user.RevokeRoleResponse, err error) {
+	var _args user.AdminUserServiceRevokeRoleArgs
+	_args.Req = req
+	var _result user.AdminUserServiceRevokeRoleResult
+	if err = p.c.Call(ctx, "RevokeRole", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
@@ -0,0 +1,55 @@
+// Code generated by Kitex v0.13.1. DO NOT EDIT.
+
+package adminuserservice
+
+import (
+	user "Redrock/seckill/kitex_gen/user"
+	"context"
+	client "github.com/cloudwego/kitex/client"
+	callopt "github.com/cloudwego/kitex/client/callopt"
+)
+
+// Client is designed to provide IDL-compatible methods with call-option parameter for kitex framework.
+type Client interface {
+	GrantRole(ctx context.Context, req *user.GrantRoleRequest, callOptions ...callopt.Option) (r *user.GrantRoleResponse, err error)
+	RevokeRole(ctx context.Context, req *user.RevokeRoleRequest, callOptions ...callopt.Option) (r *user.RevokeRoleResponse, err error)
+}
+
+// NewClient creates a client for the service defined in IDL.
+func NewClient(destService string, opts ...client.Option) (Client, error) {
+	var options []client.Option
+	options = append(options, client.WithDestService(destService))
+
+	options = append(options, opts...)
+
+	kc, err := client.NewClient(serviceInfoForClient(), options...)
+	if err != nil {
+		return nil, err
+	}
+	return &kAdminUserServiceClient{
+		kClient: newServiceClient(kc),
+	}, nil
+}
+
+// MustNewClient creates a client for the service defined in IDL. It panics if any error occurs.
+func MustNewClient(destService string, opts ...client.Option) Client {
+	kc, err := NewClient(destService, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return kc
+}
+
+type kAdminUserServiceClient struct {
+	*kClient
+}
+
+func (p *kAdminUserServiceClient) GrantRole(ctx context.Context, req *user.GrantRoleRequest, callOptions ...callopt.Option) (r *user.GrantRoleResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.GrantRole(ctx, req)
+}
+
+func (p *kAdminUserServiceClient) RevokeRole(ctx context.Context, req *user.RevokeRoleRequest, callOptions ...callopt.Option) (r *user.RevokeRoleResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.RevokeRole(ctx, req)
+}
@@ -0,0 +1,25 @@
+// Code generated by Kitex v0.13.1. DO NOT EDIT.
+package adminuserservice
+
+import (
+	user "Redrock/seckill/kitex_gen/user"
+	server "github.com/cloudwego/kitex/server"
+)
+
+// NewServer creates a server.Server with the given handler and options.
+func NewServer(handler user.AdminUserService, opts ...server.Option) server.Server {
+	var options []server.Option
+
+	options = append(options, opts...)
+	options = append(options, server.WithCompatibleMiddlewareForUnary())
+
+	svr := server.NewServer(options...)
+	if err := svr.RegisterService(serviceInfo(), handler); err != nil {
+		panic(err)
+	}
+	return svr
+}
+
+func RegisterService(svr server.Server, handler user.AdminUserService, opts ...server.RegisterOption) error {
+	return svr.RegisterService(serviceInfo(), handler, opts...)
+}
@@ -48,6 +48,7 @@ var fieldIDToName_BaseResp = map[int16]string{
 type UserInfo struct {
 	Id       int64  `thrift:"id,1" frugal:"1,default,i64" json:"id"`
 	Username string `thrift:"username,2" frugal:"2,default,string" json:"username"`
+	Role     string `thrift:"role,3" frugal:"3,default,string" json:"role"`
 }
 
 func NewUserInfo() *UserInfo {
@@ -64,12 +65,19 @@ func (p *UserInfo) GetId() (v int64) {
 func (p *UserInfo) GetUsername() (v string) {
 	return p.Username
 }
+
+func (p *UserInfo) GetRole() (v string) {
+	return p.Role
+}
 func (p *UserInfo) SetId(val int64) {
 	p.Id = val
 }
 func (p *UserInfo) SetUsername(val string) {
 	p.Username = val
 }
+func (p *UserInfo) SetRole(val string) {
+	p.Role = val
+}
 
 func (p *UserInfo) String() string {
 	if p == nil {
@@ -81,6 +89,7 @@ func (p *UserInfo) String() string {
 var fieldIDToName_UserInfo = map[int16]string{
 	1: "id",
 	2: "username",
+	3: "role",
 }
 
 type RegisterRequest struct {
@@ -207,8 +216,11 @@ var fieldIDToName_LoginRequest = map[int16]string{
 }
 
 type LoginResponse struct {
-	BaseResp *BaseResp `thrift:"baseResp,1" frugal:"1,default,BaseResp" json:"baseResp"`
-	UserId   int64     `thrift:"userId,2" frugal:"2,default,i64" json:"userId"`
+	BaseResp    *BaseResp `thrift:"baseResp,1" frugal:"1,default,BaseResp" json:"baseResp"`
+	UserId      int64     `thrift:"userId,2" frugal:"2,default,i64" json:"userId"`
+	AccessToken string    `thrift:"accessToken,3" frugal:"3,default,string" json:"accessToken"`
+	ExpiresAt   int64     `thrift:"expiresAt,4" frugal:"4,default,i64" json:"expiresAt"`
+	Role        string    `thrift:"role,5" frugal:"5,default,string" json:"role"`
 }
 
 func NewLoginResponse() *LoginResponse {
@@ -230,12 +242,33 @@ func (p *LoginResponse) GetBaseResp() (v *BaseResp) {
 func (p *LoginResponse) GetUserId() (v int64) {
 	return p.UserId
 }
+
+func (p *LoginResponse) GetAccessToken() (v string) {
+	return p.AccessToken
+}
+
+func (p *LoginResponse) GetExpiresAt() (v int64) {
+	return p.ExpiresAt
+}
+
+func (p *LoginResponse) GetRole() (v string) {
+	return p.Role
+}
 func (p *LoginResponse) SetBaseResp(val *BaseResp) {
 	p.BaseResp = val
 }
 func (p *LoginResponse) SetUserId(val int64) {
 	p.UserId = val
 }
+func (p *LoginResponse) SetAccessToken(val string) {
+	p.AccessToken = val
+}
+func (p *LoginResponse) SetExpiresAt(val int64) {
+	p.ExpiresAt = val
+}
+func (p *LoginResponse) SetRole(val string) {
+	p.Role = val
+}
 
 func (p *LoginResponse) IsSetBaseResp() bool {
 	return p.BaseResp != nil
@@ -251,162 +284,1145 @@ func (p *LoginResponse) String() string {
 var fieldIDToName_LoginResponse = map[int16]string{
 	1: "baseResp",
 	2: "userId",
+	3: "accessToken",
+	4: "expiresAt",
+	5: "role",
 }
 
-type UserService interface {
-	Register(ctx context.Context, req *RegisterRequest) (r *RegisterResponse, err error)
+type GetUserInfoRequest struct {
+	AccessToken string `thrift:"accessToken,1" frugal:"1,default,string" json:"accessToken"`
+}
 
-	Login(ctx context.Context, req *LoginRequest) (r *LoginResponse, err error)
+func NewGetUserInfoRequest() *GetUserInfoRequest {
+	return &GetUserInfoRequest{}
 }
 
-type UserServiceRegisterArgs struct {
-	Req *RegisterRequest `thrift:"req,1" frugal:"1,default,RegisterRequest" json:"req"`
+func (p *GetUserInfoRequest) InitDefault() {
 }
 
-func NewUserServiceRegisterArgs() *UserServiceRegisterArgs {
-	return &UserServiceRegisterArgs{}
+func (p *GetUserInfoRequest) GetAccessToken() (v string) {
+	return p.AccessToken
+}
+func (p *GetUserInfoRequest) SetAccessToken(val string) {
+	p.AccessToken = val
 }
 
-func (p *UserServiceRegisterArgs) InitDefault() {
+func (p *GetUserInfoRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GetUserInfoRequest(%+v)", *p)
 }
 
-var UserServiceRegisterArgs_Req_DEFAULT *RegisterRequest
+var fieldIDToName_GetUserInfoRequest = map[int16]string{
+	1: "accessToken",
+}
 
-func (p *UserServiceRegisterArgs) GetReq() (v *RegisterRequest) {
-	if !p.IsSetReq() {
-		return UserServiceRegisterArgs_Req_DEFAULT
+type GetUserInfoResponse struct {
+	BaseResp *BaseResp `thrift:"baseResp,1" frugal:"1,default,BaseResp" json:"baseResp"`
+	User     *UserInfo `thrift:"user,2" frugal:"2,default,UserInfo" json:"user"`
+}
+
+func NewGetUserInfoResponse() *GetUserInfoResponse {
+	return &GetUserInfoResponse{}
+}
+
+func (p *GetUserInfoResponse) InitDefault() {
+}
+
+var GetUserInfoResponse_BaseResp_DEFAULT *BaseResp
+
+func (p *GetUserInfoResponse) GetBaseResp() (v *BaseResp) {
+	if !p.IsSetBaseResp() {
+		return GetUserInfoResponse_BaseResp_DEFAULT
 	}
-	return p.Req
+	return p.BaseResp
 }
-func (p *UserServiceRegisterArgs) SetReq(val *RegisterRequest) {
-	p.Req = val
+
+var GetUserInfoResponse_User_DEFAULT *UserInfo
+
+func (p *GetUserInfoResponse) GetUser() (v *UserInfo) {
+	if !p.IsSetUser() {
+		return GetUserInfoResponse_User_DEFAULT
+	}
+	return p.User
+}
+func (p *GetUserInfoResponse) SetBaseResp(val *BaseResp) {
+	p.BaseResp = val
+}
+func (p *GetUserInfoResponse) SetUser(val *UserInfo) {
+	p.User = val
 }
 
-func (p *UserServiceRegisterArgs) IsSetReq() bool {
-	return p.Req != nil
+func (p *GetUserInfoResponse) IsSetBaseResp() bool {
+	return p.BaseResp != nil
 }
 
-func (p *UserServiceRegisterArgs) String() string {
+func (p *GetUserInfoResponse) IsSetUser() bool {
+	return p.User != nil
+}
+
+func (p *GetUserInfoResponse) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("UserServiceRegisterArgs(%+v)", *p)
+	return fmt.Sprintf("GetUserInfoResponse(%+v)", *p)
 }
 
-var fieldIDToName_UserServiceRegisterArgs = map[int16]string{
-	1: "req",
+var fieldIDToName_GetUserInfoResponse = map[int16]string{
+	1: "baseResp",
+	2: "user",
 }
 
-type UserServiceRegisterResult struct {
-	Success *RegisterResponse `thrift:"success,0,optional" frugal:"0,optional,RegisterResponse" json:"success,omitempty"`
+type UpdatePasswordRequest struct {
+	AccessToken string `thrift:"accessToken,1" frugal:"1,default,string" json:"accessToken"`
+	OldPassword string `thrift:"oldPassword,2" frugal:"2,default,string" json:"oldPassword"`
+	Password    string `thrift:"password,3" frugal:"3,default,string" json:"password"`
 }
 
-func NewUserServiceRegisterResult() *UserServiceRegisterResult {
-	return &UserServiceRegisterResult{}
+func NewUpdatePasswordRequest() *UpdatePasswordRequest {
+	return &UpdatePasswordRequest{}
 }
 
-func (p *UserServiceRegisterResult) InitDefault() {
+func (p *UpdatePasswordRequest) InitDefault() {
 }
 
-var UserServiceRegisterResult_Success_DEFAULT *RegisterResponse
+func (p *UpdatePasswordRequest) GetAccessToken() (v string) {
+	return p.AccessToken
+}
 
-func (p *UserServiceRegisterResult) GetSuccess() (v *RegisterResponse) {
-	if !p.IsSetSuccess() {
-		return UserServiceRegisterResult_Success_DEFAULT
+func (p *UpdatePasswordRequest) GetOldPassword() (v string) {
+	return p.OldPassword
+}
+
+func (p *UpdatePasswordRequest) GetPassword() (v string) {
+	return p.Password
+}
+func (p *UpdatePasswordRequest) SetAccessToken(val string) {
+	p.AccessToken = val
+}
+func (p *UpdatePasswordRequest) SetOldPassword(val string) {
+	p.OldPassword = val
+}
+func (p *UpdatePasswordRequest) SetPassword(val string) {
+	p.Password = val
+}
+
+func (p *UpdatePasswordRequest) String() string {
+	if p == nil {
+		return "<nil>"
 	}
-	return p.Success
+	return fmt.Sprintf("UpdatePasswordRequest(%+v)", *p)
 }
-func (p *UserServiceRegisterResult) SetSuccess(x interface{}) {
-	p.Success = x.(*RegisterResponse)
+
+var fieldIDToName_UpdatePasswordRequest = map[int16]string{
+	1: "accessToken",
+	2: "oldPassword",
+	3: "password",
 }
 
-func (p *UserServiceRegisterResult) IsSetSuccess() bool {
-	return p.Success != nil
+type UpdatePasswordResponse struct {
+	BaseResp *BaseResp `thrift:"baseResp,1" frugal:"1,default,BaseResp" json:"baseResp"`
 }
 
-func (p *UserServiceRegisterResult) String() string {
+func NewUpdatePasswordResponse() *UpdatePasswordResponse {
+	return &UpdatePasswordResponse{}
+}
+
+func (p *UpdatePasswordResponse) InitDefault() {
+}
+
+var UpdatePasswordResponse_BaseResp_DEFAULT *BaseResp
+
+func (p *UpdatePasswordResponse) GetBaseResp() (v *BaseResp) {
+	if !p.IsSetBaseResp() {
+		return UpdatePasswordResponse_BaseResp_DEFAULT
+	}
+	return p.BaseResp
+}
+func (p *UpdatePasswordResponse) SetBaseResp(val *BaseResp) {
+	p.BaseResp = val
+}
+
+func (p *UpdatePasswordResponse) IsSetBaseResp() bool {
+	return p.BaseResp != nil
+}
+
+func (p *UpdatePasswordResponse) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("UserServiceRegisterResult(%+v)", *p)
+	return fmt.Sprintf("UpdatePasswordResponse(%+v)", *p)
 }
 
-var fieldIDToName_UserServiceRegisterResult = map[int16]string{
-	0: "success",
+var fieldIDToName_UpdatePasswordResponse = map[int16]string{
+	1: "baseResp",
 }
 
-type UserServiceLoginArgs struct {
-	Req *LoginRequest `thrift:"req,1" frugal:"1,default,LoginRequest" json:"req"`
+type LogoutRequest struct {
+	AccessToken string `thrift:"accessToken,1" frugal:"1,default,string" json:"accessToken"`
 }
 
-func NewUserServiceLoginArgs() *UserServiceLoginArgs {
-	return &UserServiceLoginArgs{}
+func NewLogoutRequest() *LogoutRequest {
+	return &LogoutRequest{}
 }
 
-func (p *UserServiceLoginArgs) InitDefault() {
+func (p *LogoutRequest) InitDefault() {
 }
 
-var UserServiceLoginArgs_Req_DEFAULT *LoginRequest
+func (p *LogoutRequest) GetAccessToken() (v string) {
+	return p.AccessToken
+}
+func (p *LogoutRequest) SetAccessToken(val string) {
+	p.AccessToken = val
+}
 
-func (p *UserServiceLoginArgs) GetReq() (v *LoginRequest) {
-	if !p.IsSetReq() {
-		return UserServiceLoginArgs_Req_DEFAULT
+func (p *LogoutRequest) String() string {
+	if p == nil {
+		return "<nil>"
 	}
-	return p.Req
+	return fmt.Sprintf("LogoutRequest(%+v)", *p)
 }
-func (p *UserServiceLoginArgs) SetReq(val *LoginRequest) {
-	p.Req = val
+
+var fieldIDToName_LogoutRequest = map[int16]string{
+	1: "accessToken",
 }
 
-func (p *UserServiceLoginArgs) IsSetReq() bool {
-	return p.Req != nil
+type LogoutResponse struct {
+	BaseResp *BaseResp `thrift:"baseResp,1" frugal:"1,default,BaseResp" json:"baseResp"`
 }
 
-func (p *UserServiceLoginArgs) String() string {
+func NewLogoutResponse() *LogoutResponse {
+	return &LogoutResponse{}
+}
+
+func (p *LogoutResponse) InitDefault() {
+}
+
+var LogoutResponse_BaseResp_DEFAULT *BaseResp
+
+func (p *LogoutResponse) GetBaseResp() (v *BaseResp) {
+	if !p.IsSetBaseResp() {
+		return LogoutResponse_BaseResp_DEFAULT
+	}
+	return p.BaseResp
+}
+func (p *LogoutResponse) SetBaseResp(val *BaseResp) {
+	p.BaseResp = val
+}
+
+func (p *LogoutResponse) IsSetBaseResp() bool {
+	return p.BaseResp != nil
+}
+
+func (p *LogoutResponse) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("UserServiceLoginArgs(%+v)", *p)
+	return fmt.Sprintf("LogoutResponse(%+v)", *p)
 }
 
-var fieldIDToName_UserServiceLoginArgs = map[int16]string{
-	1: "req",
+var fieldIDToName_LogoutResponse = map[int16]string{
+	1: "baseResp",
 }
 
-type UserServiceLoginResult struct {
-	Success *LoginResponse `thrift:"success,0,optional" frugal:"0,optional,LoginResponse" json:"success,omitempty"`
+type DeleteAccountRequest struct {
+	AccessToken string `thrift:"accessToken,1" frugal:"1,default,string" json:"accessToken"`
+	Password    string `thrift:"password,2" frugal:"2,default,string" json:"password"`
 }
 
-func NewUserServiceLoginResult() *UserServiceLoginResult {
-	return &UserServiceLoginResult{}
+func NewDeleteAccountRequest() *DeleteAccountRequest {
+	return &DeleteAccountRequest{}
 }
 
-func (p *UserServiceLoginResult) InitDefault() {
+func (p *DeleteAccountRequest) InitDefault() {
 }
 
-var UserServiceLoginResult_Success_DEFAULT *LoginResponse
+func (p *DeleteAccountRequest) GetAccessToken() (v string) {
+	return p.AccessToken
+}
 
-func (p *UserServiceLoginResult) GetSuccess() (v *LoginResponse) {
-	if !p.IsSetSuccess() {
-		return UserServiceLoginResult_Success_DEFAULT
+func (p *DeleteAccountRequest) GetPassword() (v string) {
+	return p.Password
+}
+func (p *DeleteAccountRequest) SetAccessToken(val string) {
+	p.AccessToken = val
+}
+func (p *DeleteAccountRequest) SetPassword(val string) {
+	p.Password = val
+}
+
+func (p *DeleteAccountRequest) String() string {
+	if p == nil {
+		return "<nil>"
 	}
-	return p.Success
+	return fmt.Sprintf("DeleteAccountRequest(%+v)", *p)
 }
-func (p *UserServiceLoginResult) SetSuccess(x interface{}) {
-	p.Success = x.(*LoginResponse)
+
+var fieldIDToName_DeleteAccountRequest = map[int16]string{
+	1: "accessToken",
+	2: "password",
 }
 
-func (p *UserServiceLoginResult) IsSetSuccess() bool {
-	return p.Success != nil
+type DeleteAccountResponse struct {
+	BaseResp        *BaseResp `thrift:"baseResp,1" frugal:"1,default,BaseResp" json:"baseResp"`
+	CancelledOrders int32     `thrift:"cancelledOrders,2" frugal:"2,default,i32" json:"cancelledOrders"`
 }
 
-func (p *UserServiceLoginResult) String() string {
+func NewDeleteAccountResponse() *DeleteAccountResponse {
+	return &DeleteAccountResponse{}
+}
+
+func (p *DeleteAccountResponse) InitDefault() {
+}
+
+var DeleteAccountResponse_BaseResp_DEFAULT *BaseResp
+
+func (p *DeleteAccountResponse) GetBaseResp() (v *BaseResp) {
+	if !p.IsSetBaseResp() {
+		return DeleteAccountResponse_BaseResp_DEFAULT
+	}
+	return p.BaseResp
+}
+
+func (p *DeleteAccountResponse) GetCancelledOrders() (v int32) {
+	return p.CancelledOrders
+}
+func (p *DeleteAccountResponse) SetBaseResp(val *BaseResp) {
+	p.BaseResp = val
+}
+func (p *DeleteAccountResponse) SetCancelledOrders(val int32) {
+	p.CancelledOrders = val
+}
+
+func (p *DeleteAccountResponse) IsSetBaseResp() bool {
+	return p.BaseResp != nil
+}
+
+func (p *DeleteAccountResponse) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("UserServiceLoginResult(%+v)", *p)
+	return fmt.Sprintf("DeleteAccountResponse(%+v)", *p)
 }
 
-var fieldIDToName_UserServiceLoginResult = map[int16]string{
+var fieldIDToName_DeleteAccountResponse = map[int16]string{
+	1: "baseResp",
+	2: "cancelledOrders",
+}
+
+type GrantRoleRequest struct {
+	UserId int64  `thrift:"userId,1" frugal:"1,default,i64" json:"userId"`
+	Role   string `thrift:"role,2" frugal:"2,default,string" json:"role"`
+}
+
+func NewGrantRoleRequest() *GrantRoleRequest {
+	return &GrantRoleRequest{}
+}
+
+func (p *GrantRoleRequest) InitDefault() {
+}
+
+func (p *GrantRoleRequest) GetUserId() (v int64) {
+	return p.UserId
+}
+
+func (p *GrantRoleRequest) GetRole() (v string) {
+	return p.Role
+}
+func (p *GrantRoleRequest) SetUserId(val int64) {
+	p.UserId = val
+}
+func (p *GrantRoleRequest) SetRole(val string) {
+	p.Role = val
+}
+
+func (p *GrantRoleRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GrantRoleRequest(%+v)", *p)
+}
+
+var fieldIDToName_GrantRoleRequest = map[int16]string{
+	1: "userId",
+	2: "role",
+}
+
+type GrantRoleResponse struct {
+	BaseResp *BaseResp `thrift:"baseResp,1" frugal:"1,default,BaseResp" json:"baseResp"`
+	User     *UserInfo `thrift:"user,2" frugal:"2,default,UserInfo" json:"user"`
+}
+
+func NewGrantRoleResponse() *GrantRoleResponse {
+	return &GrantRoleResponse{}
+}
+
+func (p *GrantRoleResponse) InitDefault() {
+}
+
+var GrantRoleResponse_BaseResp_DEFAULT *BaseResp
+
+func (p *GrantRoleResponse) GetBaseResp() (v *BaseResp) {
+	if !p.IsSetBaseResp() {
+		return GrantRoleResponse_BaseResp_DEFAULT
+	}
+	return p.BaseResp
+}
+
+var GrantRoleResponse_User_DEFAULT *UserInfo
+
+func (p *GrantRoleResponse) GetUser() (v *UserInfo) {
+	if !p.IsSetUser() {
+		return GrantRoleResponse_User_DEFAULT
+	}
+	return p.User
+}
+func (p *GrantRoleResponse) SetBaseResp(val *BaseResp) {
+	p.BaseResp = val
+}
+func (p *GrantRoleResponse) SetUser(val *UserInfo) {
+	p.User = val
+}
+
+func (p *GrantRoleResponse) IsSetBaseResp() bool {
+	return p.BaseResp != nil
+}
+
+func (p *GrantRoleResponse) IsSetUser() bool {
+	return p.User != nil
+}
+
+func (p *GrantRoleResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GrantRoleResponse(%+v)", *p)
+}
+
+var fieldIDToName_GrantRoleResponse = map[int16]string{
+	1: "baseResp",
+	2: "user",
+}
+
+type RevokeRoleRequest struct {
+	UserId int64  `thrift:"userId,1" frugal:"1,default,i64" json:"userId"`
+	Role   string `thrift:"role,2" frugal:"2,default,string" json:"role"`
+}
+
+func NewRevokeRoleRequest() *RevokeRoleRequest {
+	return &RevokeRoleRequest{}
+}
+
+func (p *RevokeRoleRequest) InitDefault() {
+}
+
+func (p *RevokeRoleRequest) GetUserId() (v int64) {
+	return p.UserId
+}
+
+func (p *RevokeRoleRequest) GetRole() (v string) {
+	return p.Role
+}
+func (p *RevokeRoleRequest) SetUserId(val int64) {
+	p.UserId = val
+}
+func (p *RevokeRoleRequest) SetRole(val string) {
+	p.Role = val
+}
+
+func (p *RevokeRoleRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("RevokeRoleRequest(%+v)", *p)
+}
+
+var fieldIDToName_RevokeRoleRequest = map[int16]string{
+	1: "userId",
+	2: "role",
+}
+
+type RevokeRoleResponse struct {
+	BaseResp *BaseResp `thrift:"baseResp,1" frugal:"1,default,BaseResp" json:"baseResp"`
+	User     *UserInfo `thrift:"user,2" frugal:"2,default,UserInfo" json:"user"`
+}
+
+func NewRevokeRoleResponse() *RevokeRoleResponse {
+	return &RevokeRoleResponse{}
+}
+
+func (p *RevokeRoleResponse) InitDefault() {
+}
+
+var RevokeRoleResponse_BaseResp_DEFAULT *BaseResp
+
+func (p *RevokeRoleResponse) GetBaseResp() (v *BaseResp) {
+	if !p.IsSetBaseResp() {
+		return RevokeRoleResponse_BaseResp_DEFAULT
+	}
+	return p.BaseResp
+}
+
+var RevokeRoleResponse_User_DEFAULT *UserInfo
+
+func (p *RevokeRoleResponse) GetUser() (v *UserInfo) {
+	if !p.IsSetUser() {
+		return RevokeRoleResponse_User_DEFAULT
+	}
+	return p.User
+}
+func (p *RevokeRoleResponse) SetBaseResp(val *BaseResp) {
+	p.BaseResp = val
+}
+func (p *RevokeRoleResponse) SetUser(val *UserInfo) {
+	p.User = val
+}
+
+func (p *RevokeRoleResponse) IsSetBaseResp() bool {
+	return p.BaseResp != nil
+}
+
+func (p *RevokeRoleResponse) IsSetUser() bool {
+	return p.User != nil
+}
+
+func (p *RevokeRoleResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("RevokeRoleResponse(%+v)", *p)
+}
+
+var fieldIDToName_RevokeRoleResponse = map[int16]string{
+	1: "baseResp",
+	2: "user",
+}
+
+type UserService interface {
+	Register(ctx context.Context, req *RegisterRequest) (r *RegisterResponse, err error)
+
+	Login(ctx context.Context, req *LoginRequest) (r *LoginResponse, err error)
+
+	GetUserInfo(ctx context.Context, req *GetUserInfoRequest) (r *GetUserInfoResponse, err error)
+
+	UpdatePassword(ctx context.Context, req *UpdatePasswordRequest) (r *UpdatePasswordResponse, err error)
+
+	Logout(ctx context.Context, req *LogoutRequest) (r *LogoutResponse, err error)
+
+	DeleteAccount(ctx context.Context, req *DeleteAccountRequest) (r *DeleteAccountResponse, err error)
+}
+
+type UserServiceRegisterArgs struct {
+	Req *RegisterRequest `thrift:"req,1" frugal:"1,default,RegisterRequest" json:"req"`
+}
+
+func NewUserServiceRegisterArgs() *UserServiceRegisterArgs {
+	return &UserServiceRegisterArgs{}
+}
+
+func (p *UserServiceRegisterArgs) InitDefault() {
+}
+
+var UserServiceRegisterArgs_Req_DEFAULT *RegisterRequest
+
+func (p *UserServiceRegisterArgs) GetReq() (v *RegisterRequest) {
+	if !p.IsSetReq() {
+		return UserServiceRegisterArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *UserServiceRegisterArgs) SetReq(val *RegisterRequest) {
+	p.Req = val
+}
+
+func (p *UserServiceRegisterArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *UserServiceRegisterArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("UserServiceRegisterArgs(%+v)", *p)
+}
+
+var fieldIDToName_UserServiceRegisterArgs = map[int16]string{
+	1: "req",
+}
+
+type UserServiceRegisterResult struct {
+	Success *RegisterResponse `thrift:"success,0,optional" frugal:"0,optional,RegisterResponse" json:"success,omitempty"`
+}
+
+func NewUserServiceRegisterResult() *UserServiceRegisterResult {
+	return &UserServiceRegisterResult{}
+}
+
+func (p *UserServiceRegisterResult) InitDefault() {
+}
+
+var UserServiceRegisterResult_Success_DEFAULT *RegisterResponse
+
+func (p *UserServiceRegisterResult) GetSuccess() (v *RegisterResponse) {
+	if !p.IsSetSuccess() {
+		return UserServiceRegisterResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *UserServiceRegisterResult) SetSuccess(x interface{}) {
+	p.Success = x.(*RegisterResponse)
+}
+
+func (p *UserServiceRegisterResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *UserServiceRegisterResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("UserServiceRegisterResult(%+v)", *p)
+}
+
+var fieldIDToName_UserServiceRegisterResult = map[int16]string{
+	0: "success",
+}
+
+type UserServiceLoginArgs struct {
+	Req *LoginRequest `thrift:"req,1" frugal:"1,default,LoginRequest" json:"req"`
+}
+
+func NewUserServiceLoginArgs() *UserServiceLoginArgs {
+	return &UserServiceLoginArgs{}
+}
+
+func (p *UserServiceLoginArgs) InitDefault() {
+}
+
+var UserServiceLoginArgs_Req_DEFAULT *LoginRequest
+
+func (p *UserServiceLoginArgs) GetReq() (v *LoginRequest) {
+	if !p.IsSetReq() {
+		return UserServiceLoginArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *UserServiceLoginArgs) SetReq(val *LoginRequest) {
+	p.Req = val
+}
+
+func (p *UserServiceLoginArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *UserServiceLoginArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("UserServiceLoginArgs(%+v)", *p)
+}
+
+var fieldIDToName_UserServiceLoginArgs = map[int16]string{
+	1: "req",
+}
+
+type UserServiceLoginResult struct {
+	Success *LoginResponse `thrift:"success,0,optional" frugal:"0,optional,LoginResponse" json:"success,omitempty"`
+}
+
+func NewUserServiceLoginResult() *UserServiceLoginResult {
+	return &UserServiceLoginResult{}
+}
+
+func (p *UserServiceLoginResult) InitDefault() {
+}
+
+var UserServiceLoginResult_Success_DEFAULT *LoginResponse
+
+func (p *UserServiceLoginResult) GetSuccess() (v *LoginResponse) {
+	if !p.IsSetSuccess() {
+		return UserServiceLoginResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *UserServiceLoginResult) SetSuccess(x interface{}) {
+	p.Success = x.(*LoginResponse)
+}
+
+func (p *UserServiceLoginResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *UserServiceLoginResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("UserServiceLoginResult(%+v)", *p)
+}
+
+var fieldIDToName_UserServiceLoginResult = map[int16]string{
+	0: "success",
+}
+
+type UserServiceGetUserInfoArgs struct {
+	Req *GetUserInfoRequest `thrift:"req,1" frugal:"1,default,GetUserInfoRequest" json:"req"`
+}
+
+func NewUserServiceGetUserInfoArgs() *UserServiceGetUserInfoArgs {
+	return &UserServiceGetUserInfoArgs{}
+}
+
+func (p *UserServiceGetUserInfoArgs) InitDefault() {
+}
+
+var UserServiceGetUserInfoArgs_Req_DEFAULT *GetUserInfoRequest
+
+func (p *UserServiceGetUserInfoArgs) GetReq() (v *GetUserInfoRequest) {
+	if !p.IsSetReq() {
+		return UserServiceGetUserInfoArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *UserServiceGetUserInfoArgs) SetReq(val *GetUserInfoRequest) {
+	p.Req = val
+}
+
+func (p *UserServiceGetUserInfoArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *UserServiceGetUserInfoArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("UserServiceGetUserInfoArgs(%+v)", *p)
+}
+
+var fieldIDToName_UserServiceGetUserInfoArgs = map[int16]string{
+	1: "req",
+}
+
+type UserServiceGetUserInfoResult struct {
+	Success *GetUserInfoResponse `thrift:"success,0,optional" frugal:"0,optional,GetUserInfoResponse" json:"success,omitempty"`
+}
+
+func NewUserServiceGetUserInfoResult() *UserServiceGetUserInfoResult {
+	return &UserServiceGetUserInfoResult{}
+}
+
+func (p *UserServiceGetUserInfoResult) InitDefault() {
+}
+
+var UserServiceGetUserInfoResult_Success_DEFAULT *GetUserInfoResponse
+
+func (p *UserServiceGetUserInfoResult) GetSuccess() (v *GetUserInfoResponse) {
+	if !p.IsSetSuccess() {
+		return UserServiceGetUserInfoResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *UserServiceGetUserInfoResult) SetSuccess(x interface{}) {
+	p.Success = x.(*GetUserInfoResponse)
+}
+
+func (p *UserServiceGetUserInfoResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *UserServiceGetUserInfoResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("UserServiceGetUserInfoResult(%+v)", *p)
+}
+
+var fieldIDToName_UserServiceGetUserInfoResult = map[int16]string{
+	0: "success",
+}
+
+type UserServiceUpdatePasswordArgs struct {
+	Req *UpdatePasswordRequest `thrift:"req,1" frugal:"1,default,UpdatePasswordRequest" json:"req"`
+}
+
+func NewUserServiceUpdatePasswordArgs() *UserServiceUpdatePasswordArgs {
+	return &UserServiceUpdatePasswordArgs{}
+}
+
+func (p *UserServiceUpdatePasswordArgs) InitDefault() {
+}
+
+var UserServiceUpdatePasswordArgs_Req_DEFAULT *UpdatePasswordRequest
+
+func (p *UserServiceUpdatePasswordArgs) GetReq() (v *UpdatePasswordRequest) {
+	if !p.IsSetReq() {
+		return UserServiceUpdatePasswordArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *UserServiceUpdatePasswordArgs) SetReq(val *UpdatePasswordRequest) {
+	p.Req = val
+}
+
+func (p *UserServiceUpdatePasswordArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *UserServiceUpdatePasswordArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("UserServiceUpdatePasswordArgs(%+v)", *p)
+}
+
+var fieldIDToName_UserServiceUpdatePasswordArgs = map[int16]string{
+	1: "req",
+}
+
+type UserServiceUpdatePasswordResult struct {
+	Success *UpdatePasswordResponse `thrift:"success,0,optional" frugal:"0,optional,UpdatePasswordResponse" json:"success,omitempty"`
+}
+
+func NewUserServiceUpdatePasswordResult() *UserServiceUpdatePasswordResult {
+	return &UserServiceUpdatePasswordResult{}
+}
+
+func (p *UserServiceUpdatePasswordResult) InitDefault() {
+}
+
+var UserServiceUpdatePasswordResult_Success_DEFAULT *UpdatePasswordResponse
+
+func (p *UserServiceUpdatePasswordResult) GetSuccess() (v *UpdatePasswordResponse) {
+	if !p.IsSetSuccess() {
+		return UserServiceUpdatePasswordResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *UserServiceUpdatePasswordResult) SetSuccess(x interface{}) {
+	p.Success = x.(*UpdatePasswordResponse)
+}
+
+func (p *UserServiceUpdatePasswordResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *UserServiceUpdatePasswordResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("UserServiceUpdatePasswordResult(%+v)", *p)
+}
+
+var fieldIDToName_UserServiceUpdatePasswordResult = map[int16]string{
+	0: "success",
+}
+
+type UserServiceLogoutArgs struct {
+	Req *LogoutRequest `thrift:"req,1" frugal:"1,default,LogoutRequest" json:"req"`
+}
+
+func NewUserServiceLogoutArgs() *UserServiceLogoutArgs {
+	return &UserServiceLogoutArgs{}
+}
+
+func (p *UserServiceLogoutArgs) InitDefault() {
+}
+
+var UserServiceLogoutArgs_Req_DEFAULT *LogoutRequest
+
+func (p *UserServiceLogoutArgs) GetReq() (v *LogoutRequest) {
+	if !p.IsSetReq() {
+		return UserServiceLogoutArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *UserServiceLogoutArgs) SetReq(val *LogoutRequest) {
+	p.Req = val
+}
+
+func (p *UserServiceLogoutArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *UserServiceLogoutArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("UserServiceLogoutArgs(%+v)", *p)
+}
+
+var fieldIDToName_UserServiceLogoutArgs = map[int16]string{
+	1: "req",
+}
+
+type UserServiceLogoutResult struct {
+	Success *LogoutResponse `thrift:"success,0,optional" frugal:"0,optional,LogoutResponse" json:"success,omitempty"`
+}
+
+func NewUserServiceLogoutResult() *UserServiceLogoutResult {
+	return &UserServiceLogoutResult{}
+}
+
+func (p *UserServiceLogoutResult) InitDefault() {
+}
+
+var UserServiceLogoutResult_Success_DEFAULT *LogoutResponse
+
+func (p *UserServiceLogoutResult) GetSuccess() (v *LogoutResponse) {
+	if !p.IsSetSuccess() {
+		return UserServiceLogoutResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *UserServiceLogoutResult) SetSuccess(x interface{}) {
+	p.Success = x.(*LogoutResponse)
+}
+
+func (p *UserServiceLogoutResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *UserServiceLogoutResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("UserServiceLogoutResult(%+v)", *p)
+}
+
+var fieldIDToName_UserServiceLogoutResult = map[int16]string{
+	0: "success",
+}
+
+type UserServiceDeleteAccountArgs struct {
+	Req *DeleteAccountRequest `thrift:"req,1" frugal:"1,default,DeleteAccountRequest" json:"req"`
+}
+
+func NewUserServiceDeleteAccountArgs() *UserServiceDeleteAccountArgs {
+	return &UserServiceDeleteAccountArgs{}
+}
+
+func (p *UserServiceDeleteAccountArgs) InitDefault() {
+}
+
+var UserServiceDeleteAccountArgs_Req_DEFAULT *DeleteAccountRequest
+
+func (p *UserServiceDeleteAccountArgs) GetReq() (v *DeleteAccountRequest) {
+	if !p.IsSetReq() {
+		return UserServiceDeleteAccountArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *UserServiceDeleteAccountArgs) SetReq(val *DeleteAccountRequest) {
+	p.Req = val
+}
+
+func (p *UserServiceDeleteAccountArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *UserServiceDeleteAccountArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("UserServiceDeleteAccountArgs(%+v)", *p)
+}
+
+var fieldIDToName_UserServiceDeleteAccountArgs = map[int16]string{
+	1: "req",
+}
+
+type UserServiceDeleteAccountResult struct {
+	Success *DeleteAccountResponse `thrift:"success,0,optional" frugal:"0,optional,DeleteAccountResponse" json:"success,omitempty"`
+}
+
+func NewUserServiceDeleteAccountResult() *UserServiceDeleteAccountResult {
+	return &UserServiceDeleteAccountResult{}
+}
+
+func (p *UserServiceDeleteAccountResult) InitDefault() {
+}
+
+var UserServiceDeleteAccountResult_Success_DEFAULT *DeleteAccountResponse
+
+func (p *UserServiceDeleteAccountResult) GetSuccess() (v *DeleteAccountResponse) {
+	if !p.IsSetSuccess() {
+		return UserServiceDeleteAccountResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *UserServiceDeleteAccountResult) SetSuccess(x interface{}) {
+	p.Success = x.(*DeleteAccountResponse)
+}
+
+func (p *UserServiceDeleteAccountResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *UserServiceDeleteAccountResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("UserServiceDeleteAccountResult(%+v)", *p)
+}
+
+var fieldIDToName_UserServiceDeleteAccountResult = map[int16]string{
+	0: "success",
+}
+
+type AdminUserService interface {
+	GrantRole(ctx context.Context, req *GrantRoleRequest) (r *GrantRoleResponse, err error)
+
+	RevokeRole(ctx context.Context, req *RevokeRoleRequest) (r *RevokeRoleResponse, err error)
+}
+
+type AdminUserServiceGrantRoleArgs struct {
+	Req *GrantRoleRequest `thrift:"req,1" frugal:"1,default,GrantRoleRequest" json:"req"`
+}
+
+func NewAdminUserServiceGrantRoleArgs() *AdminUserServiceGrantRoleArgs {
+	return &AdminUserServiceGrantRoleArgs{}
+}
+
+func (p *AdminUserServiceGrantRoleArgs) InitDefault() {
+}
+
+var AdminUserServiceGrantRoleArgs_Req_DEFAULT *GrantRoleRequest
+
+func (p *AdminUserServiceGrantRoleArgs) GetReq() (v *GrantRoleRequest) {
+	if !p.IsSetReq() {
+		return AdminUserServiceGrantRoleArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *AdminUserServiceGrantRoleArgs) SetReq(val *GrantRoleRequest) {
+	p.Req = val
+}
+
+func (p *AdminUserServiceGrantRoleArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *AdminUserServiceGrantRoleArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AdminUserServiceGrantRoleArgs(%+v)", *p)
+}
+
+var fieldIDToName_AdminUserServiceGrantRoleArgs = map[int16]string{
+	1: "req",
+}
+
+type AdminUserServiceGrantRoleResult struct {
+	Success *GrantRoleResponse `thrift:"success,0,optional" frugal:"0,optional,GrantRoleResponse" json:"success,omitempty"`
+}
+
+func NewAdminUserServiceGrantRoleResult() *AdminUserServiceGrantRoleResult {
+	return &AdminUserServiceGrantRoleResult{}
+}
+
+func (p *AdminUserServiceGrantRoleResult) InitDefault() {
+}
+
+var AdminUserServiceGrantRoleResult_Success_DEFAULT *GrantRoleResponse
+
+func (p *AdminUserServiceGrantRoleResult) GetSuccess() (v *GrantRoleResponse) {
+	if !p.IsSetSuccess() {
+		return AdminUserServiceGrantRoleResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *AdminUserServiceGrantRoleResult) SetSuccess(x interface{}) {
+	p.Success = x.(*GrantRoleResponse)
+}
+
+func (p *AdminUserServiceGrantRoleResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *AdminUserServiceGrantRoleResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AdminUserServiceGrantRoleResult(%+v)", *p)
+}
+
+var fieldIDToName_AdminUserServiceGrantRoleResult = map[int16]string{
+	0: "success",
+}
+
+type AdminUserServiceRevokeRoleArgs struct {
+	Req *RevokeRoleRequest `thrift:"req,1" frugal:"1,default,RevokeRoleRequest" json:"req"`
+}
+
+func NewAdminUserServiceRevokeRoleArgs() *AdminUserServiceRevokeRoleArgs {
+	return &AdminUserServiceRevokeRoleArgs{}
+}
+
+func (p *AdminUserServiceRevokeRoleArgs) InitDefault() {
+}
+
+var AdminUserServiceRevokeRoleArgs_Req_DEFAULT *RevokeRoleRequest
+
+func (p *AdminUserServiceRevokeRoleArgs) GetReq() (v *RevokeRoleRequest) {
+	if !p.IsSetReq() {
+		return AdminUserServiceRevokeRoleArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *AdminUserServiceRevokeRoleArgs) SetReq(val *RevokeRoleRequest) {
+	p.Req = val
+}
+
+func (p *AdminUserServiceRevokeRoleArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *AdminUserServiceRevokeRoleArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AdminUserServiceRevokeRoleArgs(%+v)", *p)
+}
+
+var fieldIDToName_AdminUserServiceRevokeRoleArgs = map[int16]string{
+	1: "req",
+}
+
+type AdminUserServiceRevokeRoleResult struct {
+	Success *RevokeRoleResponse `thrift:"success,0,optional" frugal:"0,optional,RevokeRoleResponse" json:"success,omitempty"`
+}
+
+func NewAdminUserServiceRevokeRoleResult() *AdminUserServiceRevokeRoleResult {
+	return &AdminUserServiceRevokeRoleResult{}
+}
+
+func (p *AdminUserServiceRevokeRoleResult) InitDefault() {
+}
+
+var AdminUserServiceRevokeRoleResult_Success_DEFAULT *RevokeRoleResponse
+
+func (p *AdminUserServiceRevokeRoleResult) GetSuccess() (v *RevokeRoleResponse) {
+	if !p.IsSetSuccess() {
+		return AdminUserServiceRevokeRoleResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *AdminUserServiceRevokeRoleResult) SetSuccess(x interface{}) {
+	p.Success = x.(*RevokeRoleResponse)
+}
+
+func (p *AdminUserServiceRevokeRoleResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *AdminUserServiceRevokeRoleResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AdminUserServiceRevokeRoleResult(%+v)", *p)
+}
+
+var fieldIDToName_AdminUserServiceRevokeRoleResult = map[int16]string{
 	0: "success",
 }
@@ -13,6 +13,10 @@ import (
 type Client interface {
 	Register(ctx context.Context, req *user.RegisterRequest, callOptions ...callopt.Option) (r *user.RegisterResponse, err error)
 	Login(ctx context.Context, req *user.LoginRequest, callOptions ...callopt.Option) (r *user.LoginResponse, err error)
+	GetUserInfo(ctx context.Context, req *user.GetUserInfoRequest, callOptions ...callopt.Option) (r *user.GetUserInfoResponse, err error)
+	UpdatePassword(ctx context.Context, req *user.UpdatePasswordRequest, callOptions ...callopt.Option) (r *user.UpdatePasswordResponse, err error)
+	Logout(ctx context.Context, req *user.LogoutRequest, callOptions ...callopt.Option) (r *user.LogoutResponse, err error)
+	DeleteAccount(ctx context.Context, req *user.DeleteAccountRequest, callOptions ...callopt.Option) (r *user.DeleteAccountResponse, err error)
 }
 
 // NewClient creates a client for the service defined in IDL.
@@ -53,3 +57,23 @@ func (p *kUserServiceClient) Login(ctx context.Context, req *user.LoginRequest,
 	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
 	return p.kClient.Login(ctx, req)
 }
+
+func (p *kUserServiceClient) GetUserInfo(ctx context.Context, req *user.GetUserInfoRequest, callOptions ...callopt.Option) (r *user.GetUserInfoResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.GetUserInfo(ctx, req)
+}
+
+func (p *kUserServiceClient) UpdatePassword(ctx context.Context, req *user.UpdatePasswordRequest, callOptions ...callopt.Option) (r *user.UpdatePasswordResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.UpdatePassword(ctx, req)
+}
+
+func (p *kUserServiceClient) Logout(ctx context.Context, req *user.LogoutRequest, callOptions ...callopt.Option) (r *user.LogoutResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.Logout(ctx, req)
+}
+
+func (p *kUserServiceClient) DeleteAccount(ctx context.Context, req *user.DeleteAccountRequest, callOptions ...callopt.Option) (r *user.DeleteAccountResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.DeleteAccount(ctx, req)
+}
@@ -27,6 +27,34 @@ var serviceMethods = map[string]kitex.MethodInfo{
 		false,
 		kitex.WithStreamingMode(kitex.StreamingNone),
 	),
+	"GetUserInfo": kitex.NewMethodInfo(
+		getUserInfoHandler,
+		newUserServiceGetUserInfoArgs,
+		newUserServiceGetUserInfoResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"UpdatePassword": kitex.NewMethodInfo(
+		updatePasswordHandler,
+		newUserServiceUpdatePasswordArgs,
+		newUserServiceUpdatePasswordResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"Logout": kitex.NewMethodInfo(
+		logoutHandler,
+		newUserServiceLogoutArgs,
+		newUserServiceLogoutResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"DeleteAccount": kitex.NewMethodInfo(
+		deleteAccountHandler,
+		newUserServiceDeleteAccountArgs,
+		newUserServiceDeleteAccountResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
 }
 
 var (
@@ -129,6 +157,78 @@ func newUserServiceLoginResult() interface{} {
 	return user.NewUserServiceLoginResult()
 }
 
+func getUserInfoHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*user.UserServiceGetUserInfoArgs)
+	realResult := result.(*user.UserServiceGetUserInfoResult)
+	success, err := handler.(user.UserService).GetUserInfo(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newUserServiceGetUserInfoArgs() interface{} {
+	return user.NewUserServiceGetUserInfoArgs()
+}
+
+func newUserServiceGetUserInfoResult() interface{} {
+	return user.NewUserServiceGetUserInfoResult()
+}
+
+func updatePasswordHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*user.UserServiceUpdatePasswordArgs)
+	realResult := result.(*user.UserServiceUpdatePasswordResult)
+	success, err := handler.(user.UserService).UpdatePassword(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newUserServiceUpdatePasswordArgs() interface{} {
+	return user.NewUserServiceUpdatePasswordArgs()
+}
+
+func newUserServiceUpdatePasswordResult() interface{} {
+	return user.NewUserServiceUpdatePasswordResult()
+}
+
+func logoutHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*user.UserServiceLogoutArgs)
+	realResult := result.(*user.UserServiceLogoutResult)
+	success, err := handler.(user.UserService).Logout(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newUserServiceLogoutArgs() interface{} {
+	return user.NewUserServiceLogoutArgs()
+}
+
+func newUserServiceLogoutResult() interface{} {
+	return user.NewUserServiceLogoutResult()
+}
+
+func deleteAccountHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*user.UserServiceDeleteAccountArgs)
+	realResult := result.(*user.UserServiceDeleteAccountResult)
+	success, err := handler.(user.UserService).DeleteAccount(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newUserServiceDeleteAccountArgs() interface{} {
+	return user.NewUserServiceDeleteAccountArgs()
+}
+
+func newUserServiceDeleteAccountResult() interface{} {
+	return user.NewUserServiceDeleteAccountResult()
+}
+
 type kClient struct {
 	c client.Client
 }
@@ -158,3 +258,43 @@ func (p *kClient) Login(ctx context.Context, req *user.LoginRequest) (r *user.Lo
 	}
 	return _result.GetSuccess(), nil
 }
+
+func (p *kClient) GetUserInfo(ctx context.Context, req *user.GetUserInfoRequest) (r *user.GetUserInfoResponse, err error) {
+	var _args user.UserServiceGetUserInfoArgs
+	_args.Req = req
+	var _result user.UserServiceGetUserInfoResult
+	if err = p.c.Call(ctx, "GetUserInfo", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) UpdatePassword(ctx context.Context, req *user.UpdatePasswordRequest) (r *user.UpdatePasswordResponse, err error) {
+	var _args user.UserServiceUpdatePasswordArgs
+	_args.Req = req
+	var _result user.UserServiceUpdatePasswordResult
+	if err = p.c.Call(ctx, "UpdatePassword", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) Logout(ctx context.Context, req *user.LogoutRequest) (r *user.LogoutResponse, err error) {
+	var _args user.UserServiceLogoutArgs
+	_args.Req = req
+	var _result user.UserServiceLogoutResult
+	if err = p.c.Call(ctx, "Logout", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) DeleteAccount(ctx context.Context, req *user.DeleteAccountRequest) (r *user.DeleteAccountResponse, err error) {
+	var _args user.UserServiceDeleteAccountArgs
+	_args.Req = req
+	var _result user.UserServiceDeleteAccountResult
+	if err = p.c.Call(ctx, "DeleteAccount", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
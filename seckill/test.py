@@ -262,7 +262,7 @@ class UserPool:
     """用户池，管理大量测试用户"""
     def __init__(self, base_url):
         self.base_url = base_url
-        self.users = {}  # user_id -> {username, password}
+        self.users = {}  # user_id -> {username, password, token}
         self.user_locks = {}  # user_id -> lock (防止并发使用同一用户)
         self.lock = threading.Lock()
         self.session = requests.Session()
@@ -322,9 +322,14 @@ class UserPool:
                 if base_resp.get('code') == 0:
                     user_id = result.get('userId', 0)
                     
+                    # 登录获取访问令牌，订单接口通过令牌识别用户
+                    token = self._login_one(username, password)
+                    if not token:
+                        return None, idx
+                    
                     # 添加到用户池
                     with self.lock:
-                        self.users[user_id] = {"username": username, "password": password}
+                        self.users[user_id] = {"username": username, "password": password, "token": token}
                         self.user_locks[user_id] = threading.Lock()
                     
                     return user_id, idx
@@ -336,6 +341,28 @@ class UserPool:
             logger.error(f"注册用户异常: {str(e)}")
             return None, idx
     
+    def _login_one(self, username, password):
+        """登录单个用户，返回访问令牌"""
+        url = f"{self.base_url}/api/user/login"
+        try:
+            response = self.session.post(url, json={"username": username, "password": password}, timeout=10)
+            if response.status_code != 200:
+                return None
+            
+            result = response.json()
+            if result.get('baseResp', {}).get('code') != 0:
+                return None
+            
+            return result.get('accessToken')
+        except Exception as e:
+            logger.error(f"登录用户异常: {str(e)}")
+            return None
+    
+    def get_token(self, user_id):
+        """获取用户的访问令牌"""
+        user = self.users.get(user_id)
+        return user.get('token') if user else None
+    
     def get_random_user(self):
         """获取随机用户ID"""
         with self.lock:
@@ -372,6 +399,10 @@ class SeckillTest:
         self.session.headers.update({
             'Content-Type': 'application/json'
         })
+        # 创建活动需要商家或管理员的访问令牌，通过环境变量SECKILL_ADMIN_TOKEN传入
+        admin_token = os.environ.get('SECKILL_ADMIN_TOKEN')
+        if admin_token:
+            self.session.headers.update({'Authorization': f"Bearer {admin_token}"})
         self.stats = Statistics()
         self.user_pool = UserPool(base_url)
         self.stop_event = threading.Event()
@@ -448,20 +479,26 @@ class SeckillTest:
     def list_activities(self):
         """获取活动列表（示例函数，根据实际API调整）"""
         url = f"{self.base_url}/api/activity/list"
+        activities = []
+        params = {'page_size': 100}
         try:
-            response = self.session.get(url, timeout=10)
-            
-            if response.status_code == 200:
-                result = response.json()
-                if result.get('baseResponse', {}).get('code') == 0:
-                    activities = result.get('activities', [])
+            # 按游标翻页直到没有下一页
+            while True:
+                response = self.session.get(url, params=params, timeout=10)
+
+                if response.status_code != 200:
+                    logger.warning(f"获取活动列表请求失败: HTTP {response.status_code}")
                     return activities
-                else:
+
+                result = response.json()
+                if result.get('baseResponse', {}).get('code') != 0:
                     logger.warning("获取活动列表失败")
-                    return []
-            else:
-                logger.warning(f"获取活动列表请求失败: HTTP {response.status_code}")
-                return []
+                    return activities
+
+                activities.extend(result.get('activities', []))
+                if not result.get('hasMore'):
+                    return activities
+                params['cursor'] = result.get('nextCursor')
         except Exception as e:
             logger.error(f"获取活动列表异常: {str(e)}")
             return []
@@ -472,15 +509,14 @@ class SeckillTest:
             return False, "无有效用户ID", 0
             
         data = {
-            "userID": user_id,
             "activityID": activity_id
         }
         
         url = f"{self.base_url}/api/order/seckill"
         start = time.time()
         try:
-            # 添加用户ID到请求头，用于限流器识别
-            headers = {'X-User-ID': str(user_id)}
+            # 用户和限流都以访问令牌中的用户ID为准
+            headers = {'Authorization': f"Bearer {self.user_pool.get_token(user_id)}"}
             response = self.session.post(url, json=data, headers=headers, timeout=10)
             resp_time = (time.time() - start) * 1000  # 毫秒
             